@@ -1,5 +1,6 @@
 import { type AxiosResponse } from "axios";
 import apiClient from "./client";
+import type { FrameworkListParams, FrameworkListResponse } from "~/types";
 
 // ============================================================================
 // Types
@@ -147,9 +148,9 @@ export interface Report {
 // ============================================================================
 
 export const frameworkApi = {
-  // List all frameworks
-  list: (): Promise<AxiosResponse<Framework[]>> =>
-    apiClient.get<Framework[]>('/frameworks'),
+  // List frameworks, a page at a time
+  list: (params?: FrameworkListParams): Promise<AxiosResponse<FrameworkListResponse>> =>
+    apiClient.get<FrameworkListResponse>('/frameworks', { params }),
 
   // Get framework by ID
   getById: (id: string): Promise<AxiosResponse<Framework>> =>
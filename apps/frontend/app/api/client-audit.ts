@@ -12,8 +12,10 @@ export const clientAuditApi = {
     apiClient.get<ClientAudit[]>('/client-audit'),
 
   // Get audit detail with questions (role-based filtering)
-  getAuditDetail: (auditId: string): Promise<AxiosResponse<ClientAuditDetail>> =>
-    apiClient.get<ClientAuditDetail>(`/client-audit/${auditId}`),
+  // Questions are served in the given locale when translated, otherwise in
+  // the user's preferred locale
+  getAuditDetail: (auditId: string, locale?: string): Promise<AxiosResponse<ClientAuditDetail>> =>
+    apiClient.get<ClientAuditDetail>(`/client-audit/${auditId}`, { params: locale ? { locale } : undefined }),
 
   // Save submission (create or update draft)
   saveSubmission: (payload: ClientSubmissionPayload): Promise<AxiosResponse<any>> =>
@@ -22,4 +24,8 @@ export const clientAuditApi = {
   // Submit answer for review
   submitAnswer: (submissionId: string): Promise<AxiosResponse<any>> =>
     apiClient.post(`/client-audit/submissions/${submissionId}/submit`),
+
+  // Confirm an answer carried forward from the previous audit unchanged
+  confirmCarriedForward: (submissionId: string): Promise<AxiosResponse<any>> =>
+    apiClient.post(`/client-audit/submissions/${submissionId}/confirm`),
 };
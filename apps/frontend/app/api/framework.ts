@@ -2,6 +2,8 @@ import { type AxiosResponse } from "axios";
 import apiClient from "./client";
 import type {
   Framework,
+  FrameworkListParams,
+  FrameworkListResponse,
   FrameworkQuestion,
   CreateFrameworkPayload,
   UpdateFrameworkPayload,
@@ -11,10 +13,28 @@ import type {
 // Framework API
 // ============================================================================
 
+// Largest page framework-service returns
+const FRAMEWORK_PAGE_LIMIT = 100;
+
 export const frameworkApi = {
-  // List all frameworks
-  list: (): Promise<AxiosResponse<Framework[]>> =>
-    apiClient.get<Framework[]>('/frameworks'),
+  // List frameworks, a page at a time
+  list: (params?: FrameworkListParams): Promise<AxiosResponse<FrameworkListResponse>> =>
+    apiClient.get<FrameworkListResponse>('/frameworks', { params }),
+
+  // List every framework matching the filters, following all pages
+  listAll: async (params?: Omit<FrameworkListParams, 'limit' | 'offset'>): Promise<Framework[]> => {
+    const frameworks: Framework[] = [];
+    for (let offset = 0; ; ) {
+      const response = await apiClient.get<FrameworkListResponse>('/frameworks', {
+        params: { ...params, limit: FRAMEWORK_PAGE_LIMIT, offset },
+      });
+      frameworks.push(...response.data.data);
+      offset += response.data.data.length;
+      if (response.data.data.length === 0 || offset >= response.data.total) {
+        return frameworks;
+      }
+    }
+  },
 
   // Get framework by ID
   getById: (id: string): Promise<AxiosResponse<Framework>> =>
@@ -55,13 +55,13 @@ export default function AssignFrameworkPage() {
       const [cycleRes, clientsRes, frameworksRes, assignedFrameworksRes, usersRes] = await Promise.all([
         api.auditCycles.getById(id!),
         api.auditCycles.getClients(id!),
-        api.frameworks.list(),
+        api.frameworks.listAll(),
         api.auditCycles.getFrameworks(id!),
         api.users.listAuditors(), // Fetch all users to show as potential auditors
       ]);
 
       setCycle(cycleRes.data);
-      setFrameworks(frameworksRes.data);
+      setFrameworks(frameworksRes);
       setUsers(usersRes.data.data || []);
       
       // Filter users who are auditors (have auditor role)
@@ -81,7 +81,7 @@ export default function AssignFrameworkPage() {
         
         // Filter out already assigned frameworks
         const assignedFrameworkIds = new Set(clientAssignedFrameworks.map(f => f.framework_id));
-        const available = frameworksRes.data.filter(
+        const available = frameworksRes.filter(
           framework => !assignedFrameworkIds.has(framework.id)
         );
         setAvailableFrameworks(available);
@@ -54,6 +54,18 @@ export default function ClientAuditDetailPage() {
     },
   });
 
+  // Confirm carried forward answer mutation
+  const confirmCarriedForwardMutation = useMutation({
+    mutationFn: (submissionId: string) => api.clientAudit.confirmCarriedForward(submissionId),
+    onSuccess: () => {
+      toast.success('The carried forward answer has been confirmed');
+      queryClient.invalidateQueries({ queryKey: ['client-audit-detail', auditId] });
+    },
+    onError: () => {
+      toast.error('Failed to confirm answer');
+    },
+  });
+
   const handleSaveAnswer = (questionId: string) => {
     const data = formData[questionId];
     if (!data || !data.explanation) {
@@ -73,6 +85,10 @@ export default function ClientAuditDetailPage() {
     submitAnswerMutation.mutate(submissionId);
   };
 
+  const handleConfirmCarriedForward = (submissionId: string) => {
+    confirmCarriedForwardMutation.mutate(submissionId);
+  };
+
   const updateFormData = (questionId: string, field: string, value: string) => {
     setFormData(prev => ({
       ...prev,
@@ -189,6 +205,9 @@ export default function ClientAuditDetailPage() {
                           </Badge>
                         )}
                         {getSubmissionStatusBadge(question.submission_status)}
+                        {question.is_carried_forward && (
+                          <Badge variant="outline" className="text-xs">Carried forward</Badge>
+                        )}
                       </div>
                       <h3 className="font-semibold text-lg">{question.question_text}</h3>
                       {question.help_text && (
@@ -268,7 +287,17 @@ export default function ClientAuditDetailPage() {
                           <Save className="h-4 w-4 mr-2" />
                           Save Draft
                         </Button>
-                        {question.submission_id && question.submission_status === 'in_progress' && (
+                        {question.submission_id && question.is_carried_forward && (
+                          <Button
+                            onClick={() => handleConfirmCarriedForward(question.submission_id!)}
+                            disabled={confirmCarriedForwardMutation.isPending}
+                            variant="outline"
+                          >
+                            <CheckCircle2 className="h-4 w-4 mr-2" />
+                            Confirm Answer
+                          </Button>
+                        )}
+                        {question.submission_id && question.submission_status === 'in_progress' && !question.is_carried_forward && (
                           <Button
                             onClick={() => handleSubmitAnswer(question.submission_id!)}
                             disabled={submitAnswerMutation.isPending}
@@ -1,6 +1,6 @@
 import { useState, useEffect } from 'react';
 import { Link } from 'react-router';
-import { Plus, FileText, Search, Trash2, Edit, Eye } from 'lucide-react';
+import { Plus, FileText, Search, Trash2, Edit, Eye, ChevronLeft, ChevronRight } from 'lucide-react';
 import { Button } from '~/components/ui/button';
 import { Input } from '~/components/ui/input';
 import {
@@ -22,22 +22,34 @@ import { Badge } from '~/components/ui/badge';
 import { api } from '~/api';
 import type { Framework } from '~/types';
 
+// Frameworks shown per page
+const PAGE_SIZE = 50;
+
 export default function FrameworksPage() {
   const [frameworks, setFrameworks] = useState<Framework[]>([]);
+  const [total, setTotal] = useState(0);
+  const [offset, setOffset] = useState(0);
   const [loading, setLoading] = useState(true);
   const [searchQuery, setSearchQuery] = useState('');
   const [error, setError] = useState<string | null>(null);
 
+  // Search by name on the server, once the user stops typing
   useEffect(() => {
-    loadFrameworks();
-  }, []);
+    const timeout = setTimeout(() => loadFrameworks(offset), 300);
+    return () => clearTimeout(timeout);
+  }, [searchQuery, offset]);
 
-  const loadFrameworks = async () => {
+  const loadFrameworks = async (pageOffset: number) => {
     try {
       setLoading(true);
       setError(null);
-      const response = await api.frameworks.list();
-      setFrameworks(response.data);
+      const response = await api.frameworks.list({
+        name: searchQuery.trim() || undefined,
+        limit: PAGE_SIZE,
+        offset: pageOffset,
+      });
+      setFrameworks(response.data.data);
+      setTotal(response.data.total);
     } catch (err: any) {
       console.error('Failed to load frameworks:', err);
       setError(err.response?.data?.error || 'Failed to load frameworks');
@@ -54,16 +66,17 @@ export default function FrameworksPage() {
     try {
       await api.frameworks.delete(id);
       setFrameworks(frameworks.filter((f) => f.id !== id));
+      setTotal((t) => t - 1);
     } catch (err: any) {
       console.error('Failed to delete framework:', err);
       setError(err.response?.data?.error || 'Failed to delete framework');
     }
   };
 
-  const filteredFrameworks = frameworks.filter((framework) =>
-    framework.name.toLowerCase().includes(searchQuery.toLowerCase()) ||
-    framework.description.toLowerCase().includes(searchQuery.toLowerCase())
-  );
+  const handleSearch = (query: string) => {
+    setSearchQuery(query);
+    setOffset(0);
+  };
 
   return (
     <div className="container mx-auto py-6 space-y-6">
@@ -89,9 +102,9 @@ export default function FrameworksPage() {
           <div className="relative">
             <Search className="absolute left-3 top-1/2 transform -translate-y-1/2 h-4 w-4 text-muted-foreground" />
             <Input
-              placeholder="Search frameworks..."
+              placeholder="Search frameworks by name..."
               value={searchQuery}
-              onChange={(e) => setSearchQuery(e.target.value)}
+              onChange={(e) => handleSearch(e.target.value)}
               className="pl-10"
             />
           </div>
@@ -112,7 +125,7 @@ export default function FrameworksPage() {
         <CardHeader>
           <CardTitle>All Frameworks</CardTitle>
           <CardDescription>
-            {filteredFrameworks.length} framework{filteredFrameworks.length !== 1 ? 's' : ''} found
+            {total} framework{total !== 1 ? 's' : ''} found
           </CardDescription>
         </CardHeader>
         <CardContent>
@@ -120,7 +133,7 @@ export default function FrameworksPage() {
             <div className="flex items-center justify-center py-8">
               <div className="animate-spin rounded-full h-8 w-8 border-b-2 border-primary"></div>
             </div>
-          ) : filteredFrameworks.length === 0 ? (
+          ) : frameworks.length === 0 ? (
             <div className="text-center py-8">
               <FileText className="mx-auto h-12 w-12 text-muted-foreground" />
               <h3 className="mt-4 text-lg font-semibold">No frameworks found</h3>
@@ -151,7 +164,7 @@ export default function FrameworksPage() {
                 </TableRow>
               </TableHeader>
               <TableBody>
-                {filteredFrameworks.map((framework) => (
+                {frameworks.map((framework) => (
                   <TableRow key={framework.id}>
                     <TableCell className="font-medium">
                       <Link
@@ -199,6 +212,33 @@ export default function FrameworksPage() {
               </TableBody>
             </Table>
           )}
+          {total > PAGE_SIZE && (
+            <div className="flex items-center justify-between pt-4">
+              <p className="text-sm text-muted-foreground">
+                Showing {offset + 1}-{Math.min(offset + PAGE_SIZE, total)} of {total}
+              </p>
+              <div className="flex gap-2">
+                <Button
+                  variant="outline"
+                  size="sm"
+                  onClick={() => setOffset(Math.max(0, offset - PAGE_SIZE))}
+                  disabled={loading || offset === 0}
+                >
+                  <ChevronLeft className="h-4 w-4 mr-1" />
+                  Previous
+                </Button>
+                <Button
+                  variant="outline"
+                  size="sm"
+                  onClick={() => setOffset(offset + PAGE_SIZE)}
+                  disabled={loading || offset + PAGE_SIZE >= total}
+                >
+                  Next
+                  <ChevronRight className="h-4 w-4 ml-1" />
+                </Button>
+              </div>
+            </div>
+          )}
         </CardContent>
       </Card>
     </div>
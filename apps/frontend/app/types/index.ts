@@ -18,6 +18,7 @@ export interface User {
   created_at: string;
   updated_at: string;
   last_login?: string;
+  preferred_locale?: string;
 }
 
 export interface LoginCredentials {
@@ -251,7 +252,9 @@ export interface ClientAuditQuestion {
   submission_status?: 'not_started' | 'in_progress' | 'submitted' | 'approved' | 'rejected' | 'referred';
   submitted_at?: string;
   submitted_by?: string;
+  is_carried_forward: boolean;
   is_assigned_to_me: boolean;
+  locale?: string;
 }
 
 export interface ClientAuditDetail {
@@ -261,6 +264,8 @@ export interface ClientAuditDetail {
   due_date: string;
   status: string;
   created_at: string;
+  language: string;
+  available_locales: string[];
   questions: ClientAuditQuestion[];
 }
 
@@ -291,11 +296,30 @@ export interface Framework {
   name: string;
   description: string;
   version: string;
+  regulator?: string;
+  language: string;
   question_count?: number;
+  draft_version_id?: string;
   created_at: string;
   updated_at: string;
 }
 
+export interface FrameworkListParams {
+  name?: string;
+  regulator?: string;
+  status?: 'published' | 'draft';
+  updated_since?: string;
+  limit?: number;
+  offset?: number;
+}
+
+export interface FrameworkListResponse {
+  data: Framework[];
+  total: number;
+  limit: number;
+  offset: number;
+}
+
 export interface FrameworkChecklist {
   sections: FrameworkSection[];
 }
@@ -0,0 +1,3 @@
+module github.com/NormaTech-AI/audity/packages/go/emailtemplates
+
+go 1.25
@@ -0,0 +1,245 @@
+// Package emailtemplates renders the transactional emails of the platform from
+// versioned HTML and text templates, branded per client.
+//
+// Every email has a <name>.html.tmpl and a <name>.txt.tmpl in
+// templates/<version>/. The text template defines the "subject" and "content"
+// blocks, the HTML template the "content" block; both are wrapped in the
+// layout of their version. Changing an email means adding a new version
+// directory and bumping Version, so emails already sent can be re-rendered
+// exactly as they went out.
+package emailtemplates
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"regexp"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+)
+
+//go:embed templates
+var files embed.FS
+
+// Version is the template version new emails are rendered with
+const Version = "v1"
+
+// Name identifies an email template
+type Name string
+
+const (
+	Invitation         Name = "invitation"
+	FrameworkAssigned  Name = "framework_assigned"
+	QuestionDelegated  Name = "question_delegated"
+	SubmissionRejected Name = "submission_rejected"
+	ReferralAssigned   Name = "referral_assigned"
+	DueDateApproaching Name = "due_date_approaching"
+	ReportSigned       Name = "report_signed"
+	ReportDelivered    Name = "report_delivered"
+	CommentMention     Name = "comment_mention"
+	ExceptionExpiring  Name = "exception_expiring"
+)
+
+// Branding is the look of the emails sent on behalf of a client. Empty fields
+// fall back to DefaultBranding.
+type Branding struct {
+	Name         string // shown in the header and signature
+	LogoURL      string // shown in the header instead of the name when set
+	PrimaryColor string // hex color of the header and buttons, e.g. #1d4ed8
+	FooterText   string
+}
+
+var hexColor = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}){1,2}$`)
+
+// DefaultBranding is used for clients without branding and for emails that
+// are not sent on behalf of a client
+func DefaultBranding() Branding {
+	return Branding{
+		Name:         "Audity",
+		PrimaryColor: "#1d4ed8",
+		FooterText:   "You are receiving this email because you take part in an audit on Audity.",
+	}
+}
+
+// ValidColor reports whether color is a hex color such as #1d4ed8 or #fff
+func ValidColor(color string) bool {
+	return hexColor.MatchString(color)
+}
+
+func (b Branding) withDefaults() Branding {
+	def := DefaultBranding()
+	if strings.TrimSpace(b.Name) == "" {
+		b.Name = def.Name
+	}
+	if !ValidColor(b.PrimaryColor) {
+		b.PrimaryColor = def.PrimaryColor
+	}
+	if strings.TrimSpace(b.FooterText) == "" {
+		b.FooterText = def.FooterText
+	}
+	return b
+}
+
+// Data of the templates. Dates are preformatted strings and URLs absolute
+// links into the web app.
+
+type InvitationData struct {
+	ClientName string
+	POCEmail   string
+	LoginURL   string
+}
+
+type FrameworkAssignedData struct {
+	ClientName     string
+	FrameworkName  string
+	AuditCycleName string
+	DueDate        string
+	AuditURL       string
+}
+
+type QuestionDelegatedData struct {
+	AssigneeName   string
+	DelegatedBy    string
+	FrameworkName  string
+	QuestionNumber string
+	QuestionText   string
+	Notes          string
+	AuditURL       string
+}
+
+type SubmissionRejectedData struct {
+	RecipientName  string
+	FrameworkName  string
+	QuestionNumber string
+	QuestionText   string
+	Reason         string
+	AuditURL       string
+}
+
+type ReferralAssignedData struct {
+	RecipientName  string
+	FrameworkName  string
+	QuestionNumber string
+	QuestionText   string
+	Notes          string
+	AuditURL       string
+}
+
+type DueDateApproachingData struct {
+	ClientName    string
+	FrameworkName string
+	DueDate       string
+	DaysLeft      int
+	AuditURL      string
+}
+
+type ReportSignedData struct {
+	RecipientName string
+	ClientName    string
+	FrameworkName string
+	SignedBy      string
+	ReportURL     string
+}
+
+type ReportDeliveredData struct {
+	ClientName    string
+	FrameworkName string
+	ReportURL     string
+}
+
+type CommentMentionData struct {
+	RecipientName  string
+	AuthorName     string
+	FrameworkName  string
+	QuestionNumber string
+	QuestionText   string
+	Comment        string
+	AuditURL       string
+}
+
+// ExceptionExpiringData is about an approved exception; DaysLeft is negative
+// once it has expired
+type ExceptionExpiringData struct {
+	ClientName     string
+	FrameworkName  string
+	QuestionNumber string
+	QuestionText   string
+	ExpiresAt      string
+	DaysLeft       int
+	AuditURL       string
+}
+
+// Email is a rendered email
+type Email struct {
+	Template Name
+	Version  string
+	Subject  string
+	HTML     string
+	Text     string
+}
+
+type parsed struct {
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+var cache sync.Map // version/name -> *parsed
+
+// Render renders an email with the current template version
+func Render(name Name, branding Branding, data any) (Email, error) {
+	return RenderVersion(Version, name, branding, data)
+}
+
+// RenderVersion renders an email with the given template version
+func RenderVersion(version string, name Name, branding Branding, data any) (Email, error) {
+	t, err := load(version, name)
+	if err != nil {
+		return Email{}, err
+	}
+
+	view := struct {
+		Brand Branding
+		Data  any
+	}{Brand: branding.withDefaults(), Data: data}
+
+	var subject, text, html bytes.Buffer
+	if err := t.text.ExecuteTemplate(&subject, "subject", view); err != nil {
+		return Email{}, fmt.Errorf("failed to render subject of %s/%s: %w", version, name, err)
+	}
+	if err := t.text.ExecuteTemplate(&text, "layout", view); err != nil {
+		return Email{}, fmt.Errorf("failed to render text of %s/%s: %w", version, name, err)
+	}
+	if err := t.html.ExecuteTemplate(&html, "layout", view); err != nil {
+		return Email{}, fmt.Errorf("failed to render html of %s/%s: %w", version, name, err)
+	}
+
+	return Email{
+		Template: name,
+		Version:  version,
+		Subject:  strings.Join(strings.Fields(subject.String()), " "),
+		HTML:     html.String(),
+		Text:     strings.TrimSpace(text.String()) + "\n",
+	}, nil
+}
+
+func load(version string, name Name) (*parsed, error) {
+	key := version + "/" + string(name)
+	if t, ok := cache.Load(key); ok {
+		return t.(*parsed), nil
+	}
+
+	dir := "templates/" + version
+	html, err := htmltemplate.ParseFS(files, dir+"/layout.html.tmpl", dir+"/"+string(name)+".html.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("unknown email template %s/%s: %w", version, name, err)
+	}
+	text, err := texttemplate.ParseFS(files, dir+"/layout.txt.tmpl", dir+"/"+string(name)+".txt.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("unknown email template %s/%s: %w", version, name, err)
+	}
+
+	t, _ := cache.LoadOrStore(key, &parsed{html: html, text: text})
+	return t.(*parsed), nil
+}
@@ -0,0 +1,205 @@
+package emailtemplates
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name        string
+		template    Name
+		data        any
+		wantSubject string
+		// Rendered in both the HTML and the text part
+		wantContent []string
+	}{
+		{
+			name:        "invitation",
+			template:    Invitation,
+			data:        InvitationData{ClientName: "Acme", POCEmail: "poc@acme.test", LoginURL: "https://app.test/login"},
+			wantSubject: "You're invited to Audity",
+			wantContent: []string{"Acme", "poc@acme.test", "https://app.test/login"},
+		},
+		{
+			name:     "framework assigned",
+			template: FrameworkAssigned,
+			data: FrameworkAssignedData{
+				ClientName:     "Acme",
+				FrameworkName:  "ISO 27001",
+				AuditCycleName: "FY26",
+				DueDate:        "31 March 2026",
+				AuditURL:       "https://app.test/audit/1",
+			},
+			wantSubject: "New audit assigned: ISO 27001",
+			wantContent: []string{"ISO 27001", "31 March 2026", "https://app.test/audit/1"},
+		},
+		{
+			name:     "due date tomorrow",
+			template: DueDateApproaching,
+			data: DueDateApproachingData{
+				ClientName:    "Acme",
+				FrameworkName: "SOC 2",
+				DueDate:       "1 April 2026",
+				DaysLeft:      1,
+				AuditURL:      "https://app.test/audit/2",
+			},
+			wantSubject: "SOC 2 audit due tomorrow",
+			wantContent: []string{"1 April 2026", "which is tomorrow"},
+		},
+		{
+			name:     "due date in days",
+			template: DueDateApproaching,
+			data: DueDateApproachingData{
+				ClientName:    "Acme",
+				FrameworkName: "SOC 2",
+				DueDate:       "7 April 2026",
+				DaysLeft:      7,
+				AuditURL:      "https://app.test/audit/2",
+			},
+			wantSubject: "SOC 2 audit due in 7 days",
+			wantContent: []string{"in 7 days"},
+		},
+		{
+			name:     "report delivered",
+			template: ReportDelivered,
+			data: ReportDeliveredData{
+				ClientName:    "Acme",
+				FrameworkName: "ISO 27001",
+				ReportURL:     "https://app.test/reports/3",
+			},
+			wantSubject: "Your ISO 27001 audit report is available",
+			wantContent: []string{"https://app.test/reports/3"},
+		},
+		{
+			name:     "expired exception",
+			template: ExceptionExpiring,
+			data: ExceptionExpiringData{
+				ClientName:     "Acme",
+				FrameworkName:  "ISO 27001",
+				QuestionNumber: "A.5.1",
+				QuestionText:   "Is there an information security policy?",
+				ExpiresAt:      "1 March 2026",
+				DaysLeft:       -2,
+				AuditURL:       "https://app.test/audit/1",
+			},
+			wantSubject: "ISO 27001 exception expired",
+			wantContent: []string{"expired on", "A.5.1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			email, err := Render(tt.template, Branding{}, tt.data)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if email.Template != tt.template || email.Version != Version {
+				t.Errorf("Render() = %s/%s, want %s/%s", email.Version, email.Template, Version, tt.template)
+			}
+			if email.Subject != tt.wantSubject {
+				t.Errorf("Subject = %q, want %q", email.Subject, tt.wantSubject)
+			}
+			for _, want := range tt.wantContent {
+				if !strings.Contains(email.Text, want) {
+					t.Errorf("Text does not contain %q:\n%s", want, email.Text)
+				}
+				if !strings.Contains(email.HTML, want) {
+					t.Errorf("HTML does not contain %q", want)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderBranding(t *testing.T) {
+	data := InvitationData{ClientName: "Acme", POCEmail: "poc@acme.test", LoginURL: "https://app.test/login"}
+
+	tests := []struct {
+		name     string
+		branding Branding
+		wantHTML []string
+		wantText []string
+	}{
+		{
+			name:     "defaults",
+			branding: Branding{},
+			wantHTML: []string{DefaultBranding().PrimaryColor, DefaultBranding().FooterText},
+			wantText: []string{DefaultBranding().Name},
+		},
+		{
+			name:     "client branding",
+			branding: Branding{Name: "Acme Audits", PrimaryColor: "#ff0000", FooterText: "Sent by Acme"},
+			wantHTML: []string{"#ff0000", "Sent by Acme", "Acme Audits"},
+			wantText: []string{"Acme Audits", "Sent by Acme"},
+		},
+		{
+			name:     "invalid color falls back",
+			branding: Branding{PrimaryColor: "red"},
+			wantHTML: []string{DefaultBranding().PrimaryColor},
+		},
+		{
+			name:     "logo replaces the name",
+			branding: Branding{Name: "Acme", LogoURL: "https://cdn.test/logo.png"},
+			wantHTML: []string{`src="https://cdn.test/logo.png"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			email, err := Render(Invitation, tt.branding, data)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			for _, want := range tt.wantHTML {
+				if !strings.Contains(email.HTML, want) {
+					t.Errorf("HTML does not contain %q", want)
+				}
+			}
+			for _, want := range tt.wantText {
+				if !strings.Contains(email.Text, want) {
+					t.Errorf("Text does not contain %q:\n%s", want, email.Text)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderEscapesHTML(t *testing.T) {
+	email, err := Render(CommentMention, Branding{}, CommentMentionData{
+		RecipientName: "Bob",
+		AuthorName:    "Alice",
+		FrameworkName: "ISO 27001",
+		QuestionText:  "Policy?",
+		Comment:       `<script>alert("x")</script>`,
+		AuditURL:      "https://app.test/audit/1",
+	})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if strings.Contains(email.HTML, "<script>") {
+		t.Errorf("HTML contains the unescaped comment:\n%s", email.HTML)
+	}
+	if !strings.Contains(email.Text, `<script>alert("x")</script>`) {
+		t.Errorf("Text does not contain the comment verbatim:\n%s", email.Text)
+	}
+}
+
+func TestRenderUnknown(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  string
+		template Name
+	}{
+		{name: "unknown template", version: Version, template: "no_such_email"},
+		{name: "unknown version", version: "v0", template: Invitation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := RenderVersion(tt.version, tt.template, Branding{}, nil); err == nil {
+				t.Errorf("RenderVersion(%q, %q) error = nil, want an error", tt.version, tt.template)
+			}
+		})
+	}
+}
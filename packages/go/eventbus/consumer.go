@@ -0,0 +1,399 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.uber.org/zap"
+)
+
+// Header counting the deliveries of an event to its consumer group
+const attemptHeader = "x-attempt"
+
+// HandlerFunc handles an event. Returning an error retries the event with
+// backoff; wrap it with Permanent to dead-letter it right away. Events are
+// delivered at least once, so handlers must be idempotent, e.g. by
+// remembering Envelope.ID.
+type HandlerFunc func(ctx context.Context, env Envelope) error
+
+// PermanentError marks a failure that retrying cannot fix
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string { return e.Err.Error() }
+func (e *PermanentError) Unwrap() error { return e.Err }
+
+// Permanent wraps err so that the event goes to the dead-letter queue
+// without further attempts
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &PermanentError{Err: err}
+}
+
+// IsPermanent reports whether err or an error it wraps is permanent
+func IsPermanent(err error) bool {
+	var p *PermanentError
+	return errors.As(err, &p)
+}
+
+// ConsumerOptions configures a consumer group
+type ConsumerOptions struct {
+	// Name of the group, e.g. search-indexer. Instances with the same group
+	// share its queue, so each event is handled by one of them.
+	Group string
+	// Deliveries before an event is dead-lettered, defaults to 5
+	MaxAttempts int
+	// Delay before the first retry, doubled on every further one up to
+	// RetryMaxDelay; default to 5s and 5m
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+	// Unacknowledged events per instance, defaults to 10
+	Prefetch int
+	// Events handled in parallel per instance, defaults to 1
+	Concurrency int
+}
+
+// Consumer consumes the events of a consumer group.
+//
+// Every group gets a durable queue <exchange>.<group> bound to the handled
+// event types, a retry queue per retry delay whose messages return to the
+// group queue when their delay is over, and the dead-letter queue
+// <exchange>.<group>.dlq holding events that failed MaxAttempts times.
+type Consumer struct {
+	cfg      Config
+	opts     ConsumerOptions
+	log      *zap.SugaredLogger
+	handlers map[Type]HandlerFunc
+}
+
+// NewConsumer creates a consumer for a group. Register handlers with Handle,
+// then call Run.
+func NewConsumer(cfg Config, opts ConsumerOptions, log *zap.SugaredLogger) (*Consumer, error) {
+	if cfg.URL == "" {
+		return nil, errors.New("rabbitmq url is required")
+	}
+	if opts.Group == "" {
+		return nil, errors.New("consumer group is required")
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 5
+	}
+	if opts.RetryBaseDelay <= 0 {
+		opts.RetryBaseDelay = 5 * time.Second
+	}
+	if opts.RetryMaxDelay <= 0 {
+		opts.RetryMaxDelay = 5 * time.Minute
+	}
+	if opts.Prefetch <= 0 {
+		opts.Prefetch = 10
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+
+	return &Consumer{
+		cfg:      cfg.withDefaults(),
+		opts:     opts,
+		log:      log.With("consumer_group", opts.Group),
+		handlers: make(map[Type]HandlerFunc),
+	}, nil
+}
+
+// Handle registers the handler of an event type. Not safe to call while running.
+func (c *Consumer) Handle(t Type, h HandlerFunc) {
+	c.handlers[t] = h
+}
+
+// Queue returns the name of the group queue
+func (c *Consumer) Queue() string {
+	return c.cfg.Exchange + "." + c.opts.Group
+}
+
+// DeadLetterQueue returns the name of the dead-letter queue of the group
+func (c *Consumer) DeadLetterQueue() string {
+	return c.Queue() + ".dlq"
+}
+
+func (c *Consumer) retryQueue(delay time.Duration) string {
+	return fmt.Sprintf("%s.retry.%s", c.Queue(), delay)
+}
+
+// retryDelay returns the delay before delivery attempt+1. Delays are fixed
+// per attempt, without jitter, as each one has its own queue.
+func (c *Consumer) retryDelay(attempt int) time.Duration {
+	delay := c.opts.RetryBaseDelay
+	for i := 1; i < attempt && delay < c.opts.RetryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > c.opts.RetryMaxDelay {
+		delay = c.opts.RetryMaxDelay
+	}
+	return delay
+}
+
+// Run consumes events until ctx is cancelled, reconnecting after the
+// connection is lost
+func (c *Consumer) Run(ctx context.Context) error {
+	if len(c.handlers) == 0 {
+		return errors.New("no event handlers registered")
+	}
+
+	wait := time.Second
+	for {
+		err := c.consume(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		c.log.Errorw("Event consumer disconnected, reconnecting", "error", err, "retry_in", wait)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(wait):
+		}
+		if wait < 30*time.Second {
+			wait *= 2
+		}
+	}
+}
+
+// consume runs one connection until it is lost or ctx is cancelled
+func (c *Consumer) consume(ctx context.Context) error {
+	conn, err := amqp.DialConfig(c.cfg.URL, amqp.Config{
+		Heartbeat:  10 * time.Second,
+		Properties: amqp.Table{"connection_name": "eventbus consumer " + c.opts.Group},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to connect to rabbitmq: %w", err)
+	}
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("failed to open channel: %w", err)
+	}
+	if err := c.declare(ch); err != nil {
+		return err
+	}
+	if err := ch.Qos(c.opts.Prefetch, 0, false); err != nil {
+		return fmt.Errorf("failed to set prefetch: %w", err)
+	}
+
+	// Retries and dead letters are republished on a channel of their own so
+	// that the original is only acknowledged once the copy is confirmed
+	pubCh, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("failed to open channel: %w", err)
+	}
+	if err := pubCh.Confirm(false); err != nil {
+		return fmt.Errorf("failed to enable publisher confirms: %w", err)
+	}
+	republisher := &republisher{ch: pubCh}
+
+	deliveries, err := ch.ConsumeWithContext(ctx, c.Queue(), "", false, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("failed to consume %s: %w", c.Queue(), err)
+	}
+	closed := conn.NotifyClose(make(chan *amqp.Error, 1))
+
+	c.log.Infow("Consuming events", "queue", c.Queue(), "concurrency", c.opts.Concurrency)
+
+	// Deliveries is closed when ctx is cancelled or the connection is lost
+	var wg sync.WaitGroup
+	for i := 0; i < c.opts.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for d := range deliveries {
+				c.handle(ctx, republisher, d)
+			}
+		}()
+	}
+	wg.Wait()
+
+	select {
+	case err := <-closed:
+		if err != nil {
+			return err
+		}
+		return errors.New("connection closed")
+	default:
+		return ctx.Err()
+	}
+}
+
+// declare declares the exchange, the queues of the group and their bindings
+func (c *Consumer) declare(ch *amqp.Channel) error {
+	if err := declareExchange(ch, c.cfg.Exchange); err != nil {
+		return err
+	}
+
+	queue := c.Queue()
+	if _, err := ch.QueueDeclare(queue, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("failed to declare queue %s: %w", queue, err)
+	}
+	for t := range c.handlers {
+		if err := ch.QueueBind(queue, string(t), c.cfg.Exchange, false, nil); err != nil {
+			return fmt.Errorf("failed to bind %s to %s: %w", queue, t, err)
+		}
+	}
+
+	if _, err := ch.QueueDeclare(c.DeadLetterQueue(), true, false, false, false, nil); err != nil {
+		return fmt.Errorf("failed to declare queue %s: %w", c.DeadLetterQueue(), err)
+	}
+
+	// Expired retries are dead-lettered back into the group queue through
+	// the default exchange
+	for attempt := 1; attempt < c.opts.MaxAttempts; attempt++ {
+		delay := c.retryDelay(attempt)
+		_, err := ch.QueueDeclare(c.retryQueue(delay), true, false, false, false, amqp.Table{
+			"x-message-ttl":             delay.Milliseconds(),
+			"x-dead-letter-exchange":    "",
+			"x-dead-letter-routing-key": queue,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to declare queue %s: %w", c.retryQueue(delay), err)
+		}
+	}
+	return nil
+}
+
+func (c *Consumer) handle(ctx context.Context, republisher *republisher, d amqp.Delivery) {
+	attempt := deliveryAttempt(d)
+
+	var env Envelope
+	if err := json.Unmarshal(d.Body, &env); err != nil {
+		c.deadLetter(ctx, republisher, d, attempt, fmt.Errorf("invalid event: %w", err))
+		return
+	}
+
+	handler, ok := c.handlers[env.Type]
+	if !ok {
+		// Bound by an earlier version of the group; nothing handles it anymore
+		_ = d.Ack(false)
+		return
+	}
+
+	err := handler(ctx, env)
+	if err == nil {
+		if err := d.Ack(false); err != nil {
+			c.log.Warnw("Failed to acknowledge event", "error", err, "event_id", env.ID)
+		}
+		return
+	}
+
+	if ctx.Err() != nil {
+		// Shutting down; hand the event to another instance
+		_ = d.Nack(false, true)
+		return
+	}
+
+	if IsPermanent(err) || attempt >= c.opts.MaxAttempts {
+		c.deadLetter(ctx, republisher, d, attempt, err)
+		return
+	}
+
+	delay := c.retryDelay(attempt)
+	c.log.Warnw("Failed to handle event, retrying",
+		"error", err,
+		"event_id", env.ID,
+		"event_type", env.Type,
+		"attempt", attempt,
+		"retry_in", delay)
+	c.forward(ctx, republisher, d, c.retryQueue(delay), attempt+1, err)
+}
+
+func (c *Consumer) deadLetter(ctx context.Context, republisher *republisher, d amqp.Delivery, attempt int, cause error) {
+	c.log.Errorw("Dead-lettering event",
+		"error", cause,
+		"message_id", d.MessageId,
+		"event_type", d.Type,
+		"attempt", attempt)
+	c.forward(ctx, republisher, d, c.DeadLetterQueue(), attempt, cause)
+}
+
+// forward copies a delivery into another queue of the group and acknowledges
+// it once the copy is confirmed. On failure the delivery is requeued.
+func (c *Consumer) forward(ctx context.Context, republisher *republisher, d amqp.Delivery, queue string, attempt int, cause error) {
+	headers := amqp.Table{}
+	for k, v := range d.Headers {
+		headers[k] = v
+	}
+	headers[attemptHeader] = int32(attempt)
+	headers["x-last-error"] = truncate(cause.Error(), 1000)
+
+	msg := amqp.Publishing{
+		Headers:      headers,
+		ContentType:  d.ContentType,
+		DeliveryMode: amqp.Persistent,
+		MessageId:    d.MessageId,
+		Type:         d.Type,
+		AppId:        d.AppId,
+		Timestamp:    d.Timestamp,
+		Body:         d.Body,
+	}
+	if err := republisher.publish(context.WithoutCancel(ctx), queue, msg); err != nil {
+		c.log.Errorw("Failed to forward event, requeueing", "error", err, "queue", queue, "message_id", d.MessageId)
+		_ = d.Nack(false, true)
+		return
+	}
+	if err := d.Ack(false); err != nil {
+		c.log.Warnw("Failed to acknowledge event", "error", err, "message_id", d.MessageId)
+	}
+}
+
+type republisher struct {
+	mu sync.Mutex
+	ch *amqp.Channel
+}
+
+// publish publishes into a queue through the default exchange and waits for
+// the confirm
+func (r *republisher) publish(ctx context.Context, queue string, msg amqp.Publishing) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	confirm, err := r.ch.PublishWithDeferredConfirmWithContext(ctx, "", queue, false, false, msg)
+	if err != nil {
+		return err
+	}
+	acked, err := confirm.WaitContext(ctx)
+	if err != nil {
+		return err
+	}
+	if !acked {
+		return errors.New("broker rejected message")
+	}
+	return nil
+}
+
+// deliveryAttempt returns which delivery to the group this is, starting at 1
+func deliveryAttempt(d amqp.Delivery) int {
+	switch v := d.Headers[attemptHeader].(type) {
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case int:
+		return v
+	}
+	return 1
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
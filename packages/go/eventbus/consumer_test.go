@@ -0,0 +1,66 @@
+package eventbus
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+func TestRetryDelay(t *testing.T) {
+	c := &Consumer{opts: ConsumerOptions{RetryBaseDelay: 5 * time.Second, RetryMaxDelay: time.Minute}}
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: 5 * time.Second},
+		{attempt: 2, want: 10 * time.Second},
+		{attempt: 3, want: 20 * time.Second},
+		{attempt: 4, want: 40 * time.Second},
+		{attempt: 5, want: time.Minute},
+		{attempt: 50, want: time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := c.retryDelay(tt.attempt); got != tt.want {
+			t.Errorf("retryDelay(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestDeliveryAttempt(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers amqp.Table
+		want    int
+	}{
+		{name: "first delivery", want: 1},
+		{name: "int32", headers: amqp.Table{attemptHeader: int32(3)}, want: 3},
+		{name: "int64", headers: amqp.Table{attemptHeader: int64(4)}, want: 4},
+		{name: "unexpected type", headers: amqp.Table{attemptHeader: "2"}, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := deliveryAttempt(amqp.Delivery{Headers: tt.headers}); got != tt.want {
+				t.Errorf("deliveryAttempt() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsPermanent(t *testing.T) {
+	err := errors.New("malformed event")
+
+	if !IsPermanent(Permanent(err)) {
+		t.Error("IsPermanent(Permanent(err)) = false")
+	}
+	if IsPermanent(err) {
+		t.Error("IsPermanent(err) = true")
+	}
+	if Permanent(nil) != nil {
+		t.Error("Permanent(nil) != nil")
+	}
+}
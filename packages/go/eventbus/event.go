@@ -0,0 +1,149 @@
+// Package eventbus carries domain events between services over RabbitMQ.
+//
+// Events are wrapped in an Envelope and published to a topic exchange with
+// their type as routing key. Services store events in an outbox table in the
+// transaction of the change they describe and a Relay publishes them with
+// publisher confirms, so an event is published if and only if the change was
+// committed (at least once). Consumers join a consumer group, a durable
+// queue shared by all instances of the group, and failed events are retried
+// with backoff before landing in the dead-letter queue of the group.
+package eventbus
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Type identifies an event and is its routing key
+type Type string
+
+const (
+	SubmissionSubmitted Type = "submission.submitted"
+	SubmissionReviewed  Type = "submission.reviewed"
+	EvidenceUploaded    Type = "evidence.uploaded"
+	AuditCompleted      Type = "audit.completed"
+	ReportDelivered     Type = "report.delivered"
+	ClientCreated       Type = "client.created"
+)
+
+// Types returns all event types
+func Types() []Type {
+	return []Type{SubmissionSubmitted, SubmissionReviewed, EvidenceUploaded, AuditCompleted, ReportDelivered, ClientCreated}
+}
+
+// Envelope is the wire format of every event
+type Envelope struct {
+	ID   uuid.UUID `json:"id"`
+	Type Type      `json:"type"`
+	// Schema version of Data, bumped on breaking changes of the payload
+	Version    int             `json:"version"`
+	Source     string          `json:"source"` // service that emitted the event
+	ClientID   uuid.UUID       `json:"client_id"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// Payload is the typed data of an event
+type Payload interface {
+	EventType() Type
+}
+
+// New wraps a payload in an envelope with a new event ID
+func New(source string, clientID uuid.UUID, payload Payload) (Envelope, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Envelope{}, fmt.Errorf("failed to encode %s event: %w", payload.EventType(), err)
+	}
+	return Envelope{
+		ID:         uuid.New(),
+		Type:       payload.EventType(),
+		Version:    1,
+		Source:     source,
+		ClientID:   clientID,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	}, nil
+}
+
+// Decode unmarshals the data of the envelope into a payload of its type
+func (e Envelope) Decode(payload Payload) error {
+	if payload.EventType() != e.Type {
+		return fmt.Errorf("cannot decode %s event into %T", e.Type, payload)
+	}
+	if err := json.Unmarshal(e.Data, payload); err != nil {
+		return fmt.Errorf("failed to decode %s event: %w", e.Type, err)
+	}
+	return nil
+}
+
+// Payloads of the events. IDs refer to rows of the client database unless
+// noted otherwise; user IDs refer to tenant users.
+
+type SubmissionSubmittedEvent struct {
+	SubmissionID uuid.UUID `json:"submission_id"`
+	AuditID      uuid.UUID `json:"audit_id"`
+	QuestionID   uuid.UUID `json:"question_id"`
+	SubmittedBy  uuid.UUID `json:"submitted_by"`
+	Version      int32     `json:"version"`
+}
+
+func (SubmissionSubmittedEvent) EventType() Type { return SubmissionSubmitted }
+
+// Review actions of SubmissionReviewedEvent
+const (
+	ReviewApproved = "approved"
+	ReviewRejected = "rejected"
+	ReviewReferred = "referred"
+)
+
+type SubmissionReviewedEvent struct {
+	SubmissionID uuid.UUID `json:"submission_id"`
+	AuditID      uuid.UUID `json:"audit_id"`
+	QuestionID   uuid.UUID `json:"question_id"`
+	SubmittedBy  uuid.UUID `json:"submitted_by"`
+	ReviewedBy   uuid.UUID `json:"reviewed_by"`
+	Action       string    `json:"action"` // approved, rejected or referred
+	Notes        string    `json:"notes,omitempty"`
+}
+
+func (SubmissionReviewedEvent) EventType() Type { return SubmissionReviewed }
+
+type EvidenceUploadedEvent struct {
+	EvidenceID   uuid.UUID `json:"evidence_id"`
+	SubmissionID uuid.UUID `json:"submission_id"`
+	UploadedBy   uuid.UUID `json:"uploaded_by"`
+	FileName     string    `json:"file_name"`
+	FileSize     int64     `json:"file_size"`
+	ContentType  string    `json:"content_type"`
+}
+
+func (EvidenceUploadedEvent) EventType() Type { return EvidenceUploaded }
+
+type AuditCompletedEvent struct {
+	AuditID       uuid.UUID `json:"audit_id"`
+	FrameworkID   uuid.UUID `json:"framework_id"`
+	FrameworkName string    `json:"framework_name"`
+	CompletedAt   time.Time `json:"completed_at"`
+}
+
+func (AuditCompletedEvent) EventType() Type { return AuditCompleted }
+
+type ReportDeliveredEvent struct {
+	ReportID uuid.UUID `json:"report_id"`
+	AuditID  uuid.UUID `json:"audit_id"`
+}
+
+func (ReportDeliveredEvent) EventType() Type { return ReportDelivered }
+
+// ClientCreatedEvent is emitted by client-service; the client ID is the one
+// of the envelope
+type ClientCreatedEvent struct {
+	Name        string `json:"name"`
+	POCEmail    string `json:"poc_email"`
+	EmailDomain string `json:"email_domain,omitempty"`
+}
+
+func (ClientCreatedEvent) EventType() Type { return ClientCreated }
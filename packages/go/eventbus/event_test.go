@@ -0,0 +1,33 @@
+package eventbus
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestEnvelopeRoundTrip(t *testing.T) {
+	clientID := uuid.New()
+	payload := AuditCompletedEvent{AuditID: uuid.New(), FrameworkID: uuid.New(), FrameworkName: "ISO 27001"}
+
+	env, err := New("tenant-service", clientID, payload)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if env.Type != AuditCompleted || env.Version != 1 || env.ClientID != clientID || env.ID == uuid.Nil {
+		t.Errorf("envelope = %+v", env)
+	}
+
+	var got AuditCompletedEvent
+	if err := env.Decode(&got); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if got != payload {
+		t.Errorf("Decode() = %+v, want %+v", got, payload)
+	}
+
+	var other ReportDeliveredEvent
+	if err := env.Decode(&other); err == nil {
+		t.Error("Decode() into a payload of another type succeeded")
+	}
+}
@@ -0,0 +1,14 @@
+module github.com/NormaTech-AI/audity/packages/go/eventbus
+
+go 1.25
+
+require (
+	github.com/NormaTech-AI/audity/packages/go/retry v0.0.0
+	github.com/google/uuid v1.6.0
+	github.com/rabbitmq/amqp091-go v1.10.0
+	go.uber.org/zap v1.27.0
+)
+
+require go.uber.org/multierr v1.10.0 // indirect
+
+replace github.com/NormaTech-AI/audity/packages/go/retry => ../retry
@@ -0,0 +1,197 @@
+package eventbus
+
+import (
+	"context"
+	"time"
+
+	"github.com/NormaTech-AI/audity/packages/go/retry"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// Bound on publishing a single event
+	publishTimeout = 30 * time.Second
+	// Claimed events are picked up again by another relay when this expires,
+	// so it must outlast a batch of publishes
+	relayLease = 5 * time.Minute
+	// How often published events past the retention are deleted
+	cleanupInterval = time.Hour
+)
+
+// OutboxEvent is an event claimed from an outbox
+type OutboxEvent struct {
+	Envelope Envelope
+	Attempts int // publish attempts, this one included
+}
+
+// Outbox is an outbox table events are written to in the transaction of the
+// change they describe
+type Outbox interface {
+	// Claim leases up to limit unpublished events that are due, oldest first,
+	// so that other relays skip them
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]OutboxEvent, error)
+	MarkPublished(ctx context.Context, id uuid.UUID) error
+	// Reschedule releases an event that failed to publish until next
+	Reschedule(ctx context.Context, id uuid.UUID, next time.Time, lastError string) error
+	// DeletePublished deletes events published before the given time
+	DeletePublished(ctx context.Context, before time.Time) (int64, error)
+}
+
+// OutboxSource returns the outboxes a relay drains, e.g. one per database
+type OutboxSource func(ctx context.Context) ([]Outbox, error)
+
+// Outboxes returns a source of a fixed set of outboxes
+func Outboxes(outboxes ...Outbox) OutboxSource {
+	return func(context.Context) ([]Outbox, error) { return outboxes, nil }
+}
+
+// RelayOptions configures a Relay
+type RelayOptions struct {
+	PollInterval time.Duration // defaults to 5s
+	BatchSize    int           // events claimed at once, defaults to 100
+	// Backoff of events that failed to publish, default to 1s and 5m. Events
+	// are retried until they are published.
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+	// How long published events are kept, defaults to 7 days
+	Retention time.Duration
+}
+
+// Relay publishes the events of outboxes. Several relays can drain the same
+// outboxes; every event is published at least once.
+type Relay struct {
+	pub    *Publisher
+	source OutboxSource
+	opts   RelayOptions
+	log    *zap.SugaredLogger
+	wake   chan struct{}
+}
+
+// NewRelay creates a relay. Call Run to start publishing.
+func NewRelay(pub *Publisher, source OutboxSource, opts RelayOptions, log *zap.SugaredLogger) *Relay {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = 5 * time.Second
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 100
+	}
+	if opts.RetryBaseDelay <= 0 {
+		opts.RetryBaseDelay = time.Second
+	}
+	if opts.RetryMaxDelay <= 0 {
+		opts.RetryMaxDelay = 5 * time.Minute
+	}
+	if opts.Retention <= 0 {
+		opts.Retention = 7 * 24 * time.Hour
+	}
+	return &Relay{pub: pub, source: source, opts: opts, log: log, wake: make(chan struct{}, 1)}
+}
+
+// Wake makes the relay drain the outboxes now instead of at the next poll,
+// e.g. after an event was written
+func (r *Relay) Wake() {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run publishes events until ctx is cancelled
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.opts.PollInterval)
+	defer ticker.Stop()
+
+	lastCleanup := time.Time{}
+	for {
+		outboxes, err := r.source(ctx)
+		if err != nil && ctx.Err() == nil {
+			r.log.Errorw("Failed to list event outboxes", "error", err)
+		}
+
+		cleanup := time.Since(lastCleanup) >= cleanupInterval
+		for _, outbox := range outboxes {
+			r.drain(ctx, outbox)
+			if cleanup {
+				r.cleanup(ctx, outbox)
+			}
+		}
+		if cleanup {
+			lastCleanup = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-r.wake:
+		}
+	}
+}
+
+// drain publishes the due events of an outbox until none are left
+func (r *Relay) drain(ctx context.Context, outbox Outbox) {
+	for {
+		events, err := outbox.Claim(ctx, r.opts.BatchSize, relayLease)
+		if err != nil {
+			if ctx.Err() == nil {
+				r.log.Errorw("Failed to claim outbox events", "error", err)
+			}
+			return
+		}
+
+		for i, event := range events {
+			err := r.publish(ctx, event.Envelope)
+			if ctx.Err() != nil {
+				// Shutting down; the leases expire and the events are picked up again
+				return
+			}
+			if err != nil {
+				// The broker is likely down, so the rest of the batch is put
+				// off as well instead of failing one publish after another
+				next := time.Now().Add(retry.Backoff(event.Attempts, r.opts.RetryBaseDelay, r.opts.RetryMaxDelay))
+				r.log.Warnw("Failed to publish event, rescheduled",
+					"error", err,
+					"event_id", event.Envelope.ID,
+					"event_type", event.Envelope.Type,
+					"attempts", event.Attempts,
+					"next_attempt_at", next,
+					"batch_rescheduled", len(events)-i)
+				lastError := err.Error()
+				for _, rest := range events[i:] {
+					if err := outbox.Reschedule(ctx, rest.Envelope.ID, next, lastError); err != nil {
+						r.log.Errorw("Failed to reschedule event", "error", err, "event_id", rest.Envelope.ID)
+					}
+				}
+				return
+			}
+
+			if err := outbox.MarkPublished(ctx, event.Envelope.ID); err != nil {
+				// Published again once the lease expires; consumers are idempotent
+				r.log.Errorw("Failed to mark event published", "error", err, "event_id", event.Envelope.ID)
+			}
+		}
+		if len(events) < r.opts.BatchSize {
+			return
+		}
+	}
+}
+
+func (r *Relay) publish(ctx context.Context, env Envelope) error {
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+	return r.pub.Publish(ctx, env)
+}
+
+func (r *Relay) cleanup(ctx context.Context, outbox Outbox) {
+	deleted, err := outbox.DeletePublished(ctx, time.Now().Add(-r.opts.Retention))
+	if err != nil {
+		if ctx.Err() == nil {
+			r.log.Errorw("Failed to delete published events", "error", err)
+		}
+		return
+	}
+	if deleted > 0 {
+		r.log.Infow("Deleted published events", "count", deleted)
+	}
+}
@@ -0,0 +1,141 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// fakeOutbox hands out its events in batches and records what became of them
+type fakeOutbox struct {
+	events      []OutboxEvent
+	claimErr    error
+	leases      []time.Duration
+	published   []uuid.UUID
+	rescheduled map[uuid.UUID]time.Time
+	deleteAt    time.Time
+}
+
+func (f *fakeOutbox) Claim(ctx context.Context, limit int, lease time.Duration) ([]OutboxEvent, error) {
+	f.leases = append(f.leases, lease)
+	if f.claimErr != nil {
+		return nil, f.claimErr
+	}
+	n := min(limit, len(f.events))
+	claimed := f.events[:n]
+	f.events = f.events[n:]
+	return claimed, nil
+}
+
+func (f *fakeOutbox) MarkPublished(ctx context.Context, id uuid.UUID) error {
+	f.published = append(f.published, id)
+	return nil
+}
+
+func (f *fakeOutbox) Reschedule(ctx context.Context, id uuid.UUID, next time.Time, lastError string) error {
+	if f.rescheduled == nil {
+		f.rescheduled = make(map[uuid.UUID]time.Time)
+	}
+	f.rescheduled[id] = next
+	return nil
+}
+
+func (f *fakeOutbox) DeletePublished(ctx context.Context, before time.Time) (int64, error) {
+	f.deleteAt = before
+	return 0, nil
+}
+
+func outboxEvent(attempts int) OutboxEvent {
+	return OutboxEvent{Envelope: Envelope{ID: uuid.New(), Type: AuditCompleted}, Attempts: attempts}
+}
+
+// unreachableRelay returns a relay whose broker refuses connections
+func unreachableRelay(t *testing.T, opts RelayOptions) *Relay {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	pub, err := NewPublisher(Config{URL: "amqp://guest:guest@" + addr + "/"}, zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatalf("NewPublisher() error = %v", err)
+	}
+	return NewRelay(pub, Outboxes(), opts, zap.NewNop().Sugar())
+}
+
+func TestDrainReschedulesBatchWhenBrokerDown(t *testing.T) {
+	relay := unreachableRelay(t, RelayOptions{BatchSize: 2, RetryBaseDelay: time.Minute, RetryMaxDelay: time.Hour})
+	first, second, third := outboxEvent(3), outboxEvent(1), outboxEvent(1)
+	outbox := &fakeOutbox{events: []OutboxEvent{first, second, third}}
+
+	before := time.Now()
+	relay.drain(context.Background(), outbox)
+
+	if len(outbox.leases) != 1 || outbox.leases[0] != relayLease {
+		t.Errorf("claims = %v, want a single claim leased for %v", outbox.leases, relayLease)
+	}
+	if len(outbox.published) != 0 {
+		t.Errorf("published = %v without a broker", outbox.published)
+	}
+	if len(outbox.rescheduled) != 2 {
+		t.Fatalf("rescheduled %d events, want the claimed batch of 2", len(outbox.rescheduled))
+	}
+	// The batch is put off by the backoff of its first failed event
+	next := outbox.rescheduled[first.Envelope.ID]
+	if outbox.rescheduled[second.Envelope.ID] != next {
+		t.Errorf("batch rescheduled at %v and %v, want the same time", next, outbox.rescheduled[second.Envelope.ID])
+	}
+	if next.Before(before.Add(2*time.Minute)) || next.After(time.Now().Add(time.Hour)) {
+		t.Errorf("next attempt at %v, want the backoff of a third attempt", next)
+	}
+	if _, ok := outbox.rescheduled[third.Envelope.ID]; ok {
+		t.Error("unclaimed event rescheduled")
+	}
+}
+
+func TestDrainStopsWhenClaimFails(t *testing.T) {
+	relay := unreachableRelay(t, RelayOptions{})
+	outbox := &fakeOutbox{claimErr: errors.New("connection refused")}
+
+	relay.drain(context.Background(), outbox)
+
+	if len(outbox.leases) != 1 || len(outbox.rescheduled) != 0 {
+		t.Errorf("claims = %d rescheduled = %d, want one claim and nothing rescheduled", len(outbox.leases), len(outbox.rescheduled))
+	}
+}
+
+func TestCleanupDeletesEventsPastRetention(t *testing.T) {
+	relay := unreachableRelay(t, RelayOptions{Retention: 24 * time.Hour})
+	outbox := &fakeOutbox{}
+
+	relay.cleanup(context.Background(), outbox)
+
+	want := time.Now().Add(-24 * time.Hour)
+	if d := want.Sub(outbox.deleteAt); d < 0 || d > time.Minute {
+		t.Errorf("deleted events published before %v, want %v", outbox.deleteAt, want)
+	}
+}
+
+func TestNewRelayDefaults(t *testing.T) {
+	relay := NewRelay(nil, Outboxes(), RelayOptions{}, zap.NewNop().Sugar())
+
+	want := RelayOptions{
+		PollInterval:   5 * time.Second,
+		BatchSize:      100,
+		RetryBaseDelay: time.Second,
+		RetryMaxDelay:  5 * time.Minute,
+		Retention:      7 * 24 * time.Hour,
+	}
+	if relay.opts != want {
+		t.Errorf("options = %+v, want %+v", relay.opts, want)
+	}
+}
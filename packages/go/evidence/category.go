@@ -0,0 +1,40 @@
+// Package evidence holds the evidence vocabulary shared by the services:
+// framework-service validates evidence requirements against it and
+// tenant-service files uploaded evidence under it.
+package evidence
+
+// Document categories evidence files can be filed under
+const (
+	CategoryPolicy        = "policy"
+	CategoryProcedure     = "procedure"
+	CategoryScreenshot    = "screenshot"
+	CategoryLogExtract    = "log_extract"
+	CategoryReport        = "report"
+	CategoryCertificate   = "certificate"
+	CategoryConfiguration = "configuration"
+	CategoryOther         = "other"
+)
+
+// Categories returns all document categories
+func Categories() []string {
+	return []string{
+		CategoryPolicy,
+		CategoryProcedure,
+		CategoryScreenshot,
+		CategoryLogExtract,
+		CategoryReport,
+		CategoryCertificate,
+		CategoryConfiguration,
+		CategoryOther,
+	}
+}
+
+// ValidCategory reports whether category is a known document category
+func ValidCategory(category string) bool {
+	for _, known := range Categories() {
+		if category == known {
+			return true
+		}
+	}
+	return false
+}
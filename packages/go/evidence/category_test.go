@@ -0,0 +1,25 @@
+package evidence
+
+import "testing"
+
+func TestValidCategory(t *testing.T) {
+	tests := []struct {
+		category string
+		want     bool
+	}{
+		{category: CategoryPolicy, want: true},
+		{category: CategoryLogExtract, want: true},
+		{category: CategoryOther, want: true},
+		{category: "Policy", want: false},
+		{category: "log-extract", want: false},
+		{category: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.category, func(t *testing.T) {
+			if got := ValidCategory(tt.category); got != tt.want {
+				t.Errorf("ValidCategory(%q) = %v, want %v", tt.category, got, tt.want)
+			}
+		})
+	}
+}
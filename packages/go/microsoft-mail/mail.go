@@ -2,11 +2,16 @@ package microsoftmail
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
+	"time"
+
 	"go.uber.org/zap"
 )
 
@@ -22,22 +27,41 @@ type Config struct {
 
 // Client represents a Microsoft Graph API client for sending emails.
 type Client struct {
-	config      Config
+	config     Config
+	httpClient *http.Client
+
+	mu          sync.Mutex
 	accessToken string
+	tokenExpiry time.Time
 }
 
 // NewClient creates a new Microsoft Graph API client with the given configuration.
 func NewClient(config Config, log *zap.SugaredLogger) (*Client, error) {
 	return &Client{
-		config: config,
+		config:     config,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
 	}, nil
 }
 
+// StatusError is returned when Graph answers a sendMail request with an
+// unexpected status, so callers can tell rejected messages from transient
+// failures.
+type StatusError struct {
+	StatusCode int
+	Status     string
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("status: %s, body: %s", e.Status, e.Body)
+}
+
 // --- Structs for Microsoft Graph API ---
 
 // TokenResponse is used to decode the OAuth access token response.
 type TokenResponse struct {
 	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"` // seconds
 }
 
 // Below structs are for building the sendMail JSON payload.
@@ -65,7 +89,7 @@ type SendMailPayload struct {
 }
 
 // getAccessToken fetches an OAuth 2.0 access token from Microsoft.
-func (c *Client) getAccessToken(logger *zap.SugaredLogger) error {
+func (c *Client) getAccessToken(ctx context.Context, logger *zap.SugaredLogger) error {
 	tokenURL := fmt.Sprintf("https://login.microsoftonline.com/%s/oauth2/v2.0/token", c.config.TenantID)
 	
 	logger.Infow("Requesting access token", 
@@ -81,15 +105,14 @@ func (c *Client) getAccessToken(logger *zap.SugaredLogger) error {
 	data.Set("grant_type", "client_credentials")
 	data.Set("scope", "https://graph.microsoft.com/.default")
 
-	req, err := http.NewRequest("POST", tokenURL, strings.NewReader(data.Encode()))
+	req, err := http.NewRequestWithContext(ctx, "POST", tokenURL, strings.NewReader(data.Encode()))
 	if err != nil {
 		logger.Errorw("error creating token request", "error", err)
 		return fmt.Errorf("error creating token request: %v", err)
 	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		logger.Errorw("error performing token request", "error", err)
 		return fmt.Errorf("error performing token request: %v", err)
@@ -115,6 +138,8 @@ func (c *Client) getAccessToken(logger *zap.SugaredLogger) error {
 	}
 
 	c.accessToken = tokenResponse.AccessToken
+	// Refresh a minute early so a token never expires mid-request
+	c.tokenExpiry = time.Now().Add(time.Duration(tokenResponse.ExpiresIn)*time.Second - time.Minute)
 	logger.Info("Access token acquired successfully")
 	return nil
 }
@@ -128,7 +153,7 @@ type EmailParams struct {
 }
 
 // sendMail uses the Graph API to send an email.
-func (c *Client) sendMail(params EmailParams, logger *zap.SugaredLogger) error {
+func (c *Client) sendMail(ctx context.Context, accessToken string, params EmailParams, logger *zap.SugaredLogger) error {
 	// This is the API endpoint. We send mail "from" the user specified.
 	graphURL := fmt.Sprintf("https://graph.microsoft.com/v1.0/users/%s/sendMail", c.config.SenderEmail)
 
@@ -167,19 +192,18 @@ func (c *Client) sendMail(params EmailParams, logger *zap.SugaredLogger) error {
 	}
 
 	// 3. Create the HTTP request
-	req, err := http.NewRequest("POST", graphURL, bytes.NewBuffer(jsonBody))
+	req, err := http.NewRequestWithContext(ctx, "POST", graphURL, bytes.NewBuffer(jsonBody))
 	if err != nil {
 		logger.Errorw("error creating graph request", "error", err)
 		return fmt.Errorf("error creating graph request: %v", err)
 	}
 
 	// 4. Set headers
-	req.Header.Set("Authorization", "Bearer "+c.accessToken)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
 	req.Header.Set("Content-Type", "application/json")
 
 	// 5. Execute the request
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		logger.Errorw("error performing graph request", "error", err)
 		return fmt.Errorf("error performing graph request: %v", err)
@@ -192,27 +216,60 @@ func (c *Client) sendMail(params EmailParams, logger *zap.SugaredLogger) error {
 		var errorBody bytes.Buffer
 		errorBody.ReadFrom(resp.Body)
 		logger.Errorw("failed to send mail", "status", resp.Status, "body", errorBody.String())
-		return fmt.Errorf("failed to send mail, status: %s, body: %s", resp.Status, errorBody.String())
+		return fmt.Errorf("failed to send mail, %w", &StatusError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Body:       errorBody.String(),
+		})
 	}
 	logger.Info("email sent successfully")
 	return nil
 }
 
+// token returns a valid access token, requesting a new one when the cached
+// token is missing or about to expire.
+func (c *Client) token(ctx context.Context, logger *zap.SugaredLogger) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.accessToken == "" || time.Now().After(c.tokenExpiry) {
+		if err := c.getAccessToken(ctx, logger); err != nil {
+			return "", err
+		}
+	}
+	return c.accessToken, nil
+}
+
+// invalidateToken drops the cached access token, e.g. after Graph rejected it
+func (c *Client) invalidateToken() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.accessToken = ""
+}
+
 // SendEmail sends an email using the Microsoft Graph API.
 // It automatically handles token acquisition if needed.
 func (c *Client) SendEmail(params EmailParams, logger *zap.SugaredLogger) error {
-	// Get access token if we don't have one
-	logger.Info("Sending email...", "params", params)
-	logger.Info(c)
-	if c.accessToken == "" {
-		if err := c.getAccessToken(logger); err != nil {
-			logger.Errorw("failed to get access token", "error", err)
-			return fmt.Errorf("failed to get access token: %w", err)
-		}
+	return c.SendEmailContext(context.Background(), params, logger)
+}
+
+// SendEmailContext is SendEmail with a context bounding the token and send
+// requests.
+func (c *Client) SendEmailContext(ctx context.Context, params EmailParams, logger *zap.SugaredLogger) error {
+	logger.Infow("Sending email...", "to", params.To, "subject", params.Subject)
+
+	accessToken, err := c.token(ctx, logger)
+	if err != nil {
+		logger.Errorw("failed to get access token", "error", err)
+		return fmt.Errorf("failed to get access token: %w", err)
 	}
 
 	// Send the email
-	if err := c.sendMail(params, logger); err != nil {
+	if err := c.sendMail(ctx, accessToken, params, logger); err != nil {
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusUnauthorized {
+			c.invalidateToken()
+		}
 		return fmt.Errorf("failed to send email: %w", err)
 	}
 
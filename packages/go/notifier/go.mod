@@ -0,0 +1,16 @@
+module github.com/NormaTech-AI/audity/packages/go/notifier
+
+go 1.25
+
+require (
+	github.com/NormaTech-AI/audity/packages/go/microsoft-mail v0.0.0
+	github.com/NormaTech-AI/audity/packages/go/retry v0.0.0
+	go.uber.org/zap v1.27.0
+)
+
+require go.uber.org/multierr v1.10.0 // indirect
+
+replace (
+	github.com/NormaTech-AI/audity/packages/go/microsoft-mail => ../microsoft-mail
+	github.com/NormaTech-AI/audity/packages/go/retry => ../retry
+)
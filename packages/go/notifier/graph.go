@@ -0,0 +1,66 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	microsoftmail "github.com/NormaTech-AI/audity/packages/go/microsoft-mail"
+	"go.uber.org/zap"
+)
+
+// Graph sends messages through the Microsoft Graph sendMail API
+type Graph struct {
+	client *microsoftmail.Client
+	log    *zap.SugaredLogger
+}
+
+// NewGraph creates a Graph notifier sending as cfg.SenderEmail
+func NewGraph(cfg microsoftmail.Config, log *zap.SugaredLogger) (*Graph, error) {
+	client, err := microsoftmail.NewClient(cfg, log)
+	if err != nil {
+		return nil, err
+	}
+	return &Graph{client: client, log: log}, nil
+}
+
+func (g *Graph) Name() string { return "graph" }
+
+// Send delivers msg. Graph takes a single body, so the HTML body is preferred
+// over the text body.
+func (g *Graph) Send(ctx context.Context, msg Message) error {
+	if err := validate(msg); err != nil {
+		return err
+	}
+
+	params := microsoftmail.EmailParams{
+		To:          msg.To,
+		Subject:     msg.Subject,
+		Body:        msg.HTMLBody,
+		ContentType: "HTML",
+	}
+	if msg.HTMLBody == "" {
+		params.Body = msg.TextBody
+		params.ContentType = "Text"
+	}
+
+	err := g.client.SendEmailContext(ctx, params, g.log)
+	if err != nil && isRejected(err) {
+		return Permanent(err)
+	}
+	return err
+}
+
+// isRejected reports whether Graph rejected the request itself. Expired
+// tokens, timeouts and throttling are worth retrying.
+func isRejected(err error) bool {
+	var statusErr *microsoftmail.StatusError
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+	switch statusErr.StatusCode {
+	case http.StatusUnauthorized, http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return false
+	}
+	return statusErr.StatusCode >= 400 && statusErr.StatusCode < 500
+}
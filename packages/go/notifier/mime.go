@@ -0,0 +1,98 @@
+package notifier
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// buildMIME renders msg as an RFC 5322 message from the given sender. Messages
+// with both bodies are sent as multipart/alternative, text first.
+func buildMIME(from mail.Address, msg Message) ([]byte, error) {
+	var buf bytes.Buffer
+
+	to := make([]string, len(msg.To))
+	for i, addr := range msg.To {
+		to[i] = (&mail.Address{Address: addr}).String()
+	}
+
+	header := func(key, value string) {
+		fmt.Fprintf(&buf, "%s: %s\r\n", key, value)
+	}
+	header("From", from.String())
+	header("To", strings.Join(to, ", "))
+	header("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("Message-ID", messageID(from.Address))
+	header("MIME-Version", "1.0")
+
+	if msg.HTMLBody == "" || msg.TextBody == "" {
+		contentType, body := "text/html", msg.HTMLBody
+		if msg.HTMLBody == "" {
+			contentType, body = "text/plain", msg.TextBody
+		}
+		header("Content-Type", contentType+"; charset=utf-8")
+		header("Content-Transfer-Encoding", "quoted-printable")
+		buf.WriteString("\r\n")
+		if err := writeQuotedPrintable(&buf, body); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	parts := multipart.NewWriter(&buf)
+	header("Content-Type", "multipart/alternative; boundary="+parts.Boundary())
+	buf.WriteString("\r\n")
+
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain", msg.TextBody},
+		{"text/html", msg.HTMLBody},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType + "; charset=utf-8"},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeQuotedPrintable(w, part.body); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeQuotedPrintable(w io.Writer, body string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(body)); err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+// messageID returns a unique Message-ID in the sender's domain
+func messageID(sender string) string {
+	domain := "localhost"
+	if at := strings.LastIndex(sender, "@"); at >= 0 && at < len(sender)-1 {
+		domain = sender[at+1:]
+	}
+	return fmt.Sprintf("<%s@%s>", randomHex(16), domain)
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
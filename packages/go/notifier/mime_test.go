@@ -0,0 +1,123 @@
+package notifier
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"testing"
+)
+
+func TestBuildMIME(t *testing.T) {
+	from := mail.Address{Name: "Audity", Address: "noreply@audity.example"}
+
+	tests := []struct {
+		name      string
+		msg       Message
+		wantTypes []string
+	}{
+		{name: "text only", msg: Message{TextBody: "Hello"}, wantTypes: []string{"text/plain"}},
+		{name: "HTML only", msg: Message{HTMLBody: "<p>Hello</p>"}, wantTypes: []string{"text/html"}},
+		{name: "both bodies", msg: Message{TextBody: "Hello", HTMLBody: "<p>Hello</p>"}, wantTypes: []string{"text/plain", "text/html"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.msg.To = []string{"a@example.com", "b@example.com"}
+			tt.msg.Subject = "Prüfung fällig"
+
+			raw, err := buildMIME(from, tt.msg)
+			if err != nil {
+				t.Fatalf("buildMIME() error = %v", err)
+			}
+			parsed, err := mail.ReadMessage(bytes.NewReader(raw))
+			if err != nil {
+				t.Fatalf("invalid message: %v", err)
+			}
+
+			if got := parsed.Header.Get("To"); got != "<a@example.com>, <b@example.com>" {
+				t.Errorf("To = %q", got)
+			}
+			subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+			if err != nil || subject != tt.msg.Subject {
+				t.Errorf("Subject = %q, %v, want %q", subject, err, tt.msg.Subject)
+			}
+			if id := parsed.Header.Get("Message-ID"); !strings.HasSuffix(id, "@audity.example>") {
+				t.Errorf("Message-ID = %q, want one in the sender's domain", id)
+			}
+
+			bodies := messageBodies(t, parsed)
+			var types []string
+			for contentType := range bodies {
+				types = append(types, contentType)
+			}
+			if len(types) != len(tt.wantTypes) {
+				t.Fatalf("content types = %v, want %v", types, tt.wantTypes)
+			}
+			if tt.msg.TextBody != "" && bodies["text/plain"] != tt.msg.TextBody {
+				t.Errorf("text body = %q, want %q", bodies["text/plain"], tt.msg.TextBody)
+			}
+			if tt.msg.HTMLBody != "" && bodies["text/html"] != tt.msg.HTMLBody {
+				t.Errorf("HTML body = %q, want %q", bodies["text/html"], tt.msg.HTMLBody)
+			}
+		})
+	}
+}
+
+// messageBodies returns the decoded bodies of a message by content type
+func messageBodies(t *testing.T, msg *mail.Message) map[string]string {
+	t.Helper()
+
+	decode := func(r io.Reader) string {
+		body, err := io.ReadAll(quotedprintable.NewReader(r))
+		if err != nil {
+			t.Fatalf("invalid quoted-printable body: %v", err)
+		}
+		return string(body)
+	}
+
+	contentType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatalf("invalid Content-Type: %v", err)
+	}
+	if contentType != "multipart/alternative" {
+		return map[string]string{contentType: decode(msg.Body)}
+	}
+
+	bodies := make(map[string]string)
+	parts := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := parts.NextRawPart()
+		if err == io.EOF {
+			return bodies
+		}
+		if err != nil {
+			t.Fatalf("invalid multipart body: %v", err)
+		}
+		partType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		bodies[partType] = decode(part)
+	}
+}
+
+func TestMessageID(t *testing.T) {
+	tests := []struct {
+		sender     string
+		wantSuffix string
+	}{
+		{sender: "noreply@audity.example", wantSuffix: "@audity.example>"},
+		{sender: "noreply", wantSuffix: "@localhost>"},
+		{sender: "noreply@", wantSuffix: "@localhost>"},
+	}
+
+	for _, tt := range tests {
+		if got := messageID(tt.sender); !strings.HasPrefix(got, "<") || !strings.HasSuffix(got, tt.wantSuffix) {
+			t.Errorf("messageID(%q) = %q, want a suffix of %q", tt.sender, got, tt.wantSuffix)
+		}
+	}
+	if messageID("a@b") == messageID("a@b") {
+		t.Error("messageID() returned the same ID twice")
+	}
+}
@@ -0,0 +1,61 @@
+// Package notifier sends email through interchangeable providers: Microsoft
+// Graph, plain SMTP, and log or file sinks for development. Providers can be
+// wrapped with Retry to retry transient failures with exponential backoff.
+package notifier
+
+import (
+	"context"
+	"errors"
+)
+
+// Message is a provider-agnostic email. At least one of HTMLBody and TextBody
+// must be set; providers that support it send both as alternatives.
+type Message struct {
+	To       []string
+	Subject  string
+	HTMLBody string
+	TextBody string
+}
+
+// Notifier delivers messages through one provider
+type Notifier interface {
+	// Name identifies the provider in logs, e.g. graph or smtp
+	Name() string
+	// Send delivers msg. Errors wrapped with Permanent must not be retried.
+	Send(ctx context.Context, msg Message) error
+}
+
+// PermanentError marks a failure that retrying cannot fix, such as a rejected
+// recipient or an invalid message
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string { return e.Err.Error() }
+
+func (e *PermanentError) Unwrap() error { return e.Err }
+
+// Permanent wraps err so that IsPermanent reports true for it
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &PermanentError{Err: err}
+}
+
+// IsPermanent reports whether err, or any error it wraps, is permanent
+func IsPermanent(err error) bool {
+	var permanent *PermanentError
+	return errors.As(err, &permanent)
+}
+
+// validate rejects messages no provider can deliver
+func validate(msg Message) error {
+	if len(msg.To) == 0 {
+		return Permanent(errors.New("message has no recipients"))
+	}
+	if msg.HTMLBody == "" && msg.TextBody == "" {
+		return Permanent(errors.New("message has no body"))
+	}
+	return nil
+}
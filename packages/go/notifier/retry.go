@@ -0,0 +1,59 @@
+package notifier
+
+import (
+	"context"
+	"time"
+
+	"github.com/NormaTech-AI/audity/packages/go/retry"
+	"go.uber.org/zap"
+)
+
+// RetryOptions configures Retry
+type RetryOptions struct {
+	Attempts  int           // total attempts including the first, defaults to 3
+	BaseDelay time.Duration // defaults to 1s
+	MaxDelay  time.Duration // defaults to 30s
+}
+
+type retrying struct {
+	Notifier
+	opts RetryOptions
+	log  *zap.SugaredLogger
+}
+
+// Retry wraps n so that failed sends are retried with exponential backoff.
+// Permanent errors and cancelled contexts are returned immediately.
+func Retry(n Notifier, opts RetryOptions, log *zap.SugaredLogger) Notifier {
+	if opts.Attempts <= 0 {
+		opts.Attempts = 3
+	}
+	if opts.BaseDelay <= 0 {
+		opts.BaseDelay = time.Second
+	}
+	if opts.MaxDelay <= 0 {
+		opts.MaxDelay = 30 * time.Second
+	}
+	return &retrying{Notifier: n, opts: opts, log: log}
+}
+
+func (r *retrying) Send(ctx context.Context, msg Message) error {
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = r.Notifier.Send(ctx, msg)
+		if err == nil || IsPermanent(err) || attempt >= r.opts.Attempts {
+			return err
+		}
+
+		delay := retry.Backoff(attempt, r.opts.BaseDelay, r.opts.MaxDelay)
+		r.log.Warnw("Failed to send email, retrying",
+			"provider", r.Name(), "attempt", attempt, "retry_in", delay, "error", err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// flaky fails its first sends with the given errors and counts every send
+type flaky struct {
+	errs  []error
+	sends int
+}
+
+func (f *flaky) Name() string { return "flaky" }
+
+func (f *flaky) Send(ctx context.Context, msg Message) error {
+	f.sends++
+	if f.sends <= len(f.errs) {
+		return f.errs[f.sends-1]
+	}
+	return nil
+}
+
+func TestRetry(t *testing.T) {
+	transient := errors.New("connection reset")
+	rejected := Permanent(errors.New("mailbox unavailable"))
+
+	tests := []struct {
+		name      string
+		errs      []error
+		wantErr   error
+		wantSends int
+	}{
+		{name: "first attempt", wantSends: 1},
+		{name: "transient failures", errs: []error{transient, transient}, wantSends: 3},
+		{name: "out of attempts", errs: []error{transient, transient, transient}, wantErr: transient, wantSends: 3},
+		{name: "permanent failure", errs: []error{rejected}, wantErr: rejected, wantSends: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := &flaky{errs: tt.errs}
+			r := Retry(n, RetryOptions{Attempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}, zap.NewNop().Sugar())
+
+			err := r.Send(context.Background(), Message{To: []string{"a@example.com"}, TextBody: "Hi"})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Send() error = %v, want %v", err, tt.wantErr)
+			}
+			if n.sends != tt.wantSends {
+				t.Errorf("sends = %d, want %d", n.sends, tt.wantSends)
+			}
+		})
+	}
+}
+
+func TestRetryStopsWhenCancelled(t *testing.T) {
+	n := &flaky{errs: []error{errors.New("connection reset")}}
+	r := Retry(n, RetryOptions{Attempts: 3, BaseDelay: time.Hour, MaxDelay: time.Hour}, zap.NewNop().Sugar())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := r.Send(ctx, Message{To: []string{"a@example.com"}, TextBody: "Hi"}); err == nil {
+		t.Error("Send() succeeded after the context was cancelled")
+	}
+	if n.sends != 1 {
+		t.Errorf("sends = %d, want no retry once cancelled", n.sends)
+	}
+}
+
+func TestIsPermanent(t *testing.T) {
+	base := errors.New("rejected")
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "permanent", err: Permanent(base), want: true},
+		{name: "wrapped permanent", err: errors.Join(errors.New("send failed"), Permanent(base)), want: true},
+		{name: "transient", err: base},
+		{name: "nil", err: Permanent(nil)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsPermanent(tt.err); got != tt.want {
+				t.Errorf("IsPermanent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
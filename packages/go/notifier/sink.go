@@ -0,0 +1,77 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Log writes messages to the logger instead of sending them. Meant for local
+// development.
+type Log struct {
+	log *zap.SugaredLogger
+}
+
+// NewLog creates a notifier that logs every message
+func NewLog(log *zap.SugaredLogger) *Log {
+	return &Log{log: log}
+}
+
+func (l *Log) Name() string { return "log" }
+
+func (l *Log) Send(ctx context.Context, msg Message) error {
+	if err := validate(msg); err != nil {
+		return err
+	}
+	body := msg.TextBody
+	if body == "" {
+		body = msg.HTMLBody
+	}
+	l.log.Infow("Email (not sent, log notifier)", "to", msg.To, "subject", msg.Subject, "body", body)
+	return nil
+}
+
+// File writes every message as an .eml file into a directory, where it can be
+// opened with any mail client. Meant for local development.
+type File struct {
+	dir  string
+	from mail.Address
+}
+
+// NewFile creates a notifier writing messages into dir, creating it if needed
+func NewFile(dir, from string) (*File, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("file notifier directory is required")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create mail directory: %w", err)
+	}
+	sender := mail.Address{Address: from}
+	if from == "" {
+		sender.Address = "noreply@localhost"
+	}
+	return &File{dir: dir, from: sender}, nil
+}
+
+func (f *File) Name() string { return "file" }
+
+func (f *File) Send(ctx context.Context, msg Message) error {
+	if err := validate(msg); err != nil {
+		return err
+	}
+	raw, err := buildMIME(f.from, msg)
+	if err != nil {
+		return Permanent(fmt.Errorf("failed to build message: %w", err))
+	}
+
+	name := fmt.Sprintf("%s-%s.eml", time.Now().UTC().Format("20060102T150405.000000000"), randomHex(4))
+	if err := os.WriteFile(filepath.Join(f.dir, name), raw, 0o644); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package notifier
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileSend(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	f, err := NewFile(dir, "")
+	if err != nil {
+		t.Fatalf("NewFile() error = %v", err)
+	}
+
+	if err := f.Send(context.Background(), Message{To: []string{"a@example.com"}, Subject: "Audit due", TextBody: "Hello"}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if err := f.Send(context.Background(), Message{To: []string{"a@example.com"}}); !IsPermanent(err) {
+		t.Errorf("Send() without a body error = %v, want a permanent error", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("messages written = %v, %v, want one", files, err)
+	}
+	raw, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatalf("failed to read message: %v", err)
+	}
+	if !strings.Contains(string(raw), "From: <noreply@localhost>") || !strings.Contains(string(raw), "Subject: Audit due") {
+		t.Errorf("message = %q, want the default sender and the subject", raw)
+	}
+}
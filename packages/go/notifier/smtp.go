@@ -0,0 +1,143 @@
+package notifier
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"time"
+)
+
+// SMTPConfig configures the SMTP notifier
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string // AUTH PLAIN is used when set
+	Password string
+	From     string
+	FromName string
+	// StartTLS upgrades the connection before authenticating and fails when
+	// the server does not offer it. Only disable it for local mail catchers.
+	StartTLS bool
+	Timeout  time.Duration
+}
+
+// SMTP sends messages to an SMTP relay
+type SMTP struct {
+	cfg  SMTPConfig
+	from mail.Address
+}
+
+// NewSMTP creates an SMTP notifier. Port defaults to 587 and Timeout to 30s.
+func NewSMTP(cfg SMTPConfig) (*SMTP, error) {
+	if cfg.Host == "" {
+		return nil, errors.New("smtp host is required")
+	}
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid smtp sender %q: %w", cfg.From, err)
+	}
+	if cfg.FromName != "" {
+		from.Name = cfg.FromName
+	}
+	if cfg.Port == 0 {
+		cfg.Port = 587
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+	return &SMTP{cfg: cfg, from: *from}, nil
+}
+
+func (s *SMTP) Name() string { return "smtp" }
+
+func (s *SMTP) Send(ctx context.Context, msg Message) error {
+	if err := validate(msg); err != nil {
+		return err
+	}
+	raw, err := buildMIME(s.from, msg)
+	if err != nil {
+		return Permanent(fmt.Errorf("failed to build message: %w", err))
+	}
+
+	err = s.send(ctx, msg.To, raw)
+	var smtpErr *textproto.Error
+	if errors.As(err, &smtpErr) && smtpErr.Code >= 500 {
+		// 5xx replies are permanent: unknown mailbox, message refused, ...
+		return Permanent(err)
+	}
+	return err
+}
+
+func (s *SMTP) send(ctx context.Context, to []string, raw []byte) error {
+	addr := net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port))
+	dialer := net.Dialer{Timeout: s.cfg.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+
+	deadline := time.Now().Add(s.cfg.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+
+	c, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("smtp handshake failed: %w", err)
+	}
+	defer c.Close()
+
+	if s.cfg.StartTLS {
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("smtp server %s does not support STARTTLS", addr)
+		}
+		if err := c.StartTLS(&tls.Config{ServerName: s.cfg.Host}); err != nil {
+			return fmt.Errorf("starttls failed: %w", err)
+		}
+	}
+
+	if s.cfg.Username != "" {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return fmt.Errorf("smtp server %s does not support AUTH", addr)
+		}
+		if err := c.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)); err != nil {
+			return fmt.Errorf("smtp auth failed: %w", err)
+		}
+	}
+
+	if err := c.Mail(s.from.Address); err != nil {
+		return fmt.Errorf("smtp MAIL FROM failed: %w", err)
+	}
+	for _, rcpt := range to {
+		if err := c.Rcpt(rcpt); err != nil {
+			return fmt.Errorf("smtp RCPT TO %s failed: %w", rcpt, err)
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("smtp DATA failed: %w", err)
+	}
+	if _, err := w.Write(raw); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("smtp server refused message: %w", err)
+	}
+
+	// The message is accepted at this point; failing to say goodbye must not
+	// get it sent twice
+	_ = c.Quit()
+	return nil
+}
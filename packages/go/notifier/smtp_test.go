@@ -0,0 +1,206 @@
+package notifier
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// smtpServer is a minimal SMTP server without STARTTLS or AUTH that rejects
+// recipients at unknown.example and records the messages it accepts
+type smtpServer struct {
+	host string
+	port int
+
+	mu         sync.Mutex
+	recipients []string
+	data       string
+}
+
+func newSMTPServer(t *testing.T) *smtpServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	addr := listener.Addr().(*net.TCPAddr)
+	s := &smtpServer{host: addr.IP.String(), port: addr.Port}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *smtpServer) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 localhost ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(command, "EHLO"):
+			reply("250-localhost")
+			reply("250 8BITMIME")
+		case strings.HasPrefix(command, "MAIL FROM"):
+			reply("250 OK")
+		case strings.HasPrefix(command, "RCPT TO"):
+			if strings.Contains(command, "@UNKNOWN.EXAMPLE") {
+				reply("550 No such user")
+				continue
+			}
+			s.mu.Lock()
+			s.recipients = append(s.recipients, strings.TrimSpace(line[len("RCPT TO:"):]))
+			s.mu.Unlock()
+			reply("250 OK")
+		case command == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				data.WriteString(l)
+			}
+			s.mu.Lock()
+			s.data = data.String()
+			s.mu.Unlock()
+			reply("250 OK")
+		case command == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func TestSMTPSend(t *testing.T) {
+	server := newSMTPServer(t)
+
+	tests := []struct {
+		name          string
+		cfg           SMTPConfig
+		to            []string
+		wantErr       bool
+		wantPermanent bool
+	}{
+		{
+			name: "delivered",
+			to:   []string{"a@example.com", "b@example.com"},
+		},
+		{
+			name:          "unknown recipient",
+			to:            []string{"a@example.com", "nobody@unknown.example"},
+			wantErr:       true,
+			wantPermanent: true,
+		},
+		{
+			name:    "STARTTLS not offered",
+			cfg:     SMTPConfig{StartTLS: true},
+			to:      []string{"a@example.com"},
+			wantErr: true,
+		},
+		{
+			name:    "AUTH not offered",
+			cfg:     SMTPConfig{Username: "user", Password: "secret"},
+			to:      []string{"a@example.com"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			cfg.Host, cfg.Port, cfg.From, cfg.Timeout = server.host, server.port, "noreply@audity.example", 5*time.Second
+			s, err := NewSMTP(cfg)
+			if err != nil {
+				t.Fatalf("NewSMTP() error = %v", err)
+			}
+
+			err = s.Send(context.Background(), Message{To: tt.to, Subject: "Audit due", TextBody: "Hello"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Send() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if IsPermanent(err) != tt.wantPermanent {
+				t.Errorf("IsPermanent(%v) = %v, want %v", err, IsPermanent(err), tt.wantPermanent)
+			}
+			if err != nil {
+				return
+			}
+
+			server.mu.Lock()
+			defer server.mu.Unlock()
+			if !strings.Contains(server.data, "Subject: Audit due") {
+				t.Errorf("message not delivered, got %q", server.data)
+			}
+		})
+	}
+}
+
+func TestSMTPSendUnreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	s, err := NewSMTP(SMTPConfig{Host: "127.0.0.1", Port: port, From: "noreply@audity.example", Timeout: time.Second})
+	if err != nil {
+		t.Fatalf("NewSMTP() error = %v", err)
+	}
+
+	err = s.Send(context.Background(), Message{To: []string{"a@example.com"}, TextBody: "Hello"})
+	if err == nil || IsPermanent(err) {
+		t.Errorf("Send() error = %v, want a transient error", err)
+	}
+}
+
+func TestNewSMTP(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      SMTPConfig
+		wantErr  bool
+		wantPort int
+	}{
+		{name: "defaults", cfg: SMTPConfig{Host: "smtp.example.com", From: "noreply@audity.example"}, wantPort: 587},
+		{name: "explicit port", cfg: SMTPConfig{Host: "smtp.example.com", Port: 25, From: "noreply@audity.example"}, wantPort: 25},
+		{name: "no host", cfg: SMTPConfig{From: "noreply@audity.example"}, wantErr: true},
+		{name: "invalid sender", cfg: SMTPConfig{Host: "smtp.example.com", From: "not an address"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewSMTP(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewSMTP() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && s.cfg.Port != tt.wantPort {
+				t.Errorf("port = %d, want %d", s.cfg.Port, tt.wantPort)
+			}
+		})
+	}
+}
@@ -192,6 +192,12 @@ func RequireAnyRole(store Store, logger *zap.SugaredLogger, roles ...string) ech
 	}
 }
 
+// HasPermission checks if a user has a specific permission
+// Use this inside handlers when a permission only guards part of a request
+func HasPermission(ctx context.Context, store Store, userID uuid.UUID, permissionName string) (bool, error) {
+	return checkUserPermission(ctx, store, userID, permissionName)
+}
+
 // getUserRole fetches the user's role from the database
 func getUserRole(ctx context.Context, store Store, userID uuid.UUID) (string, error) {
 	query := `SELECT role FROM users WHERE id = $1`
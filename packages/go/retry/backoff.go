@@ -0,0 +1,25 @@
+// Package retry holds the retry policy shared by the email notifier, the
+// event relay and the outboxes built on them.
+package retry
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// Backoff returns how long to wait before retry number attempt (starting at
+// 1): base doubled on every attempt, capped at max, with up to half of it
+// randomized so that failing senders do not retry in lockstep.
+func Backoff(attempt int, base, max time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempt && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		delay = max
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
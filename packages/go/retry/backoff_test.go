@@ -0,0 +1,35 @@
+package retry
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		attempt int
+		base    time.Duration
+		max     time.Duration
+		wantMin time.Duration
+		wantMax time.Duration
+	}{
+		{name: "first retry", attempt: 1, base: time.Second, max: time.Minute, wantMin: 500 * time.Millisecond, wantMax: time.Second},
+		{name: "doubles per attempt", attempt: 3, base: time.Second, max: time.Minute, wantMin: 2 * time.Second, wantMax: 4 * time.Second},
+		{name: "capped at max", attempt: 20, base: time.Second, max: 10 * time.Second, wantMin: 5 * time.Second, wantMax: 10 * time.Second},
+		{name: "attempt zero uses base", attempt: 0, base: time.Second, max: time.Minute, wantMin: 500 * time.Millisecond, wantMax: time.Second},
+		{name: "no delay", attempt: 3, base: 0, max: time.Minute, wantMin: 0, wantMax: 0},
+		{name: "base above max", attempt: 1, base: time.Hour, max: time.Minute, wantMin: 30 * time.Second, wantMax: time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for range 100 {
+				got := Backoff(tt.attempt, tt.base, tt.max)
+				if got < tt.wantMin || got > tt.wantMax {
+					t.Fatalf("Backoff(%d, %s, %s) = %s, want between %s and %s", tt.attempt, tt.base, tt.max, got, tt.wantMin, tt.wantMax)
+				}
+			}
+		})
+	}
+}
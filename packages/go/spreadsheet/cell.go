@@ -0,0 +1,31 @@
+// Package spreadsheet keeps exported CSV and XLSX files safe to open in a
+// spreadsheet application.
+package spreadsheet
+
+// Leading characters a spreadsheet application reads as the start of a formula
+const formulaPrefixes = "=+-@\t\r"
+
+// SafeCell returns value so that a spreadsheet shows it as text: values that
+// would be read as a formula are prefixed with a single quote. Question texts
+// and answers come from imported checklists and clients, so every exported
+// cell goes through it.
+func SafeCell(value string) string {
+	if value == "" {
+		return value
+	}
+	for _, prefix := range formulaPrefixes {
+		if rune(value[0]) == prefix {
+			return "'" + value
+		}
+	}
+	return value
+}
+
+// SafeRow applies SafeCell to every cell of a row
+func SafeRow(row []string) []string {
+	out := make([]string, len(row))
+	for i, value := range row {
+		out[i] = SafeCell(value)
+	}
+	return out
+}
@@ -0,0 +1,43 @@
+package spreadsheet
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSafeCell(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "empty", value: "", want: ""},
+		{name: "text", value: "Is there a security policy?", want: "Is there a security policy?"},
+		{name: "formula", value: "=HYPERLINK(\"http://example.com\")", want: "'=HYPERLINK(\"http://example.com\")"},
+		{name: "plus", value: "+1-555-0100", want: "'+1-555-0100"},
+		{name: "minus", value: "-2+3", want: "'-2+3"},
+		{name: "at", value: "@SUM(A1:A2)", want: "'@SUM(A1:A2)"},
+		{name: "tab", value: "\t=1", want: "'\t=1"},
+		{name: "carriage return", value: "\r=1", want: "'\r=1"},
+		{name: "formula character later", value: "a=b", want: "a=b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SafeCell(tt.value); got != tt.want {
+				t.Errorf("SafeCell(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSafeRow(t *testing.T) {
+	row := []string{"A.1", "=1+1", ""}
+	got := SafeRow(row)
+	if want := []string{"A.1", "'=1+1", ""}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SafeRow() = %q, want %q", got, want)
+	}
+	if row[1] != "=1+1" {
+		t.Errorf("SafeRow() modified its input: %q", row)
+	}
+}
@@ -0,0 +1,4 @@
+-- name: EnqueueEmail :exec
+-- Queues an email in the outbox of tenant-service, which delivers it
+INSERT INTO email_outbox (recipients, subject, html_body, text_body, template, template_version)
+VALUES ($1, $2, $3, $4, $5, $6);
@@ -0,0 +1,5 @@
+-- name: EnqueueEvent :exec
+-- Writes a domain event to the event outbox of tenant_db, published by the
+-- relay of tenant-service
+INSERT INTO event_outbox (id, event_type, envelope)
+VALUES ($1, $2, $3);
@@ -4,6 +4,8 @@ go 1.25
 
 require (
 	github.com/NormaTech-AI/audity/packages/go/auth v0.0.0
+	github.com/NormaTech-AI/audity/packages/go/emailtemplates v0.0.0
+	github.com/NormaTech-AI/audity/packages/go/eventbus v0.0.0
 	github.com/go-playground/validator/v10 v10.24.0
 	github.com/google/uuid v1.6.0
 	github.com/jackc/pgx/v5 v5.7.6
@@ -14,6 +16,7 @@ require (
 )
 
 require (
+	github.com/NormaTech-AI/audity/packages/go/retry v0.0.0 // indirect
 	github.com/dustin/go-humanize v1.0.1 // indirect
 	github.com/fsnotify/fsnotify v1.7.0 // indirect
 	github.com/gabriel-vasile/mimetype v1.4.8 // indirect
@@ -37,6 +40,7 @@ require (
 	github.com/minio/md5-simd v1.1.2 // indirect
 	github.com/mitchellh/mapstructure v1.5.0 // indirect
 	github.com/pelletier/go-toml/v2 v2.2.3 // indirect
+	github.com/rabbitmq/amqp091-go v1.10.0 // indirect
 	github.com/rogpeppe/go-internal v1.13.1 // indirect
 	github.com/rs/xid v1.6.0 // indirect
 	github.com/sagikazarmark/locafero v0.6.0 // indirect
@@ -61,3 +65,9 @@ require (
 )
 
 replace github.com/NormaTech-AI/audity/packages/go/auth => ../../packages/go/auth
+
+replace github.com/NormaTech-AI/audity/packages/go/emailtemplates => ../../packages/go/emailtemplates
+
+replace github.com/NormaTech-AI/audity/packages/go/eventbus => ../../packages/go/eventbus
+
+replace github.com/NormaTech-AI/audity/packages/go/retry => ../../packages/go/retry
@@ -13,6 +13,7 @@ type Config struct {
     Auth     AuthConfig     `mapstructure:"auth"`
     Logging  LoggingConfig  `mapstructure:"logging"`
     Services ServicesConfig `mapstructure:"services"`
+    Mail     MailConfig     `mapstructure:"mail"`
 }
 
 type ServerConfig struct {
@@ -51,6 +52,11 @@ type ServicesConfig struct {
     TenantBaseURL string `mapstructure:"tenant_base_url"`
 }
 
+type MailConfig struct {
+	// Base URL of the web app, used for links in emails
+	AppURL string `mapstructure:"app_url"`
+}
+
 func LoadConfig(configPath string) (*Config, error) {
 	viper.SetConfigFile(configPath)
 	viper.AutomaticEnv()
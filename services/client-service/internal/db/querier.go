@@ -21,6 +21,11 @@ type Querier interface {
 	DeleteClient(ctx context.Context, id uuid.UUID) error
 	DeleteClientBucket(ctx context.Context, clientID uuid.UUID) error
 	DeleteClientDatabase(ctx context.Context, clientID uuid.UUID) error
+	// Queues an email in the outbox of tenant-service, which delivers it
+	EnqueueEmail(ctx context.Context, arg EnqueueEmailParams) error
+	// Writes a domain event to the event outbox of tenant_db, published by the
+	// relay of tenant-service
+	EnqueueEvent(ctx context.Context, arg EnqueueEventParams) error
 	GetClient(ctx context.Context, id uuid.UUID) (Client, error)
 	GetClientBucket(ctx context.Context, clientID uuid.UUID) (ClientBucket, error)
 	GetClientBucketByName(ctx context.Context, bucketName string) (ClientBucket, error)
@@ -2,9 +2,12 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 
+	"github.com/NormaTech-AI/audity/packages/go/emailtemplates"
+	"github.com/NormaTech-AI/audity/packages/go/eventbus"
 	"github.com/NormaTech-AI/audity/services/client-service/internal/db"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -112,6 +115,16 @@ func (h *Handler) CreateClient(c echo.Context) error {
 			return fmt.Errorf("failed to store bucket info: %w", err)
 		}
 
+		// 4. Queue the invitation of the POC, delivered by tenant-service
+		if err := h.queueInvitation(ctx, q, client); err != nil {
+			return fmt.Errorf("failed to queue invitation email: %w", err)
+		}
+
+		// 5. Record the client.created event, published by tenant-service
+		if err := emitClientCreated(ctx, q, client); err != nil {
+			return fmt.Errorf("failed to record client created event: %w", err)
+		}
+
 		return nil
 	})
 
@@ -519,3 +532,50 @@ type UpdateClientRequest struct {
 	EmailDomain string `json:"email_domain"`
 	Status      string `json:"status"`
 }
+
+// queueInvitation queues the onboarding invitation email of a new client's POC
+func (h *Handler) queueInvitation(ctx context.Context, q *db.Queries, client db.Client) error {
+	email, err := emailtemplates.Render(emailtemplates.Invitation, emailtemplates.DefaultBranding(), emailtemplates.InvitationData{
+		ClientName: client.Name,
+		POCEmail:   client.PocEmail,
+		LoginURL:   h.getAppURL() + "/login",
+	})
+	if err != nil {
+		return err
+	}
+
+	template := string(email.Template)
+	return q.EnqueueEmail(ctx, db.EnqueueEmailParams{
+		Recipients:      []string{client.PocEmail},
+		Subject:         email.Subject,
+		HtmlBody:        &email.HTML,
+		TextBody:        &email.Text,
+		Template:        &template,
+		TemplateVersion: &email.Version,
+	})
+}
+
+// emitClientCreated writes the client.created event to the event outbox
+func emitClientCreated(ctx context.Context, q *db.Queries, client db.Client) error {
+	payload := eventbus.ClientCreatedEvent{
+		Name:     client.Name,
+		POCEmail: client.PocEmail,
+	}
+	if client.EmailDomain != nil {
+		payload.EmailDomain = *client.EmailDomain
+	}
+
+	env, err := eventbus.New("client-service", client.ID, payload)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+	return q.EnqueueEvent(ctx, db.EnqueueEventParams{
+		ID:        env.ID,
+		EventType: string(env.Type),
+		Envelope:  body,
+	})
+}
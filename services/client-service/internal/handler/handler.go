@@ -2,6 +2,7 @@ package handler
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/NormaTech-AI/audity/services/client-service/internal/config"
 	"github.com/NormaTech-AI/audity/services/client-service/internal/store"
@@ -37,6 +38,15 @@ func (h *Handler) getTenantBaseURL() string {
     return "http://tenant-service:8081"
 }
 
+// getAppURL returns the configured web app base URL used in email links
+func (h *Handler) getAppURL() string {
+    if h.config.Mail.AppURL != "" {
+        return strings.TrimRight(h.config.Mail.AppURL, "/")
+    }
+    // Fallback to the local frontend
+    return "http://localhost:5173"
+}
+
 // RootHandler handles the root endpoint
 func (h *Handler) RootHandler(c echo.Context) error {
 	return c.JSON(http.StatusOK, map[string]string{
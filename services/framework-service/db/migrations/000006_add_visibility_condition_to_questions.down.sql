@@ -0,0 +1,2 @@
+-- Remove visibility_condition column from framework_questions
+ALTER TABLE framework_questions DROP COLUMN IF EXISTS visibility_condition;
@@ -0,0 +1,5 @@
+-- Add visibility_condition column to framework_questions
+-- A question with a condition is only shown when the answer to an earlier
+-- question (referenced by control_id) matches, e.g.
+-- {"depends_on": "1.1", "operator": "equals", "values": ["yes"]}
+ALTER TABLE framework_questions ADD COLUMN visibility_condition JSONB;
@@ -0,0 +1,3 @@
+-- Remove scoring attributes from framework_questions
+ALTER TABLE framework_questions DROP COLUMN IF EXISTS severity;
+ALTER TABLE framework_questions DROP COLUMN IF EXISTS weight;
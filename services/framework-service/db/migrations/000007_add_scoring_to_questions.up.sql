@@ -0,0 +1,6 @@
+-- Add scoring attributes to framework_questions
+-- weight: relative importance of the question in the compliance score
+-- severity: how heavily a non-compliant ("no") answer counts against the score
+ALTER TABLE framework_questions ADD COLUMN weight INTEGER NOT NULL DEFAULT 1 CHECK (weight > 0);
+ALTER TABLE framework_questions ADD COLUMN severity TEXT NOT NULL DEFAULT 'medium'
+    CHECK (severity IN ('low', 'medium', 'high', 'critical'));
@@ -0,0 +1,17 @@
+-- Remove framework versions; questions of all versions but the latest
+-- published one are dropped
+DROP TRIGGER IF EXISTS prevent_published_question_changes ON framework_questions;
+DROP FUNCTION IF EXISTS prevent_published_question_changes();
+
+DELETE FROM framework_questions fq
+WHERE fq.version_id <> (
+    SELECT fv.id FROM framework_versions fv
+    WHERE fv.framework_id = fq.framework_id AND fv.status = 'published'
+    ORDER BY fv.published_at DESC
+    LIMIT 1
+);
+
+DROP INDEX IF EXISTS idx_framework_questions_version_id;
+ALTER TABLE framework_questions DROP COLUMN IF EXISTS version_id;
+
+DROP TABLE IF EXISTS framework_versions;
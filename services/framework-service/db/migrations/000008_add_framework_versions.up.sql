@@ -0,0 +1,65 @@
+-- Framework versions
+-- Questions belong to a version of a framework. A draft version can be edited
+-- freely; publishing freezes it so audits pinned to the version keep referring
+-- to exactly the questions they were created from.
+CREATE TABLE framework_versions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    framework_id UUID NOT NULL REFERENCES compliance_frameworks(id) ON DELETE CASCADE,
+    version VARCHAR(50) NOT NULL,
+    status TEXT NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'published')),
+    notes TEXT,
+    created_by UUID,
+    published_by UUID,
+    published_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (framework_id, version),
+    CONSTRAINT published_version_has_timestamp CHECK (status = 'draft' OR published_at IS NOT NULL)
+);
+
+CREATE INDEX idx_framework_versions_framework_id ON framework_versions(framework_id);
+
+-- A framework has at most one draft at a time
+CREATE UNIQUE INDEX idx_framework_versions_single_draft ON framework_versions(framework_id)
+    WHERE status = 'draft';
+
+CREATE TRIGGER update_framework_versions_updated_at BEFORE UPDATE ON framework_versions
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Existing frameworks become their first published version
+INSERT INTO framework_versions (framework_id, version, status, published_at, created_at)
+SELECT id, COALESCE(version, '1.0'), 'published', updated_at, created_at
+FROM compliance_frameworks;
+
+ALTER TABLE framework_questions ADD COLUMN version_id UUID REFERENCES framework_versions(id) ON DELETE CASCADE;
+
+UPDATE framework_questions fq
+SET version_id = fv.id
+FROM framework_versions fv
+WHERE fv.framework_id = fq.framework_id;
+
+ALTER TABLE framework_questions ALTER COLUMN version_id SET NOT NULL;
+
+CREATE INDEX idx_framework_questions_version_id ON framework_questions(version_id);
+
+-- Questions of a published version cannot be added or changed. Deletes are
+-- left to the cascade when a whole framework is removed.
+CREATE OR REPLACE FUNCTION prevent_published_question_changes()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM framework_versions
+        WHERE id IN (NEW.version_id, CASE WHEN TG_OP = 'UPDATE' THEN OLD.version_id END)
+            AND status = 'published'
+    ) THEN
+        RAISE EXCEPTION 'questions of a published framework version cannot be changed';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER prevent_published_question_changes BEFORE INSERT OR UPDATE ON framework_questions
+    FOR EACH ROW EXECUTE FUNCTION prevent_published_question_changes();
+
+COMMENT ON TABLE framework_versions IS 'Versions of a framework; published versions are immutable';
+COMMENT ON COLUMN compliance_frameworks.version IS 'Label of the latest published version';
@@ -0,0 +1,2 @@
+-- Remove control mappings
+DROP TABLE IF EXISTS control_mappings;
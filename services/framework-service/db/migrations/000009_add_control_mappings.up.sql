@@ -0,0 +1,32 @@
+-- Control mappings across frameworks
+-- Exchange checklists overlap: a control of one framework can be mapped to a
+-- control of another. Mappings refer to control IDs rather than question rows
+-- so they hold across framework versions. A mapping works both ways, so a pair
+-- of controls is mapped at most once whichever side is the source.
+CREATE TABLE control_mappings (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    source_framework_id UUID NOT NULL REFERENCES compliance_frameworks(id) ON DELETE CASCADE,
+    source_control_id TEXT NOT NULL,
+    target_framework_id UUID NOT NULL REFERENCES compliance_frameworks(id) ON DELETE CASCADE,
+    target_control_id TEXT NOT NULL,
+    strength TEXT NOT NULL CHECK (strength IN ('equivalent', 'partial', 'related')),
+    notes TEXT,
+    created_by UUID,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT control_mapping_across_frameworks CHECK (source_framework_id <> target_framework_id)
+);
+
+CREATE UNIQUE INDEX idx_control_mappings_pair ON control_mappings (
+    LEAST(source_framework_id::text || '/' || source_control_id, target_framework_id::text || '/' || target_control_id),
+    GREATEST(source_framework_id::text || '/' || source_control_id, target_framework_id::text || '/' || target_control_id)
+);
+
+CREATE INDEX idx_control_mappings_source ON control_mappings(source_framework_id, source_control_id);
+CREATE INDEX idx_control_mappings_target ON control_mappings(target_framework_id, target_control_id);
+
+CREATE TRIGGER update_control_mappings_updated_at BEFORE UPDATE ON control_mappings
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE control_mappings IS 'Controls of different frameworks that cover the same requirement';
+COMMENT ON COLUMN control_mappings.strength IS 'equivalent controls share answers; partial and related ones are informational';
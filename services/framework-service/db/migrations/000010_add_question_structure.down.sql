@@ -0,0 +1,8 @@
+-- Remove question structure from framework_questions
+DROP INDEX IF EXISTS idx_framework_questions_version_order;
+ALTER TABLE framework_questions DROP COLUMN IF EXISTS evidence_required;
+ALTER TABLE framework_questions DROP COLUMN IF EXISTS subsection_title;
+ALTER TABLE framework_questions DROP COLUMN IF EXISTS display_order;
+ALTER TABLE framework_questions DROP COLUMN IF EXISTS is_mandatory;
+ALTER TABLE framework_questions DROP COLUMN IF EXISTS options;
+ALTER TABLE framework_questions DROP COLUMN IF EXISTS question_type;
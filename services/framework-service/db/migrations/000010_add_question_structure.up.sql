@@ -0,0 +1,37 @@
+-- Question structure on framework_questions
+-- Questions carry the answer type and options the client sees, whether an
+-- answer is mandatory, whether evidence must be attached, an explicit order
+-- within the checklist and an optional sub-section below the section title.
+ALTER TABLE framework_questions ADD COLUMN question_type TEXT NOT NULL DEFAULT 'yes_no'
+    CHECK (question_type IN ('yes_no', 'text', 'single_choice', 'multiple_choice', 'numeric', 'date', 'table'));
+
+-- Choice options, or column definitions for table questions:
+-- [{"value": "daily", "label": "Daily"}, ...]
+ALTER TABLE framework_questions ADD COLUMN options JSONB;
+
+ALTER TABLE framework_questions ADD COLUMN is_mandatory BOOLEAN NOT NULL DEFAULT true;
+ALTER TABLE framework_questions ADD COLUMN display_order INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE framework_questions ADD COLUMN subsection_title TEXT;
+ALTER TABLE framework_questions ADD COLUMN evidence_required BOOLEAN NOT NULL DEFAULT false;
+
+-- Existing questions keep the control_id order they were listed in. Published
+-- versions are otherwise immutable, so the guard is lifted for the backfill.
+ALTER TABLE framework_questions DISABLE TRIGGER prevent_published_question_changes;
+
+UPDATE framework_questions fq
+SET display_order = ordered.position
+FROM (
+    SELECT question_id, ROW_NUMBER() OVER (PARTITION BY version_id ORDER BY control_id) AS position
+    FROM framework_questions
+) ordered
+WHERE fq.question_id = ordered.question_id;
+
+ALTER TABLE framework_questions ENABLE TRIGGER prevent_published_question_changes;
+
+CREATE INDEX idx_framework_questions_version_order ON framework_questions(version_id, display_order);
+
+COMMENT ON COLUMN framework_questions.question_type IS 'Answer type: yes_no, text, single_choice, multiple_choice, numeric, date or table';
+COMMENT ON COLUMN framework_questions.options IS 'Choice options or table column definitions';
+COMMENT ON COLUMN framework_questions.display_order IS 'Position of the question in the checklist of its version';
+COMMENT ON COLUMN framework_questions.subsection_title IS 'Sub-section within the section title';
+COMMENT ON COLUMN framework_questions.evidence_required IS 'Whether an answer must be backed by uploaded evidence';
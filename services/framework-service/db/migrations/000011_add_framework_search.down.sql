@@ -0,0 +1,6 @@
+-- Remove framework listing filters and question search
+DROP INDEX IF EXISTS idx_framework_questions_search;
+DROP FUNCTION IF EXISTS framework_question_search_vector(TEXT, TEXT, TEXT, TEXT, TEXT);
+DROP INDEX IF EXISTS idx_compliance_frameworks_updated_at;
+DROP INDEX IF EXISTS idx_compliance_frameworks_regulator;
+ALTER TABLE compliance_frameworks DROP COLUMN IF EXISTS regulator;
@@ -0,0 +1,30 @@
+-- Framework listing filters and question search
+-- Frameworks record the regulator or exchange that publishes them so the
+-- listing can be filtered by it. Questions are searched with Postgres
+-- full-text search over their control ID, text, sections and help text.
+ALTER TABLE compliance_frameworks ADD COLUMN regulator TEXT;
+
+CREATE INDEX idx_compliance_frameworks_regulator ON compliance_frameworks(LOWER(regulator));
+CREATE INDEX idx_compliance_frameworks_updated_at ON compliance_frameworks(updated_at);
+
+-- The search document of a question. Control IDs rank highest, then the
+-- question text, its sections and its help text. Queries must call this
+-- function with the same arguments for the index below to be used.
+CREATE OR REPLACE FUNCTION framework_question_search_vector(
+    control_id TEXT,
+    question_text TEXT,
+    section_title TEXT,
+    subsection_title TEXT,
+    help_text TEXT
+) RETURNS tsvector AS $$
+    SELECT setweight(to_tsvector('english', COALESCE(control_id, '')), 'A') ||
+        setweight(to_tsvector('english', COALESCE(question_text, '')), 'B') ||
+        setweight(to_tsvector('english', COALESCE(section_title, '') || ' ' || COALESCE(subsection_title, '')), 'C') ||
+        setweight(to_tsvector('english', COALESCE(help_text, '')), 'D')
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE INDEX idx_framework_questions_search ON framework_questions USING GIN (
+    framework_question_search_vector(control_id, question_text, section_title, subsection_title, help_text)
+);
+
+COMMENT ON COLUMN compliance_frameworks.regulator IS 'Regulator or exchange that publishes the framework, e.g. SEBI or NSE';
@@ -0,0 +1,2 @@
+-- Remove structured evidence requirements from framework_questions
+ALTER TABLE framework_questions DROP COLUMN IF EXISTS evidence_requirements;
@@ -0,0 +1,8 @@
+-- Structured evidence requirements on framework_questions
+-- acceptable_evidence only describes evidence in free text. Requirements are
+-- checked when a client submits an answer: the minimum number of files, the
+-- file types allowed and the document categories that must be among them.
+-- {"min_files": 2, "allowed_file_types": [".pdf", ".png"], "required_categories": ["policy", "screenshot"]}
+ALTER TABLE framework_questions ADD COLUMN evidence_requirements JSONB;
+
+COMMENT ON COLUMN framework_questions.evidence_requirements IS 'Minimum files, allowed file types and required document categories of the evidence';
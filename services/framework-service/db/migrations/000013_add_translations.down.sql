@@ -0,0 +1,4 @@
+-- Remove question and section translations
+DROP TABLE IF EXISTS framework_section_translations;
+DROP TABLE IF EXISTS framework_question_translations;
+ALTER TABLE compliance_frameworks DROP COLUMN IF EXISTS language;
@@ -0,0 +1,38 @@
+-- Question and section translations
+-- A framework is authored in its canonical language; reports always render
+-- in it. Translations are a presentation layer over a version: they refer to
+-- questions by control ID, so they survive draft saves, and they can be
+-- corrected after a version is published without changing its questions.
+ALTER TABLE compliance_frameworks ADD COLUMN language VARCHAR(35) NOT NULL DEFAULT 'en';
+
+CREATE TABLE framework_question_translations (
+    version_id UUID NOT NULL REFERENCES framework_versions(id) ON DELETE CASCADE,
+    control_id TEXT NOT NULL,
+    locale VARCHAR(35) NOT NULL,
+    question_text TEXT NOT NULL,
+    help_text TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (version_id, control_id, locale)
+);
+
+-- Section and sub-section titles are translated once per version
+CREATE TABLE framework_section_translations (
+    version_id UUID NOT NULL REFERENCES framework_versions(id) ON DELETE CASCADE,
+    locale VARCHAR(35) NOT NULL,
+    source_title TEXT NOT NULL,
+    title TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (version_id, locale, source_title)
+);
+
+CREATE TRIGGER update_framework_question_translations_updated_at BEFORE UPDATE ON framework_question_translations
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_framework_section_translations_updated_at BEFORE UPDATE ON framework_section_translations
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON COLUMN compliance_frameworks.language IS 'Canonical language of the framework as a BCP 47 tag; reports render in it';
+COMMENT ON TABLE framework_question_translations IS 'Translated question and help text of the questions of a version, by control ID';
+COMMENT ON TABLE framework_section_translations IS 'Translated section and sub-section titles of a version';
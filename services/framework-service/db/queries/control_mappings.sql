@@ -0,0 +1,51 @@
+-- name: CreateControlMapping :one
+INSERT INTO control_mappings (
+    source_framework_id,
+    source_control_id,
+    target_framework_id,
+    target_control_id,
+    strength,
+    notes,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetControlMapping :one
+SELECT * FROM control_mappings
+WHERE id = $1 LIMIT 1;
+
+-- name: ListFrameworkControlMappings :many
+-- Mappings with a control of the framework on either side
+SELECT
+    m.*,
+    sf.name AS source_framework_name,
+    tf.name AS target_framework_name
+FROM control_mappings m
+JOIN compliance_frameworks sf ON sf.id = m.source_framework_id
+JOIN compliance_frameworks tf ON tf.id = m.target_framework_id
+WHERE m.source_framework_id = @framework_id::uuid OR m.target_framework_id = @framework_id::uuid
+ORDER BY m.created_at;
+
+-- name: ListControlMappingsForControl :many
+-- Mappings of one control of a framework, on either side
+SELECT
+    m.*,
+    sf.name AS source_framework_name,
+    tf.name AS target_framework_name
+FROM control_mappings m
+JOIN compliance_frameworks sf ON sf.id = m.source_framework_id
+JOIN compliance_frameworks tf ON tf.id = m.target_framework_id
+WHERE (m.source_framework_id = @framework_id::uuid AND m.source_control_id = @control_id::text)
+   OR (m.target_framework_id = @framework_id::uuid AND m.target_control_id = @control_id::text)
+ORDER BY m.created_at;
+
+-- name: UpdateControlMapping :one
+UPDATE control_mappings
+SET strength = $2, notes = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteControlMapping :execrows
+DELETE FROM control_mappings
+WHERE id = $1;
@@ -5,9 +5,20 @@ INSERT INTO framework_questions (
     control_id,
     question_text,
     help_text,
-    acceptable_evidence
+    acceptable_evidence,
+    visibility_condition,
+    weight,
+    severity,
+    version_id,
+    question_type,
+    options,
+    is_mandatory,
+    display_order,
+    subsection_title,
+    evidence_required,
+    evidence_requirements
 ) VALUES (
-    $1, $2, $3, $4, $5, $6
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
 )
 RETURNING *;
 
@@ -17,9 +28,18 @@ INSERT INTO framework_questions (
     control_id,
     question_text,
     help_text,
-    acceptable_evidence
+    acceptable_evidence,
+    version_id,
+    section_title,
+    question_type,
+    options,
+    is_mandatory,
+    display_order,
+    subsection_title,
+    evidence_required,
+    evidence_requirements
 ) VALUES (
-    $1, $2, $3, $4, $5
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
 );
 
 -- name: GetFrameworkQuestion :one
@@ -27,9 +47,20 @@ SELECT * FROM framework_questions
 WHERE question_id = $1 LIMIT 1;
 
 -- name: ListFrameworkQuestions :many
+-- Questions of the latest published version of a framework
+SELECT * FROM framework_questions
+WHERE version_id = (
+    SELECT fv.id FROM framework_versions fv
+    WHERE fv.framework_id = $1 AND fv.status = 'published'
+    ORDER BY fv.published_at DESC
+    LIMIT 1
+)
+ORDER BY display_order, control_id;
+
+-- name: ListVersionQuestions :many
 SELECT * FROM framework_questions
-WHERE framework_id = $1
-ORDER BY control_id;
+WHERE version_id = $1
+ORDER BY display_order, control_id;
 
 -- name: UpdateFrameworkQuestion :one
 UPDATE framework_questions
@@ -38,7 +69,17 @@ SET
     control_id = $3,
     question_text = $4,
     help_text = $5,
-    acceptable_evidence = $6
+    acceptable_evidence = $6,
+    visibility_condition = $7,
+    weight = $8,
+    severity = $9,
+    question_type = $10,
+    options = $11,
+    is_mandatory = $12,
+    display_order = $13,
+    subsection_title = $14,
+    evidence_required = $15,
+    evidence_requirements = $16
 WHERE question_id = $1
 RETURNING *;
 
@@ -50,9 +91,61 @@ WHERE question_id = $1;
 DELETE FROM framework_questions
 WHERE framework_id = $1;
 
+-- name: DeleteFrameworkQuestionsByVersion :exec
+DELETE FROM framework_questions
+WHERE version_id = $1;
+
+-- name: CopyFrameworkVersionQuestions :execrows
+-- Copies the questions of a version into a new draft
+INSERT INTO framework_questions (
+    framework_id,
+    section_title,
+    control_id,
+    question_text,
+    help_text,
+    acceptable_evidence,
+    visibility_condition,
+    weight,
+    severity,
+    version_id,
+    question_type,
+    options,
+    is_mandatory,
+    display_order,
+    subsection_title,
+    evidence_required,
+    evidence_requirements
+)
+SELECT
+    framework_id,
+    section_title,
+    control_id,
+    question_text,
+    help_text,
+    acceptable_evidence,
+    visibility_condition,
+    weight,
+    severity,
+    @target_version_id::uuid,
+    question_type,
+    options,
+    is_mandatory,
+    display_order,
+    subsection_title,
+    evidence_required,
+    evidence_requirements
+FROM framework_questions
+WHERE version_id = @source_version_id;
+
 -- name: CountFrameworkQuestions :one
+-- Questions in the latest published version of a framework
 SELECT COUNT(*) FROM framework_questions
-WHERE framework_id = $1;
+WHERE version_id = (
+    SELECT fv.id FROM framework_versions fv
+    WHERE fv.framework_id = $1 AND fv.status = 'published'
+    ORDER BY fv.published_at DESC
+    LIMIT 1
+);
 
 -- name: GetFrameworkWithQuestions :many
 SELECT 
@@ -69,3 +162,69 @@ FROM compliance_frameworks f
 LEFT JOIN framework_questions fq ON f.id = fq.framework_id
 WHERE f.id = $1
 ORDER BY fq.control_id;
+
+-- name: FrameworkControlExists :one
+-- Whether any version of the framework has a question for the control
+SELECT EXISTS (
+    SELECT 1 FROM framework_questions
+    WHERE framework_id = $1 AND control_id = $2
+);
+
+-- name: SearchFrameworkQuestions :many
+-- Full-text search over the questions of the latest published version of
+-- every framework, best matches first. Control IDs also match by prefix.
+SELECT
+    fq.question_id,
+    fq.framework_id,
+    f.name AS framework_name,
+    fq.version_id,
+    fv.version AS framework_version,
+    fq.section_title,
+    fq.subsection_title,
+    fq.control_id,
+    fq.question_text,
+    fq.help_text,
+    fq.question_type,
+    ts_rank(
+        framework_question_search_vector(fq.control_id, fq.question_text, fq.section_title, fq.subsection_title, fq.help_text),
+        websearch_to_tsquery('english', @query)
+    )::real AS rank
+FROM framework_questions fq
+JOIN framework_versions fv ON fv.id = fq.version_id
+JOIN compliance_frameworks f ON f.id = fq.framework_id
+WHERE fv.id = (
+    SELECT lv.id FROM framework_versions lv
+    WHERE lv.framework_id = fq.framework_id AND lv.status = 'published'
+    ORDER BY lv.published_at DESC
+    LIMIT 1
+)
+    AND (
+        framework_question_search_vector(fq.control_id, fq.question_text, fq.section_title, fq.subsection_title, fq.help_text)
+            @@ websearch_to_tsquery('english', @query)
+        OR fq.control_id ILIKE @control_id_prefix || '%'
+    )
+    AND (sqlc.narg('framework_id')::uuid IS NULL OR fq.framework_id = sqlc.narg('framework_id'))
+    AND (sqlc.narg('section')::text IS NULL
+        OR fq.section_title ILIKE '%' || sqlc.narg('section') || '%'
+        OR fq.subsection_title ILIKE '%' || sqlc.narg('section') || '%')
+ORDER BY rank DESC, f.name, fq.display_order
+LIMIT @page_size OFFSET @page_offset;
+
+-- name: CountSearchFrameworkQuestions :one
+-- Questions matching SearchFrameworkQuestions
+SELECT COUNT(*) FROM framework_questions fq
+WHERE fq.version_id = (
+    SELECT lv.id FROM framework_versions lv
+    WHERE lv.framework_id = fq.framework_id AND lv.status = 'published'
+    ORDER BY lv.published_at DESC
+    LIMIT 1
+)
+    AND (
+        framework_question_search_vector(fq.control_id, fq.question_text, fq.section_title, fq.subsection_title, fq.help_text)
+            @@ websearch_to_tsquery('english', @query)
+        OR fq.control_id ILIKE @control_id_prefix || '%'
+    )
+    AND (sqlc.narg('framework_id')::uuid IS NULL OR fq.framework_id = sqlc.narg('framework_id'))
+    AND (sqlc.narg('section')::text IS NULL
+        OR fq.section_title ILIKE '%' || sqlc.narg('section') || '%'
+        OR fq.subsection_title ILIKE '%' || sqlc.narg('section') || '%');
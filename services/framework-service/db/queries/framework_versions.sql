@@ -0,0 +1,49 @@
+-- name: CreateFrameworkVersion :one
+INSERT INTO framework_versions (framework_id, version, notes, created_by)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetFrameworkVersion :one
+SELECT * FROM framework_versions
+WHERE id = $1 LIMIT 1;
+
+-- name: GetDraftFrameworkVersion :one
+SELECT * FROM framework_versions
+WHERE framework_id = $1 AND status = 'draft'
+LIMIT 1;
+
+-- name: GetLatestPublishedFrameworkVersion :one
+SELECT * FROM framework_versions
+WHERE framework_id = $1 AND status = 'published'
+ORDER BY published_at DESC
+LIMIT 1;
+
+-- name: ListFrameworkVersions :many
+SELECT
+    fv.*,
+    (SELECT COUNT(*) FROM framework_questions fq WHERE fq.version_id = fv.id) as question_count
+FROM framework_versions fv
+WHERE fv.framework_id = $1
+ORDER BY fv.created_at DESC;
+
+-- name: UpdateDraftFrameworkVersion :one
+UPDATE framework_versions
+SET version = $2, notes = $3
+WHERE id = $1 AND status = 'draft'
+RETURNING *;
+
+-- name: PublishFrameworkVersion :one
+-- Freezes a draft; published versions cannot be changed afterwards
+UPDATE framework_versions
+SET status = 'published', published_by = $2, published_at = NOW()
+WHERE id = $1 AND status = 'draft'
+RETURNING *;
+
+-- name: DeleteDraftFrameworkVersion :execrows
+DELETE FROM framework_versions
+WHERE id = $1 AND status = 'draft';
+
+-- name: SetFrameworkCurrentVersion :exec
+UPDATE compliance_frameworks
+SET version = $2
+WHERE id = $1;
@@ -1,7 +1,7 @@
 -- name: CreateFramework :one
-INSERT INTO compliance_frameworks (name, description, version)
-VALUES ($1, $2, $3)
-RETURNING id, name, description, version, created_at, updated_at;
+INSERT INTO compliance_frameworks (name, description, version, regulator, language)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, name, description, version, created_at, updated_at, regulator, language;
 
 -- name: GetFramework :one
 SELECT * FROM compliance_frameworks
@@ -15,15 +15,67 @@ WHERE name = $1 LIMIT 1;
 SELECT * FROM compliance_frameworks
 ORDER BY created_at DESC;
 
+-- name: ListFrameworksFiltered :many
+-- A page of the frameworks matching the optional filters, with the question
+-- count of their latest published version and their draft version, if any.
+-- status is published for frameworks with a published version and draft for
+-- frameworks with a draft.
+SELECT
+    f.id,
+    f.name,
+    f.description,
+    f.version,
+    f.regulator,
+    f.language,
+    f.created_at,
+    f.updated_at,
+    dv.id AS draft_version_id,
+    (SELECT COUNT(*) FROM framework_questions fq WHERE fq.version_id = pv.id) AS question_count
+FROM compliance_frameworks f
+LEFT JOIN LATERAL (
+    SELECT fv.id FROM framework_versions fv
+    WHERE fv.framework_id = f.id AND fv.status = 'published'
+    ORDER BY fv.published_at DESC
+    LIMIT 1
+) pv ON true
+LEFT JOIN framework_versions dv ON dv.framework_id = f.id AND dv.status = 'draft'
+WHERE (sqlc.narg('name')::text IS NULL OR f.name ILIKE '%' || sqlc.narg('name') || '%')
+    AND (sqlc.narg('regulator')::text IS NULL OR LOWER(f.regulator) = LOWER(sqlc.narg('regulator')))
+    AND (sqlc.narg('status')::text IS NULL
+        OR (sqlc.narg('status') = 'published' AND pv.id IS NOT NULL)
+        OR (sqlc.narg('status') = 'draft' AND dv.id IS NOT NULL))
+    AND (sqlc.narg('updated_since')::timestamptz IS NULL OR f.updated_at >= sqlc.narg('updated_since'))
+ORDER BY f.name
+LIMIT @page_size OFFSET @page_offset;
+
+-- name: CountFrameworksFiltered :one
+-- Frameworks matching the filters of ListFrameworksFiltered
+SELECT COUNT(*) FROM compliance_frameworks f
+WHERE (sqlc.narg('name')::text IS NULL OR f.name ILIKE '%' || sqlc.narg('name') || '%')
+    AND (sqlc.narg('regulator')::text IS NULL OR LOWER(f.regulator) = LOWER(sqlc.narg('regulator')))
+    AND (sqlc.narg('status')::text IS NULL
+        OR (sqlc.narg('status') = 'published' AND EXISTS (
+            SELECT 1 FROM framework_versions fv WHERE fv.framework_id = f.id AND fv.status = 'published'
+        ))
+        OR (sqlc.narg('status') = 'draft' AND EXISTS (
+            SELECT 1 FROM framework_versions fv WHERE fv.framework_id = f.id AND fv.status = 'draft'
+        )))
+    AND (sqlc.narg('updated_since')::timestamptz IS NULL OR f.updated_at >= sqlc.narg('updated_since'));
+
 -- name: UpdateFramework :one
 UPDATE compliance_frameworks
-SET name = $2, description = $3, version = $5
+SET name = $2, description = $3, version = $4, regulator = $5, language = $6
 WHERE id = $1
 RETURNING *;
 
--- name: DeleteFramework :exec
-DELETE FROM compliance_frameworks
-WHERE id = $1;
+-- name: DeleteFramework :execrows
+-- Keeps frameworks with a published version, audits are pinned to those versions
+DELETE FROM compliance_frameworks cf
+WHERE cf.id = $1
+  AND NOT EXISTS (
+    SELECT 1 FROM framework_versions fv
+    WHERE fv.framework_id = cf.id AND fv.status = 'published'
+  );
 
 -- name: CountFrameworks :one
 SELECT COUNT(*) FROM compliance_frameworks;
@@ -0,0 +1,56 @@
+-- name: UpsertQuestionTranslation :exec
+INSERT INTO framework_question_translations (
+    version_id,
+    control_id,
+    locale,
+    question_text,
+    help_text
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (version_id, control_id, locale) DO UPDATE
+SET question_text = EXCLUDED.question_text, help_text = EXCLUDED.help_text;
+
+-- name: UpsertSectionTranslation :exec
+INSERT INTO framework_section_translations (
+    version_id,
+    locale,
+    source_title,
+    title
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (version_id, locale, source_title) DO UPDATE
+SET title = EXCLUDED.title;
+
+-- name: ListVersionQuestionTranslations :many
+SELECT * FROM framework_question_translations
+WHERE version_id = $1
+ORDER BY locale, control_id;
+
+-- name: ListVersionSectionTranslations :many
+SELECT * FROM framework_section_translations
+WHERE version_id = $1
+ORDER BY locale, source_title;
+
+-- name: DeleteQuestionTranslations :execrows
+DELETE FROM framework_question_translations
+WHERE version_id = $1 AND locale = $2;
+
+-- name: DeleteSectionTranslations :execrows
+DELETE FROM framework_section_translations
+WHERE version_id = $1 AND locale = $2;
+
+-- name: CopyQuestionTranslations :exec
+-- Copies the question translations of a version into a new draft
+INSERT INTO framework_question_translations (version_id, control_id, locale, question_text, help_text)
+SELECT @target_version_id::uuid, control_id, locale, question_text, help_text
+FROM framework_question_translations
+WHERE version_id = @source_version_id;
+
+-- name: CopySectionTranslations :exec
+-- Copies the section translations of a version into a new draft
+INSERT INTO framework_section_translations (version_id, locale, source_title, title)
+SELECT @target_version_id::uuid, locale, source_title, title
+FROM framework_section_translations
+WHERE version_id = @source_version_id;
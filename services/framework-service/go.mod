@@ -4,7 +4,9 @@ go 1.25
 
 require (
 	github.com/NormaTech-AI/audity/packages/go/auth v0.0.0
+	github.com/NormaTech-AI/audity/packages/go/evidence v0.0.0
 	github.com/NormaTech-AI/audity/packages/go/rbac v0.0.0
+	github.com/NormaTech-AI/audity/packages/go/spreadsheet v0.0.0
 	github.com/go-playground/validator/v10 v10.23.0
 	github.com/golang-migrate/migrate/v4 v4.18.1
 	github.com/google/uuid v1.6.0
@@ -14,6 +16,7 @@ require (
 	github.com/swaggo/echo-swagger v1.4.1
 	github.com/swaggo/swag v1.16.4
 	go.uber.org/zap v1.27.0
+	golang.org/x/text v0.25.0
 )
 
 require (
@@ -63,7 +66,6 @@ require (
 	golang.org/x/net v0.40.0 // indirect
 	golang.org/x/sync v0.14.0 // indirect
 	golang.org/x/sys v0.33.0 // indirect
-	golang.org/x/text v0.25.0 // indirect
 	golang.org/x/time v0.11.0 // indirect
 	golang.org/x/tools v0.24.0 // indirect
 	gopkg.in/ini.v1 v1.67.0 // indirect
@@ -71,4 +73,8 @@ require (
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
-replace github.com/NormaTech-AI/audity/packages/go/auth => ../../packages/go/auth
+replace (
+	github.com/NormaTech-AI/audity/packages/go/auth => ../../packages/go/auth
+	github.com/NormaTech-AI/audity/packages/go/evidence => ../../packages/go/evidence
+	github.com/NormaTech-AI/audity/packages/go/spreadsheet => ../../packages/go/spreadsheet
+)
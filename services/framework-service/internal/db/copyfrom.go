@@ -34,6 +34,15 @@ func (r iteratorForBulkCreateFrameworkQuestions) Values() ([]interface{}, error)
 		r.rows[0].QuestionText,
 		r.rows[0].HelpText,
 		r.rows[0].AcceptableEvidence,
+		r.rows[0].VersionID,
+		r.rows[0].SectionTitle,
+		r.rows[0].QuestionType,
+		r.rows[0].Options,
+		r.rows[0].IsMandatory,
+		r.rows[0].DisplayOrder,
+		r.rows[0].SubsectionTitle,
+		r.rows[0].EvidenceRequired,
+		r.rows[0].EvidenceRequirements,
 	}, nil
 }
 
@@ -42,5 +51,5 @@ func (r iteratorForBulkCreateFrameworkQuestions) Err() error {
 }
 
 func (q *Queries) BulkCreateFrameworkQuestions(ctx context.Context, arg []BulkCreateFrameworkQuestionsParams) (int64, error) {
-	return q.db.CopyFrom(ctx, []string{"framework_questions"}, []string{"framework_id", "control_id", "question_text", "help_text", "acceptable_evidence"}, &iteratorForBulkCreateFrameworkQuestions{rows: arg})
+	return q.db.CopyFrom(ctx, []string{"framework_questions"}, []string{"framework_id", "control_id", "question_text", "help_text", "acceptable_evidence", "version_id", "section_title", "question_type", "options", "is_mandatory", "display_order", "subsection_title", "evidence_required", "evidence_requirements"}, &iteratorForBulkCreateFrameworkQuestions{rows: arg})
 }
@@ -13,18 +13,89 @@ import (
 )
 
 type BulkCreateFrameworkQuestionsParams struct {
-	FrameworkID        uuid.UUID `json:"framework_id"`
-	ControlID          string    `json:"control_id"`
-	QuestionText       string    `json:"question_text"`
-	HelpText           *string   `json:"help_text"`
-	AcceptableEvidence []string  `json:"acceptable_evidence"`
+	FrameworkID          uuid.UUID `json:"framework_id"`
+	ControlID            string    `json:"control_id"`
+	QuestionText         string    `json:"question_text"`
+	HelpText             *string   `json:"help_text"`
+	AcceptableEvidence   []string  `json:"acceptable_evidence"`
+	VersionID            uuid.UUID `json:"version_id"`
+	SectionTitle         *string   `json:"section_title"`
+	QuestionType         string    `json:"question_type"`
+	Options              []byte    `json:"options"`
+	IsMandatory          bool      `json:"is_mandatory"`
+	DisplayOrder         int32     `json:"display_order"`
+	SubsectionTitle      *string   `json:"subsection_title"`
+	EvidenceRequired     bool      `json:"evidence_required"`
+	EvidenceRequirements []byte    `json:"evidence_requirements"`
+}
+
+const CopyFrameworkVersionQuestions = `-- name: CopyFrameworkVersionQuestions :execrows
+INSERT INTO framework_questions (
+    framework_id,
+    section_title,
+    control_id,
+    question_text,
+    help_text,
+    acceptable_evidence,
+    visibility_condition,
+    weight,
+    severity,
+    version_id,
+    question_type,
+    options,
+    is_mandatory,
+    display_order,
+    subsection_title,
+    evidence_required,
+    evidence_requirements
+)
+SELECT
+    framework_id,
+    section_title,
+    control_id,
+    question_text,
+    help_text,
+    acceptable_evidence,
+    visibility_condition,
+    weight,
+    severity,
+    $1::uuid,
+    question_type,
+    options,
+    is_mandatory,
+    display_order,
+    subsection_title,
+    evidence_required,
+    evidence_requirements
+FROM framework_questions
+WHERE version_id = $2
+`
+
+type CopyFrameworkVersionQuestionsParams struct {
+	TargetVersionID uuid.UUID `json:"target_version_id"`
+	SourceVersionID uuid.UUID `json:"source_version_id"`
+}
+
+// Copies the questions of a version into a new draft
+func (q *Queries) CopyFrameworkVersionQuestions(ctx context.Context, arg CopyFrameworkVersionQuestionsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, CopyFrameworkVersionQuestions, arg.TargetVersionID, arg.SourceVersionID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const CountFrameworkQuestions = `-- name: CountFrameworkQuestions :one
 SELECT COUNT(*) FROM framework_questions
-WHERE framework_id = $1
+WHERE version_id = (
+    SELECT fv.id FROM framework_versions fv
+    WHERE fv.framework_id = $1 AND fv.status = 'published'
+    ORDER BY fv.published_at DESC
+    LIMIT 1
+)
 `
 
+// Questions in the latest published version of a framework
 func (q *Queries) CountFrameworkQuestions(ctx context.Context, frameworkID uuid.UUID) (int64, error) {
 	row := q.db.QueryRow(ctx, CountFrameworkQuestions, frameworkID)
 	var count int64
@@ -32,6 +103,45 @@ func (q *Queries) CountFrameworkQuestions(ctx context.Context, frameworkID uuid.
 	return count, err
 }
 
+const CountSearchFrameworkQuestions = `-- name: CountSearchFrameworkQuestions :one
+SELECT COUNT(*) FROM framework_questions fq
+WHERE fq.version_id = (
+    SELECT lv.id FROM framework_versions lv
+    WHERE lv.framework_id = fq.framework_id AND lv.status = 'published'
+    ORDER BY lv.published_at DESC
+    LIMIT 1
+)
+    AND (
+        framework_question_search_vector(fq.control_id, fq.question_text, fq.section_title, fq.subsection_title, fq.help_text)
+            @@ websearch_to_tsquery('english', $1)
+        OR fq.control_id ILIKE $2 || '%'
+    )
+    AND ($3::uuid IS NULL OR fq.framework_id = $3)
+    AND ($4::text IS NULL
+        OR fq.section_title ILIKE '%' || $4 || '%'
+        OR fq.subsection_title ILIKE '%' || $4 || '%')
+`
+
+type CountSearchFrameworkQuestionsParams struct {
+	Query           string      `json:"query"`
+	ControlIDPrefix string      `json:"control_id_prefix"`
+	FrameworkID     pgtype.UUID `json:"framework_id"`
+	Section         *string     `json:"section"`
+}
+
+// Questions matching SearchFrameworkQuestions
+func (q *Queries) CountSearchFrameworkQuestions(ctx context.Context, arg CountSearchFrameworkQuestionsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, CountSearchFrameworkQuestions,
+		arg.Query,
+		arg.ControlIDPrefix,
+		arg.FrameworkID,
+		arg.Section,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const CreateFrameworkQuestion = `-- name: CreateFrameworkQuestion :one
 INSERT INTO framework_questions (
     framework_id,
@@ -39,20 +149,42 @@ INSERT INTO framework_questions (
     control_id,
     question_text,
     help_text,
-    acceptable_evidence
+    acceptable_evidence,
+    visibility_condition,
+    weight,
+    severity,
+    version_id,
+    question_type,
+    options,
+    is_mandatory,
+    display_order,
+    subsection_title,
+    evidence_required,
+    evidence_requirements
 ) VALUES (
-    $1, $2, $3, $4, $5, $6
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
 )
-RETURNING question_id, framework_id, control_id, question_text, help_text, acceptable_evidence, created_at, updated_at, section_title
+RETURNING question_id, framework_id, control_id, question_text, help_text, acceptable_evidence, created_at, updated_at, section_title, visibility_condition, weight, severity, version_id, question_type, options, is_mandatory, display_order, subsection_title, evidence_required, evidence_requirements
 `
 
 type CreateFrameworkQuestionParams struct {
-	FrameworkID        uuid.UUID `json:"framework_id"`
-	SectionTitle       *string   `json:"section_title"`
-	ControlID          string    `json:"control_id"`
-	QuestionText       string    `json:"question_text"`
-	HelpText           *string   `json:"help_text"`
-	AcceptableEvidence []string  `json:"acceptable_evidence"`
+	FrameworkID          uuid.UUID `json:"framework_id"`
+	SectionTitle         *string   `json:"section_title"`
+	ControlID            string    `json:"control_id"`
+	QuestionText         string    `json:"question_text"`
+	HelpText             *string   `json:"help_text"`
+	AcceptableEvidence   []string  `json:"acceptable_evidence"`
+	VisibilityCondition  []byte    `json:"visibility_condition"`
+	Weight               int32     `json:"weight"`
+	Severity             string    `json:"severity"`
+	VersionID            uuid.UUID `json:"version_id"`
+	QuestionType         string    `json:"question_type"`
+	Options              []byte    `json:"options"`
+	IsMandatory          bool      `json:"is_mandatory"`
+	DisplayOrder         int32     `json:"display_order"`
+	SubsectionTitle      *string   `json:"subsection_title"`
+	EvidenceRequired     bool      `json:"evidence_required"`
+	EvidenceRequirements []byte    `json:"evidence_requirements"`
 }
 
 func (q *Queries) CreateFrameworkQuestion(ctx context.Context, arg CreateFrameworkQuestionParams) (FrameworkQuestion, error) {
@@ -63,6 +195,17 @@ func (q *Queries) CreateFrameworkQuestion(ctx context.Context, arg CreateFramewo
 		arg.QuestionText,
 		arg.HelpText,
 		arg.AcceptableEvidence,
+		arg.VisibilityCondition,
+		arg.Weight,
+		arg.Severity,
+		arg.VersionID,
+		arg.QuestionType,
+		arg.Options,
+		arg.IsMandatory,
+		arg.DisplayOrder,
+		arg.SubsectionTitle,
+		arg.EvidenceRequired,
+		arg.EvidenceRequirements,
 	)
 	var i FrameworkQuestion
 	err := row.Scan(
@@ -75,6 +218,17 @@ func (q *Queries) CreateFrameworkQuestion(ctx context.Context, arg CreateFramewo
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.SectionTitle,
+		&i.VisibilityCondition,
+		&i.Weight,
+		&i.Severity,
+		&i.VersionID,
+		&i.QuestionType,
+		&i.Options,
+		&i.IsMandatory,
+		&i.DisplayOrder,
+		&i.SubsectionTitle,
+		&i.EvidenceRequired,
+		&i.EvidenceRequirements,
 	)
 	return i, err
 }
@@ -99,8 +253,38 @@ func (q *Queries) DeleteFrameworkQuestionsByFrameworkId(ctx context.Context, fra
 	return err
 }
 
+const DeleteFrameworkQuestionsByVersion = `-- name: DeleteFrameworkQuestionsByVersion :exec
+DELETE FROM framework_questions
+WHERE version_id = $1
+`
+
+func (q *Queries) DeleteFrameworkQuestionsByVersion(ctx context.Context, versionID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, DeleteFrameworkQuestionsByVersion, versionID)
+	return err
+}
+
+const FrameworkControlExists = `-- name: FrameworkControlExists :one
+SELECT EXISTS (
+    SELECT 1 FROM framework_questions
+    WHERE framework_id = $1 AND control_id = $2
+)
+`
+
+type FrameworkControlExistsParams struct {
+	FrameworkID uuid.UUID `json:"framework_id"`
+	ControlID   string    `json:"control_id"`
+}
+
+// Whether any version of the framework has a question for the control
+func (q *Queries) FrameworkControlExists(ctx context.Context, arg FrameworkControlExistsParams) (bool, error) {
+	row := q.db.QueryRow(ctx, FrameworkControlExists, arg.FrameworkID, arg.ControlID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
 const GetFrameworkQuestion = `-- name: GetFrameworkQuestion :one
-SELECT question_id, framework_id, control_id, question_text, help_text, acceptable_evidence, created_at, updated_at, section_title FROM framework_questions
+SELECT question_id, framework_id, control_id, question_text, help_text, acceptable_evidence, created_at, updated_at, section_title, visibility_condition, weight, severity, version_id, question_type, options, is_mandatory, display_order, subsection_title, evidence_required, evidence_requirements FROM framework_questions
 WHERE question_id = $1 LIMIT 1
 `
 
@@ -117,6 +301,17 @@ func (q *Queries) GetFrameworkQuestion(ctx context.Context, questionID uuid.UUID
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.SectionTitle,
+		&i.VisibilityCondition,
+		&i.Weight,
+		&i.Severity,
+		&i.VersionID,
+		&i.QuestionType,
+		&i.Options,
+		&i.IsMandatory,
+		&i.DisplayOrder,
+		&i.SubsectionTitle,
+		&i.EvidenceRequired,
+		&i.EvidenceRequirements,
 	)
 	return i, err
 }
@@ -181,11 +376,17 @@ func (q *Queries) GetFrameworkWithQuestions(ctx context.Context, id uuid.UUID) (
 }
 
 const ListFrameworkQuestions = `-- name: ListFrameworkQuestions :many
-SELECT question_id, framework_id, control_id, question_text, help_text, acceptable_evidence, created_at, updated_at, section_title FROM framework_questions
-WHERE framework_id = $1
-ORDER BY control_id
+SELECT question_id, framework_id, control_id, question_text, help_text, acceptable_evidence, created_at, updated_at, section_title, visibility_condition, weight, severity, version_id, question_type, options, is_mandatory, display_order, subsection_title, evidence_required, evidence_requirements FROM framework_questions
+WHERE version_id = (
+    SELECT fv.id FROM framework_versions fv
+    WHERE fv.framework_id = $1 AND fv.status = 'published'
+    ORDER BY fv.published_at DESC
+    LIMIT 1
+)
+ORDER BY display_order, control_id
 `
 
+// Questions of the latest published version of a framework
 func (q *Queries) ListFrameworkQuestions(ctx context.Context, frameworkID uuid.UUID) ([]FrameworkQuestion, error) {
 	rows, err := q.db.Query(ctx, ListFrameworkQuestions, frameworkID)
 	if err != nil {
@@ -205,6 +406,169 @@ func (q *Queries) ListFrameworkQuestions(ctx context.Context, frameworkID uuid.U
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.SectionTitle,
+			&i.VisibilityCondition,
+			&i.Weight,
+			&i.Severity,
+			&i.VersionID,
+			&i.QuestionType,
+			&i.Options,
+			&i.IsMandatory,
+			&i.DisplayOrder,
+			&i.SubsectionTitle,
+			&i.EvidenceRequired,
+			&i.EvidenceRequirements,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListVersionQuestions = `-- name: ListVersionQuestions :many
+SELECT question_id, framework_id, control_id, question_text, help_text, acceptable_evidence, created_at, updated_at, section_title, visibility_condition, weight, severity, version_id, question_type, options, is_mandatory, display_order, subsection_title, evidence_required, evidence_requirements FROM framework_questions
+WHERE version_id = $1
+ORDER BY display_order, control_id
+`
+
+func (q *Queries) ListVersionQuestions(ctx context.Context, versionID uuid.UUID) ([]FrameworkQuestion, error) {
+	rows, err := q.db.Query(ctx, ListVersionQuestions, versionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FrameworkQuestion{}
+	for rows.Next() {
+		var i FrameworkQuestion
+		if err := rows.Scan(
+			&i.QuestionID,
+			&i.FrameworkID,
+			&i.ControlID,
+			&i.QuestionText,
+			&i.HelpText,
+			&i.AcceptableEvidence,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.SectionTitle,
+			&i.VisibilityCondition,
+			&i.Weight,
+			&i.Severity,
+			&i.VersionID,
+			&i.QuestionType,
+			&i.Options,
+			&i.IsMandatory,
+			&i.DisplayOrder,
+			&i.SubsectionTitle,
+			&i.EvidenceRequired,
+			&i.EvidenceRequirements,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const SearchFrameworkQuestions = `-- name: SearchFrameworkQuestions :many
+SELECT
+    fq.question_id,
+    fq.framework_id,
+    f.name AS framework_name,
+    fq.version_id,
+    fv.version AS framework_version,
+    fq.section_title,
+    fq.subsection_title,
+    fq.control_id,
+    fq.question_text,
+    fq.help_text,
+    fq.question_type,
+    ts_rank(
+        framework_question_search_vector(fq.control_id, fq.question_text, fq.section_title, fq.subsection_title, fq.help_text),
+        websearch_to_tsquery('english', $1)
+    )::real AS rank
+FROM framework_questions fq
+JOIN framework_versions fv ON fv.id = fq.version_id
+JOIN compliance_frameworks f ON f.id = fq.framework_id
+WHERE fv.id = (
+    SELECT lv.id FROM framework_versions lv
+    WHERE lv.framework_id = fq.framework_id AND lv.status = 'published'
+    ORDER BY lv.published_at DESC
+    LIMIT 1
+)
+    AND (
+        framework_question_search_vector(fq.control_id, fq.question_text, fq.section_title, fq.subsection_title, fq.help_text)
+            @@ websearch_to_tsquery('english', $1)
+        OR fq.control_id ILIKE $2 || '%'
+    )
+    AND ($3::uuid IS NULL OR fq.framework_id = $3)
+    AND ($4::text IS NULL
+        OR fq.section_title ILIKE '%' || $4 || '%'
+        OR fq.subsection_title ILIKE '%' || $4 || '%')
+ORDER BY rank DESC, f.name, fq.display_order
+LIMIT $5 OFFSET $6
+`
+
+type SearchFrameworkQuestionsParams struct {
+	Query           string      `json:"query"`
+	ControlIDPrefix string      `json:"control_id_prefix"`
+	FrameworkID     pgtype.UUID `json:"framework_id"`
+	Section         *string     `json:"section"`
+	PageSize        int32       `json:"page_size"`
+	PageOffset      int32       `json:"page_offset"`
+}
+
+type SearchFrameworkQuestionsRow struct {
+	QuestionID       uuid.UUID `json:"question_id"`
+	FrameworkID      uuid.UUID `json:"framework_id"`
+	FrameworkName    string    `json:"framework_name"`
+	VersionID        uuid.UUID `json:"version_id"`
+	FrameworkVersion string    `json:"framework_version"`
+	SectionTitle     *string   `json:"section_title"`
+	SubsectionTitle  *string   `json:"subsection_title"`
+	ControlID        string    `json:"control_id"`
+	QuestionText     string    `json:"question_text"`
+	HelpText         *string   `json:"help_text"`
+	QuestionType     string    `json:"question_type"`
+	Rank             float32   `json:"rank"`
+}
+
+// Full-text search over the questions of the latest published version of
+// every framework, best matches first. Control IDs also match by prefix.
+func (q *Queries) SearchFrameworkQuestions(ctx context.Context, arg SearchFrameworkQuestionsParams) ([]SearchFrameworkQuestionsRow, error) {
+	rows, err := q.db.Query(ctx, SearchFrameworkQuestions,
+		arg.Query,
+		arg.ControlIDPrefix,
+		arg.FrameworkID,
+		arg.Section,
+		arg.PageSize,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchFrameworkQuestionsRow{}
+	for rows.Next() {
+		var i SearchFrameworkQuestionsRow
+		if err := rows.Scan(
+			&i.QuestionID,
+			&i.FrameworkID,
+			&i.FrameworkName,
+			&i.VersionID,
+			&i.FrameworkVersion,
+			&i.SectionTitle,
+			&i.SubsectionTitle,
+			&i.ControlID,
+			&i.QuestionText,
+			&i.HelpText,
+			&i.QuestionType,
+			&i.Rank,
 		); err != nil {
 			return nil, err
 		}
@@ -223,18 +587,38 @@ SET
     control_id = $3,
     question_text = $4,
     help_text = $5,
-    acceptable_evidence = $6
+    acceptable_evidence = $6,
+    visibility_condition = $7,
+    weight = $8,
+    severity = $9,
+    question_type = $10,
+    options = $11,
+    is_mandatory = $12,
+    display_order = $13,
+    subsection_title = $14,
+    evidence_required = $15,
+    evidence_requirements = $16
 WHERE question_id = $1
-RETURNING question_id, framework_id, control_id, question_text, help_text, acceptable_evidence, created_at, updated_at, section_title
+RETURNING question_id, framework_id, control_id, question_text, help_text, acceptable_evidence, created_at, updated_at, section_title, visibility_condition, weight, severity, version_id, question_type, options, is_mandatory, display_order, subsection_title, evidence_required, evidence_requirements
 `
 
 type UpdateFrameworkQuestionParams struct {
-	QuestionID         uuid.UUID `json:"question_id"`
-	SectionTitle       *string   `json:"section_title"`
-	ControlID          string    `json:"control_id"`
-	QuestionText       string    `json:"question_text"`
-	HelpText           *string   `json:"help_text"`
-	AcceptableEvidence []string  `json:"acceptable_evidence"`
+	QuestionID           uuid.UUID `json:"question_id"`
+	SectionTitle         *string   `json:"section_title"`
+	ControlID            string    `json:"control_id"`
+	QuestionText         string    `json:"question_text"`
+	HelpText             *string   `json:"help_text"`
+	AcceptableEvidence   []string  `json:"acceptable_evidence"`
+	VisibilityCondition  []byte    `json:"visibility_condition"`
+	Weight               int32     `json:"weight"`
+	Severity             string    `json:"severity"`
+	QuestionType         string    `json:"question_type"`
+	Options              []byte    `json:"options"`
+	IsMandatory          bool      `json:"is_mandatory"`
+	DisplayOrder         int32     `json:"display_order"`
+	SubsectionTitle      *string   `json:"subsection_title"`
+	EvidenceRequired     bool      `json:"evidence_required"`
+	EvidenceRequirements []byte    `json:"evidence_requirements"`
 }
 
 func (q *Queries) UpdateFrameworkQuestion(ctx context.Context, arg UpdateFrameworkQuestionParams) (FrameworkQuestion, error) {
@@ -245,6 +629,16 @@ func (q *Queries) UpdateFrameworkQuestion(ctx context.Context, arg UpdateFramewo
 		arg.QuestionText,
 		arg.HelpText,
 		arg.AcceptableEvidence,
+		arg.VisibilityCondition,
+		arg.Weight,
+		arg.Severity,
+		arg.QuestionType,
+		arg.Options,
+		arg.IsMandatory,
+		arg.DisplayOrder,
+		arg.SubsectionTitle,
+		arg.EvidenceRequired,
+		arg.EvidenceRequirements,
 	)
 	var i FrameworkQuestion
 	err := row.Scan(
@@ -257,6 +651,17 @@ func (q *Queries) UpdateFrameworkQuestion(ctx context.Context, arg UpdateFramewo
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.SectionTitle,
+		&i.VisibilityCondition,
+		&i.Weight,
+		&i.Severity,
+		&i.VersionID,
+		&i.QuestionType,
+		&i.Options,
+		&i.IsMandatory,
+		&i.DisplayOrder,
+		&i.SubsectionTitle,
+		&i.EvidenceRequired,
+		&i.EvidenceRequirements,
 	)
 	return i, err
 }
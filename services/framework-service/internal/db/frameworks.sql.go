@@ -23,16 +23,52 @@ func (q *Queries) CountFrameworks(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+const CountFrameworksFiltered = `-- name: CountFrameworksFiltered :one
+SELECT COUNT(*) FROM compliance_frameworks f
+WHERE ($1::text IS NULL OR f.name ILIKE '%' || $1 || '%')
+    AND ($2::text IS NULL OR LOWER(f.regulator) = LOWER($2))
+    AND ($3::text IS NULL
+        OR ($3 = 'published' AND EXISTS (
+            SELECT 1 FROM framework_versions fv WHERE fv.framework_id = f.id AND fv.status = 'published'
+        ))
+        OR ($3 = 'draft' AND EXISTS (
+            SELECT 1 FROM framework_versions fv WHERE fv.framework_id = f.id AND fv.status = 'draft'
+        )))
+    AND ($4::timestamptz IS NULL OR f.updated_at >= $4)
+`
+
+type CountFrameworksFilteredParams struct {
+	Name         *string            `json:"name"`
+	Regulator    *string            `json:"regulator"`
+	Status       *string            `json:"status"`
+	UpdatedSince pgtype.Timestamptz `json:"updated_since"`
+}
+
+// Frameworks matching the filters of ListFrameworksFiltered
+func (q *Queries) CountFrameworksFiltered(ctx context.Context, arg CountFrameworksFilteredParams) (int64, error) {
+	row := q.db.QueryRow(ctx, CountFrameworksFiltered,
+		arg.Name,
+		arg.Regulator,
+		arg.Status,
+		arg.UpdatedSince,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const CreateFramework = `-- name: CreateFramework :one
-INSERT INTO compliance_frameworks (name, description, version)
-VALUES ($1, $2, $3)
-RETURNING id, name, description, version, created_at, updated_at
+INSERT INTO compliance_frameworks (name, description, version, regulator, language)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, name, description, version, created_at, updated_at, regulator, language
 `
 
 type CreateFrameworkParams struct {
 	Name        string  `json:"name"`
 	Description *string `json:"description"`
 	Version     *string `json:"version"`
+	Regulator   *string `json:"regulator"`
+	Language    string  `json:"language"`
 }
 
 type CreateFrameworkRow struct {
@@ -42,10 +78,18 @@ type CreateFrameworkRow struct {
 	Version     *string            `json:"version"`
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	Regulator   *string            `json:"regulator"`
+	Language    string             `json:"language"`
 }
 
 func (q *Queries) CreateFramework(ctx context.Context, arg CreateFrameworkParams) (CreateFrameworkRow, error) {
-	row := q.db.QueryRow(ctx, CreateFramework, arg.Name, arg.Description, arg.Version)
+	row := q.db.QueryRow(ctx, CreateFramework,
+		arg.Name,
+		arg.Description,
+		arg.Version,
+		arg.Regulator,
+		arg.Language,
+	)
 	var i CreateFrameworkRow
 	err := row.Scan(
 		&i.ID,
@@ -54,22 +98,32 @@ func (q *Queries) CreateFramework(ctx context.Context, arg CreateFrameworkParams
 		&i.Version,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Regulator,
+		&i.Language,
 	)
 	return i, err
 }
 
-const DeleteFramework = `-- name: DeleteFramework :exec
-DELETE FROM compliance_frameworks
-WHERE id = $1
+const DeleteFramework = `-- name: DeleteFramework :execrows
+DELETE FROM compliance_frameworks cf
+WHERE cf.id = $1
+  AND NOT EXISTS (
+    SELECT 1 FROM framework_versions fv
+    WHERE fv.framework_id = cf.id AND fv.status = 'published'
+  )
 `
 
-func (q *Queries) DeleteFramework(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.Exec(ctx, DeleteFramework, id)
-	return err
+// Keeps frameworks with a published version, audits are pinned to those versions
+func (q *Queries) DeleteFramework(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, DeleteFramework, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const GetFramework = `-- name: GetFramework :one
-SELECT id, name, description, version, created_at, updated_at FROM compliance_frameworks
+SELECT id, name, description, version, created_at, updated_at, regulator, language FROM compliance_frameworks
 WHERE id = $1 LIMIT 1
 `
 
@@ -83,12 +137,14 @@ func (q *Queries) GetFramework(ctx context.Context, id uuid.UUID) (ComplianceFra
 		&i.Version,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Regulator,
+		&i.Language,
 	)
 	return i, err
 }
 
 const GetFrameworkByName = `-- name: GetFrameworkByName :one
-SELECT id, name, description, version, created_at, updated_at FROM compliance_frameworks
+SELECT id, name, description, version, created_at, updated_at, regulator, language FROM compliance_frameworks
 WHERE name = $1 LIMIT 1
 `
 
@@ -102,12 +158,14 @@ func (q *Queries) GetFrameworkByName(ctx context.Context, name string) (Complian
 		&i.Version,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Regulator,
+		&i.Language,
 	)
 	return i, err
 }
 
 const ListFrameworks = `-- name: ListFrameworks :many
-SELECT id, name, description, version, created_at, updated_at FROM compliance_frameworks
+SELECT id, name, description, version, created_at, updated_at, regulator, language FROM compliance_frameworks
 ORDER BY created_at DESC
 `
 
@@ -127,6 +185,102 @@ func (q *Queries) ListFrameworks(ctx context.Context) ([]ComplianceFramework, er
 			&i.Version,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Regulator,
+			&i.Language,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListFrameworksFiltered = `-- name: ListFrameworksFiltered :many
+SELECT
+    f.id,
+    f.name,
+    f.description,
+    f.version,
+    f.regulator,
+    f.language,
+    f.created_at,
+    f.updated_at,
+    dv.id AS draft_version_id,
+    (SELECT COUNT(*) FROM framework_questions fq WHERE fq.version_id = pv.id) AS question_count
+FROM compliance_frameworks f
+LEFT JOIN LATERAL (
+    SELECT fv.id FROM framework_versions fv
+    WHERE fv.framework_id = f.id AND fv.status = 'published'
+    ORDER BY fv.published_at DESC
+    LIMIT 1
+) pv ON true
+LEFT JOIN framework_versions dv ON dv.framework_id = f.id AND dv.status = 'draft'
+WHERE ($1::text IS NULL OR f.name ILIKE '%' || $1 || '%')
+    AND ($2::text IS NULL OR LOWER(f.regulator) = LOWER($2))
+    AND ($3::text IS NULL
+        OR ($3 = 'published' AND pv.id IS NOT NULL)
+        OR ($3 = 'draft' AND dv.id IS NOT NULL))
+    AND ($4::timestamptz IS NULL OR f.updated_at >= $4)
+ORDER BY f.name
+LIMIT $5 OFFSET $6
+`
+
+type ListFrameworksFilteredParams struct {
+	Name         *string            `json:"name"`
+	Regulator    *string            `json:"regulator"`
+	Status       *string            `json:"status"`
+	UpdatedSince pgtype.Timestamptz `json:"updated_since"`
+	PageSize     int32              `json:"page_size"`
+	PageOffset   int32              `json:"page_offset"`
+}
+
+type ListFrameworksFilteredRow struct {
+	ID             uuid.UUID          `json:"id"`
+	Name           string             `json:"name"`
+	Description    *string            `json:"description"`
+	Version        *string            `json:"version"`
+	Regulator      *string            `json:"regulator"`
+	Language       string             `json:"language"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	DraftVersionID pgtype.UUID        `json:"draft_version_id"`
+	QuestionCount  int64              `json:"question_count"`
+}
+
+// A page of the frameworks matching the optional filters, with the question
+// count of their latest published version and their draft version, if any.
+// status is published for frameworks with a published version and draft for
+// frameworks with a draft.
+func (q *Queries) ListFrameworksFiltered(ctx context.Context, arg ListFrameworksFilteredParams) ([]ListFrameworksFilteredRow, error) {
+	rows, err := q.db.Query(ctx, ListFrameworksFiltered,
+		arg.Name,
+		arg.Regulator,
+		arg.Status,
+		arg.UpdatedSince,
+		arg.PageSize,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListFrameworksFilteredRow{}
+	for rows.Next() {
+		var i ListFrameworksFilteredRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.Version,
+			&i.Regulator,
+			&i.Language,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DraftVersionID,
+			&i.QuestionCount,
 		); err != nil {
 			return nil, err
 		}
@@ -140,16 +294,18 @@ func (q *Queries) ListFrameworks(ctx context.Context) ([]ComplianceFramework, er
 
 const UpdateFramework = `-- name: UpdateFramework :one
 UPDATE compliance_frameworks
-SET name = $2, description = $3, version = $4
+SET name = $2, description = $3, version = $4, regulator = $5, language = $6
 WHERE id = $1
-RETURNING id, name, description, version, created_at, updated_at
+RETURNING id, name, description, version, created_at, updated_at, regulator, language
 `
 
 type UpdateFrameworkParams struct {
-	ID            uuid.UUID `json:"id"`
-	Name          string    `json:"name"`
-	Description   *string   `json:"description"`
-	Version       *string   `json:"version"`
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description *string   `json:"description"`
+	Version     *string   `json:"version"`
+	Regulator   *string   `json:"regulator"`
+	Language    string    `json:"language"`
 }
 
 func (q *Queries) UpdateFramework(ctx context.Context, arg UpdateFrameworkParams) (ComplianceFramework, error) {
@@ -158,6 +314,8 @@ func (q *Queries) UpdateFramework(ctx context.Context, arg UpdateFrameworkParams
 		arg.Name,
 		arg.Description,
 		arg.Version,
+		arg.Regulator,
+		arg.Language,
 	)
 	var i ComplianceFramework
 	err := row.Scan(
@@ -167,6 +325,8 @@ func (q *Queries) UpdateFramework(ctx context.Context, arg UpdateFrameworkParams
 		&i.Version,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Regulator,
+		&i.Language,
 	)
 	return i, err
 }
@@ -10,23 +10,95 @@ import (
 )
 
 type ComplianceFramework struct {
-	ID            uuid.UUID          `json:"id"`
-	Name          string             `json:"name"`
-	Description   *string            `json:"description"`
-	ChecklistJson []byte             `json:"checklist_json"`
-	Version       *string            `json:"version"`
-	CreatedAt     pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+	ID            uuid.UUID `json:"id"`
+	Name          string    `json:"name"`
+	Description   *string   `json:"description"`
+	ChecklistJson []byte    `json:"checklist_json"`
+	// Label of the latest published version
+	Version   *string            `json:"version"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	// Regulator or exchange that publishes the framework, e.g. SEBI or NSE
+	Regulator *string `json:"regulator"`
+	// Canonical language of the framework as a BCP 47 tag; reports render in it
+	Language string `json:"language"`
+}
+
+// Controls of different frameworks that cover the same requirement
+type ControlMapping struct {
+	ID                uuid.UUID `json:"id"`
+	SourceFrameworkID uuid.UUID `json:"source_framework_id"`
+	SourceControlID   string    `json:"source_control_id"`
+	TargetFrameworkID uuid.UUID `json:"target_framework_id"`
+	TargetControlID   string    `json:"target_control_id"`
+	// equivalent controls share answers; partial and related ones are informational
+	Strength  string             `json:"strength"`
+	Notes     *string            `json:"notes"`
+	CreatedBy pgtype.UUID        `json:"created_by"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
 }
 
 type FrameworkQuestion struct {
-	QuestionID         uuid.UUID          `json:"question_id"`
-	FrameworkID        uuid.UUID          `json:"framework_id"`
-	ControlID          string             `json:"control_id"`
-	QuestionText       string             `json:"question_text"`
-	HelpText           *string            `json:"help_text"`
-	AcceptableEvidence []string           `json:"acceptable_evidence"`
-	CreatedAt          pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
-	SectionTitle       *string            `json:"section_title"`
+	QuestionID          uuid.UUID          `json:"question_id"`
+	FrameworkID         uuid.UUID          `json:"framework_id"`
+	ControlID           string             `json:"control_id"`
+	QuestionText        string             `json:"question_text"`
+	HelpText            *string            `json:"help_text"`
+	AcceptableEvidence  []string           `json:"acceptable_evidence"`
+	CreatedAt           pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt           pgtype.Timestamptz `json:"updated_at"`
+	SectionTitle        *string            `json:"section_title"`
+	VisibilityCondition []byte             `json:"visibility_condition"`
+	Weight              int32              `json:"weight"`
+	Severity            string             `json:"severity"`
+	VersionID           uuid.UUID          `json:"version_id"`
+	// Answer type: yes_no, text, single_choice, multiple_choice, numeric, date or table
+	QuestionType string `json:"question_type"`
+	// Choice options or table column definitions
+	Options     []byte `json:"options"`
+	IsMandatory bool   `json:"is_mandatory"`
+	// Position of the question in the checklist of its version
+	DisplayOrder int32 `json:"display_order"`
+	// Sub-section within the section title
+	SubsectionTitle *string `json:"subsection_title"`
+	// Whether an answer must be backed by uploaded evidence
+	EvidenceRequired bool `json:"evidence_required"`
+	// Minimum files, allowed file types and required document categories of the evidence
+	EvidenceRequirements []byte `json:"evidence_requirements"`
+}
+
+// Translated question and help text of the questions of a version, by control ID
+type FrameworkQuestionTranslation struct {
+	VersionID    uuid.UUID          `json:"version_id"`
+	ControlID    string             `json:"control_id"`
+	Locale       string             `json:"locale"`
+	QuestionText string             `json:"question_text"`
+	HelpText     *string            `json:"help_text"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Translated section and sub-section titles of a version
+type FrameworkSectionTranslation struct {
+	VersionID   uuid.UUID          `json:"version_id"`
+	Locale      string             `json:"locale"`
+	SourceTitle string             `json:"source_title"`
+	Title       string             `json:"title"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Versions of a framework; published versions are immutable
+type FrameworkVersion struct {
+	ID          uuid.UUID          `json:"id"`
+	FrameworkID uuid.UUID          `json:"framework_id"`
+	Version     string             `json:"version"`
+	Status      string             `json:"status"`
+	Notes       *string            `json:"notes"`
+	CreatedBy   pgtype.UUID        `json:"created_by"`
+	PublishedBy pgtype.UUID        `json:"published_by"`
+	PublishedAt pgtype.Timestamptz `json:"published_at"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
 }
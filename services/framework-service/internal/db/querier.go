@@ -12,21 +12,70 @@ import (
 
 type Querier interface {
 	BulkCreateFrameworkQuestions(ctx context.Context, arg []BulkCreateFrameworkQuestionsParams) (int64, error)
+	// Copies the questions of a version into a new draft
+	CopyFrameworkVersionQuestions(ctx context.Context, arg CopyFrameworkVersionQuestionsParams) (int64, error)
+	// Copies the question translations of a version into a new draft
+	CopyQuestionTranslations(ctx context.Context, arg CopyQuestionTranslationsParams) error
+	// Copies the section translations of a version into a new draft
+	CopySectionTranslations(ctx context.Context, arg CopySectionTranslationsParams) error
+	// Questions in the latest published version of a framework
 	CountFrameworkQuestions(ctx context.Context, frameworkID uuid.UUID) (int64, error)
 	CountFrameworks(ctx context.Context) (int64, error)
+	// Frameworks matching the filters of ListFrameworksFiltered
+	CountFrameworksFiltered(ctx context.Context, arg CountFrameworksFilteredParams) (int64, error)
+	// Questions matching SearchFrameworkQuestions
+	CountSearchFrameworkQuestions(ctx context.Context, arg CountSearchFrameworkQuestionsParams) (int64, error)
+	CreateControlMapping(ctx context.Context, arg CreateControlMappingParams) (ControlMapping, error)
 	CreateFramework(ctx context.Context, arg CreateFrameworkParams) (CreateFrameworkRow, error)
 	CreateFrameworkQuestion(ctx context.Context, arg CreateFrameworkQuestionParams) (FrameworkQuestion, error)
-	DeleteFramework(ctx context.Context, id uuid.UUID) error
+	CreateFrameworkVersion(ctx context.Context, arg CreateFrameworkVersionParams) (FrameworkVersion, error)
+	DeleteControlMapping(ctx context.Context, id uuid.UUID) (int64, error)
+	DeleteDraftFrameworkVersion(ctx context.Context, id uuid.UUID) (int64, error)
+	// Keeps frameworks with a published version, audits are pinned to those versions
+	DeleteFramework(ctx context.Context, id uuid.UUID) (int64, error)
 	DeleteFrameworkQuestion(ctx context.Context, questionID uuid.UUID) error
 	DeleteFrameworkQuestionsByFrameworkId(ctx context.Context, frameworkID uuid.UUID) error
+	DeleteFrameworkQuestionsByVersion(ctx context.Context, versionID uuid.UUID) error
+	DeleteQuestionTranslations(ctx context.Context, arg DeleteQuestionTranslationsParams) (int64, error)
+	DeleteSectionTranslations(ctx context.Context, arg DeleteSectionTranslationsParams) (int64, error)
+	// Whether any version of the framework has a question for the control
+	FrameworkControlExists(ctx context.Context, arg FrameworkControlExistsParams) (bool, error)
+	GetControlMapping(ctx context.Context, id uuid.UUID) (ControlMapping, error)
+	GetDraftFrameworkVersion(ctx context.Context, frameworkID uuid.UUID) (FrameworkVersion, error)
 	GetFramework(ctx context.Context, id uuid.UUID) (ComplianceFramework, error)
 	GetFrameworkByName(ctx context.Context, name string) (ComplianceFramework, error)
 	GetFrameworkQuestion(ctx context.Context, questionID uuid.UUID) (FrameworkQuestion, error)
+	GetFrameworkVersion(ctx context.Context, id uuid.UUID) (FrameworkVersion, error)
 	GetFrameworkWithQuestions(ctx context.Context, id uuid.UUID) ([]GetFrameworkWithQuestionsRow, error)
+	GetLatestPublishedFrameworkVersion(ctx context.Context, frameworkID uuid.UUID) (FrameworkVersion, error)
+	// Mappings of one control of a framework, on either side
+	ListControlMappingsForControl(ctx context.Context, arg ListControlMappingsForControlParams) ([]ListControlMappingsForControlRow, error)
+	// Mappings with a control of the framework on either side
+	ListFrameworkControlMappings(ctx context.Context, frameworkID uuid.UUID) ([]ListFrameworkControlMappingsRow, error)
+	// Questions of the latest published version of a framework
 	ListFrameworkQuestions(ctx context.Context, frameworkID uuid.UUID) ([]FrameworkQuestion, error)
+	ListFrameworkVersions(ctx context.Context, frameworkID uuid.UUID) ([]ListFrameworkVersionsRow, error)
 	ListFrameworks(ctx context.Context) ([]ComplianceFramework, error)
+	// A page of the frameworks matching the optional filters, with the question
+	// count of their latest published version and their draft version, if any.
+	// status is published for frameworks with a published version and draft for
+	// frameworks with a draft.
+	ListFrameworksFiltered(ctx context.Context, arg ListFrameworksFilteredParams) ([]ListFrameworksFilteredRow, error)
+	ListVersionQuestionTranslations(ctx context.Context, versionID uuid.UUID) ([]FrameworkQuestionTranslation, error)
+	ListVersionQuestions(ctx context.Context, versionID uuid.UUID) ([]FrameworkQuestion, error)
+	ListVersionSectionTranslations(ctx context.Context, versionID uuid.UUID) ([]FrameworkSectionTranslation, error)
+	// Freezes a draft; published versions cannot be changed afterwards
+	PublishFrameworkVersion(ctx context.Context, arg PublishFrameworkVersionParams) (FrameworkVersion, error)
+	// Full-text search over the questions of the latest published version of
+	// every framework, best matches first. Control IDs also match by prefix.
+	SearchFrameworkQuestions(ctx context.Context, arg SearchFrameworkQuestionsParams) ([]SearchFrameworkQuestionsRow, error)
+	SetFrameworkCurrentVersion(ctx context.Context, arg SetFrameworkCurrentVersionParams) error
+	UpdateControlMapping(ctx context.Context, arg UpdateControlMappingParams) (ControlMapping, error)
+	UpdateDraftFrameworkVersion(ctx context.Context, arg UpdateDraftFrameworkVersionParams) (FrameworkVersion, error)
 	UpdateFramework(ctx context.Context, arg UpdateFrameworkParams) (ComplianceFramework, error)
 	UpdateFrameworkQuestion(ctx context.Context, arg UpdateFrameworkQuestionParams) (FrameworkQuestion, error)
+	UpsertQuestionTranslation(ctx context.Context, arg UpsertQuestionTranslationParams) error
+	UpsertSectionTranslation(ctx context.Context, arg UpsertSectionTranslationParams) error
 }
 
 var _ Querier = (*Queries)(nil)
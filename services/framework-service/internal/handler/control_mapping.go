@@ -0,0 +1,418 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/NormaTech-AI/audity/packages/go/auth"
+	"github.com/NormaTech-AI/audity/services/framework-service/internal/db"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+)
+
+// Control mapping strengths. Only equivalent controls share answers across
+// audits; partial and related mappings are informational.
+const (
+	MappingStrengthEquivalent = "equivalent"
+	MappingStrengthPartial    = "partial"
+	MappingStrengthRelated    = "related"
+)
+
+// ControlMappingResponse is a mapping seen from one framework: ControlID
+// belongs to that framework and the mapped control to the other one
+type ControlMappingResponse struct {
+	ID                  string  `json:"id"`
+	ControlID           string  `json:"control_id"`
+	MappedFrameworkID   string  `json:"mapped_framework_id"`
+	MappedFrameworkName string  `json:"mapped_framework_name"`
+	MappedControlID     string  `json:"mapped_control_id"`
+	Strength            string  `json:"strength"`
+	Notes               *string `json:"notes"`
+	CreatedBy           *string `json:"created_by"`
+	CreatedAt           string  `json:"created_at"`
+	UpdatedAt           string  `json:"updated_at"`
+}
+
+// CreateControlMappingRequest maps a control of the framework to a control
+// of another framework
+type CreateControlMappingRequest struct {
+	ControlID         string  `json:"control_id" validate:"required"`
+	MappedFrameworkID string  `json:"mapped_framework_id" validate:"required,uuid"`
+	MappedControlID   string  `json:"mapped_control_id" validate:"required"`
+	Strength          string  `json:"strength" validate:"required,oneof=equivalent partial related"`
+	Notes             *string `json:"notes"`
+}
+
+// UpdateControlMappingRequest changes the strength and notes of a mapping
+type UpdateControlMappingRequest struct {
+	Strength string  `json:"strength" validate:"required,oneof=equivalent partial related"`
+	Notes    *string `json:"notes"`
+}
+
+// ListControlMappings returns the mappings of a framework's controls
+// @Summary List control mappings
+// @Description Get the mappings between controls of a framework and controls of other frameworks, optionally for one control or strength
+// @Tags frameworks
+// @Produce json
+// @Param id path string true "Framework ID"
+// @Param control_id query string false "Only mappings of this control"
+// @Param strength query string false "Only mappings of this strength (equivalent, partial, related)"
+// @Success 200 {array} ControlMappingResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/v1/frameworks/{id}/mappings [get]
+func (h *Handler) ListControlMappings(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	frameworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid framework ID",
+		})
+	}
+
+	strength := c.QueryParam("strength")
+	if strength != "" && !validMappingStrength(strength) {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "strength must be equivalent, partial or related",
+		})
+	}
+
+	if _, err := h.store.GetFramework(ctx, frameworkID); err != nil {
+		h.logger.Errorw("Failed to get framework", "error", err, "id", frameworkID)
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Framework not found",
+		})
+	}
+
+	var rows []db.ListFrameworkControlMappingsRow
+	if controlID := c.QueryParam("control_id"); controlID != "" {
+		controlRows, err := h.store.ListControlMappingsForControl(ctx, db.ListControlMappingsForControlParams{
+			FrameworkID: frameworkID,
+			ControlID:   controlID,
+		})
+		if err != nil {
+			h.logger.Errorw("Failed to list control mappings", "error", err, "framework_id", frameworkID, "control_id", controlID)
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Failed to retrieve control mappings",
+			})
+		}
+		for _, row := range controlRows {
+			rows = append(rows, db.ListFrameworkControlMappingsRow(row))
+		}
+	} else {
+		rows, err = h.store.ListFrameworkControlMappings(ctx, frameworkID)
+		if err != nil {
+			h.logger.Errorw("Failed to list control mappings", "error", err, "framework_id", frameworkID)
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Failed to retrieve control mappings",
+			})
+		}
+	}
+
+	responses := make([]ControlMappingResponse, 0, len(rows))
+	for _, row := range rows {
+		if strength != "" && row.Strength != strength {
+			continue
+		}
+		m := db.ControlMapping{
+			ID:                row.ID,
+			SourceFrameworkID: row.SourceFrameworkID,
+			SourceControlID:   row.SourceControlID,
+			TargetFrameworkID: row.TargetFrameworkID,
+			TargetControlID:   row.TargetControlID,
+			Strength:          row.Strength,
+			Notes:             row.Notes,
+			CreatedBy:         row.CreatedBy,
+			CreatedAt:         row.CreatedAt,
+			UpdatedAt:         row.UpdatedAt,
+		}
+		mappedName := row.TargetFrameworkName
+		if row.TargetFrameworkID == frameworkID {
+			mappedName = row.SourceFrameworkName
+		}
+		responses = append(responses, toControlMappingResponse(frameworkID, m, mappedName))
+	}
+
+	return c.JSON(http.StatusOK, responses)
+}
+
+// CreateControlMapping maps a control of the framework to a control of
+// another framework
+// @Summary Create control mapping
+// @Description Map a control to a control of another framework. Mappings work both ways and hold across framework versions.
+// @Tags frameworks
+// @Accept json
+// @Produce json
+// @Param id path string true "Framework ID"
+// @Param mapping body CreateControlMappingRequest true "Control mapping"
+// @Success 201 {object} ControlMappingResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/v1/frameworks/{id}/mappings [post]
+func (h *Handler) CreateControlMapping(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	frameworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid framework ID",
+		})
+	}
+
+	var req CreateControlMappingRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	mappedFrameworkID := uuid.MustParse(req.MappedFrameworkID)
+	if mappedFrameworkID == frameworkID {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Controls can only be mapped to controls of another framework",
+		})
+	}
+
+	claims, err := auth.GetUserFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User not authenticated",
+		})
+	}
+
+	if _, err := h.store.GetFramework(ctx, frameworkID); err != nil {
+		h.logger.Errorw("Failed to get framework", "error", err, "id", frameworkID)
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Framework not found",
+		})
+	}
+
+	mappedFramework, err := h.store.GetFramework(ctx, mappedFrameworkID)
+	if err != nil {
+		h.logger.Errorw("Failed to get mapped framework", "error", err, "id", mappedFrameworkID)
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Mapped framework not found",
+		})
+	}
+
+	for _, control := range []db.FrameworkControlExistsParams{
+		{FrameworkID: frameworkID, ControlID: req.ControlID},
+		{FrameworkID: mappedFrameworkID, ControlID: req.MappedControlID},
+	} {
+		exists, err := h.store.FrameworkControlExists(ctx, control)
+		if err != nil {
+			h.logger.Errorw("Failed to check control", "error", err, "framework_id", control.FrameworkID, "control_id", control.ControlID)
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Failed to create control mapping",
+			})
+		}
+		if !exists {
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "Control " + control.ControlID + " not found in framework " + control.FrameworkID.String(),
+			})
+		}
+	}
+
+	mapping, err := h.store.CreateControlMapping(ctx, db.CreateControlMappingParams{
+		SourceFrameworkID: frameworkID,
+		SourceControlID:   req.ControlID,
+		TargetFrameworkID: mappedFrameworkID,
+		TargetControlID:   req.MappedControlID,
+		Strength:          req.Strength,
+		Notes:             req.Notes,
+		CreatedBy:         pgtype.UUID{Bytes: claims.UserID, Valid: true},
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return c.JSON(http.StatusConflict, map[string]string{
+				"error": "These controls are already mapped",
+			})
+		}
+		h.logger.Errorw("Failed to create control mapping", "error", err, "framework_id", frameworkID, "control_id", req.ControlID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to create control mapping",
+		})
+	}
+
+	h.logger.Infow("Control mapping created", "id", mapping.ID, "framework_id", frameworkID, "control_id", req.ControlID,
+		"mapped_framework_id", mappedFrameworkID, "mapped_control_id", req.MappedControlID, "strength", req.Strength)
+
+	return c.JSON(http.StatusCreated, toControlMappingResponse(frameworkID, mapping, mappedFramework.Name))
+}
+
+// UpdateControlMapping changes the strength and notes of a control mapping
+// @Summary Update control mapping
+// @Description Change the strength and notes of a mapping of one of the framework's controls
+// @Tags frameworks
+// @Accept json
+// @Produce json
+// @Param id path string true "Framework ID"
+// @Param mappingId path string true "Mapping ID"
+// @Param mapping body UpdateControlMappingRequest true "Control mapping"
+// @Success 200 {object} ControlMappingResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/v1/frameworks/{id}/mappings/{mappingId} [put]
+func (h *Handler) UpdateControlMapping(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	frameworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid framework ID",
+		})
+	}
+
+	mappingID, err := uuid.Parse(c.Param("mappingId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid mapping ID",
+		})
+	}
+
+	var req UpdateControlMappingRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	mapping, err := h.store.GetControlMapping(ctx, mappingID)
+	if err != nil || !mapsFramework(mapping, frameworkID) {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Control mapping not found",
+		})
+	}
+
+	mappedFrameworkID := mapping.TargetFrameworkID
+	if mappedFrameworkID == frameworkID {
+		mappedFrameworkID = mapping.SourceFrameworkID
+	}
+	mappedFramework, err := h.store.GetFramework(ctx, mappedFrameworkID)
+	if err != nil {
+		h.logger.Errorw("Failed to get mapped framework", "error", err, "id", mappedFrameworkID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to update control mapping",
+		})
+	}
+
+	mapping, err = h.store.UpdateControlMapping(ctx, db.UpdateControlMappingParams{
+		ID:       mappingID,
+		Strength: req.Strength,
+		Notes:    req.Notes,
+	})
+	if err != nil {
+		h.logger.Errorw("Failed to update control mapping", "error", err, "id", mappingID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to update control mapping",
+		})
+	}
+
+	h.logger.Infow("Control mapping updated", "id", mappingID, "strength", req.Strength)
+
+	return c.JSON(http.StatusOK, toControlMappingResponse(frameworkID, mapping, mappedFramework.Name))
+}
+
+// DeleteControlMapping removes a control mapping
+// @Summary Delete control mapping
+// @Description Remove a mapping of one of the framework's controls. Answers already shared through it are kept.
+// @Tags frameworks
+// @Produce json
+// @Param id path string true "Framework ID"
+// @Param mappingId path string true "Mapping ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/v1/frameworks/{id}/mappings/{mappingId} [delete]
+func (h *Handler) DeleteControlMapping(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	frameworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid framework ID",
+		})
+	}
+
+	mappingID, err := uuid.Parse(c.Param("mappingId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid mapping ID",
+		})
+	}
+
+	mapping, err := h.store.GetControlMapping(ctx, mappingID)
+	if err != nil || !mapsFramework(mapping, frameworkID) {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Control mapping not found",
+		})
+	}
+
+	if _, err := h.store.DeleteControlMapping(ctx, mappingID); err != nil {
+		h.logger.Errorw("Failed to delete control mapping", "error", err, "id", mappingID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to delete control mapping",
+		})
+	}
+
+	h.logger.Infow("Control mapping deleted", "id", mappingID, "framework_id", frameworkID)
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func validMappingStrength(strength string) bool {
+	switch strength {
+	case MappingStrengthEquivalent, MappingStrengthPartial, MappingStrengthRelated:
+		return true
+	}
+	return false
+}
+
+// mapsFramework reports whether a control of the framework is on either side
+// of the mapping
+func mapsFramework(m db.ControlMapping, frameworkID uuid.UUID) bool {
+	return m.SourceFrameworkID == frameworkID || m.TargetFrameworkID == frameworkID
+}
+
+// toControlMappingResponse orients a mapping from the point of view of the
+// given framework
+func toControlMappingResponse(frameworkID uuid.UUID, m db.ControlMapping, mappedFrameworkName string) ControlMappingResponse {
+	response := ControlMappingResponse{
+		ID:                  m.ID.String(),
+		ControlID:           m.SourceControlID,
+		MappedFrameworkID:   m.TargetFrameworkID.String(),
+		MappedFrameworkName: mappedFrameworkName,
+		MappedControlID:     m.TargetControlID,
+		Strength:            m.Strength,
+		Notes:               m.Notes,
+		CreatedAt:           m.CreatedAt.Time.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:           m.UpdatedAt.Time.Format("2006-01-02T15:04:05Z"),
+	}
+	if m.TargetFrameworkID == frameworkID {
+		response.ControlID = m.TargetControlID
+		response.MappedFrameworkID = m.SourceFrameworkID.String()
+		response.MappedControlID = m.SourceControlID
+	}
+	if m.CreatedBy.Valid {
+		createdBy := uuid.UUID(m.CreatedBy.Bytes).String()
+		response.CreatedBy = &createdBy
+	}
+	return response
+}
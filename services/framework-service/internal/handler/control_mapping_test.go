@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/NormaTech-AI/audity/packages/go/auth"
+	"github.com/NormaTech-AI/audity/services/framework-service/internal/db"
+	"github.com/NormaTech-AI/audity/services/framework-service/internal/store"
+	"github.com/NormaTech-AI/audity/services/framework-service/internal/validator"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+func TestCreateControlMapping(t *testing.T) {
+	frameworkID, mappedFrameworkID := uuid.New(), uuid.New()
+	found := func(dest ...any) error { return nil }
+	controlExists := func(exists bool) rowFunc {
+		return func(dest ...any) error {
+			*dest[0].(*bool) = exists
+			return nil
+		}
+	}
+
+	tests := []struct {
+		name       string
+		mappedID   uuid.UUID
+		strength   string
+		rows       map[string]rowFunc
+		wantStatus int
+	}{
+		{
+			name:       "same framework",
+			mappedID:   frameworkID,
+			strength:   MappingStrengthEquivalent,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unknown strength",
+			mappedID:   mappedFrameworkID,
+			strength:   "identical",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unknown framework",
+			mappedID:   mappedFrameworkID,
+			strength:   MappingStrengthEquivalent,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:     "unknown control",
+			mappedID: mappedFrameworkID,
+			strength: MappingStrengthEquivalent,
+			rows: map[string]rowFunc{
+				db.GetFramework:           found,
+				db.FrameworkControlExists: controlExists(false),
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:     "already mapped",
+			mappedID: mappedFrameworkID,
+			strength: MappingStrengthEquivalent,
+			rows: map[string]rowFunc{
+				db.GetFramework:           found,
+				db.FrameworkControlExists: controlExists(true),
+				db.CreateControlMapping:   func(dest ...any) error { return &pgconn.PgError{Code: "23505"} },
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:     "created",
+			mappedID: mappedFrameworkID,
+			strength: MappingStrengthPartial,
+			rows: map[string]rowFunc{
+				db.GetFramework:           found,
+				db.FrameworkControlExists: controlExists(true),
+				db.CreateControlMapping:   found,
+			},
+			wantStatus: http.StatusCreated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{store: &store.Store{Queries: db.New(&fakeDB{rows: tt.rows})}, logger: zap.NewNop().Sugar()}
+
+			body := `{"control_id":"1.1","mapped_framework_id":"` + tt.mappedID.String() + `","mapped_control_id":"A.5","strength":"` + tt.strength + `"}`
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e := echo.New()
+			e.Validator = validator.NewValidator()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(frameworkID.String())
+			c.Set("user", &auth.JWTClaims{UserID: uuid.New()})
+
+			if err := h.CreateControlMapping(c); err != nil {
+				t.Fatalf("CreateControlMapping() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestToControlMappingResponse(t *testing.T) {
+	source, target := uuid.New(), uuid.New()
+	mapping := db.ControlMapping{
+		ID:                uuid.New(),
+		SourceFrameworkID: source,
+		SourceControlID:   "NSE-1",
+		TargetFrameworkID: target,
+		TargetControlID:   "BSE-7",
+		Strength:          MappingStrengthEquivalent,
+	}
+
+	tests := []struct {
+		name              string
+		frameworkID       uuid.UUID
+		wantControlID     string
+		wantMappedID      uuid.UUID
+		wantMappedControl string
+	}{
+		{name: "from the source framework", frameworkID: source, wantControlID: "NSE-1", wantMappedID: target, wantMappedControl: "BSE-7"},
+		{name: "from the target framework", frameworkID: target, wantControlID: "BSE-7", wantMappedID: source, wantMappedControl: "NSE-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !mapsFramework(mapping, tt.frameworkID) {
+				t.Fatal("mapsFramework() = false for a framework on one side of the mapping")
+			}
+
+			got := toControlMappingResponse(tt.frameworkID, mapping, "Other")
+			if got.ControlID != tt.wantControlID || got.MappedFrameworkID != tt.wantMappedID.String() || got.MappedControlID != tt.wantMappedControl {
+				t.Errorf("response = %s -> %s %s, want %s -> %s %s",
+					got.ControlID, got.MappedFrameworkID, got.MappedControlID,
+					tt.wantControlID, tt.wantMappedID, tt.wantMappedControl)
+			}
+		})
+	}
+
+	if mapsFramework(mapping, uuid.New()) {
+		t.Error("mapsFramework() = true for an unrelated framework")
+	}
+}
@@ -1,62 +1,195 @@
 package handler
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 
+	"github.com/NormaTech-AI/audity/packages/go/auth"
+	"github.com/NormaTech-AI/audity/packages/go/evidence"
 	"github.com/NormaTech-AI/audity/services/framework-service/internal/db"
+	"github.com/NormaTech-AI/audity/services/framework-service/internal/importer"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/labstack/echo/v4"
 )
 
 // FrameworkResponse represents a framework in API responses
 type FrameworkResponse struct {
-	ID            string `json:"id"`
-	Name          string `json:"name"`
-	Description   string `json:"description"`
-	Version       string `json:"version"`
-	QuestionCount int    `json:"question_count,omitempty"`
-	CreatedAt     string `json:"created_at"`
-	UpdatedAt     string `json:"updated_at"`
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Description    string `json:"description"`
+	Version        string `json:"version"`
+	Regulator      string `json:"regulator,omitempty"`
+	Language       string `json:"language"`
+	QuestionCount  int    `json:"question_count,omitempty"`
+	DraftVersionID string `json:"draft_version_id,omitempty"`
+	CreatedAt      string `json:"created_at"`
+	UpdatedAt      string `json:"updated_at"`
 }
 
-// FrameworkQuestionRequest represents a question in the request
+// FrameworkListResponse is a page of frameworks. Total counts all frameworks
+// matching the filters.
+type FrameworkListResponse struct {
+	Data   []FrameworkResponse `json:"data"`
+	Total  int64               `json:"total"`
+	Limit  int                 `json:"limit"`
+	Offset int                 `json:"offset"`
+}
+
+// QuestionResponse represents a framework question in API responses
+type QuestionResponse struct {
+	QuestionID          string          `json:"question_id"`
+	SectionTitle        *string         `json:"section_title"`
+	ControlID           string          `json:"control_id"`
+	QuestionText        string          `json:"question_text"`
+	HelpText            *string         `json:"help_text"`
+	AcceptableEvidence  []string        `json:"acceptable_evidence"`
+	VisibilityCondition json.RawMessage `json:"visibility_condition,omitempty"`
+	Weight              int32           `json:"weight"`
+	Severity            string          `json:"severity"`
+	QuestionType        string          `json:"question_type"`
+	Options             json.RawMessage `json:"options,omitempty"`
+	IsMandatory         bool            `json:"is_mandatory"`
+	DisplayOrder        int32           `json:"display_order"`
+	SubsectionTitle     *string         `json:"subsection_title"`
+	EvidenceRequired    bool            `json:"evidence_required"`
+	// EvidenceRequirements are checked when a client submits an answer
+	EvidenceRequirements json.RawMessage `json:"evidence_requirements,omitempty"`
+	// Translations of the question by locale
+	Translations map[string]LocalizedQuestion `json:"translations,omitempty"`
+}
+
+// Visibility condition operators
+const (
+	ConditionOperatorEquals = "equals"
+	ConditionOperatorAnyOf  = "any_of"
+)
+
+// Question severities, from least to most critical
+const (
+	SeverityLow      = "low"
+	SeverityMedium   = "medium"
+	SeverityHigh     = "high"
+	SeverityCritical = "critical"
+)
+
+// Scoring defaults applied when a question does not specify them
+const (
+	DefaultQuestionWeight   int32 = 1
+	DefaultQuestionSeverity       = SeverityMedium
+)
+
+// VisibilityCondition shows a question only when the answer to an earlier
+// question (referenced by control_id) matches one of the given values
+type VisibilityCondition struct {
+	DependsOn string   `json:"depends_on"`
+	Operator  string   `json:"operator"`
+	Values    []string `json:"values"`
+}
+
+// QuestionOption is a choice of a choice question, or a column of a table question
+type QuestionOption struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+}
+
+// EvidenceRequirements describe the evidence an answer must be backed by:
+// at least MinFiles files, only of the AllowedFileTypes extensions when any
+// are given, with at least one file of each of the RequiredCategories
+type EvidenceRequirements struct {
+	MinFiles           int      `json:"min_files,omitempty"`
+	AllowedFileTypes   []string `json:"allowed_file_types,omitempty"`
+	RequiredCategories []string `json:"required_categories,omitempty"`
+}
+
+// FrameworkQuestionRequest represents a question in the request. Questions
+// are listed in checklist order. The type defaults to yes_no and questions
+// are mandatory unless IsMandatory is false.
 type FrameworkQuestionRequest struct {
 	SectionTitle       *string  `json:"section_title"`
+	SubsectionTitle    *string  `json:"subsection_title"`
 	ControlID          string   `json:"control_id" validate:"required"`
 	QuestionText       string   `json:"question_text" validate:"required"`
 	HelpText           *string  `json:"help_text"`
 	AcceptableEvidence []string `json:"acceptable_evidence"`
+	EvidenceRequired   bool     `json:"evidence_required"`
+	// EvidenceRequirements imply EvidenceRequired
+	EvidenceRequirements *EvidenceRequirements `json:"evidence_requirements"`
+	VisibilityCondition  *VisibilityCondition  `json:"visibility_condition"`
+	Weight               *int32                `json:"weight"`
+	Severity             *string               `json:"severity"`
+	QuestionType         *string               `json:"question_type"`
+	Options              []QuestionOption      `json:"options"`
+	IsMandatory          *bool                 `json:"is_mandatory"`
 }
 
-// CreateFrameworkRequest represents the request to create a framework
+// CreateFrameworkRequest represents the request to create a framework. The
+// questions become the first version, which is published right away unless
+// Draft is set. Language is the canonical language of the questions and
+// defaults to English.
 type CreateFrameworkRequest struct {
 	Name        string                     `json:"name" validate:"required"`
 	Description string                     `json:"description" validate:"required"`
-	Version     string                     `json:"version" validate:"required"`
+	Version     string                     `json:"version" validate:"required,max=50"`
+	Regulator   string                     `json:"regulator"`
+	Language    string                     `json:"language"`
 	Questions   []FrameworkQuestionRequest `json:"questions" validate:"required,min=1"`
+	Draft       bool                       `json:"draft"`
 }
 
-// UpdateFrameworkRequest represents the request to update a framework
+// UpdateFrameworkRequest represents the request to update a framework. The
+// questions are saved to the framework's draft version, which is opened under
+// Version when there is none; published versions are never changed. The
+// canonical language is kept unless Language is given.
 type UpdateFrameworkRequest struct {
 	Name        string                     `json:"name" validate:"required"`
 	Description string                     `json:"description" validate:"required"`
-	Version     string                     `json:"version" validate:"required"`
+	Version     string                     `json:"version" validate:"required,max=50"`
+	Regulator   string                     `json:"regulator"`
+	Language    string                     `json:"language"`
 	Questions   []FrameworkQuestionRequest `json:"questions" validate:"required,min=1"`
 }
 
-// ListFrameworks returns all compliance frameworks
-// @Summary List all frameworks
-// @Description Get a list of all compliance frameworks
+// ListFrameworks returns a page of the compliance frameworks matching the
+// optional filters
+// @Summary List frameworks
+// @Description Get a page of compliance frameworks, filtered by name, regulator, version status and last update
 // @Tags frameworks
 // @Accept json
 // @Produce json
-// @Success 200 {array} FrameworkResponse
+// @Param name query string false "Part of the framework name"
+// @Param regulator query string false "Regulator or exchange, e.g. SEBI"
+// @Param status query string false "published or draft"
+// @Param updated_since query string false "RFC 3339 timestamp"
+// @Param limit query int false "Page size, at most 100" default(50)
+// @Param offset query int false "Page offset" default(0)
+// @Success 200 {object} FrameworkListResponse
+// @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /api/v1/frameworks [get]
 func (h *Handler) ListFrameworks(c echo.Context) error {
 	ctx := c.Request().Context()
 
-	frameworks, err := h.store.ListFrameworks(ctx)
+	filters, msg := parseFrameworkFilters(c)
+	if msg != "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": msg,
+		})
+	}
+	limit, offset := parsePagination(c)
+
+	frameworks, err := h.store.ListFrameworksFiltered(ctx, db.ListFrameworksFilteredParams{
+		Name:         filters.Name,
+		Regulator:    filters.Regulator,
+		Status:       filters.Status,
+		UpdatedSince: filters.UpdatedSince,
+		PageSize:     int32(limit),
+		PageOffset:   int32(offset),
+	})
 	if err != nil {
 		h.logger.Errorw("Failed to list frameworks", "error", err)
 		return c.JSON(http.StatusInternalServerError, map[string]string{
@@ -64,16 +197,17 @@ func (h *Handler) ListFrameworks(c echo.Context) error {
 		})
 	}
 
+	total, err := h.store.CountFrameworksFiltered(ctx, filters)
+	if err != nil {
+		h.logger.Errorw("Failed to count frameworks", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve frameworks",
+		})
+	}
+
 	// Convert to response format
 	responses := make([]FrameworkResponse, 0, len(frameworks))
 	for _, fw := range frameworks {
-		// Count questions from the new table
-		count, err := h.store.CountFrameworkQuestions(ctx, fw.ID)
-		if err != nil {
-			h.logger.Errorw("Failed to count questions", "error", err, "framework_id", fw.ID)
-			count = 0
-		}
-
 		desc := ""
 		if fw.Description != nil {
 			desc = *fw.Description
@@ -82,19 +216,31 @@ func (h *Handler) ListFrameworks(c echo.Context) error {
 		if fw.Version != nil {
 			ver = *fw.Version
 		}
+		draftVersionID := ""
+		if fw.DraftVersionID.Valid {
+			draftVersionID = uuid.UUID(fw.DraftVersionID.Bytes).String()
+		}
 
 		responses = append(responses, FrameworkResponse{
-			ID:            fw.ID.String(),
-			Name:          fw.Name,
-			Description:   desc,
-			Version:       ver,
-			QuestionCount: int(count),
-			CreatedAt:     fw.CreatedAt.Time.Format("2006-01-02T15:04:05Z"),
-			UpdatedAt:     fw.UpdatedAt.Time.Format("2006-01-02T15:04:05Z"),
+			ID:             fw.ID.String(),
+			Name:           fw.Name,
+			Description:    desc,
+			Version:        ver,
+			Regulator:      optionalText(fw.Regulator),
+			Language:       fw.Language,
+			QuestionCount:  int(fw.QuestionCount),
+			DraftVersionID: draftVersionID,
+			CreatedAt:      fw.CreatedAt.Time.Format("2006-01-02T15:04:05Z"),
+			UpdatedAt:      fw.UpdatedAt.Time.Format("2006-01-02T15:04:05Z"),
 		})
 	}
 
-	return c.JSON(http.StatusOK, responses)
+	return c.JSON(http.StatusOK, FrameworkListResponse{
+		Data:   responses,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	})
 }
 
 // GetFramework returns a specific framework by ID
@@ -147,6 +293,8 @@ func (h *Handler) GetFramework(c echo.Context) error {
 		Name:          framework.Name,
 		Description:   desc,
 		Version:       ver,
+		Regulator:     optionalText(framework.Regulator),
+		Language:      framework.Language,
 		QuestionCount: int(count),
 		CreatedAt:     framework.CreatedAt.Time.Format("2006-01-02T15:04:05Z"),
 		UpdatedAt:     framework.UpdatedAt.Time.Format("2006-01-02T15:04:05Z"),
@@ -182,40 +330,81 @@ func (h *Handler) CreateFramework(c echo.Context) error {
 		})
 	}
 
-	// Ensure checklist_json has a default empty object; future could build from questions
-	framework, err := h.store.CreateFramework(ctx, db.CreateFrameworkParams{
-		Name:        req.Name,
-		Description: &req.Description,
-		Version:     &req.Version,
-	})
-	if err != nil {
-		h.logger.Errorw("Failed to create framework", "error", err)
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to create framework",
+	if err := validateQuestions(req.Questions); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
 		})
 	}
 
-	// Create questions
-	for _, q := range req.Questions {
-		_, err := h.store.CreateFrameworkQuestion(ctx, db.CreateFrameworkQuestionParams{
-			FrameworkID:        framework.ID,
-			SectionTitle:       q.SectionTitle,
-			ControlID:          q.ControlID,
-			QuestionText:       q.QuestionText,
-			HelpText:           q.HelpText,
-			AcceptableEvidence: q.AcceptableEvidence,
+	claims, err := auth.GetUserFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User not authenticated",
 		})
+	}
+
+	language := DefaultLanguage
+	if req.Language != "" {
+		language, err = canonicalLocale(req.Language)
 		if err != nil {
-			h.logger.Errorw("Failed to create question", "error", err)
-			// Rollback: delete the framework
-			_ = h.store.DeleteFramework(ctx, framework.ID)
-			return c.JSON(http.StatusInternalServerError, map[string]string{
-				"error": "Failed to create framework questions",
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
 			})
 		}
 	}
 
-	h.logger.Infow("Framework created", "id", framework.ID, "name", framework.Name, "questions", len(req.Questions))
+	// The framework only carries a version label once a version is published
+	var currentVersion *string
+	if !req.Draft {
+		currentVersion = &req.Version
+	}
+
+	// Create the framework and its first version in one transaction
+	var framework db.CreateFrameworkRow
+	var version db.FrameworkVersion
+	err = h.store.ExecTx(ctx, func(q *db.Queries) error {
+		framework, err = q.CreateFramework(ctx, db.CreateFrameworkParams{
+			Name:        req.Name,
+			Description: &req.Description,
+			Version:     currentVersion,
+			Regulator:   optionalString(req.Regulator),
+			Language:    language,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create framework: %w", err)
+		}
+
+		version, err = q.CreateFrameworkVersion(ctx, db.CreateFrameworkVersionParams{
+			FrameworkID: framework.ID,
+			Version:     req.Version,
+			CreatedBy:   pgtype.UUID{Bytes: claims.UserID, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create framework version: %w", err)
+		}
+
+		if err := createVersionQuestions(ctx, q, framework.ID, version.ID, req.Questions); err != nil {
+			return err
+		}
+
+		if req.Draft {
+			return nil
+		}
+
+		_, err = q.PublishFrameworkVersion(ctx, db.PublishFrameworkVersionParams{
+			ID:          version.ID,
+			PublishedBy: pgtype.UUID{Bytes: claims.UserID, Valid: true},
+		})
+		return err
+	})
+	if err != nil {
+		h.logger.Errorw("Failed to create framework", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to create framework",
+		})
+	}
+
+	h.logger.Infow("Framework created", "id", framework.ID, "name", framework.Name, "questions", len(req.Questions), "draft", req.Draft)
 
 	desc := ""
 	if framework.Description != nil {
@@ -231,9 +420,14 @@ func (h *Handler) CreateFramework(c echo.Context) error {
 		Name:        framework.Name,
 		Description: desc,
 		Version:     ver,
+		Regulator:   optionalText(framework.Regulator),
+		Language:    framework.Language,
 		CreatedAt:   framework.CreatedAt.Time.Format("2006-01-02T15:04:05Z"),
 		UpdatedAt:   framework.UpdatedAt.Time.Format("2006-01-02T15:04:05Z"),
 	}
+	if req.Draft {
+		response.DraftVersionID = version.ID.String()
+	}
 
 	return c.JSON(http.StatusCreated, response)
 }
@@ -273,46 +467,95 @@ func (h *Handler) UpdateFramework(c echo.Context) error {
 		})
 	}
 
-	framework, err := h.store.UpdateFramework(ctx, db.UpdateFrameworkParams{
-		ID:          frameworkID,
-		Name:        req.Name,
-		Description: &req.Description,
-		Version:     &req.Version,
-	})
+	if err := validateQuestions(req.Questions); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	claims, err := auth.GetUserFromContext(c)
 	if err != nil {
-		h.logger.Errorw("Failed to update framework", "error", err, "id", frameworkID)
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to update framework",
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User not authenticated",
+		})
+	}
+
+	existing, err := h.store.GetFramework(ctx, frameworkID)
+	if err != nil {
+		h.logger.Errorw("Failed to get framework", "error", err, "id", frameworkID)
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Framework not found",
 		})
 	}
 
-	// Delete existing questions and create new ones
-	if err := h.store.DeleteFrameworkQuestionsByFrameworkId(ctx, frameworkID); err != nil {
-		h.logger.Errorw("Failed to delete existing questions", "error", err)
+	language := existing.Language
+	if req.Language != "" {
+		language, err = canonicalLocale(req.Language)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	// Check the version label before anything is written
+	draft, err := h.store.GetDraftFrameworkVersion(ctx, frameworkID)
+	hasDraft := err == nil
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		h.logger.Errorw("Failed to get draft version", "error", err, "framework_id", frameworkID)
 		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to update framework questions",
+			"error": "Failed to update framework",
 		})
 	}
+	if !hasDraft || req.Version != draft.Version {
+		if status, msg := h.checkVersionLabel(ctx, frameworkID, req.Version); msg != "" {
+			return c.JSON(status, map[string]string{
+				"error": msg,
+			})
+		}
+	}
 
-	// Create new questions
-	for _, q := range req.Questions {
-		_, err := h.store.CreateFrameworkQuestion(ctx, db.CreateFrameworkQuestionParams{
-			FrameworkID:        framework.ID,
-			SectionTitle:       q.SectionTitle,
-			ControlID:          q.ControlID,
-			QuestionText:       q.QuestionText,
-			HelpText:           q.HelpText,
-			AcceptableEvidence: q.AcceptableEvidence,
+	// Update the framework and save the questions to its draft, opening one
+	// when there is none, in one transaction
+	var framework db.ComplianceFramework
+	err = h.store.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+		// The version label only moves when a draft is published
+		framework, err = q.UpdateFramework(ctx, db.UpdateFrameworkParams{
+			ID:          frameworkID,
+			Name:        req.Name,
+			Description: &req.Description,
+			Version:     existing.Version,
+			Regulator:   optionalString(req.Regulator),
+			Language:    language,
 		})
 		if err != nil {
-			h.logger.Errorw("Failed to create question", "error", err)
-			return c.JSON(http.StatusInternalServerError, map[string]string{
-				"error": "Failed to update framework questions",
-			})
+			return fmt.Errorf("failed to update framework: %w", err)
+		}
+
+		if hasDraft {
+			draft, err = saveDraftQuestions(ctx, q, draft.ID, frameworkID, req.Version, draft.Notes, req.Questions)
+			return err
 		}
+
+		draft, err = q.CreateFrameworkVersion(ctx, db.CreateFrameworkVersionParams{
+			FrameworkID: frameworkID,
+			Version:     req.Version,
+			CreatedBy:   pgtype.UUID{Bytes: claims.UserID, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create draft version: %w", err)
+		}
+		return createVersionQuestions(ctx, q, frameworkID, draft.ID, req.Questions)
+	})
+	if err != nil {
+		h.logger.Errorw("Failed to update framework", "error", err, "id", frameworkID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to update framework",
+		})
 	}
 
-	h.logger.Infow("Framework updated", "id", framework.ID, "name", framework.Name, "questions", len(req.Questions))
+	h.logger.Infow("Framework updated", "id", framework.ID, "name", framework.Name, "draft_version", draft.Version, "questions", len(req.Questions))
 
 	desc := ""
 	if framework.Description != nil {
@@ -324,12 +567,15 @@ func (h *Handler) UpdateFramework(c echo.Context) error {
 	}
 
 	response := FrameworkResponse{
-		ID:          framework.ID.String(),
-		Name:        framework.Name,
-		Description: desc,
-		Version:     ver,
-		CreatedAt:   framework.CreatedAt.Time.Format("2006-01-02T15:04:05Z"),
-		UpdatedAt:   framework.UpdatedAt.Time.Format("2006-01-02T15:04:05Z"),
+		ID:             framework.ID.String(),
+		Name:           framework.Name,
+		Description:    desc,
+		Version:        ver,
+		Regulator:      optionalText(framework.Regulator),
+		Language:       framework.Language,
+		DraftVersionID: draft.ID.String(),
+		CreatedAt:      framework.CreatedAt.Time.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:      framework.UpdatedAt.Time.Format("2006-01-02T15:04:05Z"),
 	}
 
 	return c.JSON(http.StatusOK, response)
@@ -337,13 +583,15 @@ func (h *Handler) UpdateFramework(c echo.Context) error {
 
 // DeleteFramework deletes a framework
 // @Summary Delete a framework
-// @Description Delete a compliance framework
+// @Description Delete a compliance framework. Frameworks with a published version cannot be deleted.
 // @Tags frameworks
 // @Accept json
 // @Produce json
 // @Param id path string true "Framework ID"
 // @Success 204
 // @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /api/v1/frameworks/{id} [delete]
 func (h *Handler) DeleteFramework(c echo.Context) error {
@@ -356,26 +604,39 @@ func (h *Handler) DeleteFramework(c echo.Context) error {
 		})
 	}
 
-	// Delete framework
-	if err := h.store.DeleteFramework(ctx, frameworkID); err != nil {
+	// Frameworks with a published version are kept, audits are pinned to them
+	deleted, err := h.store.DeleteFramework(ctx, frameworkID)
+	if err != nil {
 		h.logger.Errorw("Failed to delete framework", "error", err, "id", frameworkID)
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to delete framework",
 		})
 	}
+	if deleted == 0 {
+		if _, err := h.store.GetFramework(ctx, frameworkID); errors.Is(err, pgx.ErrNoRows) {
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "Framework not found",
+			})
+		}
+		return c.JSON(http.StatusConflict, map[string]string{
+			"error": "Frameworks with a published version cannot be deleted",
+		})
+	}
 
 	h.logger.Infow("Framework deleted", "id", frameworkID)
 
 	return c.NoContent(http.StatusNoContent)
 }
 
-// GetFrameworkChecklist returns the full checklist JSON for a framework
+// GetFrameworkChecklist returns the questions of the latest published version
+// of a framework, or of the version given by version_id
 // @Summary Get framework checklist
 // @Description Get the full checklist JSON for a framework
 // @Tags frameworks
 // @Accept json
 // @Produce json
 // @Param id path string true "Framework ID"
+// @Param version_id query string false "Version ID, defaults to the latest published version"
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
@@ -400,8 +661,19 @@ func (h *Handler) GetFrameworkChecklist(c echo.Context) error {
 		})
 	}
 
-	// Get questions from the new table
-	questions, err := h.store.ListFrameworkQuestions(ctx, frameworkID)
+	versionParam := c.QueryParam("version_id")
+	if versionParam == "" {
+		versionParam = LatestVersion
+	}
+
+	version, status, msg := h.resolveFrameworkVersion(ctx, frameworkID, versionParam)
+	if msg != "" {
+		return c.JSON(status, map[string]string{
+			"error": msg,
+		})
+	}
+
+	questions, err := h.store.ListVersionQuestions(ctx, version.ID)
 	if err != nil {
 		h.logger.Errorw("Failed to get framework questions", "error", err, "id", frameworkID)
 		return c.JSON(http.StatusInternalServerError, map[string]string{
@@ -409,27 +681,237 @@ func (h *Handler) GetFrameworkChecklist(c echo.Context) error {
 		})
 	}
 
-	// Convert to response format
-	type QuestionResponse struct {
-		QuestionID         string   `json:"question_id"`
-		SectionTitle       *string  `json:"section_title"`
-		ControlID          string   `json:"control_id"`
-		QuestionText       string   `json:"question_text"`
-		HelpText           *string  `json:"help_text"`
-		AcceptableEvidence []string `json:"acceptable_evidence"`
+	responses, err := h.translatedQuestionResponses(ctx, version.ID, questions)
+	if err != nil {
+		h.logger.Errorw("Failed to get version translations", "error", err, "version_id", version.ID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve framework questions",
+		})
 	}
 
+	return c.JSON(http.StatusOK, responses)
+}
+
+// toQuestionResponses converts questions to their API representation
+func toQuestionResponses(questions []db.FrameworkQuestion) []QuestionResponse {
 	response := make([]QuestionResponse, 0, len(questions))
 	for _, q := range questions {
 		response = append(response, QuestionResponse{
-			QuestionID:         q.QuestionID.String(),
-			SectionTitle:       q.SectionTitle,
-			ControlID:          q.ControlID,
-			QuestionText:       q.QuestionText,
-			HelpText:           q.HelpText,
-			AcceptableEvidence: q.AcceptableEvidence,
+			QuestionID:           q.QuestionID.String(),
+			SectionTitle:         q.SectionTitle,
+			ControlID:            q.ControlID,
+			QuestionText:         q.QuestionText,
+			HelpText:             q.HelpText,
+			AcceptableEvidence:   q.AcceptableEvidence,
+			VisibilityCondition:  q.VisibilityCondition,
+			Weight:               q.Weight,
+			Severity:             q.Severity,
+			QuestionType:         q.QuestionType,
+			Options:              q.Options,
+			IsMandatory:          q.IsMandatory,
+			DisplayOrder:         q.DisplayOrder,
+			SubsectionTitle:      q.SubsectionTitle,
+			EvidenceRequired:     q.EvidenceRequired,
+			EvidenceRequirements: q.EvidenceRequirements,
 		})
 	}
+	return response
+}
 
-	return c.JSON(http.StatusOK, response)
+// validateQuestions checks the questions of a framework or version before
+// they are saved: visibility conditions, scoring, answer types and evidence
+// requirements
+func validateQuestions(questions []FrameworkQuestionRequest) error {
+	validators := []func([]FrameworkQuestionRequest) error{
+		validateQuestionConditions,
+		validateQuestionScoring,
+		validateQuestionStructure,
+		validateEvidenceRequirements,
+	}
+	for _, validate := range validators {
+		if err := validate(questions); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateQuestionConditions checks that every visibility condition refers to
+// a question that appears earlier in the list, so answers are always available
+// before the dependent question is evaluated
+func validateQuestionConditions(questions []FrameworkQuestionRequest) error {
+	seen := make(map[string]bool, len(questions))
+	for _, q := range questions {
+		if cond := q.VisibilityCondition; cond != nil {
+			if !seen[cond.DependsOn] {
+				return fmt.Errorf("question %s: visibility condition must depend on an earlier question, got %q", q.ControlID, cond.DependsOn)
+			}
+			switch cond.Operator {
+			case ConditionOperatorEquals:
+				if len(cond.Values) != 1 {
+					return fmt.Errorf("question %s: %q condition takes exactly one value", q.ControlID, cond.Operator)
+				}
+			case ConditionOperatorAnyOf:
+				if len(cond.Values) == 0 {
+					return fmt.Errorf("question %s: %q condition needs at least one value", q.ControlID, cond.Operator)
+				}
+			default:
+				return fmt.Errorf("question %s: unsupported visibility operator %q", q.ControlID, cond.Operator)
+			}
+		}
+		seen[q.ControlID] = true
+	}
+	return nil
+}
+
+// validateQuestionScoring checks the optional weight and severity of every question
+func validateQuestionScoring(questions []FrameworkQuestionRequest) error {
+	for _, q := range questions {
+		if q.Weight != nil && *q.Weight < 1 {
+			return fmt.Errorf("question %s: weight must be at least 1", q.ControlID)
+		}
+		if q.Severity != nil {
+			switch *q.Severity {
+			case SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical:
+			default:
+				return fmt.Errorf("question %s: unsupported severity %q", q.ControlID, *q.Severity)
+			}
+		}
+	}
+	return nil
+}
+
+// validateQuestionStructure checks the answer type and options of every
+// question: choice and table questions need options with distinct values,
+// other types take none
+func validateQuestionStructure(questions []FrameworkQuestionRequest) error {
+	for _, q := range questions {
+		answerType := questionType(q)
+		if !importer.ValidType(answerType) {
+			return fmt.Errorf("question %s: unsupported question type %q", q.ControlID, answerType)
+		}
+
+		if !importer.TakesOptions(answerType) {
+			if len(q.Options) > 0 {
+				return fmt.Errorf("question %s: %s questions do not take options", q.ControlID, answerType)
+			}
+			continue
+		}
+		if len(q.Options) == 0 {
+			return fmt.Errorf("question %s: %s questions need options", q.ControlID, answerType)
+		}
+
+		seen := make(map[string]bool, len(q.Options))
+		for _, option := range q.Options {
+			if option.Value == "" || option.Label == "" {
+				return fmt.Errorf("question %s: options need a value and a label", q.ControlID)
+			}
+			if seen[option.Value] {
+				return fmt.Errorf("question %s: option value %q is used more than once", q.ControlID, option.Value)
+			}
+			seen[option.Value] = true
+		}
+	}
+	return nil
+}
+
+// validateEvidenceRequirements checks the evidence requirements of every
+// question and normalizes file types to lower case extensions with a dot
+func validateEvidenceRequirements(questions []FrameworkQuestionRequest) error {
+	for _, q := range questions {
+		req := q.EvidenceRequirements
+		if req == nil {
+			continue
+		}
+		if req.MinFiles < 0 {
+			return fmt.Errorf("question %s: min_files cannot be negative", q.ControlID)
+		}
+		for i, fileType := range req.AllowedFileTypes {
+			fileType = strings.ToLower(strings.TrimSpace(fileType))
+			if fileType == "" || fileType == "." {
+				return fmt.Errorf("question %s: allowed file types cannot be empty", q.ControlID)
+			}
+			if !strings.HasPrefix(fileType, ".") {
+				fileType = "." + fileType
+			}
+			req.AllowedFileTypes[i] = fileType
+		}
+		for _, category := range req.RequiredCategories {
+			if !evidence.ValidCategory(category) {
+				return fmt.Errorf("question %s: unsupported evidence category %q", q.ControlID, category)
+			}
+		}
+		if req.MinFiles < len(req.RequiredCategories) {
+			req.MinFiles = len(req.RequiredCategories)
+		}
+	}
+	return nil
+}
+
+// questionEvidenceRequired reports whether the answer must be backed by
+// evidence; questions with evidence requirements always need evidence
+func questionEvidenceRequired(q FrameworkQuestionRequest) bool {
+	return q.EvidenceRequired || q.EvidenceRequirements != nil
+}
+
+// marshalEvidenceRequirements converts requirements to their JSONB
+// representation. Questions that only require evidence need at least one file.
+func marshalEvidenceRequirements(q FrameworkQuestionRequest) ([]byte, error) {
+	req := q.EvidenceRequirements
+	if req == nil {
+		if !q.EvidenceRequired {
+			return nil, nil
+		}
+		req = &EvidenceRequirements{}
+	}
+	if req.MinFiles < 1 {
+		req.MinFiles = 1
+	}
+	return json.Marshal(req)
+}
+
+// questionType returns the requested answer type or yes_no
+func questionType(q FrameworkQuestionRequest) string {
+	if q.QuestionType != nil {
+		return *q.QuestionType
+	}
+	return importer.TypeYesNo
+}
+
+// questionMandatory reports whether the question must be answered; questions
+// are mandatory unless marked otherwise
+func questionMandatory(q FrameworkQuestionRequest) bool {
+	return q.IsMandatory == nil || *q.IsMandatory
+}
+
+// marshalQuestionOptions converts options to their JSONB representation
+func marshalQuestionOptions(options []QuestionOption) ([]byte, error) {
+	if len(options) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(options)
+}
+
+// questionWeight returns the requested weight or the default
+func questionWeight(q FrameworkQuestionRequest) int32 {
+	if q.Weight != nil {
+		return *q.Weight
+	}
+	return DefaultQuestionWeight
+}
+
+// questionSeverity returns the requested severity or the default
+func questionSeverity(q FrameworkQuestionRequest) string {
+	if q.Severity != nil {
+		return *q.Severity
+	}
+	return DefaultQuestionSeverity
+}
+
+// marshalVisibilityCondition converts a condition to its JSONB representation
+func marshalVisibilityCondition(cond *VisibilityCondition) ([]byte, error) {
+	if cond == nil {
+		return nil, nil
+	}
+	return json.Marshal(cond)
 }
@@ -0,0 +1,102 @@
+package handler
+
+import "testing"
+
+func TestValidateQuestionConditions(t *testing.T) {
+	condition := func(dependsOn, operator string, values ...string) *VisibilityCondition {
+		return &VisibilityCondition{DependsOn: dependsOn, Operator: operator, Values: values}
+	}
+
+	tests := []struct {
+		name      string
+		questions []FrameworkQuestionRequest
+		wantErr   bool
+	}{
+		{
+			name: "no conditions",
+			questions: []FrameworkQuestionRequest{
+				{ControlID: "A.1"},
+				{ControlID: "A.2"},
+			},
+		},
+		{
+			name: "depends on an earlier question",
+			questions: []FrameworkQuestionRequest{
+				{ControlID: "A.1"},
+				{ControlID: "A.2", VisibilityCondition: condition("A.1", ConditionOperatorEquals, "yes")},
+				{ControlID: "A.3", VisibilityCondition: condition("A.1", ConditionOperatorAnyOf, "no", "na")},
+			},
+		},
+		{
+			name: "depends on a later question",
+			questions: []FrameworkQuestionRequest{
+				{ControlID: "A.1", VisibilityCondition: condition("A.2", ConditionOperatorEquals, "yes")},
+				{ControlID: "A.2"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "depends on itself",
+			questions: []FrameworkQuestionRequest{
+				{ControlID: "A.1", VisibilityCondition: condition("A.1", ConditionOperatorEquals, "yes")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "equals with two values",
+			questions: []FrameworkQuestionRequest{
+				{ControlID: "A.1"},
+				{ControlID: "A.2", VisibilityCondition: condition("A.1", ConditionOperatorEquals, "yes", "no")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "any of without values",
+			questions: []FrameworkQuestionRequest{
+				{ControlID: "A.1"},
+				{ControlID: "A.2", VisibilityCondition: condition("A.1", ConditionOperatorAnyOf)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported operator",
+			questions: []FrameworkQuestionRequest{
+				{ControlID: "A.1"},
+				{ControlID: "A.2", VisibilityCondition: condition("A.1", "contains", "yes")},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateQuestionConditions(tt.questions); (err != nil) != tt.wantErr {
+				t.Errorf("validateQuestionConditions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateQuestionScoring(t *testing.T) {
+	weight := func(w int32) *int32 { return &w }
+
+	tests := []struct {
+		name     string
+		question FrameworkQuestionRequest
+		wantErr  bool
+	}{
+		{name: "defaults", question: FrameworkQuestionRequest{ControlID: "A.1"}},
+		{name: "weight and severity", question: FrameworkQuestionRequest{ControlID: "A.1", Weight: weight(3), Severity: strPtr(SeverityCritical)}},
+		{name: "zero weight", question: FrameworkQuestionRequest{ControlID: "A.1", Weight: weight(0)}, wantErr: true},
+		{name: "negative weight", question: FrameworkQuestionRequest{ControlID: "A.1", Weight: weight(-1)}, wantErr: true},
+		{name: "unknown severity", question: FrameworkQuestionRequest{ControlID: "A.1", Severity: strPtr("severe")}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateQuestionScoring([]FrameworkQuestionRequest{tt.question}); (err != nil) != tt.wantErr {
+				t.Errorf("validateQuestionScoring() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
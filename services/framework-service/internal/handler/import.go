@@ -0,0 +1,487 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/NormaTech-AI/audity/packages/go/auth"
+	"github.com/NormaTech-AI/audity/services/framework-service/internal/db"
+	"github.com/NormaTech-AI/audity/services/framework-service/internal/importer"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+)
+
+// maxImportFileSize is the largest checklist spreadsheet accepted for import
+const maxImportFileSize = 10 * 1024 * 1024
+
+// ImportSectionSummary counts the imported questions of a section
+type ImportSectionSummary struct {
+	SectionTitle  string `json:"section_title"`
+	QuestionCount int    `json:"question_count"`
+}
+
+// ImportPreviewResponse shows what an import would create. It is returned for
+// dry runs and, with status 422, when rows fail validation.
+type ImportPreviewResponse struct {
+	DryRun        bool                   `json:"dry_run"`
+	Valid         bool                   `json:"valid"`
+	RowsRead      int                    `json:"rows_read"`
+	QuestionCount int                    `json:"question_count"`
+	Sections      []ImportSectionSummary `json:"sections"`
+	Questions     []importer.Question    `json:"questions"`
+	Errors        []importer.RowError    `json:"errors"`
+}
+
+// ImportFrameworkResponse reports the framework version written by an import
+type ImportFrameworkResponse struct {
+	Framework         FrameworkResponse        `json:"framework"`
+	Version           FrameworkVersionResponse `json:"version"`
+	QuestionsImported int64                    `json:"questions_imported"`
+}
+
+// importUpload is the parsed multipart form shared by both import endpoints
+type importUpload struct {
+	result  *importer.Result
+	version string
+	dryRun  bool
+}
+
+// ImportFramework creates a framework from a CSV or XLSX checklist
+// @Summary Import framework from spreadsheet
+// @Description Create a framework from a CSV or XLSX checklist. The mapping form field is a JSON column mapping. With dry_run the validated questions are previewed without saving.
+// @Tags frameworks
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV or XLSX checklist"
+// @Param mapping formData string false "Column mapping as JSON"
+// @Param name formData string true "Framework name"
+// @Param description formData string false "Framework description"
+// @Param version formData string true "Version label"
+// @Param draft formData bool false "Keep the first version as a draft"
+// @Param dry_run formData bool false "Validate and preview without saving"
+// @Success 201 {object} ImportFrameworkResponse
+// @Success 200 {object} ImportPreviewResponse
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} ImportPreviewResponse
+// @Router /api/v1/frameworks/import [post]
+func (h *Handler) ImportFramework(c echo.Context) error {
+	upload, ok := h.parseImportUpload(c)
+	if !ok {
+		return nil
+	}
+
+	name := c.FormValue("name")
+	if name == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "name is required",
+		})
+	}
+
+	if upload.dryRun || len(upload.result.Errors) > 0 {
+		return respondImportPreview(c, upload)
+	}
+
+	draft, _ := strconv.ParseBool(c.FormValue("draft"))
+
+	return h.createImportedFramework(c, name, c.FormValue("description"), upload.version, draft, upload.result.Questions)
+}
+
+// ImportFrameworkVersion replaces the questions of a framework's draft with
+// those of a CSV or XLSX checklist, opening a draft when there is none
+// @Summary Import framework questions from spreadsheet
+// @Description Import a CSV or XLSX checklist into the framework's draft version. Published versions are never changed.
+// @Tags frameworks
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Framework ID"
+// @Param file formData file true "CSV or XLSX checklist"
+// @Param mapping formData string false "Column mapping as JSON"
+// @Param version formData string true "Version label of the draft"
+// @Param dry_run formData bool false "Validate and preview without saving"
+// @Success 200 {object} ImportFrameworkResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} ImportPreviewResponse
+// @Router /api/v1/frameworks/{id}/import [post]
+func (h *Handler) ImportFrameworkVersion(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	frameworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid framework ID",
+		})
+	}
+
+	upload, ok := h.parseImportUpload(c)
+	if !ok {
+		return nil
+	}
+
+	framework, err := h.store.GetFramework(ctx, frameworkID)
+	if err != nil {
+		h.logger.Errorw("Failed to get framework", "error", err, "id", frameworkID)
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Framework not found",
+		})
+	}
+
+	if upload.dryRun || len(upload.result.Errors) > 0 {
+		return respondImportPreview(c, upload)
+	}
+
+	claims, err := auth.GetUserFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User not authenticated",
+		})
+	}
+
+	draft, err := h.store.GetDraftFrameworkVersion(ctx, frameworkID)
+	hasDraft := err == nil
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		h.logger.Errorw("Failed to get draft framework version", "error", err, "framework_id", frameworkID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve framework versions",
+		})
+	}
+
+	if !hasDraft || draft.Version != upload.version {
+		if status, msg := h.checkVersionLabel(ctx, frameworkID, upload.version); msg != "" {
+			return c.JSON(status, map[string]string{
+				"error": msg,
+			})
+		}
+	}
+
+	var imported int64
+	err = h.store.ExecTx(ctx, func(q *db.Queries) error {
+		if hasDraft {
+			draft, err = q.UpdateDraftFrameworkVersion(ctx, db.UpdateDraftFrameworkVersionParams{
+				ID:      draft.ID,
+				Version: upload.version,
+				Notes:   draft.Notes,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to update draft version: %w", err)
+			}
+			if err := q.DeleteFrameworkQuestionsByVersion(ctx, draft.ID); err != nil {
+				return fmt.Errorf("failed to delete draft questions: %w", err)
+			}
+		} else {
+			draft, err = q.CreateFrameworkVersion(ctx, db.CreateFrameworkVersionParams{
+				FrameworkID: frameworkID,
+				Version:     upload.version,
+				CreatedBy:   pgtype.UUID{Bytes: claims.UserID, Valid: true},
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create draft version: %w", err)
+			}
+		}
+
+		imported, err = bulkCreateImportedQuestions(ctx, q, frameworkID, draft.ID, upload.result.Questions)
+		return err
+	})
+	if err != nil {
+		h.logger.Errorw("Failed to import framework questions", "error", err, "framework_id", frameworkID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to import framework questions",
+		})
+	}
+
+	h.logger.Infow("Framework questions imported", "framework_id", frameworkID, "draft_version", draft.Version, "questions", imported)
+
+	desc := ""
+	if framework.Description != nil {
+		desc = *framework.Description
+	}
+	ver := ""
+	if framework.Version != nil {
+		ver = *framework.Version
+	}
+
+	response := ImportFrameworkResponse{
+		Framework: FrameworkResponse{
+			ID:             framework.ID.String(),
+			Name:           framework.Name,
+			Description:    desc,
+			Version:        ver,
+			Language:       framework.Language,
+			DraftVersionID: draft.ID.String(),
+			CreatedAt:      framework.CreatedAt.Time.Format("2006-01-02T15:04:05Z"),
+			UpdatedAt:      framework.UpdatedAt.Time.Format("2006-01-02T15:04:05Z"),
+		},
+		Version:           toFrameworkVersionResponse(draft),
+		QuestionsImported: imported,
+	}
+	response.Version.QuestionCount = int(imported)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// createImportedFramework creates a framework and its first version from
+// imported questions in one transaction. The version is published unless
+// draft is set.
+func (h *Handler) createImportedFramework(c echo.Context, name, description, label string, draft bool, questions []importer.Question) error {
+	ctx := c.Request().Context()
+
+	claims, err := auth.GetUserFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User not authenticated",
+		})
+	}
+
+	if _, err := h.store.GetFrameworkByName(ctx, name); err == nil {
+		return c.JSON(http.StatusConflict, map[string]string{
+			"error": fmt.Sprintf("Framework %q already exists; import into it to create a new version", name),
+		})
+	}
+
+	var currentVersion *string
+	if !draft {
+		currentVersion = &label
+	}
+
+	var framework db.CreateFrameworkRow
+	var version db.FrameworkVersion
+	var imported int64
+	err = h.store.ExecTx(ctx, func(q *db.Queries) error {
+		framework, err = q.CreateFramework(ctx, db.CreateFrameworkParams{
+			Name:        name,
+			Description: &description,
+			Version:     currentVersion,
+			Language:    DefaultLanguage,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create framework: %w", err)
+		}
+
+		version, err = q.CreateFrameworkVersion(ctx, db.CreateFrameworkVersionParams{
+			FrameworkID: framework.ID,
+			Version:     label,
+			CreatedBy:   pgtype.UUID{Bytes: claims.UserID, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create framework version: %w", err)
+		}
+
+		imported, err = bulkCreateImportedQuestions(ctx, q, framework.ID, version.ID, questions)
+		if err != nil || draft {
+			return err
+		}
+
+		version, err = q.PublishFrameworkVersion(ctx, db.PublishFrameworkVersionParams{
+			ID:          version.ID,
+			PublishedBy: pgtype.UUID{Bytes: claims.UserID, Valid: true},
+		})
+		return err
+	})
+	if err != nil {
+		h.logger.Errorw("Failed to import framework", "error", err, "name", name)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to import framework",
+		})
+	}
+
+	h.logger.Infow("Framework imported", "id", framework.ID, "name", framework.Name, "questions", imported, "draft", draft)
+
+	response := ImportFrameworkResponse{
+		Framework: FrameworkResponse{
+			ID:            framework.ID.String(),
+			Name:          framework.Name,
+			Description:   description,
+			Version:       label,
+			Language:      framework.Language,
+			QuestionCount: int(imported),
+			CreatedAt:     framework.CreatedAt.Time.Format("2006-01-02T15:04:05Z"),
+			UpdatedAt:     framework.UpdatedAt.Time.Format("2006-01-02T15:04:05Z"),
+		},
+		Version:           toFrameworkVersionResponse(version),
+		QuestionsImported: imported,
+	}
+	response.Version.QuestionCount = int(imported)
+	if draft {
+		response.Framework.Version = ""
+		response.Framework.DraftVersionID = version.ID.String()
+	}
+
+	return c.JSON(http.StatusCreated, response)
+}
+
+// parseImportUpload reads the uploaded checklist and validates its rows. When
+// the upload itself is unusable it writes the error response and returns
+// false.
+func (h *Handler) parseImportUpload(c echo.Context) (*importUpload, bool) {
+	upload := &importUpload{version: c.FormValue("version")}
+	upload.dryRun, _ = strconv.ParseBool(c.FormValue("dry_run"))
+
+	if upload.version == "" && !upload.dryRun {
+		_ = c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "version is required",
+		})
+		return nil, false
+	}
+	if len(upload.version) > 50 {
+		_ = c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "version must be at most 50 characters",
+		})
+		return nil, false
+	}
+
+	var mapping importer.Mapping
+	if raw := c.FormValue("mapping"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &mapping); err != nil {
+			_ = c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid column mapping: " + err.Error(),
+			})
+			return nil, false
+		}
+	}
+
+	data, fileName, ok := h.readImportFile(c, maxImportFileSize)
+	if !ok {
+		return nil, false
+	}
+
+	rows, err := importer.ReadRows(fileName, data, mapping.Sheet)
+	if err != nil {
+		_ = c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return nil, false
+	}
+
+	upload.result, err = importer.Parse(rows, mapping)
+	if err != nil {
+		_ = c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return nil, false
+	}
+
+	return upload, true
+}
+
+// readImportFile reads the uploaded file field. When the file is missing or
+// too large it writes the error response and returns false.
+func (h *Handler) readImportFile(c echo.Context, maxSize int64) ([]byte, string, bool) {
+	file, err := c.FormFile("file")
+	if err != nil {
+		_ = c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "File is required",
+		})
+		return nil, "", false
+	}
+
+	if file.Size > maxSize {
+		_ = c.JSON(http.StatusBadRequest, map[string]string{
+			"error": fmt.Sprintf("File size exceeds maximum allowed size of %dMB", maxSize/(1024*1024)),
+		})
+		return nil, "", false
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		h.logger.Errorw("Failed to open uploaded file", "error", err)
+		_ = c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to read uploaded file",
+		})
+		return nil, "", false
+	}
+	defer src.Close()
+
+	data, err := io.ReadAll(io.LimitReader(src, maxSize+1))
+	if err != nil {
+		h.logger.Errorw("Failed to read uploaded file", "error", err)
+		_ = c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to read uploaded file",
+		})
+		return nil, "", false
+	}
+
+	return data, file.Filename, true
+}
+
+// respondImportPreview returns the validated questions grouped by section.
+// Imports with row errors are rejected with 422 so nothing is half imported.
+func respondImportPreview(c echo.Context, upload *importUpload) error {
+	result := upload.result
+
+	var sections []ImportSectionSummary
+	index := make(map[string]int)
+	for _, q := range result.Questions {
+		title := sectionName(q.SectionTitle)
+		i, ok := index[title]
+		if !ok {
+			i = len(sections)
+			index[title] = i
+			sections = append(sections, ImportSectionSummary{SectionTitle: title})
+		}
+		sections[i].QuestionCount++
+	}
+	if sections == nil {
+		sections = []ImportSectionSummary{}
+	}
+
+	status := http.StatusOK
+	if len(result.Errors) > 0 {
+		status = http.StatusUnprocessableEntity
+	}
+
+	return c.JSON(status, ImportPreviewResponse{
+		DryRun:        upload.dryRun,
+		Valid:         len(result.Errors) == 0,
+		RowsRead:      result.RowsRead,
+		QuestionCount: len(result.Questions),
+		Sections:      sections,
+		Questions:     result.Questions,
+		Errors:        result.Errors,
+	})
+}
+
+// bulkCreateImportedQuestions copies imported questions into a version in
+// row order. Weight and severity take their column defaults.
+func bulkCreateImportedQuestions(ctx context.Context, q *db.Queries, frameworkID, versionID uuid.UUID, questions []importer.Question) (int64, error) {
+	params := make([]db.BulkCreateFrameworkQuestionsParams, 0, len(questions))
+	for i, question := range questions {
+		var options []byte
+		if len(question.Options) > 0 {
+			var err error
+			if options, err = json.Marshal(question.Options); err != nil {
+				return 0, fmt.Errorf("question %s: %w", question.ControlID, err)
+			}
+		}
+
+		params = append(params, db.BulkCreateFrameworkQuestionsParams{
+			FrameworkID:        frameworkID,
+			ControlID:          question.ControlID,
+			QuestionText:       question.QuestionText,
+			HelpText:           question.HelpText,
+			AcceptableEvidence: question.AcceptableEvidence,
+			VersionID:          versionID,
+			SectionTitle:       question.SectionTitle,
+			QuestionType:       question.Type,
+			Options:            options,
+			IsMandatory:        question.Mandatory,
+			DisplayOrder:       int32(i + 1),
+			SubsectionTitle:    question.SubsectionTitle,
+			EvidenceRequired:   question.EvidenceRequired,
+		})
+	}
+
+	imported, err := q.BulkCreateFrameworkQuestions(ctx, params)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create questions: %w", err)
+	}
+	return imported, nil
+}
@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NormaTech-AI/audity/services/framework-service/internal/importer"
+	"github.com/NormaTech-AI/audity/services/framework-service/internal/oscal"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// maxOSCALFileSize is the largest OSCAL catalog accepted for import; full
+// control catalogs such as NIST SP 800-53 are larger than checklists
+const maxOSCALFileSize = 25 * 1024 * 1024
+
+// ExportFrameworkCatalog exports a framework version as an OSCAL catalog
+// @Summary Export framework as OSCAL catalog
+// @Description Export a framework version as an OSCAL JSON catalog with one group per section and one control per question
+// @Tags frameworks
+// @Produce json
+// @Param id path string true "Framework ID"
+// @Param version_id query string false "Version ID, defaults to the latest published version"
+// @Success 200 {object} oscal.CatalogDocument
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/v1/frameworks/{id}/oscal/catalog [get]
+func (h *Handler) ExportFrameworkCatalog(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	frameworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid framework ID",
+		})
+	}
+
+	framework, err := h.store.GetFramework(ctx, frameworkID)
+	if err != nil {
+		h.logger.Errorw("Failed to get framework", "error", err, "id", frameworkID)
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Framework not found",
+		})
+	}
+
+	versionParam := c.QueryParam("version_id")
+	if versionParam == "" {
+		versionParam = LatestVersion
+	}
+	version, status, msg := h.resolveFrameworkVersion(ctx, frameworkID, versionParam)
+	if msg != "" {
+		return c.JSON(status, map[string]string{
+			"error": msg,
+		})
+	}
+
+	questions, err := h.store.ListVersionQuestions(ctx, version.ID)
+	if err != nil {
+		h.logger.Errorw("Failed to get version questions", "error", err, "version_id", version.ID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve framework questions",
+		})
+	}
+
+	fileName := fmt.Sprintf("%s-%s-catalog.json", fileNamePart(framework.Name), fileNamePart(version.Version))
+	return h.respondOSCAL(c, fileName, oscal.MediaTypeCatalog, oscal.NewCatalog(framework, version, questions))
+}
+
+// ExportFrameworkProfile exports a framework version as an OSCAL profile
+// @Summary Export framework as OSCAL profile
+// @Description Export a framework version as an OSCAL JSON profile selecting every control of the framework's catalog
+// @Tags frameworks
+// @Produce json
+// @Param id path string true "Framework ID"
+// @Param version_id query string false "Version ID, defaults to the latest published version"
+// @Success 200 {object} oscal.ProfileDocument
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/v1/frameworks/{id}/oscal/profile [get]
+func (h *Handler) ExportFrameworkProfile(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	frameworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid framework ID",
+		})
+	}
+
+	framework, err := h.store.GetFramework(ctx, frameworkID)
+	if err != nil {
+		h.logger.Errorw("Failed to get framework", "error", err, "id", frameworkID)
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Framework not found",
+		})
+	}
+
+	versionParam := c.QueryParam("version_id")
+	if versionParam == "" {
+		versionParam = LatestVersion
+	}
+	version, status, msg := h.resolveFrameworkVersion(ctx, frameworkID, versionParam)
+	if msg != "" {
+		return c.JSON(status, map[string]string{
+			"error": msg,
+		})
+	}
+
+	// Point at the catalog export of the same version
+	catalogHref := strings.TrimSuffix(c.Request().URL.Path, "/profile") + "/catalog?version_id=" + version.ID.String()
+
+	fileName := fmt.Sprintf("%s-%s-profile.json", fileNamePart(framework.Name), fileNamePart(version.Version))
+	return h.respondOSCAL(c, fileName, oscal.MediaTypeProfile, oscal.NewProfile(framework, version, catalogHref))
+}
+
+// ImportOSCALCatalog creates a framework from an OSCAL catalog
+// @Summary Import framework from OSCAL catalog
+// @Description Create a framework from an OSCAL JSON catalog. Groups become sections and controls, including enhancements, become questions. With dry_run the questions are previewed without saving.
+// @Tags frameworks
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "OSCAL catalog in JSON format"
+// @Param name formData string false "Framework name, defaults to the catalog title"
+// @Param description formData string false "Framework description, defaults to the catalog remarks"
+// @Param version formData string false "Version label, defaults to the catalog version"
+// @Param draft formData bool false "Keep the first version as a draft"
+// @Param dry_run formData bool false "Validate and preview without saving"
+// @Success 201 {object} ImportFrameworkResponse
+// @Success 200 {object} ImportPreviewResponse
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/v1/frameworks/import/oscal [post]
+func (h *Handler) ImportOSCALCatalog(c echo.Context) error {
+	data, _, ok := h.readImportFile(c, maxOSCALFileSize)
+	if !ok {
+		return nil
+	}
+
+	catalog, err := oscal.ParseCatalog(data)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	questions, err := catalog.Questions()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid catalog: " + err.Error(),
+		})
+	}
+
+	name := strings.TrimSpace(c.FormValue("name"))
+	if name == "" {
+		name = strings.TrimSpace(catalog.Metadata.Title)
+	}
+
+	description := c.FormValue("description")
+	if description == "" {
+		description = catalog.Metadata.Remarks
+	}
+
+	label := strings.TrimSpace(c.FormValue("version"))
+	if label == "" {
+		label = strings.TrimSpace(catalog.Metadata.Version)
+	}
+	if label == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Catalog has no version; provide one",
+		})
+	}
+	if len(label) > 50 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "version must be at most 50 characters",
+		})
+	}
+
+	if dryRun, _ := strconv.ParseBool(c.FormValue("dry_run")); dryRun {
+		return respondImportPreview(c, &importUpload{
+			result: &importer.Result{
+				RowsRead:  len(questions),
+				Questions: questions,
+				Errors:    []importer.RowError{},
+			},
+			version: label,
+			dryRun:  true,
+		})
+	}
+
+	draft, _ := strconv.ParseBool(c.FormValue("draft"))
+
+	return h.createImportedFramework(c, name, description, label, draft, questions)
+}
+
+// respondOSCAL sends an OSCAL document as a JSON download
+func (h *Handler) respondOSCAL(c echo.Context, fileName, mediaType string, doc any) error {
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		h.logger.Errorw("Failed to encode OSCAL document", "error", err, "file_name", fileName)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to export OSCAL document",
+		})
+	}
+
+	c.Response().Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", fileName))
+
+	return c.Blob(http.StatusOK, mediaType, data)
+}
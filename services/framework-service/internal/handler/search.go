@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NormaTech-AI/audity/services/framework-service/internal/db"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+)
+
+// Pagination defaults for list and search endpoints
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 100
+)
+
+// QuestionSearchResult is a question matching a search, with the framework
+// version it belongs to
+type QuestionSearchResult struct {
+	QuestionID       string  `json:"question_id"`
+	FrameworkID      string  `json:"framework_id"`
+	FrameworkName    string  `json:"framework_name"`
+	VersionID        string  `json:"version_id"`
+	FrameworkVersion string  `json:"framework_version"`
+	SectionTitle     *string `json:"section_title"`
+	SubsectionTitle  *string `json:"subsection_title"`
+	ControlID        string  `json:"control_id"`
+	QuestionText     string  `json:"question_text"`
+	HelpText         *string `json:"help_text"`
+	QuestionType     string  `json:"question_type"`
+	Rank             float32 `json:"rank"`
+}
+
+// QuestionSearchResponse is a page of search results. Total counts all
+// matching questions.
+type QuestionSearchResponse struct {
+	Data   []QuestionSearchResult `json:"data"`
+	Total  int64                  `json:"total"`
+	Limit  int                    `json:"limit"`
+	Offset int                    `json:"offset"`
+}
+
+// SearchQuestions searches the questions of the latest published version of
+// every framework
+// @Summary Search framework questions
+// @Description Full-text search over question text, control IDs, sections and help text across all frameworks
+// @Tags frameworks
+// @Accept json
+// @Produce json
+// @Param q query string true "Search terms; control IDs also match by prefix"
+// @Param framework_id query string false "Only search this framework"
+// @Param section query string false "Part of the section or sub-section title"
+// @Param limit query int false "Page size, at most 100" default(50)
+// @Param offset query int false "Page offset" default(0)
+// @Success 200 {object} QuestionSearchResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/frameworks/questions/search [get]
+func (h *Handler) SearchQuestions(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	query := strings.TrimSpace(c.QueryParam("q"))
+	if query == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "q is required",
+		})
+	}
+
+	var frameworkID pgtype.UUID
+	if param := c.QueryParam("framework_id"); param != "" {
+		id, err := uuid.Parse(param)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid framework ID",
+			})
+		}
+		frameworkID = pgtype.UUID{Bytes: id, Valid: true}
+	}
+	section := optionalString(escapeLike(strings.TrimSpace(c.QueryParam("section"))))
+	limit, offset := parsePagination(c)
+
+	results, err := h.store.SearchFrameworkQuestions(ctx, db.SearchFrameworkQuestionsParams{
+		Query:           query,
+		ControlIDPrefix: escapeLike(query),
+		FrameworkID:     frameworkID,
+		Section:         section,
+		PageSize:        int32(limit),
+		PageOffset:      int32(offset),
+	})
+	if err != nil {
+		h.logger.Errorw("Failed to search questions", "error", err, "query", query)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to search questions",
+		})
+	}
+
+	total, err := h.store.CountSearchFrameworkQuestions(ctx, db.CountSearchFrameworkQuestionsParams{
+		Query:           query,
+		ControlIDPrefix: escapeLike(query),
+		FrameworkID:     frameworkID,
+		Section:         section,
+	})
+	if err != nil {
+		h.logger.Errorw("Failed to count search results", "error", err, "query", query)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to search questions",
+		})
+	}
+
+	response := make([]QuestionSearchResult, 0, len(results))
+	for _, r := range results {
+		response = append(response, QuestionSearchResult{
+			QuestionID:       r.QuestionID.String(),
+			FrameworkID:      r.FrameworkID.String(),
+			FrameworkName:    r.FrameworkName,
+			VersionID:        r.VersionID.String(),
+			FrameworkVersion: r.FrameworkVersion,
+			SectionTitle:     r.SectionTitle,
+			SubsectionTitle:  r.SubsectionTitle,
+			ControlID:        r.ControlID,
+			QuestionText:     r.QuestionText,
+			HelpText:         r.HelpText,
+			QuestionType:     r.QuestionType,
+			Rank:             r.Rank,
+		})
+	}
+
+	return c.JSON(http.StatusOK, QuestionSearchResponse{
+		Data:   response,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	})
+}
+
+// parseFrameworkFilters reads the optional framework listing filters. It
+// returns an error message for filters that cannot be parsed.
+func parseFrameworkFilters(c echo.Context) (db.CountFrameworksFilteredParams, string) {
+	filters := db.CountFrameworksFilteredParams{
+		Name:      optionalString(escapeLike(strings.TrimSpace(c.QueryParam("name")))),
+		Regulator: optionalString(strings.TrimSpace(c.QueryParam("regulator"))),
+	}
+
+	if status := c.QueryParam("status"); status != "" {
+		if status != VersionStatusPublished && status != VersionStatusDraft {
+			return filters, "status must be published or draft"
+		}
+		filters.Status = &status
+	}
+
+	if since := c.QueryParam("updated_since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return filters, "updated_since must be an RFC 3339 timestamp"
+		}
+		filters.UpdatedSince = pgtype.Timestamptz{Time: t, Valid: true}
+	}
+
+	return filters, ""
+}
+
+// likeEscaper escapes the wildcards of LIKE patterns, using the default
+// escape character of PostgreSQL
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike makes user input match literally inside an ILIKE pattern, so a
+// name filter of 100% does not match every name starting with 100
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+// parsePagination reads the limit and offset query parameters, falling back
+// to the defaults for missing or out of range values
+func parsePagination(c echo.Context) (int, int) {
+	limit := DefaultPageSize
+	offset := 0
+
+	if l := c.QueryParam("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= MaxPageSize {
+			limit = parsed
+		}
+	}
+
+	if o := c.QueryParam("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		}
+	}
+
+	return limit, offset
+}
+
+// optionalString stores empty strings as NULL
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,458 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/NormaTech-AI/audity/services/framework-service/internal/db"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/text/language"
+)
+
+// DefaultLanguage is the canonical language of frameworks that do not name one
+const DefaultLanguage = "en"
+
+// QuestionTranslation is the translated text of a question
+type QuestionTranslation struct {
+	QuestionText string  `json:"question_text"`
+	HelpText     *string `json:"help_text"`
+}
+
+// VersionTranslation is the translation of a framework version into one
+// locale. Questions are keyed by control ID and sections by the title they
+// translate; sub-section titles are translated as sections too.
+type VersionTranslation struct {
+	Questions map[string]QuestionTranslation `json:"questions"`
+	Sections  map[string]string              `json:"sections"`
+}
+
+// VersionTranslationsResponse is every translation of a framework version.
+// Language is the canonical language the translations are made from.
+type VersionTranslationsResponse struct {
+	VersionID string                        `json:"version_id"`
+	Language  string                        `json:"language"`
+	Locales   map[string]VersionTranslation `json:"locales"`
+}
+
+// LocalizedQuestion is a question in one locale. Fields are omitted when
+// they are not translated and the canonical text applies.
+type LocalizedQuestion struct {
+	QuestionText    string  `json:"question_text,omitempty"`
+	HelpText        *string `json:"help_text,omitempty"`
+	SectionTitle    *string `json:"section_title,omitempty"`
+	SubsectionTitle *string `json:"subsection_title,omitempty"`
+}
+
+// ListVersionTranslations returns the translations of a framework version
+// @Summary List framework version translations
+// @Description Get the translated questions and section titles of a framework version in every locale
+// @Tags frameworks
+// @Produce json
+// @Param id path string true "Framework ID"
+// @Param versionId path string true "Version ID or latest"
+// @Success 200 {object} VersionTranslationsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/v1/frameworks/{id}/versions/{versionId}/translations [get]
+func (h *Handler) ListVersionTranslations(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	frameworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid framework ID",
+		})
+	}
+
+	framework, err := h.store.GetFramework(ctx, frameworkID)
+	if err != nil {
+		h.logger.Errorw("Failed to get framework", "error", err, "id", frameworkID)
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Framework not found",
+		})
+	}
+
+	version, status, msg := h.resolveFrameworkVersion(ctx, frameworkID, c.Param("versionId"))
+	if msg != "" {
+		return c.JSON(status, map[string]string{
+			"error": msg,
+		})
+	}
+
+	questions, sections, err := h.listVersionTranslations(ctx, version.ID)
+	if err != nil {
+		h.logger.Errorw("Failed to get version translations", "error", err, "version_id", version.ID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve translations",
+		})
+	}
+
+	locales := make(map[string]VersionTranslation)
+	translation := func(locale string) VersionTranslation {
+		t, ok := locales[locale]
+		if !ok {
+			t = VersionTranslation{
+				Questions: make(map[string]QuestionTranslation),
+				Sections:  make(map[string]string),
+			}
+			locales[locale] = t
+		}
+		return t
+	}
+	for _, qt := range questions {
+		translation(qt.Locale).Questions[qt.ControlID] = QuestionTranslation{
+			QuestionText: qt.QuestionText,
+			HelpText:     qt.HelpText,
+		}
+	}
+	for _, st := range sections {
+		translation(st.Locale).Sections[st.SourceTitle] = st.Title
+	}
+
+	return c.JSON(http.StatusOK, VersionTranslationsResponse{
+		VersionID: version.ID.String(),
+		Language:  framework.Language,
+		Locales:   locales,
+	})
+}
+
+// SaveVersionTranslation replaces the translation of a framework version
+// into one locale. Translations are not part of the checklist, so those of
+// published versions can be corrected too.
+// @Summary Save framework version translation
+// @Description Replace the translated questions and section titles of a framework version in one locale
+// @Tags frameworks
+// @Accept json
+// @Produce json
+// @Param id path string true "Framework ID"
+// @Param versionId path string true "Version ID or latest"
+// @Param locale path string true "BCP 47 language tag, e.g. hi or mr"
+// @Param translation body VersionTranslation true "Translation"
+// @Success 200 {object} VersionTranslation
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/v1/frameworks/{id}/versions/{versionId}/translations/{locale} [put]
+func (h *Handler) SaveVersionTranslation(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	frameworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid framework ID",
+		})
+	}
+
+	locale, err := canonicalLocale(c.Param("locale"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	var req VersionTranslation
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	framework, err := h.store.GetFramework(ctx, frameworkID)
+	if err != nil {
+		h.logger.Errorw("Failed to get framework", "error", err, "id", frameworkID)
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Framework not found",
+		})
+	}
+
+	if locale == framework.Language {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": fmt.Sprintf("%s is the canonical language of the framework", locale),
+		})
+	}
+
+	version, status, msg := h.resolveFrameworkVersion(ctx, frameworkID, c.Param("versionId"))
+	if msg != "" {
+		return c.JSON(status, map[string]string{
+			"error": msg,
+		})
+	}
+
+	questions, err := h.store.ListVersionQuestions(ctx, version.ID)
+	if err != nil {
+		h.logger.Errorw("Failed to get version questions", "error", err, "version_id", version.ID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve framework questions",
+		})
+	}
+
+	if err := validateVersionTranslation(req, questions); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	err = h.store.ExecTx(ctx, func(q *db.Queries) error {
+		if _, err := q.DeleteQuestionTranslations(ctx, db.DeleteQuestionTranslationsParams{
+			VersionID: version.ID,
+			Locale:    locale,
+		}); err != nil {
+			return fmt.Errorf("failed to delete question translations: %w", err)
+		}
+		if _, err := q.DeleteSectionTranslations(ctx, db.DeleteSectionTranslationsParams{
+			VersionID: version.ID,
+			Locale:    locale,
+		}); err != nil {
+			return fmt.Errorf("failed to delete section translations: %w", err)
+		}
+
+		for controlID, t := range req.Questions {
+			if err := q.UpsertQuestionTranslation(ctx, db.UpsertQuestionTranslationParams{
+				VersionID:    version.ID,
+				ControlID:    controlID,
+				Locale:       locale,
+				QuestionText: strings.TrimSpace(t.QuestionText),
+				HelpText:     t.HelpText,
+			}); err != nil {
+				return fmt.Errorf("failed to save translation of question %s: %w", controlID, err)
+			}
+		}
+
+		for source, title := range req.Sections {
+			if err := q.UpsertSectionTranslation(ctx, db.UpsertSectionTranslationParams{
+				VersionID:   version.ID,
+				Locale:      locale,
+				SourceTitle: source,
+				Title:       strings.TrimSpace(title),
+			}); err != nil {
+				return fmt.Errorf("failed to save translation of section %q: %w", source, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		h.logger.Errorw("Failed to save version translation", "error", err, "version_id", version.ID, "locale", locale)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to save translation",
+		})
+	}
+
+	h.logger.Infow("Framework version translation saved",
+		"framework_id", frameworkID,
+		"version_id", version.ID,
+		"locale", locale,
+		"questions", len(req.Questions),
+		"sections", len(req.Sections))
+
+	return c.JSON(http.StatusOK, req)
+}
+
+// DeleteVersionTranslation removes the translation of a framework version
+// into one locale
+// @Summary Delete framework version translation
+// @Description Remove the translated questions and section titles of a framework version in one locale
+// @Tags frameworks
+// @Produce json
+// @Param id path string true "Framework ID"
+// @Param versionId path string true "Version ID or latest"
+// @Param locale path string true "BCP 47 language tag"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/v1/frameworks/{id}/versions/{versionId}/translations/{locale} [delete]
+func (h *Handler) DeleteVersionTranslation(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	frameworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid framework ID",
+		})
+	}
+
+	locale, err := canonicalLocale(c.Param("locale"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	version, status, msg := h.resolveFrameworkVersion(ctx, frameworkID, c.Param("versionId"))
+	if msg != "" {
+		return c.JSON(status, map[string]string{
+			"error": msg,
+		})
+	}
+
+	var deleted int64
+	err = h.store.ExecTx(ctx, func(q *db.Queries) error {
+		questions, err := q.DeleteQuestionTranslations(ctx, db.DeleteQuestionTranslationsParams{
+			VersionID: version.ID,
+			Locale:    locale,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete question translations: %w", err)
+		}
+		sections, err := q.DeleteSectionTranslations(ctx, db.DeleteSectionTranslationsParams{
+			VersionID: version.ID,
+			Locale:    locale,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete section translations: %w", err)
+		}
+		deleted = questions + sections
+		return nil
+	})
+	if err != nil {
+		h.logger.Errorw("Failed to delete version translation", "error", err, "version_id", version.ID, "locale", locale)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to delete translation",
+		})
+	}
+
+	if deleted == 0 {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Translation not found",
+		})
+	}
+
+	h.logger.Infow("Framework version translation deleted", "framework_id", frameworkID, "version_id", version.ID, "locale", locale)
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// translatedQuestionResponses converts the questions of a version to their
+// API representation with their translations
+func (h *Handler) translatedQuestionResponses(ctx context.Context, versionID uuid.UUID, questions []db.FrameworkQuestion) ([]QuestionResponse, error) {
+	questionTranslations, sectionTranslations, err := h.listVersionTranslations(ctx, versionID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := toQuestionResponses(questions)
+	if len(questionTranslations) == 0 && len(sectionTranslations) == 0 {
+		return responses, nil
+	}
+
+	texts := make(map[string]map[string]db.FrameworkQuestionTranslation)
+	titles := make(map[string]map[string]string)
+	var locales []string
+	for _, qt := range questionTranslations {
+		if texts[qt.Locale] == nil {
+			texts[qt.Locale] = make(map[string]db.FrameworkQuestionTranslation)
+			locales = append(locales, qt.Locale)
+		}
+		texts[qt.Locale][qt.ControlID] = qt
+	}
+	for _, st := range sectionTranslations {
+		if titles[st.Locale] == nil {
+			titles[st.Locale] = make(map[string]string)
+			if texts[st.Locale] == nil {
+				locales = append(locales, st.Locale)
+			}
+		}
+		titles[st.Locale][st.SourceTitle] = st.Title
+	}
+
+	for i := range responses {
+		r := &responses[i]
+		for _, locale := range locales {
+			var localized LocalizedQuestion
+			if qt, ok := texts[locale][r.ControlID]; ok {
+				localized.QuestionText = qt.QuestionText
+				localized.HelpText = qt.HelpText
+			}
+			localized.SectionTitle = translatedTitle(titles[locale], r.SectionTitle)
+			localized.SubsectionTitle = translatedTitle(titles[locale], r.SubsectionTitle)
+
+			if localized == (LocalizedQuestion{}) {
+				continue
+			}
+			if r.Translations == nil {
+				r.Translations = make(map[string]LocalizedQuestion)
+			}
+			r.Translations[locale] = localized
+		}
+	}
+
+	return responses, nil
+}
+
+// listVersionTranslations returns the question and section translations of a version
+func (h *Handler) listVersionTranslations(ctx context.Context, versionID uuid.UUID) ([]db.FrameworkQuestionTranslation, []db.FrameworkSectionTranslation, error) {
+	questions, err := h.store.ListVersionQuestionTranslations(ctx, versionID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list question translations: %w", err)
+	}
+
+	sections, err := h.store.ListVersionSectionTranslations(ctx, versionID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list section translations: %w", err)
+	}
+
+	return questions, sections, nil
+}
+
+// validateVersionTranslation checks that a translation only refers to
+// questions and sections of the version and translates them to some text
+func validateVersionTranslation(t VersionTranslation, questions []db.FrameworkQuestion) error {
+	if len(t.Questions) == 0 && len(t.Sections) == 0 {
+		return fmt.Errorf("translation has no questions or sections")
+	}
+
+	controls := make(map[string]bool, len(questions))
+	sections := make(map[string]bool)
+	for _, q := range questions {
+		controls[q.ControlID] = true
+		if q.SectionTitle != nil {
+			sections[*q.SectionTitle] = true
+		}
+		if q.SubsectionTitle != nil {
+			sections[*q.SubsectionTitle] = true
+		}
+	}
+
+	for _, controlID := range slices.Sorted(maps.Keys(t.Questions)) {
+		if !controls[controlID] {
+			return fmt.Errorf("version has no question with control ID %s", controlID)
+		}
+		if strings.TrimSpace(t.Questions[controlID].QuestionText) == "" {
+			return fmt.Errorf("question %s: question_text is required", controlID)
+		}
+	}
+
+	for _, source := range slices.Sorted(maps.Keys(t.Sections)) {
+		if !sections[source] {
+			return fmt.Errorf("version has no section titled %q", source)
+		}
+		if strings.TrimSpace(t.Sections[source]) == "" {
+			return fmt.Errorf("section %q: title is required", source)
+		}
+	}
+
+	return nil
+}
+
+// canonicalLocale normalizes a BCP 47 language tag, e.g. hi_in to hi-IN
+func canonicalLocale(locale string) (string, error) {
+	tag, err := language.Parse(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+	if err != nil || tag == language.Und {
+		return "", fmt.Errorf("locale %q is not a BCP 47 language tag such as hi or mr-IN", locale)
+	}
+	return tag.String(), nil
+}
+
+// translatedTitle returns the translation of a section title, if there is one
+func translatedTitle(titles map[string]string, source *string) *string {
+	if source == nil {
+		return nil
+	}
+	if title, ok := titles[*source]; ok {
+		return &title
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/NormaTech-AI/audity/services/framework-service/internal/db"
+)
+
+func TestValidateVersionTranslation(t *testing.T) {
+	questions := []db.FrameworkQuestion{
+		{ControlID: "1.1", SectionTitle: strPtr("Governance"), SubsectionTitle: strPtr("Policies")},
+		{ControlID: "1.2", SectionTitle: strPtr("Governance")},
+	}
+
+	tests := []struct {
+		name        string
+		translation VersionTranslation
+		wantErr     bool
+	}{
+		{
+			name: "questions and sections",
+			translation: VersionTranslation{
+				Questions: map[string]QuestionTranslation{"1.1": {QuestionText: "क्या नीति स्वीकृत है?"}},
+				Sections:  map[string]string{"Governance": "शासन", "Policies": "नीतियाँ"},
+			},
+		},
+		{
+			name:        "only sections",
+			translation: VersionTranslation{Sections: map[string]string{"Governance": "शासन"}},
+		},
+		{
+			name:    "empty",
+			wantErr: true,
+		},
+		{
+			name:        "unknown control",
+			translation: VersionTranslation{Questions: map[string]QuestionTranslation{"9.9": {QuestionText: "?"}}},
+			wantErr:     true,
+		},
+		{
+			name:        "blank question text",
+			translation: VersionTranslation{Questions: map[string]QuestionTranslation{"1.2": {QuestionText: " "}}},
+			wantErr:     true,
+		},
+		{
+			name:        "unknown section",
+			translation: VersionTranslation{Sections: map[string]string{"Operations": "संचालन"}},
+			wantErr:     true,
+		},
+		{
+			name:        "blank section title",
+			translation: VersionTranslation{Sections: map[string]string{"Governance": ""}},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateVersionTranslation(tt.translation, questions); (err != nil) != tt.wantErr {
+				t.Errorf("validateVersionTranslation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCanonicalLocale(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "hi", want: "hi"},
+		{in: "mr_in", want: "mr-IN"},
+		{in: "", wantErr: true},
+		{in: "hindi language", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := canonicalLocale(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("canonicalLocale(%q) = %q, %v, want %q and error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestTranslatedTitle(t *testing.T) {
+	titles := map[string]string{"Governance": "शासन"}
+
+	if got := translatedTitle(titles, strPtr("Governance")); got == nil || *got != "शासन" {
+		t.Errorf("translatedTitle(Governance) = %v, want शासन", got)
+	}
+	if got := translatedTitle(titles, strPtr("Operations")); got != nil {
+		t.Errorf("translatedTitle(Operations) = %q, want nil", *got)
+	}
+	if got := translatedTitle(titles, nil); got != nil {
+		t.Errorf("translatedTitle(nil) = %q, want nil", *got)
+	}
+}
@@ -0,0 +1,701 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/NormaTech-AI/audity/packages/go/auth"
+	"github.com/NormaTech-AI/audity/services/framework-service/internal/db"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+)
+
+// Framework version statuses
+const (
+	VersionStatusDraft     = "draft"
+	VersionStatusPublished = "published"
+)
+
+// LatestVersion can be used in place of a version ID to refer to the latest
+// published version of a framework
+const LatestVersion = "latest"
+
+// FrameworkVersionResponse represents a framework version in API responses
+type FrameworkVersionResponse struct {
+	ID            string  `json:"id"`
+	FrameworkID   string  `json:"framework_id"`
+	Version       string  `json:"version"`
+	Status        string  `json:"status"`
+	Notes         *string `json:"notes"`
+	QuestionCount int     `json:"question_count"`
+	CreatedBy     *string `json:"created_by"`
+	PublishedBy   *string `json:"published_by"`
+	PublishedAt   *string `json:"published_at"`
+	CreatedAt     string  `json:"created_at"`
+	UpdatedAt     string  `json:"updated_at"`
+}
+
+// FrameworkVersionDetailResponse is a framework version with its questions.
+// Language is the canonical language of the questions.
+type FrameworkVersionDetailResponse struct {
+	FrameworkVersionResponse
+	Language  string             `json:"language"`
+	Questions []QuestionResponse `json:"questions"`
+}
+
+// CreateFrameworkVersionRequest opens a new draft. Without questions the
+// draft starts as a copy of the latest published version.
+type CreateFrameworkVersionRequest struct {
+	Version   string                     `json:"version" validate:"required,max=50"`
+	Notes     *string                    `json:"notes"`
+	Questions []FrameworkQuestionRequest `json:"questions"`
+}
+
+// UpdateFrameworkVersionRequest replaces the label, notes and questions of a draft
+type UpdateFrameworkVersionRequest struct {
+	Version   string                     `json:"version" validate:"required,max=50"`
+	Notes     *string                    `json:"notes"`
+	Questions []FrameworkQuestionRequest `json:"questions" validate:"required,min=1"`
+}
+
+// ListFrameworkVersions returns every version of a framework, newest first
+// @Summary List framework versions
+// @Description Get the draft and published versions of a framework with who published them and when
+// @Tags frameworks
+// @Produce json
+// @Param id path string true "Framework ID"
+// @Success 200 {array} FrameworkVersionResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/v1/frameworks/{id}/versions [get]
+func (h *Handler) ListFrameworkVersions(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	frameworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid framework ID",
+		})
+	}
+
+	if _, err := h.store.GetFramework(ctx, frameworkID); err != nil {
+		h.logger.Errorw("Failed to get framework", "error", err, "id", frameworkID)
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Framework not found",
+		})
+	}
+
+	versions, err := h.store.ListFrameworkVersions(ctx, frameworkID)
+	if err != nil {
+		h.logger.Errorw("Failed to list framework versions", "error", err, "framework_id", frameworkID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve framework versions",
+		})
+	}
+
+	responses := make([]FrameworkVersionResponse, 0, len(versions))
+	for _, v := range versions {
+		response := toFrameworkVersionResponse(db.FrameworkVersion{
+			ID:          v.ID,
+			FrameworkID: v.FrameworkID,
+			Version:     v.Version,
+			Status:      v.Status,
+			Notes:       v.Notes,
+			CreatedBy:   v.CreatedBy,
+			PublishedBy: v.PublishedBy,
+			PublishedAt: v.PublishedAt,
+			CreatedAt:   v.CreatedAt,
+			UpdatedAt:   v.UpdatedAt,
+		})
+		response.QuestionCount = int(v.QuestionCount)
+		responses = append(responses, response)
+	}
+
+	return c.JSON(http.StatusOK, responses)
+}
+
+// GetFrameworkVersion returns a framework version with its questions
+// @Summary Get framework version
+// @Description Get a framework version and its questions with their translations. Use "latest" for the latest published version.
+// @Tags frameworks
+// @Produce json
+// @Param id path string true "Framework ID"
+// @Param versionId path string true "Version ID or latest"
+// @Success 200 {object} FrameworkVersionDetailResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/v1/frameworks/{id}/versions/{versionId} [get]
+func (h *Handler) GetFrameworkVersion(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	frameworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid framework ID",
+		})
+	}
+
+	version, status, msg := h.resolveFrameworkVersion(ctx, frameworkID, c.Param("versionId"))
+	if msg != "" {
+		return c.JSON(status, map[string]string{
+			"error": msg,
+		})
+	}
+
+	framework, err := h.store.GetFramework(ctx, frameworkID)
+	if err != nil {
+		h.logger.Errorw("Failed to get framework", "error", err, "id", frameworkID)
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Framework not found",
+		})
+	}
+
+	questions, err := h.store.ListVersionQuestions(ctx, version.ID)
+	if err != nil {
+		h.logger.Errorw("Failed to get version questions", "error", err, "version_id", version.ID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve framework questions",
+		})
+	}
+
+	responses, err := h.translatedQuestionResponses(ctx, version.ID, questions)
+	if err != nil {
+		h.logger.Errorw("Failed to get version translations", "error", err, "version_id", version.ID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve framework questions",
+		})
+	}
+
+	response := FrameworkVersionDetailResponse{
+		FrameworkVersionResponse: toFrameworkVersionResponse(version),
+		Language:                 framework.Language,
+		Questions:                responses,
+	}
+	response.QuestionCount = len(questions)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// CreateFrameworkVersion opens a new draft version of a framework
+// @Summary Create framework draft version
+// @Description Open a draft version. Without questions the draft copies the latest published version.
+// @Tags frameworks
+// @Accept json
+// @Produce json
+// @Param id path string true "Framework ID"
+// @Param version body CreateFrameworkVersionRequest true "Draft version"
+// @Success 201 {object} FrameworkVersionResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/v1/frameworks/{id}/versions [post]
+func (h *Handler) CreateFrameworkVersion(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	frameworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid framework ID",
+		})
+	}
+
+	var req CreateFrameworkVersionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	if err := validateQuestions(req.Questions); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	claims, err := auth.GetUserFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User not authenticated",
+		})
+	}
+
+	if _, err := h.store.GetFramework(ctx, frameworkID); err != nil {
+		h.logger.Errorw("Failed to get framework", "error", err, "id", frameworkID)
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Framework not found",
+		})
+	}
+
+	if status, msg := h.checkNewDraft(ctx, frameworkID, req.Version); msg != "" {
+		return c.JSON(status, map[string]string{
+			"error": msg,
+		})
+	}
+
+	var version db.FrameworkVersion
+	var questionCount int
+	err = h.store.ExecTx(ctx, func(q *db.Queries) error {
+		version, err = q.CreateFrameworkVersion(ctx, db.CreateFrameworkVersionParams{
+			FrameworkID: frameworkID,
+			Version:     req.Version,
+			Notes:       req.Notes,
+			CreatedBy:   pgtype.UUID{Bytes: claims.UserID, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create version: %w", err)
+		}
+
+		if len(req.Questions) > 0 {
+			questionCount = len(req.Questions)
+			return createVersionQuestions(ctx, q, frameworkID, version.ID, req.Questions)
+		}
+
+		latest, err := q.GetLatestPublishedFrameworkVersion(ctx, frameworkID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get latest published version: %w", err)
+		}
+
+		copied, err := q.CopyFrameworkVersionQuestions(ctx, db.CopyFrameworkVersionQuestionsParams{
+			TargetVersionID: version.ID,
+			SourceVersionID: latest.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to copy questions of version %s: %w", latest.Version, err)
+		}
+		questionCount = int(copied)
+
+		// The copied questions keep their translations
+		if err := q.CopyQuestionTranslations(ctx, db.CopyQuestionTranslationsParams{
+			TargetVersionID: version.ID,
+			SourceVersionID: latest.ID,
+		}); err != nil {
+			return fmt.Errorf("failed to copy question translations of version %s: %w", latest.Version, err)
+		}
+		if err := q.CopySectionTranslations(ctx, db.CopySectionTranslationsParams{
+			TargetVersionID: version.ID,
+			SourceVersionID: latest.ID,
+		}); err != nil {
+			return fmt.Errorf("failed to copy section translations of version %s: %w", latest.Version, err)
+		}
+		return nil
+	})
+	if err != nil {
+		h.logger.Errorw("Failed to create framework version", "error", err, "framework_id", frameworkID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to create framework version",
+		})
+	}
+
+	h.logger.Infow("Framework draft version created", "framework_id", frameworkID, "version", version.Version, "questions", questionCount)
+
+	response := toFrameworkVersionResponse(version)
+	response.QuestionCount = questionCount
+
+	return c.JSON(http.StatusCreated, response)
+}
+
+// UpdateFrameworkVersion replaces the questions of a draft version
+// @Summary Update framework draft version
+// @Description Replace the label, notes and questions of a draft version. Published versions cannot be changed.
+// @Tags frameworks
+// @Accept json
+// @Produce json
+// @Param id path string true "Framework ID"
+// @Param versionId path string true "Version ID"
+// @Param version body UpdateFrameworkVersionRequest true "Draft version"
+// @Success 200 {object} FrameworkVersionResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/v1/frameworks/{id}/versions/{versionId} [put]
+func (h *Handler) UpdateFrameworkVersion(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	frameworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid framework ID",
+		})
+	}
+
+	var req UpdateFrameworkVersionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	if err := validateQuestions(req.Questions); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	version, status, msg := h.resolveFrameworkVersion(ctx, frameworkID, c.Param("versionId"))
+	if msg != "" {
+		return c.JSON(status, map[string]string{
+			"error": msg,
+		})
+	}
+
+	if version.Status != VersionStatusDraft {
+		return c.JSON(http.StatusConflict, map[string]string{
+			"error": "Published versions cannot be changed; create a new draft instead",
+		})
+	}
+
+	if req.Version != version.Version {
+		if status, msg := h.checkVersionLabel(ctx, frameworkID, req.Version); msg != "" {
+			return c.JSON(status, map[string]string{
+				"error": msg,
+			})
+		}
+	}
+
+	draftID := version.ID
+	version, err = h.saveDraftVersion(ctx, draftID, frameworkID, req.Version, req.Notes, req.Questions)
+	if err != nil {
+		h.logger.Errorw("Failed to update framework version", "error", err, "version_id", draftID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to update framework version",
+		})
+	}
+
+	h.logger.Infow("Framework draft version updated", "framework_id", frameworkID, "version", version.Version, "questions", len(req.Questions))
+
+	response := toFrameworkVersionResponse(version)
+	response.QuestionCount = len(req.Questions)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// PublishFrameworkVersion freezes a draft version and makes it the version
+// new audits are created from
+// @Summary Publish framework version
+// @Description Publish a draft version. Published versions are immutable.
+// @Tags frameworks
+// @Produce json
+// @Param id path string true "Framework ID"
+// @Param versionId path string true "Version ID"
+// @Success 200 {object} FrameworkVersionResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/v1/frameworks/{id}/versions/{versionId}/publish [post]
+func (h *Handler) PublishFrameworkVersion(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	frameworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid framework ID",
+		})
+	}
+
+	claims, err := auth.GetUserFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User not authenticated",
+		})
+	}
+
+	version, status, msg := h.resolveFrameworkVersion(ctx, frameworkID, c.Param("versionId"))
+	if msg != "" {
+		return c.JSON(status, map[string]string{
+			"error": msg,
+		})
+	}
+
+	if version.Status != VersionStatusDraft {
+		return c.JSON(http.StatusConflict, map[string]string{
+			"error": "Version is already published",
+		})
+	}
+
+	questions, err := h.store.ListVersionQuestions(ctx, version.ID)
+	if err != nil {
+		h.logger.Errorw("Failed to get version questions", "error", err, "version_id", version.ID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve framework questions",
+		})
+	}
+
+	if len(questions) == 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "A version needs at least one question to be published",
+		})
+	}
+
+	var published db.FrameworkVersion
+	err = h.store.ExecTx(ctx, func(q *db.Queries) error {
+		published, err = q.PublishFrameworkVersion(ctx, db.PublishFrameworkVersionParams{
+			ID:          version.ID,
+			PublishedBy: pgtype.UUID{Bytes: claims.UserID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		return q.SetFrameworkCurrentVersion(ctx, db.SetFrameworkCurrentVersionParams{
+			ID:      frameworkID,
+			Version: &published.Version,
+		})
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Published concurrently
+		return c.JSON(http.StatusConflict, map[string]string{
+			"error": "Version is already published",
+		})
+	}
+	if err != nil {
+		h.logger.Errorw("Failed to publish framework version", "error", err, "version_id", version.ID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to publish framework version",
+		})
+	}
+
+	h.logger.Infow("Framework version published",
+		"framework_id", frameworkID,
+		"version", published.Version,
+		"published_by", claims.UserID)
+
+	response := toFrameworkVersionResponse(published)
+	response.QuestionCount = len(questions)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// DeleteFrameworkVersion discards a draft version
+// @Summary Delete framework draft version
+// @Description Discard a draft version. Published versions cannot be deleted.
+// @Tags frameworks
+// @Param id path string true "Framework ID"
+// @Param versionId path string true "Version ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/v1/frameworks/{id}/versions/{versionId} [delete]
+func (h *Handler) DeleteFrameworkVersion(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	frameworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid framework ID",
+		})
+	}
+
+	version, status, msg := h.resolveFrameworkVersion(ctx, frameworkID, c.Param("versionId"))
+	if msg != "" {
+		return c.JSON(status, map[string]string{
+			"error": msg,
+		})
+	}
+
+	if version.Status != VersionStatusDraft {
+		return c.JSON(http.StatusConflict, map[string]string{
+			"error": "Published versions cannot be deleted",
+		})
+	}
+
+	deleted, err := h.store.DeleteDraftFrameworkVersion(ctx, version.ID)
+	if err != nil {
+		h.logger.Errorw("Failed to delete framework version", "error", err, "version_id", version.ID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to delete framework version",
+		})
+	}
+
+	if deleted == 0 {
+		return c.JSON(http.StatusConflict, map[string]string{
+			"error": "Published versions cannot be deleted",
+		})
+	}
+
+	h.logger.Infow("Framework draft version deleted", "framework_id", frameworkID, "version", version.Version)
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// resolveFrameworkVersion looks up a version of a framework by ID or "latest".
+// On failure it returns the HTTP status and message to respond with.
+func (h *Handler) resolveFrameworkVersion(ctx context.Context, frameworkID uuid.UUID, param string) (db.FrameworkVersion, int, string) {
+	if param == LatestVersion {
+		version, err := h.store.GetLatestPublishedFrameworkVersion(ctx, frameworkID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return version, http.StatusNotFound, "Framework has no published version"
+		}
+		if err != nil {
+			h.logger.Errorw("Failed to get latest framework version", "error", err, "framework_id", frameworkID)
+			return version, http.StatusInternalServerError, "Failed to retrieve framework version"
+		}
+		return version, http.StatusOK, ""
+	}
+
+	versionID, err := uuid.Parse(param)
+	if err != nil {
+		return db.FrameworkVersion{}, http.StatusBadRequest, "Invalid version ID"
+	}
+
+	version, err := h.store.GetFrameworkVersion(ctx, versionID)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && version.FrameworkID != frameworkID) {
+		return db.FrameworkVersion{}, http.StatusNotFound, "Framework version not found"
+	}
+	if err != nil {
+		h.logger.Errorw("Failed to get framework version", "error", err, "version_id", versionID)
+		return version, http.StatusInternalServerError, "Failed to retrieve framework version"
+	}
+
+	return version, http.StatusOK, ""
+}
+
+// checkNewDraft reports whether a new draft with the given label can be
+// opened: a framework has at most one draft and labels are unique
+func (h *Handler) checkNewDraft(ctx context.Context, frameworkID uuid.UUID, label string) (int, string) {
+	_, err := h.store.GetDraftFrameworkVersion(ctx, frameworkID)
+	if err == nil {
+		return http.StatusConflict, "Framework already has a draft version; update or delete it first"
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		h.logger.Errorw("Failed to get draft framework version", "error", err, "framework_id", frameworkID)
+		return http.StatusInternalServerError, "Failed to retrieve framework versions"
+	}
+
+	return h.checkVersionLabel(ctx, frameworkID, label)
+}
+
+// checkVersionLabel reports whether a version label is still free
+func (h *Handler) checkVersionLabel(ctx context.Context, frameworkID uuid.UUID, label string) (int, string) {
+	versions, err := h.store.ListFrameworkVersions(ctx, frameworkID)
+	if err != nil {
+		h.logger.Errorw("Failed to list framework versions", "error", err, "framework_id", frameworkID)
+		return http.StatusInternalServerError, "Failed to retrieve framework versions"
+	}
+
+	for _, v := range versions {
+		if v.Version == label {
+			return http.StatusConflict, fmt.Sprintf("Version %q already exists", label)
+		}
+	}
+
+	return http.StatusOK, ""
+}
+
+// saveDraftVersion replaces the label, notes and questions of a draft in one transaction
+func (h *Handler) saveDraftVersion(ctx context.Context, versionID, frameworkID uuid.UUID, label string, notes *string, questions []FrameworkQuestionRequest) (db.FrameworkVersion, error) {
+	var version db.FrameworkVersion
+	err := h.store.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+		version, err = saveDraftQuestions(ctx, q, versionID, frameworkID, label, notes, questions)
+		return err
+	})
+	return version, err
+}
+
+// saveDraftQuestions replaces the label, notes and questions of a draft with q
+func saveDraftQuestions(ctx context.Context, q *db.Queries, versionID, frameworkID uuid.UUID, label string, notes *string, questions []FrameworkQuestionRequest) (db.FrameworkVersion, error) {
+	version, err := q.UpdateDraftFrameworkVersion(ctx, db.UpdateDraftFrameworkVersionParams{
+		ID:      versionID,
+		Version: label,
+		Notes:   notes,
+	})
+	if err != nil {
+		return version, fmt.Errorf("failed to update version: %w", err)
+	}
+
+	if err := q.DeleteFrameworkQuestionsByVersion(ctx, versionID); err != nil {
+		return version, fmt.Errorf("failed to delete draft questions: %w", err)
+	}
+
+	return version, createVersionQuestions(ctx, q, frameworkID, versionID, questions)
+}
+
+// createVersionQuestions adds questions to a version in the order they are listed
+func createVersionQuestions(ctx context.Context, q *db.Queries, frameworkID, versionID uuid.UUID, questions []FrameworkQuestionRequest) error {
+	for i, question := range questions {
+		condition, err := marshalVisibilityCondition(question.VisibilityCondition)
+		if err != nil {
+			return fmt.Errorf("question %s: %w", question.ControlID, err)
+		}
+
+		options, err := marshalQuestionOptions(question.Options)
+		if err != nil {
+			return fmt.Errorf("question %s: %w", question.ControlID, err)
+		}
+
+		requirements, err := marshalEvidenceRequirements(question)
+		if err != nil {
+			return fmt.Errorf("question %s: %w", question.ControlID, err)
+		}
+
+		_, err = q.CreateFrameworkQuestion(ctx, db.CreateFrameworkQuestionParams{
+			FrameworkID:          frameworkID,
+			SectionTitle:         question.SectionTitle,
+			ControlID:            question.ControlID,
+			QuestionText:         question.QuestionText,
+			HelpText:             question.HelpText,
+			AcceptableEvidence:   question.AcceptableEvidence,
+			VisibilityCondition:  condition,
+			Weight:               questionWeight(question),
+			Severity:             questionSeverity(question),
+			VersionID:            versionID,
+			QuestionType:         questionType(question),
+			Options:              options,
+			IsMandatory:          questionMandatory(question),
+			DisplayOrder:         int32(i + 1),
+			SubsectionTitle:      question.SubsectionTitle,
+			EvidenceRequired:     questionEvidenceRequired(question),
+			EvidenceRequirements: requirements,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create question %s: %w", question.ControlID, err)
+		}
+	}
+	return nil
+}
+
+// toFrameworkVersionResponse converts a version to its API representation
+func toFrameworkVersionResponse(v db.FrameworkVersion) FrameworkVersionResponse {
+	response := FrameworkVersionResponse{
+		ID:          v.ID.String(),
+		FrameworkID: v.FrameworkID.String(),
+		Version:     v.Version,
+		Status:      v.Status,
+		Notes:       v.Notes,
+		CreatedAt:   v.CreatedAt.Time.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:   v.UpdatedAt.Time.Format("2006-01-02T15:04:05Z"),
+	}
+	if v.CreatedBy.Valid {
+		createdBy := uuid.UUID(v.CreatedBy.Bytes).String()
+		response.CreatedBy = &createdBy
+	}
+	if v.PublishedBy.Valid {
+		publishedBy := uuid.UUID(v.PublishedBy.Bytes).String()
+		response.PublishedBy = &publishedBy
+	}
+	if v.PublishedAt.Valid {
+		publishedAt := v.PublishedAt.Time.Format("2006-01-02T15:04:05Z")
+		response.PublishedAt = &publishedAt
+	}
+	return response
+}
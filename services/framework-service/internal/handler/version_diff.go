@@ -0,0 +1,447 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/NormaTech-AI/audity/packages/go/spreadsheet"
+	"github.com/NormaTech-AI/audity/services/framework-service/internal/db"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// Kinds of change between two versions of a question
+const (
+	DiffChangeAdded    = "added"
+	DiffChangeRemoved  = "removed"
+	DiffChangeReworded = "reworded"
+	DiffChangeMoved    = "moved"
+	DiffChangeHelpText = "help_text_changed"
+	DiffChangeEvidence = "evidence_changed"
+	DiffChangeAnswer   = "answer_format_changed"
+	DiffChangeRequired = "requirement_changed"
+)
+
+// VersionRef identifies a framework version in a diff
+type VersionRef struct {
+	ID      string `json:"id"`
+	Version string `json:"version"`
+	Status  string `json:"status"`
+}
+
+// VersionDiffSummary counts the questions per kind of change
+type VersionDiffSummary struct {
+	Added     int `json:"added"`
+	Removed   int `json:"removed"`
+	Reworded  int `json:"reworded"`
+	Moved     int `json:"moved"`
+	HelpText  int `json:"help_text_changed"`
+	Evidence  int `json:"evidence_changed"`
+	Answer    int `json:"answer_format_changed"`
+	Required  int `json:"requirement_changed"`
+	Unchanged int `json:"unchanged"`
+}
+
+// QuestionDiff describes how a question, matched by control_id, differs
+// between two versions. Before is nil for added questions and After for
+// removed ones.
+type QuestionDiff struct {
+	ControlID       string            `json:"control_id"`
+	Changes         []string          `json:"changes"`
+	Before          *QuestionResponse `json:"before,omitempty"`
+	After           *QuestionResponse `json:"after,omitempty"`
+	EvidenceAdded   []string          `json:"evidence_added,omitempty"`
+	EvidenceRemoved []string          `json:"evidence_removed,omitempty"`
+}
+
+// VersionDiffResponse is the structured diff between two framework versions
+type VersionDiffResponse struct {
+	FrameworkID   string             `json:"framework_id"`
+	FrameworkName string             `json:"framework_name"`
+	From          VersionRef         `json:"from"`
+	To            VersionRef         `json:"to"`
+	Summary       VersionDiffSummary `json:"summary"`
+	Questions     []QuestionDiff     `json:"questions"`
+}
+
+// DiffFrameworkVersions compares the questions of two versions of a framework
+// @Summary Diff framework versions
+// @Description Compare two framework versions by control_id, reporting added, removed, reworded and moved questions, changed help text and acceptable evidence, changed answer types or options and changed mandatory or evidence requirements
+// @Tags frameworks
+// @Produce json
+// @Produce text/csv
+// @Param id path string true "Framework ID"
+// @Param from query string true "Version ID or latest"
+// @Param to query string true "Version ID or latest"
+// @Param format query string false "json (default) or csv"
+// @Success 200 {object} VersionDiffResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/v1/frameworks/{id}/diff [get]
+func (h *Handler) DiffFrameworkVersions(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	frameworkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid framework ID",
+		})
+	}
+
+	fromParam, toParam := c.QueryParam("from"), c.QueryParam("to")
+	if fromParam == "" || toParam == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Both from and to versions are required",
+		})
+	}
+
+	format := c.QueryParam("format")
+	if format != "" && format != "json" && format != "csv" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Unsupported format; use json or csv",
+		})
+	}
+
+	framework, err := h.store.GetFramework(ctx, frameworkID)
+	if err != nil {
+		h.logger.Errorw("Failed to get framework", "error", err, "id", frameworkID)
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Framework not found",
+		})
+	}
+
+	from, status, msg := h.resolveFrameworkVersion(ctx, frameworkID, fromParam)
+	if msg != "" {
+		return c.JSON(status, map[string]string{
+			"error": msg,
+		})
+	}
+
+	to, status, msg := h.resolveFrameworkVersion(ctx, frameworkID, toParam)
+	if msg != "" {
+		return c.JSON(status, map[string]string{
+			"error": msg,
+		})
+	}
+
+	fromQuestions, err := h.store.ListVersionQuestions(ctx, from.ID)
+	if err != nil {
+		h.logger.Errorw("Failed to get version questions", "error", err, "version_id", from.ID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve framework questions",
+		})
+	}
+
+	toQuestions, err := h.store.ListVersionQuestions(ctx, to.ID)
+	if err != nil {
+		h.logger.Errorw("Failed to get version questions", "error", err, "version_id", to.ID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve framework questions",
+		})
+	}
+
+	questions, summary := diffQuestions(fromQuestions, toQuestions)
+
+	response := VersionDiffResponse{
+		FrameworkID:   framework.ID.String(),
+		FrameworkName: framework.Name,
+		From:          VersionRef{ID: from.ID.String(), Version: from.Version, Status: from.Status},
+		To:            VersionRef{ID: to.ID.String(), Version: to.Version, Status: to.Status},
+		Summary:       summary,
+		Questions:     questions,
+	}
+
+	if format != "csv" {
+		return c.JSON(http.StatusOK, response)
+	}
+
+	data, err := versionDiffCSV(response)
+	if err != nil {
+		h.logger.Errorw("Failed to write version diff", "error", err, "framework_id", frameworkID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to export version diff",
+		})
+	}
+
+	fileName := fmt.Sprintf("framework-diff-%s-to-%s.csv", fileNamePart(from.Version), fileNamePart(to.Version))
+	c.Response().Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", fileName))
+
+	return c.Blob(http.StatusOK, "text/csv", data)
+}
+
+// diffQuestions matches questions by control_id and reports the changed ones
+// in control_id order. Whitespace-only edits are not reported. A question is
+// moved when its section changes or when it changes place among the questions
+// that stay in its section, so inserting or removing a question does not
+// report every question after it as moved.
+func diffQuestions(from, to []db.FrameworkQuestion) ([]QuestionDiff, VersionDiffSummary) {
+	before := make(map[string]db.FrameworkQuestion, len(from))
+	for _, q := range from {
+		before[q.ControlID] = q
+	}
+	after := make(map[string]db.FrameworkQuestion, len(to))
+	for _, q := range to {
+		after[q.ControlID] = q
+	}
+
+	controlIDs := make([]string, 0, len(before)+len(after))
+	for id := range before {
+		controlIDs = append(controlIDs, id)
+	}
+	for id := range after {
+		if _, ok := before[id]; !ok {
+			controlIDs = append(controlIDs, id)
+		}
+	}
+	sort.Strings(controlIDs)
+
+	stays := func(id string) bool {
+		old, inFrom := before[id]
+		updated, inTo := after[id]
+		return inFrom && inTo &&
+			sectionPath(old.SectionTitle, old.SubsectionTitle) == sectionPath(updated.SectionTitle, updated.SubsectionTitle)
+	}
+	fromPlaces, toPlaces := sectionPlaces(from, stays), sectionPlaces(to, stays)
+
+	var summary VersionDiffSummary
+	diffs := make([]QuestionDiff, 0)
+	for _, id := range controlIDs {
+		old, inFrom := before[id]
+		updated, inTo := after[id]
+
+		diff := QuestionDiff{ControlID: id}
+		switch {
+		case !inTo:
+			diff.Changes = []string{DiffChangeRemoved}
+			diff.Before = toQuestionResponse(old)
+			summary.Removed++
+		case !inFrom:
+			diff.Changes = []string{DiffChangeAdded}
+			diff.After = toQuestionResponse(updated)
+			summary.Added++
+		default:
+			if normalizeText(old.QuestionText) != normalizeText(updated.QuestionText) {
+				diff.Changes = append(diff.Changes, DiffChangeReworded)
+				summary.Reworded++
+			}
+			if !stays(id) || fromPlaces[id] != toPlaces[id] {
+				diff.Changes = append(diff.Changes, DiffChangeMoved)
+				summary.Moved++
+			}
+			if normalizeText(optionalText(old.HelpText)) != normalizeText(optionalText(updated.HelpText)) {
+				diff.Changes = append(diff.Changes, DiffChangeHelpText)
+				summary.HelpText++
+			}
+			diff.EvidenceAdded, diff.EvidenceRemoved = diffEvidence(old.AcceptableEvidence, updated.AcceptableEvidence)
+			if len(diff.EvidenceAdded) > 0 || len(diff.EvidenceRemoved) > 0 {
+				diff.Changes = append(diff.Changes, DiffChangeEvidence)
+				summary.Evidence++
+			}
+			if old.QuestionType != updated.QuestionType || !bytes.Equal(old.Options, updated.Options) {
+				diff.Changes = append(diff.Changes, DiffChangeAnswer)
+				summary.Answer++
+			}
+			if old.IsMandatory != updated.IsMandatory || old.EvidenceRequired != updated.EvidenceRequired ||
+				!bytes.Equal(old.EvidenceRequirements, updated.EvidenceRequirements) {
+				diff.Changes = append(diff.Changes, DiffChangeRequired)
+				summary.Required++
+			}
+			if len(diff.Changes) == 0 {
+				summary.Unchanged++
+				continue
+			}
+			diff.Before = toQuestionResponse(old)
+			diff.After = toQuestionResponse(updated)
+		}
+
+		diffs = append(diffs, diff)
+	}
+
+	return diffs, summary
+}
+
+// sectionPlaces returns the place of each kept question among the kept
+// questions of its section, in display order
+func sectionPlaces(questions []db.FrameworkQuestion, keep func(controlID string) bool) map[string]int {
+	ordered := slices.Clone(questions)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].DisplayOrder < ordered[j].DisplayOrder
+	})
+
+	counts := make(map[string]int)
+	places := make(map[string]int, len(ordered))
+	for _, q := range ordered {
+		if !keep(q.ControlID) {
+			continue
+		}
+		section := sectionPath(q.SectionTitle, q.SubsectionTitle)
+		places[q.ControlID] = counts[section]
+		counts[section]++
+	}
+	return places
+}
+
+// diffEvidence returns the evidence items only in the new list and only in the old one
+func diffEvidence(old, updated []string) (added, removed []string) {
+	normalized := func(items []string) []string {
+		out := make([]string, 0, len(items))
+		for _, item := range items {
+			out = append(out, normalizeText(item))
+		}
+		return out
+	}
+	oldItems, newItems := normalized(old), normalized(updated)
+
+	for i, item := range updated {
+		if !slices.Contains(oldItems, newItems[i]) {
+			added = append(added, item)
+		}
+	}
+	for i, item := range old {
+		if !slices.Contains(newItems, oldItems[i]) {
+			removed = append(removed, item)
+		}
+	}
+	return added, removed
+}
+
+// versionDiffCSV flattens a diff into one row per changed field
+func versionDiffCSV(diff VersionDiffResponse) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"control_id", "change", "section", "field", "from_version", "to_version", "old_value", "new_value"})
+
+	row := func(q QuestionDiff, change, field, oldValue, newValue string) {
+		section := ""
+		if q.After != nil {
+			section = sectionName(q.After.SectionTitle)
+		} else if q.Before != nil {
+			section = sectionName(q.Before.SectionTitle)
+		}
+		w.Write(spreadsheet.SafeRow([]string{q.ControlID, change, section, field, diff.From.Version, diff.To.Version, oldValue, newValue}))
+	}
+
+	for _, q := range diff.Questions {
+		for _, change := range q.Changes {
+			switch change {
+			case DiffChangeAdded:
+				row(q, change, "question_text", "", q.After.QuestionText)
+			case DiffChangeRemoved:
+				row(q, change, "question_text", q.Before.QuestionText, "")
+			case DiffChangeReworded:
+				row(q, change, "question_text", q.Before.QuestionText, q.After.QuestionText)
+			case DiffChangeMoved:
+				oldSection := sectionPath(q.Before.SectionTitle, q.Before.SubsectionTitle)
+				newSection := sectionPath(q.After.SectionTitle, q.After.SubsectionTitle)
+				if oldSection == newSection {
+					row(q, change, "display_order", strconv.Itoa(int(q.Before.DisplayOrder)), strconv.Itoa(int(q.After.DisplayOrder)))
+				} else {
+					row(q, change, "section_title", oldSection, newSection)
+				}
+			case DiffChangeHelpText:
+				row(q, change, "help_text", optionalText(q.Before.HelpText), optionalText(q.After.HelpText))
+			case DiffChangeEvidence:
+				row(q, change, "acceptable_evidence", strings.Join(q.EvidenceRemoved, "; "), strings.Join(q.EvidenceAdded, "; "))
+			case DiffChangeAnswer:
+				row(q, change, "question_type", answerFormat(q.Before), answerFormat(q.After))
+			case DiffChangeRequired:
+				row(q, change, "requirements", requirements(q.Before), requirements(q.After))
+			}
+		}
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// toQuestionResponse converts a single question to its API representation
+func toQuestionResponse(q db.FrameworkQuestion) *QuestionResponse {
+	return &toQuestionResponses([]db.FrameworkQuestion{q})[0]
+}
+
+// sectionName returns the section of a question, "General" when it has none
+func sectionName(title *string) string {
+	if title == nil || strings.TrimSpace(*title) == "" {
+		return "General"
+	}
+	return strings.TrimSpace(*title)
+}
+
+// sectionPath returns the section of a question followed by its sub-section
+func sectionPath(section, subsection *string) string {
+	path := sectionName(section)
+	if subsection != nil && strings.TrimSpace(*subsection) != "" {
+		path += " / " + strings.TrimSpace(*subsection)
+	}
+	return path
+}
+
+// answerFormat describes the answer type of a question and its options
+func answerFormat(q *QuestionResponse) string {
+	var options []QuestionOption
+	if len(q.Options) == 0 || json.Unmarshal(q.Options, &options) != nil {
+		return q.QuestionType
+	}
+
+	labels := make([]string, 0, len(options))
+	for _, option := range options {
+		labels = append(labels, option.Label)
+	}
+	return q.QuestionType + ": " + strings.Join(labels, "; ")
+}
+
+// requirements describes whether a question is mandatory and needs evidence
+func requirements(q *QuestionResponse) string {
+	text := "optional"
+	if q.IsMandatory {
+		text = "mandatory"
+	}
+	if q.EvidenceRequired {
+		text += ", evidence required"
+	}
+
+	var evidence EvidenceRequirements
+	if len(q.EvidenceRequirements) == 0 || json.Unmarshal(q.EvidenceRequirements, &evidence) != nil {
+		return text
+	}
+	if evidence.MinFiles > 1 {
+		text += fmt.Sprintf(" (at least %d files)", evidence.MinFiles)
+	}
+	if len(evidence.AllowedFileTypes) > 0 {
+		text += "; file types: " + strings.Join(evidence.AllowedFileTypes, " ")
+	}
+	if len(evidence.RequiredCategories) > 0 {
+		text += "; categories: " + strings.Join(evidence.RequiredCategories, " ")
+	}
+	return text
+}
+
+// optionalText dereferences an optional text column
+func optionalText(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// normalizeText collapses whitespace so formatting-only edits compare equal
+func normalizeText(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// fileNamePart keeps a version label safe to use in a file name
+func fileNamePart(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '"' || r == '/' || r == '\\' || r == ' ' {
+			return '-'
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,304 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"testing"
+
+	"github.com/NormaTech-AI/audity/services/framework-service/internal/db"
+)
+
+func strPtr(s string) *string { return &s }
+
+func question(controlID, text string) db.FrameworkQuestion {
+	return db.FrameworkQuestion{
+		ControlID:          controlID,
+		QuestionText:       text,
+		SectionTitle:       strPtr("Governance"),
+		AcceptableEvidence: []string{"Policy document"},
+		QuestionType:       "yes_no",
+		IsMandatory:        true,
+	}
+}
+
+func TestDiffQuestions(t *testing.T) {
+	base := question("A.1", "Is there a security policy?")
+
+	tests := []struct {
+		name        string
+		from        []db.FrameworkQuestion
+		to          []db.FrameworkQuestion
+		wantChanges map[string][]string
+		wantSummary VersionDiffSummary
+	}{
+		{
+			name:        "unchanged",
+			from:        []db.FrameworkQuestion{base},
+			to:          []db.FrameworkQuestion{base},
+			wantChanges: map[string][]string{},
+			wantSummary: VersionDiffSummary{Unchanged: 1},
+		},
+		{
+			name: "whitespace only edit",
+			from: []db.FrameworkQuestion{base},
+			to: func() []db.FrameworkQuestion {
+				q := base
+				q.QuestionText = "  Is there a   security\npolicy? "
+				q.AcceptableEvidence = []string{" Policy  document"}
+				return []db.FrameworkQuestion{q}
+			}(),
+			wantChanges: map[string][]string{},
+			wantSummary: VersionDiffSummary{Unchanged: 1},
+		},
+		{
+			name:        "added and removed",
+			from:        []db.FrameworkQuestion{base},
+			to:          []db.FrameworkQuestion{question("A.2", "Are policies reviewed?")},
+			wantChanges: map[string][]string{"A.1": {DiffChangeRemoved}, "A.2": {DiffChangeAdded}},
+			wantSummary: VersionDiffSummary{Added: 1, Removed: 1},
+		},
+		{
+			name: "reworded",
+			from: []db.FrameworkQuestion{base},
+			to: func() []db.FrameworkQuestion {
+				q := base
+				q.QuestionText = "Is there an approved security policy?"
+				return []db.FrameworkQuestion{q}
+			}(),
+			wantChanges: map[string][]string{"A.1": {DiffChangeReworded}},
+			wantSummary: VersionDiffSummary{Reworded: 1},
+		},
+		{
+			name: "moved to a sub-section",
+			from: []db.FrameworkQuestion{base},
+			to: func() []db.FrameworkQuestion {
+				q := base
+				q.SubsectionTitle = strPtr("Policies")
+				return []db.FrameworkQuestion{q}
+			}(),
+			wantChanges: map[string][]string{"A.1": {DiffChangeMoved}},
+			wantSummary: VersionDiffSummary{Moved: 1},
+		},
+		{
+			name: "reordered within a section",
+			from: []db.FrameworkQuestion{ordered(base, 1), ordered(question("A.2", "Are policies reviewed?"), 2)},
+			to:   []db.FrameworkQuestion{ordered(base, 2), ordered(question("A.2", "Are policies reviewed?"), 1)},
+			wantChanges: map[string][]string{
+				"A.1": {DiffChangeMoved},
+				"A.2": {DiffChangeMoved},
+			},
+			wantSummary: VersionDiffSummary{Moved: 2},
+		},
+		{
+			name:        "question inserted before",
+			from:        []db.FrameworkQuestion{ordered(base, 1)},
+			to:          []db.FrameworkQuestion{ordered(question("A.0", "Is there a security officer?"), 1), ordered(base, 2)},
+			wantChanges: map[string][]string{"A.0": {DiffChangeAdded}},
+			wantSummary: VersionDiffSummary{Added: 1, Unchanged: 1},
+		},
+		{
+			name: "help text, evidence, answer format and requirements",
+			from: []db.FrameworkQuestion{base},
+			to: func() []db.FrameworkQuestion {
+				q := base
+				q.HelpText = strPtr("Attach the signed policy")
+				q.AcceptableEvidence = []string{"Board minutes"}
+				q.QuestionType = "single_choice"
+				q.Options = []byte(`[{"value":"yes","label":"Yes"}]`)
+				q.EvidenceRequired = true
+				return []db.FrameworkQuestion{q}
+			}(),
+			wantChanges: map[string][]string{"A.1": {
+				DiffChangeHelpText,
+				DiffChangeEvidence,
+				DiffChangeAnswer,
+				DiffChangeRequired,
+			}},
+			wantSummary: VersionDiffSummary{HelpText: 1, Evidence: 1, Answer: 1, Required: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diffs, summary := diffQuestions(tt.from, tt.to)
+			if summary != tt.wantSummary {
+				t.Errorf("summary = %+v, want %+v", summary, tt.wantSummary)
+			}
+
+			got := make(map[string][]string, len(diffs))
+			for _, d := range diffs {
+				got[d.ControlID] = d.Changes
+			}
+			if !reflect.DeepEqual(got, tt.wantChanges) {
+				t.Errorf("changes = %v, want %v", got, tt.wantChanges)
+			}
+		})
+	}
+}
+
+func ordered(q db.FrameworkQuestion, displayOrder int32) db.FrameworkQuestion {
+	q.DisplayOrder = displayOrder
+	return q
+}
+
+func TestDiffQuestionsOrder(t *testing.T) {
+	from := []db.FrameworkQuestion{question("B.1", "Second"), question("A.1", "First")}
+	diffs, _ := diffQuestions(from, nil)
+
+	var got []string
+	for _, d := range diffs {
+		got = append(got, d.ControlID)
+	}
+	if want := []string{"A.1", "B.1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("control IDs = %v, want %v", got, want)
+	}
+}
+
+func TestDiffEvidence(t *testing.T) {
+	tests := []struct {
+		name        string
+		old         []string
+		updated     []string
+		wantAdded   []string
+		wantRemoved []string
+	}{
+		{name: "same", old: []string{"Policy"}, updated: []string{"Policy"}},
+		{name: "reformatted", old: []string{"Signed  policy"}, updated: []string{" Signed policy "}},
+		{name: "reordered", old: []string{"Policy", "Minutes"}, updated: []string{"Minutes", "Policy"}},
+		{
+			name:        "replaced",
+			old:         []string{"Policy", "Minutes"},
+			updated:     []string{"Policy", "Audit log"},
+			wantAdded:   []string{"Audit log"},
+			wantRemoved: []string{"Minutes"},
+		},
+		{name: "from none", updated: []string{"Policy"}, wantAdded: []string{"Policy"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added, removed := diffEvidence(tt.old, tt.updated)
+			if !reflect.DeepEqual(added, tt.wantAdded) {
+				t.Errorf("added = %v, want %v", added, tt.wantAdded)
+			}
+			if !reflect.DeepEqual(removed, tt.wantRemoved) {
+				t.Errorf("removed = %v, want %v", removed, tt.wantRemoved)
+			}
+		})
+	}
+}
+
+func TestVersionDiffCSV(t *testing.T) {
+	old := question("A.1", "Is there a security policy?")
+	updated := old
+	updated.QuestionText = "Is there an approved security policy?"
+	updated.SectionTitle = strPtr("Policies")
+
+	questions, summary := diffQuestions([]db.FrameworkQuestion{old}, []db.FrameworkQuestion{updated, question("A.2", "New")})
+	data, err := versionDiffCSV(VersionDiffResponse{
+		From:      VersionRef{Version: "1.0"},
+		To:        VersionRef{Version: "2.0"},
+		Summary:   summary,
+		Questions: questions,
+	})
+	if err != nil {
+		t.Fatalf("versionDiffCSV() error = %v", err)
+	}
+
+	rows, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+
+	want := [][]string{
+		{"control_id", "change", "section", "field", "from_version", "to_version", "old_value", "new_value"},
+		{"A.1", DiffChangeReworded, "Policies", "question_text", "1.0", "2.0", old.QuestionText, updated.QuestionText},
+		{"A.1", DiffChangeMoved, "Policies", "section_title", "1.0", "2.0", "Governance", "Policies"},
+		{"A.2", DiffChangeAdded, "Governance", "question_text", "1.0", "2.0", "", "New"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows = %q, want %q", rows, want)
+	}
+}
+
+func TestVersionDiffCSVReordered(t *testing.T) {
+	first, second := question("A.1", "First"), question("A.2", "Second")
+	questions, summary := diffQuestions(
+		[]db.FrameworkQuestion{ordered(first, 1), ordered(second, 2)},
+		[]db.FrameworkQuestion{ordered(first, 2), ordered(second, 1)},
+	)
+	data, err := versionDiffCSV(VersionDiffResponse{
+		From:      VersionRef{Version: "1.0"},
+		To:        VersionRef{Version: "2.0"},
+		Summary:   summary,
+		Questions: questions,
+	})
+	if err != nil {
+		t.Fatalf("versionDiffCSV() error = %v", err)
+	}
+
+	rows, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+
+	want := [][]string{
+		{"control_id", "change", "section", "field", "from_version", "to_version", "old_value", "new_value"},
+		{"A.1", DiffChangeMoved, "Governance", "display_order", "1.0", "2.0", "1", "2"},
+		{"A.2", DiffChangeMoved, "Governance", "display_order", "1.0", "2.0", "2", "1"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows = %q, want %q", rows, want)
+	}
+}
+
+func TestVersionDiffCSVNeutralizesFormulas(t *testing.T) {
+	updated := question("A.1", "=HYPERLINK(\"http://example.com\")")
+	questions, summary := diffQuestions(nil, []db.FrameworkQuestion{updated})
+	data, err := versionDiffCSV(VersionDiffResponse{
+		From:      VersionRef{Version: "1.0"},
+		To:        VersionRef{Version: "2.0"},
+		Summary:   summary,
+		Questions: questions,
+	})
+	if err != nil {
+		t.Fatalf("versionDiffCSV() error = %v", err)
+	}
+
+	rows, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if got, want := rows[1][7], "'"+updated.QuestionText; got != want {
+		t.Errorf("new_value = %q, want %q", got, want)
+	}
+}
+
+func TestRequirements(t *testing.T) {
+	tests := []struct {
+		name string
+		q    QuestionResponse
+		want string
+	}{
+		{name: "optional", q: QuestionResponse{}, want: "optional"},
+		{name: "mandatory", q: QuestionResponse{IsMandatory: true}, want: "mandatory"},
+		{
+			name: "evidence rules",
+			q: QuestionResponse{
+				IsMandatory:          true,
+				EvidenceRequired:     true,
+				EvidenceRequirements: []byte(`{"min_files":2,"allowed_file_types":["pdf"],"required_categories":["policy"]}`),
+			},
+			want: "mandatory, evidence required (at least 2 files); file types: pdf; categories: policy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := requirements(&tt.q); got != tt.want {
+				t.Errorf("requirements() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NormaTech-AI/audity/packages/go/auth"
+	"github.com/NormaTech-AI/audity/services/framework-service/internal/db"
+	"github.com/NormaTech-AI/audity/services/framework-service/internal/store"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// rowFunc is a pgx.Row that scans with the given function
+type rowFunc func(dest ...any) error
+
+func (f rowFunc) Scan(dest ...any) error { return f(dest...) }
+
+// emptyRows is a query result without rows
+type emptyRows struct{}
+
+func (emptyRows) Close()                                       {}
+func (emptyRows) Err() error                                   { return nil }
+func (emptyRows) CommandTag() pgconn.CommandTag                { return pgconn.CommandTag{} }
+func (emptyRows) FieldDescriptions() []pgconn.FieldDescription { return nil }
+func (emptyRows) Next() bool                                   { return false }
+func (emptyRows) Scan(dest ...any) error                       { return errors.New("no rows") }
+func (emptyRows) Values() ([]any, error)                       { return nil, nil }
+func (emptyRows) RawValues() [][]byte                          { return nil }
+func (emptyRows) Conn() *pgx.Conn                              { return nil }
+
+// fakeDB answers single row queries from canned rows keyed by their SQL and
+// statements with the canned number of affected rows. Lists are empty and
+// queries without a canned row find nothing.
+type fakeDB struct {
+	rows     map[string]rowFunc
+	affected map[string]int64
+}
+
+func (f *fakeDB) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return pgconn.NewCommandTag(fmt.Sprintf("DELETE %d", f.affected[sql])), nil
+}
+
+func (f *fakeDB) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return emptyRows{}, nil
+}
+
+func (f *fakeDB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	if row, ok := f.rows[sql]; ok {
+		return row
+	}
+	return rowFunc(func(dest ...any) error { return pgx.ErrNoRows })
+}
+
+func (f *fakeDB) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	return 0, errors.New("unexpected copy")
+}
+
+// versionRow scans a framework version with the given framework and status
+func versionRow(frameworkID uuid.UUID, status string) rowFunc {
+	return func(dest ...any) error {
+		*dest[1].(*uuid.UUID) = frameworkID
+		*dest[2].(*string) = "1.0"
+		*dest[3].(*string) = status
+		return nil
+	}
+}
+
+// serve calls a handler with the given framework and version path parameters
+func serve(t *testing.T, database *fakeDB, handle func(*Handler, echo.Context) error, frameworkID, versionID uuid.UUID) int {
+	t.Helper()
+
+	h := &Handler{store: &store.Store{Queries: db.New(database)}, logger: zap.NewNop().Sugar()}
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodPost, "/", nil), rec)
+	c.SetParamNames("id", "versionId")
+	c.SetParamValues(frameworkID.String(), versionID.String())
+	c.Set("user", &auth.JWTClaims{UserID: uuid.New()})
+
+	if err := handle(h, c); err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	return rec.Code
+}
+
+func TestPublishFrameworkVersionChecks(t *testing.T) {
+	frameworkID := uuid.New()
+
+	tests := []struct {
+		name       string
+		version    rowFunc
+		wantStatus int
+	}{
+		{name: "unknown version", wantStatus: http.StatusNotFound},
+		{name: "version of another framework", version: versionRow(uuid.New(), VersionStatusDraft), wantStatus: http.StatusNotFound},
+		{name: "already published", version: versionRow(frameworkID, VersionStatusPublished), wantStatus: http.StatusConflict},
+		{name: "draft without questions", version: versionRow(frameworkID, VersionStatusDraft), wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database := &fakeDB{rows: map[string]rowFunc{}}
+			if tt.version != nil {
+				database.rows[db.GetFrameworkVersion] = tt.version
+			}
+
+			got := serve(t, database, (*Handler).PublishFrameworkVersion, frameworkID, uuid.New())
+			if got != tt.wantStatus {
+				t.Errorf("status = %d, want %d", got, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestDeleteFrameworkVersion(t *testing.T) {
+	frameworkID := uuid.New()
+
+	tests := []struct {
+		name       string
+		status     string
+		deleted    int64
+		wantStatus int
+	}{
+		{name: "draft", status: VersionStatusDraft, deleted: 1, wantStatus: http.StatusNoContent},
+		{name: "published", status: VersionStatusPublished, wantStatus: http.StatusConflict},
+		{name: "published meanwhile", status: VersionStatusDraft, deleted: 0, wantStatus: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database := &fakeDB{
+				rows:     map[string]rowFunc{db.GetFrameworkVersion: versionRow(frameworkID, tt.status)},
+				affected: map[string]int64{db.DeleteDraftFrameworkVersion: tt.deleted},
+			}
+
+			got := serve(t, database, (*Handler).DeleteFrameworkVersion, frameworkID, uuid.New())
+			if got != tt.wantStatus {
+				t.Errorf("status = %d, want %d", got, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestDeleteFramework(t *testing.T) {
+	tests := []struct {
+		name       string
+		deleted    int64
+		exists     bool
+		wantStatus int
+	}{
+		{name: "without published versions", deleted: 1, exists: true, wantStatus: http.StatusNoContent},
+		{name: "with a published version", exists: true, wantStatus: http.StatusConflict},
+		{name: "unknown framework", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database := &fakeDB{
+				rows:     map[string]rowFunc{},
+				affected: map[string]int64{db.DeleteFramework: tt.deleted},
+			}
+			if tt.exists {
+				database.rows[db.GetFramework] = func(dest ...any) error { return nil }
+			}
+
+			got := serve(t, database, (*Handler).DeleteFramework, uuid.New(), uuid.New())
+			if got != tt.wantStatus {
+				t.Errorf("status = %d, want %d", got, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,389 @@
+// Package importer reads framework questions from the CSV and XLSX checklists
+// published by exchanges and regulators.
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// maxPartSize caps the uncompressed size of a single XLSX part
+const maxPartSize = 50 << 20
+
+// Question types understood by the client audit checklist
+const (
+	TypeYesNo          = "yes_no"
+	TypeText           = "text"
+	TypeMultipleChoice = "multiple_choice"
+	TypeSingleChoice   = "single_choice"
+	TypeNumeric        = "numeric"
+	TypeDate           = "date"
+	TypeTable          = "table"
+)
+
+var questionTypes = map[string]bool{
+	TypeYesNo:          true,
+	TypeText:           true,
+	TypeMultipleChoice: true,
+	TypeSingleChoice:   true,
+	TypeNumeric:        true,
+	TypeDate:           true,
+	TypeTable:          true,
+}
+
+// TakesOptions reports whether questions of the type need options: the
+// choices of a choice question or the columns of a table question
+func TakesOptions(questionType string) bool {
+	switch questionType {
+	case TypeSingleChoice, TypeMultipleChoice, TypeTable:
+		return true
+	}
+	return false
+}
+
+// ValidType reports whether questionType is a known question type
+func ValidType(questionType string) bool {
+	return questionTypes[questionType]
+}
+
+var columnLetters = regexp.MustCompile(`^[A-Za-z]{1,3}$`)
+
+// Mapping tells which spreadsheet column holds each question field. Columns
+// are referred to by header name, case-insensitively, or by column letter.
+// Fields left empty fall back to a column named after the field, if any.
+type Mapping struct {
+	Section            string `json:"section"`
+	ControlID          string `json:"control_id"`
+	QuestionText       string `json:"question_text"`
+	HelpText           string `json:"help_text"`
+	AcceptableEvidence string `json:"acceptable_evidence"`
+	Type               string `json:"type"`
+	Mandatory          string `json:"mandatory"`
+	Subsection         string `json:"subsection"`
+	Options            string `json:"options"`
+	EvidenceRequired   string `json:"evidence_required"`
+	// Sheet selects the worksheet of an XLSX file, the first one by default
+	Sheet string `json:"sheet"`
+	// HeaderRow is the 1-based row holding the column headers, 1 by default
+	HeaderRow int `json:"header_row"`
+	// EvidenceSeparator splits acceptable evidence into items. Items are
+	// split on semicolons and line breaks by default.
+	EvidenceSeparator string `json:"evidence_separator"`
+	// OptionSeparator splits the options of choice and table questions.
+	// Options are split on semicolons and line breaks by default.
+	OptionSeparator string `json:"option_separator"`
+}
+
+// Option is a choice of a choice question, or a column of a table question
+type Option struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+}
+
+// Question is a validated question read from a spreadsheet row
+type Question struct {
+	Row                int      `json:"row"`
+	SectionTitle       *string  `json:"section_title"`
+	ControlID          string   `json:"control_id"`
+	QuestionText       string   `json:"question_text"`
+	HelpText           *string  `json:"help_text"`
+	AcceptableEvidence []string `json:"acceptable_evidence"`
+	Type               string   `json:"type"`
+	Options            []Option `json:"options,omitempty"`
+	Mandatory          bool     `json:"mandatory"`
+	SubsectionTitle    *string  `json:"subsection_title"`
+	EvidenceRequired   bool     `json:"evidence_required"`
+}
+
+// RowError reports a problem with a spreadsheet row. Row numbers are the ones
+// shown by the spreadsheet application.
+type RowError struct {
+	Row     int    `json:"row"`
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// Result holds the questions read from a spreadsheet and the rows that failed validation
+type Result struct {
+	RowsRead  int        `json:"rows_read"`
+	Questions []Question `json:"questions"`
+	Errors    []RowError `json:"errors"`
+}
+
+// ReadRows reads the cells of a CSV or XLSX file, chosen by file extension
+func ReadRows(fileName string, data []byte, sheet string) ([][]string, error) {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+		r.FieldsPerRecord = -1
+		rows, err := r.ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV file: %w", err)
+		}
+		return rows, nil
+	case ".xlsx":
+		return readXLSX(data, sheet)
+	default:
+		return nil, fmt.Errorf("unsupported file type %q; upload a .csv or .xlsx file", filepath.Ext(fileName))
+	}
+}
+
+// column is a resolved mapping of a field to a column index
+type column struct {
+	field string
+	index int
+	name  string
+}
+
+// Parse maps and validates the rows below the header row. Problems with the
+// mapping itself are returned as an error; problems with individual rows are
+// collected in the result so they can all be fixed in one go.
+func Parse(rows [][]string, m Mapping) (*Result, error) {
+	headerRow := m.HeaderRow
+	if headerRow == 0 {
+		headerRow = 1
+	}
+	if headerRow < 1 || headerRow > len(rows) {
+		return nil, fmt.Errorf("header row %d is outside the sheet", headerRow)
+	}
+	header := rows[headerRow-1]
+
+	fields := []struct {
+		name     string
+		mapped   string
+		required bool
+	}{
+		{"section", m.Section, false},
+		{"control_id", m.ControlID, true},
+		{"question_text", m.QuestionText, true},
+		{"help_text", m.HelpText, false},
+		{"acceptable_evidence", m.AcceptableEvidence, false},
+		{"type", m.Type, false},
+		{"mandatory", m.Mandatory, false},
+		{"subsection", m.Subsection, false},
+		{"options", m.Options, false},
+		{"evidence_required", m.EvidenceRequired, false},
+	}
+
+	columns := make(map[string]column, len(fields))
+	for _, f := range fields {
+		ref := f.mapped
+		if ref == "" {
+			ref = f.name
+		}
+		index, ok := resolveColumn(header, ref)
+		if !ok {
+			if f.mapped != "" || f.required {
+				return nil, fmt.Errorf("column %q for %s not found in header row %d", ref, f.name, headerRow)
+			}
+			continue
+		}
+		name := ref
+		if index < len(header) && strings.TrimSpace(header[index]) != "" {
+			name = strings.TrimSpace(header[index])
+		}
+		columns[f.name] = column{field: f.name, index: index, name: name}
+	}
+
+	result := &Result{Questions: []Question{}, Errors: []RowError{}}
+	seen := make(map[string]int)
+	for i := headerRow; i < len(rows); i++ {
+		row := rows[i]
+		rowNum := i + 1
+		if isBlank(row) {
+			continue
+		}
+		result.RowsRead++
+
+		cell := func(field string) string {
+			col, ok := columns[field]
+			if !ok || col.index >= len(row) {
+				return ""
+			}
+			return strings.TrimSpace(row[col.index])
+		}
+		fail := func(field, format string, args ...any) {
+			result.Errors = append(result.Errors, RowError{
+				Row:     rowNum,
+				Column:  columns[field].name,
+				Message: fmt.Sprintf(format, args...),
+			})
+		}
+
+		errorsBefore := len(result.Errors)
+		q := Question{
+			Row:                rowNum,
+			SectionTitle:       optional(cell("section")),
+			SubsectionTitle:    optional(cell("subsection")),
+			ControlID:          cell("control_id"),
+			QuestionText:       cell("question_text"),
+			HelpText:           optional(cell("help_text")),
+			AcceptableEvidence: splitList(cell("acceptable_evidence"), m.EvidenceSeparator),
+			Type:               TypeYesNo,
+			Mandatory:          true,
+		}
+
+		if q.ControlID == "" {
+			fail("control_id", "control_id is required")
+		} else if first, ok := seen[q.ControlID]; ok {
+			fail("control_id", "control_id %s is already used in row %d", q.ControlID, first)
+		} else {
+			seen[q.ControlID] = rowNum
+		}
+
+		if q.QuestionText == "" {
+			fail("question_text", "question text is required")
+		}
+
+		if t := cell("type"); t != "" {
+			q.Type = strings.ReplaceAll(strings.ToLower(t), " ", "_")
+			if !questionTypes[q.Type] {
+				fail("type", "unsupported question type %q", t)
+			}
+		}
+
+		if v := cell("mandatory"); v != "" {
+			mandatory, ok := parseBool(v)
+			if !ok {
+				fail("mandatory", "mandatory must be yes or no, got %q", v)
+			}
+			q.Mandatory = mandatory
+		}
+
+		if v := cell("evidence_required"); v != "" {
+			required, ok := parseBool(v)
+			if !ok {
+				fail("evidence_required", "evidence_required must be yes or no, got %q", v)
+			}
+			q.EvidenceRequired = required
+		}
+
+		labels := splitList(cell("options"), m.OptionSeparator)
+		switch {
+		case TakesOptions(q.Type) && len(labels) == 0:
+			fail("options", "%s questions need options", q.Type)
+		case !TakesOptions(q.Type) && len(labels) > 0:
+			fail("options", "%s questions do not take options", q.Type)
+		default:
+			options, err := newOptions(labels)
+			if err != nil {
+				fail("options", "%v", err)
+			}
+			q.Options = options
+		}
+
+		if len(result.Errors) == errorsBefore {
+			result.Questions = append(result.Questions, q)
+		}
+	}
+
+	if result.RowsRead == 0 {
+		return nil, fmt.Errorf("no questions found below header row %d", headerRow)
+	}
+
+	return result, nil
+}
+
+// resolveColumn finds a column by header name, falling back to a column letter
+func resolveColumn(header []string, ref string) (int, bool) {
+	want := normalizeHeader(ref)
+	for i, h := range header {
+		if normalizeHeader(h) == want {
+			return i, true
+		}
+	}
+
+	if columnLetters.MatchString(ref) {
+		index, err := columnIndex(strings.ToUpper(ref))
+		return index, err == nil
+	}
+
+	return 0, false
+}
+
+// normalizeHeader makes "Control ID", "control_id" and "CONTROL-ID" compare equal
+func normalizeHeader(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	return strings.Join(strings.FieldsFunc(s, func(r rune) bool {
+		return r == ' ' || r == '_' || r == '-'
+	}), "_")
+}
+
+// splitList splits a cell holding a list, such as acceptable evidence, into
+// trimmed items
+func splitList(s, separator string) []string {
+	if s == "" {
+		return nil
+	}
+
+	var parts []string
+	if separator != "" {
+		parts = strings.Split(s, separator)
+	} else {
+		parts = strings.FieldsFunc(s, func(r rune) bool {
+			return r == ';' || r == '\n' || r == '\r'
+		})
+	}
+
+	items := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			items = append(items, p)
+		}
+	}
+	return items
+}
+
+// newOptions turns option labels into options, deriving each value from its
+// label: "Every quarter" becomes "every_quarter"
+func newOptions(labels []string) ([]Option, error) {
+	if len(labels) == 0 {
+		return nil, nil
+	}
+
+	options := make([]Option, 0, len(labels))
+	seen := make(map[string]string, len(labels))
+	for _, label := range labels {
+		value := normalizeHeader(label)
+		if value == "" {
+			return nil, fmt.Errorf("option %q has no usable value", label)
+		}
+		if first, ok := seen[value]; ok {
+			return nil, fmt.Errorf("options %q and %q have the same value %q", first, label, value)
+		}
+		seen[value] = label
+		options = append(options, Option{Value: value, Label: label})
+	}
+	return options, nil
+}
+
+// parseBool accepts the ways spreadsheets usually spell yes and no
+func parseBool(s string) (bool, bool) {
+	switch strings.ToLower(s) {
+	case "yes", "y", "true", "1", "mandatory", "required":
+		return true, true
+	case "no", "n", "false", "0", "optional":
+		return false, true
+	}
+	return false, false
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func isBlank(row []string) bool {
+	for _, v := range row {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
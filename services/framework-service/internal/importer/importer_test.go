@@ -0,0 +1,351 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	header := []string{"Section", "Control ID", "Question Text", "Type", "Options", "Mandatory"}
+
+	tests := []struct {
+		name          string
+		rows          [][]string
+		mapping       Mapping
+		wantQuestions []string
+		wantErrors    []RowError
+	}{
+		{
+			name: "defaults",
+			rows: [][]string{
+				header,
+				{"Governance", "A.1", "Is there a policy?", "", "", ""},
+			},
+			wantQuestions: []string{"A.1"},
+		},
+		{
+			name: "blank rows are skipped",
+			rows: [][]string{
+				header,
+				{"", " ", "", "", "", ""},
+				{"Governance", "A.1", "Is there a policy?"},
+			},
+			wantQuestions: []string{"A.1"},
+		},
+		{
+			name: "choice question with options",
+			rows: [][]string{
+				header,
+				{"", "A.1", "How often?", "Single Choice", "Monthly; Every quarter", "no"},
+			},
+			wantQuestions: []string{"A.1"},
+		},
+		{
+			name: "row errors",
+			rows: [][]string{
+				header,
+				{"", "", "Missing control", "", "", ""},
+				{"", "A.1", "", "", "", ""},
+				{"", "A.2", "Bad type", "essay", "", ""},
+				{"", "A.3", "Bad mandatory", "", "", "sometimes"},
+				{"", "A.4", "No options", "multiple_choice", "", ""},
+				{"", "A.5", "Unexpected options", "text", "One", ""},
+				{"", "A.6", "Valid", "", "", ""},
+				{"", "A.6", "Duplicate", "", "", ""},
+			},
+			wantQuestions: []string{"A.6"},
+			wantErrors: []RowError{
+				{Row: 2, Column: "Control ID", Message: "control_id is required"},
+				{Row: 3, Column: "Question Text", Message: "question text is required"},
+				{Row: 4, Column: "Type", Message: `unsupported question type "essay"`},
+				{Row: 5, Column: "Mandatory", Message: `mandatory must be yes or no, got "sometimes"`},
+				{Row: 6, Column: "Options", Message: "multiple_choice questions need options"},
+				{Row: 7, Column: "Options", Message: "text questions do not take options"},
+				{Row: 9, Column: "Control ID", Message: "control_id A.6 is already used in row 8"},
+			},
+		},
+		{
+			name: "mapping by letter and header row",
+			rows: [][]string{
+				{"Checklist export"},
+				{"Ref", "Requirement"},
+				{"C.1", "Are backups tested?"},
+			},
+			mapping:       Mapping{ControlID: "A", QuestionText: "requirement", HeaderRow: 2},
+			wantQuestions: []string{"C.1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Parse(tt.rows, tt.mapping)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			var got []string
+			for _, q := range result.Questions {
+				got = append(got, q.ControlID)
+			}
+			if !reflect.DeepEqual(got, tt.wantQuestions) {
+				t.Errorf("questions = %v, want %v", got, tt.wantQuestions)
+			}
+
+			wantErrors := tt.wantErrors
+			if wantErrors == nil {
+				wantErrors = []RowError{}
+			}
+			if !reflect.DeepEqual(result.Errors, wantErrors) {
+				t.Errorf("errors = %+v, want %+v", result.Errors, wantErrors)
+			}
+		})
+	}
+}
+
+func TestParseQuestion(t *testing.T) {
+	rows := [][]string{
+		{"section", "subsection", "control_id", "question_text", "help_text", "acceptable_evidence", "type", "options", "mandatory", "evidence_required"},
+		{"Access", "Reviews", "B.2", "How often are accounts reviewed?", "Include service accounts", "Review log;\nSign-off", "single choice", "Monthly\nEvery quarter", "optional", "yes"},
+	}
+
+	result, err := Parse(rows, Mapping{})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(result.Questions) != 1 {
+		t.Fatalf("questions = %d, want 1 (errors %+v)", len(result.Questions), result.Errors)
+	}
+
+	section, subsection, help := "Access", "Reviews", "Include service accounts"
+	want := Question{
+		Row:                2,
+		SectionTitle:       &section,
+		SubsectionTitle:    &subsection,
+		ControlID:          "B.2",
+		QuestionText:       "How often are accounts reviewed?",
+		HelpText:           &help,
+		AcceptableEvidence: []string{"Review log", "Sign-off"},
+		Type:               TypeSingleChoice,
+		Options: []Option{
+			{Value: "monthly", Label: "Monthly"},
+			{Value: "every_quarter", Label: "Every quarter"},
+		},
+		Mandatory:        false,
+		EvidenceRequired: true,
+	}
+	if got := result.Questions[0]; !reflect.DeepEqual(got, want) {
+		t.Errorf("question = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseMappingErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		rows    [][]string
+		mapping Mapping
+		wantErr string
+	}{
+		{
+			name:    "header row outside the sheet",
+			rows:    [][]string{{"control_id", "question_text"}},
+			mapping: Mapping{HeaderRow: 3},
+			wantErr: "header row 3 is outside the sheet",
+		},
+		{
+			name:    "required column missing",
+			rows:    [][]string{{"control_id"}, {"A.1"}},
+			wantErr: `column "question_text" for question_text not found`,
+		},
+		{
+			name:    "mapped column missing",
+			rows:    [][]string{{"control_id", "question_text"}, {"A.1", "Q"}},
+			mapping: Mapping{HelpText: "Guidance"},
+			wantErr: `column "Guidance" for help_text not found`,
+		},
+		{
+			name:    "no questions",
+			rows:    [][]string{{"control_id", "question_text"}, {"", ""}},
+			wantErr: "no questions found below header row 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.rows, tt.mapping)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Parse() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	tests := []struct {
+		name      string
+		s         string
+		separator string
+		want      []string
+	}{
+		{name: "empty", s: "", want: nil},
+		{name: "semicolons and line breaks", s: "a; b\r\nc;;", want: []string{"a", "b", "c"}},
+		{name: "custom separator", s: "a; b | c", separator: "|", want: []string{"a; b", "c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitList(tt.s, tt.separator); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitList(%q, %q) = %q, want %q", tt.s, tt.separator, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		labels  []string
+		want    []Option
+		wantErr bool
+	}{
+		{name: "none", labels: nil, want: nil},
+		{
+			name:   "values from labels",
+			labels: []string{"Yes", "Not applicable"},
+			want:   []Option{{Value: "yes", Label: "Yes"}, {Value: "not_applicable", Label: "Not applicable"}},
+		},
+		{name: "same value", labels: []string{"Not-applicable", "not applicable"}, wantErr: true},
+		{name: "no value", labels: []string{"--"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newOptions(tt.labels)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newOptions() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColumnIndex(t *testing.T) {
+	tests := []struct {
+		ref     string
+		want    int
+		wantErr bool
+	}{
+		{ref: "A1", want: 0},
+		{ref: "Z9", want: 25},
+		{ref: "AA10", want: 26},
+		{ref: "AB", want: 27},
+		{ref: "12", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got, err := columnIndex(tt.ref)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("columnIndex(%q) error = %v, wantErr %v", tt.ref, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("columnIndex(%q) = %d, want %d", tt.ref, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadRows(t *testing.T) {
+	workbook := xlsxFile(t, map[string]string{
+		"xl/workbook.xml": `<workbook xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+			<sheets>
+				<sheet name="Cover" r:id="rId1"/>
+				<sheet name="Questions" r:id="rId2"/>
+			</sheets>
+		</workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships>
+			<Relationship Id="rId1" Target="worksheets/sheet1.xml"/>
+			<Relationship Id="rId2" Target="/xl/worksheets/sheet2.xml"/>
+		</Relationships>`,
+		"xl/sharedStrings.xml": `<sst>
+			<si><t>control_id</t></si>
+			<si><r><t>question</t></r><r><t>_text</t></r></si>
+		</sst>`,
+		"xl/worksheets/sheet1.xml": `<worksheet><sheetData>
+			<row r="1"><c r="A1" t="inlineStr"><is><t>Cover page</t></is></c></row>
+		</sheetData></worksheet>`,
+		"xl/worksheets/sheet2.xml": `<worksheet><sheetData>
+			<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c></row>
+			<row r="3"><c r="A3"><v>1.1</v></c><c r="C3" t="b"><v>1</v></c></row>
+		</sheetData></worksheet>`,
+	})
+
+	tests := []struct {
+		name     string
+		fileName string
+		data     []byte
+		sheet    string
+		want     [][]string
+		wantErr  bool
+	}{
+		{
+			name:     "csv with byte order mark",
+			fileName: "checklist.CSV",
+			data:     []byte("\xef\xbb\xbfcontrol_id,question_text\nA.1,\"Is there a policy, signed?\"\nA.2\n"),
+			want:     [][]string{{"control_id", "question_text"}, {"A.1", "Is there a policy, signed?"}, {"A.2"}},
+		},
+		{
+			name:     "first xlsx sheet",
+			fileName: "checklist.xlsx",
+			data:     workbook,
+			want:     [][]string{{"Cover page"}},
+		},
+		{
+			name:     "named xlsx sheet keeps blank rows",
+			fileName: "checklist.xlsx",
+			data:     workbook,
+			sheet:    "questions",
+			want:     [][]string{{"control_id", "question_text"}, nil, {"1.1", "", "TRUE"}},
+		},
+		{name: "missing xlsx sheet", fileName: "checklist.xlsx", data: workbook, sheet: "Answers", wantErr: true},
+		{name: "not a zip", fileName: "checklist.xlsx", data: []byte("control_id"), wantErr: true},
+		{name: "unsupported type", fileName: "checklist.xls", data: []byte{}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadRows(tt.fileName, tt.data, tt.sheet)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadRows() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadRows() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// xlsxFile zips the given parts into an XLSX file
+func xlsxFile(t *testing.T, parts map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range parts {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
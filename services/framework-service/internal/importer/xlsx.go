@@ -0,0 +1,215 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// XLSX files are zip archives of SpreadsheetML parts. Only the parts needed to
+// read cell values are parsed: the workbook for sheet names, its relationships
+// to locate the sheet part, the shared strings table and the sheet itself.
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+		RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// xlsxText is a rich or plain text run, as used by shared and inline strings
+type xlsxText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+	var b strings.Builder
+	for _, r := range t.Runs {
+		b.WriteString(r.T)
+	}
+	return b.String()
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxText `xml:"si"`
+}
+
+type xlsxSheet struct {
+	Rows []struct {
+		R     int `xml:"r,attr"`
+		Cells []struct {
+			Ref    string    `xml:"r,attr"`
+			Type   string    `xml:"t,attr"`
+			Value  string    `xml:"v"`
+			Inline *xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// readXLSX returns the cell values of a worksheet, the first one when sheet is
+// empty. Rows are indexed from the top of the sheet, so blank rows are kept
+// and row numbers match what the user sees in Excel.
+func readXLSX(data []byte, sheet string) ([][]string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("not a valid XLSX file: %w", err)
+	}
+
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, f := range archive.File {
+		files[f.Name] = f
+	}
+
+	var workbook xlsxWorkbook
+	if err := decodeXLSXPart(files, "xl/workbook.xml", &workbook); err != nil {
+		return nil, err
+	}
+	if len(workbook.Sheets) == 0 {
+		return nil, fmt.Errorf("workbook has no sheets")
+	}
+
+	rid := workbook.Sheets[0].RID
+	if sheet != "" {
+		rid = ""
+		for _, s := range workbook.Sheets {
+			if strings.EqualFold(s.Name, sheet) {
+				rid = s.RID
+				break
+			}
+		}
+		if rid == "" {
+			return nil, fmt.Errorf("sheet %q not found", sheet)
+		}
+	}
+
+	var rels xlsxRelationships
+	if err := decodeXLSXPart(files, "xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return nil, err
+	}
+	var sheetPath string
+	for _, rel := range rels.Relationships {
+		if rel.ID == rid {
+			// Targets are relative to xl/ unless absolute within the package
+			if strings.HasPrefix(rel.Target, "/") {
+				sheetPath = strings.TrimPrefix(rel.Target, "/")
+			} else {
+				sheetPath = path.Join("xl", rel.Target)
+			}
+			break
+		}
+	}
+	if sheetPath == "" {
+		return nil, fmt.Errorf("worksheet part not found")
+	}
+
+	var shared xlsxSharedStrings
+	if _, ok := files["xl/sharedStrings.xml"]; ok {
+		if err := decodeXLSXPart(files, "xl/sharedStrings.xml", &shared); err != nil {
+			return nil, err
+		}
+	}
+
+	var ws xlsxSheet
+	if err := decodeXLSXPart(files, sheetPath, &ws); err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	for i, row := range ws.Rows {
+		rowNum := row.R
+		if rowNum == 0 {
+			rowNum = i + 1
+		}
+		for len(rows) < rowNum {
+			rows = append(rows, nil)
+		}
+
+		var values []string
+		for j, cell := range row.Cells {
+			col := j
+			if cell.Ref != "" {
+				if col, err = columnIndex(cell.Ref); err != nil {
+					return nil, err
+				}
+			}
+			for len(values) <= col {
+				values = append(values, "")
+			}
+
+			switch cell.Type {
+			case "s":
+				idx, err := strconv.Atoi(cell.Value)
+				if err != nil || idx < 0 || idx >= len(shared.Items) {
+					return nil, fmt.Errorf("cell %s refers to a missing shared string", cell.Ref)
+				}
+				values[col] = shared.Items[idx].String()
+			case "inlineStr":
+				if cell.Inline != nil {
+					values[col] = cell.Inline.String()
+				}
+			case "b":
+				values[col] = map[string]string{"1": "TRUE", "0": "FALSE"}[cell.Value]
+			default:
+				values[col] = cell.Value
+			}
+		}
+		rows[rowNum-1] = values
+	}
+
+	return rows, nil
+}
+
+// decodeXLSXPart unmarshals an XML part of the archive
+func decodeXLSXPart(files map[string]*zip.File, name string, v any) error {
+	f, ok := files[name]
+	if !ok {
+		return fmt.Errorf("not a valid XLSX file: missing %s", name)
+	}
+
+	r, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", name, err)
+	}
+	defer r.Close()
+
+	// Parts are small XML documents; cap them to guard against zip bombs
+	if err := xml.NewDecoder(io.LimitReader(r, maxPartSize)).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	return nil
+}
+
+// columnIndex converts a cell reference such as "AB12" to a zero-based column index
+func columnIndex(ref string) (int, error) {
+	col := 0
+	n := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		col = col*26 + int(r-'A'+1)
+		n++
+	}
+	if n == 0 {
+		return 0, fmt.Errorf("invalid cell reference %q", ref)
+	}
+	return col - 1, nil
+}
@@ -0,0 +1,471 @@
+package oscal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NormaTech-AI/audity/services/framework-service/internal/db"
+	"github.com/NormaTech-AI/audity/services/framework-service/internal/importer"
+	"github.com/google/uuid"
+)
+
+// defaultSection is the group of questions without a section title
+const defaultSection = "General"
+
+var paramInsertion = regexp.MustCompile(`\{\{\s*insert:\s*param,\s*([^}\s]+)\s*\}\}`)
+
+// answerParamClass marks the parameter holding the options of a question
+const answerParamClass = "answer-options"
+
+// NewCatalog exports a framework version as a catalog with one group per
+// section, nested groups for sub-sections and one control per question, in
+// checklist order. Published versions are immutable, so their catalogs keep
+// the version ID as document UUID.
+func NewCatalog(framework db.ComplianceFramework, version db.FrameworkVersion, questions []db.FrameworkQuestion) CatalogDocument {
+	documentID := uuid.New()
+	if version.PublishedAt.Valid {
+		documentID = version.ID
+	}
+
+	catalog := &Catalog{
+		UUID:     documentID.String(),
+		Metadata: newMetadata(framework, version, framework.Name),
+		Groups:   []Group{},
+	}
+
+	sections := make(map[string]int)
+	subsections := make(map[string]int)
+	for _, q := range questions {
+		section := defaultSection
+		if q.SectionTitle != nil && strings.TrimSpace(*q.SectionTitle) != "" {
+			section = strings.TrimSpace(*q.SectionTitle)
+		}
+
+		i, ok := sections[section]
+		if !ok {
+			i = len(catalog.Groups)
+			sections[section] = i
+			catalog.Groups = append(catalog.Groups, Group{
+				ID:    fmt.Sprintf("sec-%d", i+1),
+				Class: "section",
+				Title: section,
+			})
+		}
+		group := &catalog.Groups[i]
+
+		if q.SubsectionTitle != nil && strings.TrimSpace(*q.SubsectionTitle) != "" {
+			subsection := strings.TrimSpace(*q.SubsectionTitle)
+			key := section + "\x00" + subsection
+			j, ok := subsections[key]
+			if !ok {
+				j = len(group.Groups)
+				subsections[key] = j
+				group.Groups = append(group.Groups, Group{
+					ID:    fmt.Sprintf("%s-%d", group.ID, j+1),
+					Class: "subsection",
+					Title: subsection,
+				})
+			}
+			group = &group.Groups[j]
+		}
+
+		group.Controls = append(group.Controls, newControl(q))
+	}
+
+	return CatalogDocument{Catalog: catalog}
+}
+
+// NewProfile exports a framework version as a profile that selects every
+// control of its catalog, which is referenced through the back matter
+func NewProfile(framework db.ComplianceFramework, version db.FrameworkVersion, catalogHref string) ProfileDocument {
+	catalogResource := uuid.NewSHA1(version.ID, []byte("catalog"))
+
+	return ProfileDocument{Profile: &Profile{
+		UUID:     uuid.New().String(),
+		Metadata: newMetadata(framework, version, framework.Name+" profile"),
+		Imports: []Import{{
+			Href:       "#" + catalogResource.String(),
+			IncludeAll: &struct{}{},
+		}},
+		Merge: &Merge{AsIs: true},
+		BackMatter: &BackMatter{Resources: []Resource{{
+			UUID:   catalogResource.String(),
+			Title:  framework.Name + " " + version.Version + " catalog",
+			RLinks: []RLink{{Href: catalogHref, MediaType: MediaTypeCatalog}},
+		}}},
+	}}
+}
+
+func newMetadata(framework db.ComplianceFramework, version db.FrameworkVersion, title string) Metadata {
+	metadata := Metadata{
+		Title:        title,
+		LastModified: version.UpdatedAt.Time.UTC().Format(time.RFC3339),
+		Version:      version.Version,
+		OSCALVersion: Version,
+		Props: []Property{
+			{Name: "framework-id", Value: framework.ID.String(), NS: Namespace},
+			{Name: "framework-version-id", Value: version.ID.String(), NS: Namespace},
+			{Name: "framework-version-status", Value: version.Status, NS: Namespace},
+		},
+	}
+	if version.PublishedAt.Valid {
+		metadata.Published = version.PublishedAt.Time.UTC().Format(time.RFC3339)
+	}
+	if framework.Description != nil {
+		metadata.Remarks = *framework.Description
+	}
+	return metadata
+}
+
+func newControl(q db.FrameworkQuestion) Control {
+	id := ControlToken(q.ControlID)
+
+	control := Control{
+		ID:    id,
+		Title: q.ControlID,
+		Props: []Property{
+			{Name: "label", Value: q.ControlID},
+			{Name: "weight", Value: strconv.Itoa(int(q.Weight)), NS: Namespace},
+			{Name: "severity", Value: q.Severity, NS: Namespace},
+			{Name: "question-type", Value: q.QuestionType, NS: Namespace},
+			{Name: "mandatory", Value: strconv.FormatBool(q.IsMandatory), NS: Namespace},
+			{Name: "evidence-required", Value: strconv.FormatBool(q.EvidenceRequired), NS: Namespace},
+		},
+		Parts: []Part{{
+			ID:    id + "_smt",
+			Name:  PartStatement,
+			Prose: q.QuestionText,
+		}},
+	}
+
+	if param, ok := answerParam(id, q); ok {
+		control.Params = []Param{param}
+	}
+
+	if q.HelpText != nil && *q.HelpText != "" {
+		control.Parts = append(control.Parts, Part{
+			ID:    id + "_gdn",
+			Name:  PartGuidance,
+			Prose: *q.HelpText,
+		})
+	}
+
+	if len(q.AcceptableEvidence) > 0 {
+		method := Part{
+			ID:    id + "_asm-examine",
+			Name:  PartAssessmentMethod,
+			Props: []Property{{Name: "method", Value: "EXAMINE"}},
+		}
+		for _, item := range q.AcceptableEvidence {
+			method.Parts = append(method.Parts, Part{Name: PartAssessmentObjects, Prose: item})
+		}
+		control.Parts = append(control.Parts, method)
+	}
+
+	return control
+}
+
+// answerParam exports the options of a choice or table question as a
+// selection parameter. OSCAL choices are plain text, so the option values are
+// kept in option-value properties, one per choice in the same order.
+func answerParam(controlID string, q db.FrameworkQuestion) (Param, bool) {
+	var options []importer.Option
+	if len(q.Options) == 0 || json.Unmarshal(q.Options, &options) != nil || len(options) == 0 {
+		return Param{}, false
+	}
+
+	howMany := "one"
+	if q.QuestionType != importer.TypeSingleChoice {
+		howMany = "one-or-more"
+	}
+
+	param := Param{
+		ID:     controlID + "_prm_answer",
+		Class:  answerParamClass,
+		Label:  q.QuestionType,
+		Select: &Selection{HowMany: howMany},
+	}
+	for _, option := range options {
+		param.Select.Choice = append(param.Select.Choice, option.Label)
+		param.Props = append(param.Props, Property{Name: "option-value", Value: option.Value, NS: Namespace})
+	}
+	return param, true
+}
+
+// ParseCatalog reads an OSCAL catalog in JSON format
+func ParseCatalog(data []byte) (*Catalog, error) {
+	var doc struct {
+		CatalogDocument
+		Profile json.RawMessage `json:"profile"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid OSCAL JSON: %w", err)
+	}
+	if doc.Catalog == nil {
+		if doc.Profile != nil {
+			return nil, errors.New("profiles cannot be imported; resolve the profile into a catalog first")
+		}
+		return nil, errors.New("not an OSCAL catalog: missing catalog object")
+	}
+	if strings.TrimSpace(doc.Catalog.Metadata.Title) == "" {
+		return nil, errors.New("catalog metadata has no title")
+	}
+	return doc.Catalog, nil
+}
+
+// Questions flattens the controls of a catalog, including control
+// enhancements, into framework questions. Top-level groups become sections
+// and nested groups sub-sections, joined with " / " when nested deeper. The
+// control label is used as control ID when present, and parameter insertions
+// in prose are replaced by their labels. The answer type, options and
+// requirements written by NewCatalog are read back; other controls become
+// mandatory yes/no questions. Withdrawn controls are skipped.
+func (c *Catalog) Questions() ([]importer.Question, error) {
+	w := &catalogWalker{
+		params: make(map[string]string),
+		seen:   make(map[string]string),
+	}
+
+	w.addParams(c.Params)
+	w.controls(nil, nil, c.Controls)
+	for _, g := range c.Groups {
+		section := strings.TrimSpace(g.Title)
+		w.group(&section, "", g)
+	}
+
+	if len(w.errs) > 0 {
+		return nil, errors.Join(w.errs...)
+	}
+	if len(w.questions) == 0 {
+		return nil, errors.New("catalog has no controls")
+	}
+	return w.questions, nil
+}
+
+// catalogWalker collects questions in document order. Parameters are
+// collected as controls are walked, so a parameter defined on a group or
+// control is known to the prose nested in it.
+type catalogWalker struct {
+	params    map[string]string
+	seen      map[string]string
+	questions []importer.Question
+	errs      []error
+}
+
+// group walks a group of a section; subsection is empty for the section's own
+// group
+func (w *catalogWalker) group(section *string, subsection string, g Group) {
+	var sub *string
+	if subsection != "" {
+		sub = &subsection
+	}
+
+	w.addParams(g.Params)
+	w.controls(section, sub, g.Controls)
+	for _, child := range g.Groups {
+		title := strings.TrimSpace(child.Title)
+		if subsection != "" {
+			title = subsection + " / " + title
+		}
+		w.group(section, title, child)
+	}
+}
+
+func (w *catalogWalker) controls(section, subsection *string, controls []Control) {
+	for _, ctl := range controls {
+		if propValue(ctl.Props, "status") == "withdrawn" {
+			continue
+		}
+
+		w.addParams(ctl.Params)
+
+		controlID := propValue(ctl.Props, "label")
+		if controlID == "" {
+			controlID = ctl.ID
+		}
+
+		if first, ok := w.seen[controlID]; ok {
+			w.errs = append(w.errs, fmt.Errorf("control %s: label %q is already used by control %s", ctl.ID, controlID, first))
+			w.controls(section, subsection, ctl.Controls)
+			continue
+		}
+		w.seen[controlID] = ctl.ID
+
+		text := w.prose(findParts(ctl.Parts, PartStatement), true)
+		if text == "" {
+			text = strings.TrimSpace(ctl.Title)
+		}
+		if text == "" {
+			w.errs = append(w.errs, fmt.Errorf("control %s has neither a statement nor a title", ctl.ID))
+			w.controls(section, subsection, ctl.Controls)
+			continue
+		}
+
+		var help *string
+		if guidance := w.prose(findParts(ctl.Parts, PartGuidance), false); guidance != "" {
+			help = &guidance
+		}
+
+		var evidence []string
+		for _, method := range findParts(ctl.Parts, PartAssessmentMethod) {
+			for _, objects := range findParts(method.Parts, PartAssessmentObjects) {
+				for _, line := range strings.Split(w.expand(objects.Prose), "\n") {
+					if line = strings.TrimSpace(line); line != "" {
+						evidence = append(evidence, line)
+					}
+				}
+			}
+		}
+
+		question := importer.Question{
+			Row:                len(w.questions) + 1,
+			SectionTitle:       section,
+			SubsectionTitle:    subsection,
+			ControlID:          controlID,
+			QuestionText:       text,
+			HelpText:           help,
+			AcceptableEvidence: evidence,
+			Type:               importer.TypeYesNo,
+			Mandatory:          true,
+		}
+		if err := readAnswerFormat(&question, ctl); err != nil {
+			w.errs = append(w.errs, fmt.Errorf("control %s: %w", ctl.ID, err))
+		} else {
+			w.questions = append(w.questions, question)
+		}
+
+		w.controls(section, subsection, ctl.Controls)
+	}
+}
+
+// readAnswerFormat reads the answer type, options and requirements that
+// NewCatalog writes as namespaced properties and an answer parameter
+func readAnswerFormat(q *importer.Question, ctl Control) error {
+	if t := nsPropValue(ctl.Props, "question-type"); t != "" {
+		if !importer.ValidType(t) {
+			return fmt.Errorf("unsupported question type %q", t)
+		}
+		q.Type = t
+	}
+	if v := nsPropValue(ctl.Props, "mandatory"); v != "" {
+		mandatory, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid mandatory property %q", v)
+		}
+		q.Mandatory = mandatory
+	}
+	if v := nsPropValue(ctl.Props, "evidence-required"); v != "" {
+		required, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid evidence-required property %q", v)
+		}
+		q.EvidenceRequired = required
+	}
+
+	if !importer.TakesOptions(q.Type) {
+		return nil
+	}
+	for _, p := range ctl.Params {
+		if p.Class != answerParamClass || p.Select == nil {
+			continue
+		}
+		values := nsPropValues(p.Props, "option-value")
+		for i, label := range p.Select.Choice {
+			value := label
+			if i < len(values) {
+				value = values[i]
+			}
+			q.Options = append(q.Options, importer.Option{Value: value, Label: label})
+		}
+	}
+	if len(q.Options) == 0 {
+		return fmt.Errorf("%s question has no %s parameter", q.Type, answerParamClass)
+	}
+	return nil
+}
+
+// addParams records the text shown in place of each parameter: its label,
+// or its choices for a selection
+func (w *catalogWalker) addParams(params []Param) {
+	for _, p := range params {
+		switch {
+		case p.Select != nil && len(p.Select.Choice) > 0:
+			w.params[p.ID] = "[Selection: " + strings.Join(p.Select.Choice, "; ") + "]"
+		case p.Label != "":
+			w.params[p.ID] = "[Assignment: " + p.Label + "]"
+		}
+	}
+}
+
+// prose joins the prose of parts and their sub-parts, one per line, prefixing
+// sub-parts with their label when labelled is set
+func (w *catalogWalker) prose(parts []Part, labelled bool) string {
+	var lines []string
+	var walk func(parts []Part)
+	walk = func(parts []Part) {
+		for _, p := range parts {
+			if text := strings.TrimSpace(w.expand(p.Prose)); text != "" {
+				if label := propValue(p.Props, "label"); labelled && label != "" {
+					text = label + " " + text
+				}
+				lines = append(lines, text)
+			}
+			walk(p.Parts)
+		}
+	}
+	walk(parts)
+	return strings.Join(lines, "\n")
+}
+
+// expand replaces parameter insertions with an assignment placeholder
+func (w *catalogWalker) expand(prose string) string {
+	return paramInsertion.ReplaceAllStringFunc(prose, func(m string) string {
+		id := paramInsertion.FindStringSubmatch(m)[1]
+		if text, ok := w.params[id]; ok {
+			return text
+		}
+		return "[Assignment: organization-defined value]"
+	})
+}
+
+func findParts(parts []Part, name string) []Part {
+	var found []Part
+	for _, p := range parts {
+		if p.Name == name {
+			found = append(found, p)
+		}
+	}
+	return found
+}
+
+// nsPropValue returns a property written by this platform
+func nsPropValue(props []Property, name string) string {
+	if values := nsPropValues(props, name); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func nsPropValues(props []Property, name string) []string {
+	var values []string
+	for _, p := range props {
+		if p.Name == name && p.NS == Namespace {
+			values = append(values, p.Value)
+		}
+	}
+	return values
+}
+
+func propValue(props []Property, name string) string {
+	for _, p := range props {
+		if p.Name == name {
+			return p.Value
+		}
+	}
+	return ""
+}
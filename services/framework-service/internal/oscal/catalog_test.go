@@ -0,0 +1,219 @@
+package oscal
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/NormaTech-AI/audity/services/framework-service/internal/db"
+	"github.com/NormaTech-AI/audity/services/framework-service/internal/importer"
+	"github.com/google/uuid"
+)
+
+func strPtr(s string) *string { return &s }
+
+func TestCatalogRoundTrip(t *testing.T) {
+	questions := []db.FrameworkQuestion{
+		{
+			ControlID:          "1.1",
+			SectionTitle:       strPtr("Governance"),
+			QuestionText:       "Is there an approved information security policy?",
+			HelpText:           strPtr("Attach the signed policy"),
+			AcceptableEvidence: []string{"Policy document", "Board minutes"},
+			QuestionType:       importer.TypeYesNo,
+			IsMandatory:        true,
+			EvidenceRequired:   true,
+			Weight:             2,
+			Severity:           "high",
+		},
+		{
+			ControlID:       "1.2",
+			SectionTitle:    strPtr("Governance"),
+			SubsectionTitle: strPtr("Reviews"),
+			QuestionText:    "How often is the policy reviewed?",
+			QuestionType:    importer.TypeSingleChoice,
+			Options:         []byte(`[{"value":"yearly","label":"Every year"},{"value":"never","label":"Never"}]`),
+			Weight:          1,
+			Severity:        "low",
+		},
+		{
+			ControlID:    "A-7",
+			QuestionText: "Describe the backup schedule",
+			QuestionType: importer.TypeText,
+			IsMandatory:  true,
+			Weight:       1,
+			Severity:     "medium",
+		},
+	}
+
+	framework := db.ComplianceFramework{ID: uuid.New(), Name: "ISO 27001"}
+	version := db.FrameworkVersion{ID: uuid.New(), Version: "2022", Status: "published"}
+
+	data, err := json.Marshal(NewCatalog(framework, version, questions))
+	if err != nil {
+		t.Fatalf("failed to marshal catalog: %v", err)
+	}
+
+	catalog, err := ParseCatalog(data)
+	if err != nil {
+		t.Fatalf("ParseCatalog() error = %v", err)
+	}
+	got, err := catalog.Questions()
+	if err != nil {
+		t.Fatalf("Questions() error = %v", err)
+	}
+
+	want := []importer.Question{
+		{
+			Row:                1,
+			SectionTitle:       strPtr("Governance"),
+			ControlID:          "1.1",
+			QuestionText:       "Is there an approved information security policy?",
+			HelpText:           strPtr("Attach the signed policy"),
+			AcceptableEvidence: []string{"Policy document", "Board minutes"},
+			Type:               importer.TypeYesNo,
+			Mandatory:          true,
+			EvidenceRequired:   true,
+		},
+		{
+			Row:             2,
+			SectionTitle:    strPtr("Governance"),
+			SubsectionTitle: strPtr("Reviews"),
+			ControlID:       "1.2",
+			QuestionText:    "How often is the policy reviewed?",
+			Type:            importer.TypeSingleChoice,
+			Options:         []importer.Option{{Value: "yearly", Label: "Every year"}, {Value: "never", Label: "Never"}},
+		},
+		{
+			Row:          3,
+			SectionTitle: strPtr(defaultSection),
+			ControlID:    "A-7",
+			QuestionText: "Describe the backup schedule",
+			Type:         importer.TypeText,
+			Mandatory:    true,
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		gotJSON, _ := json.MarshalIndent(got, "", "  ")
+		wantJSON, _ := json.MarshalIndent(want, "", "  ")
+		t.Errorf("Questions() =\n%s\nwant\n%s", gotJSON, wantJSON)
+	}
+}
+
+func TestParseCatalog(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{name: "catalog", data: `{"catalog":{"uuid":"x","metadata":{"title":"NIST 800-53"}}}`},
+		{name: "invalid JSON", data: `{"catalog":`, wantErr: "invalid OSCAL JSON"},
+		{name: "profile", data: `{"profile":{"uuid":"x"}}`, wantErr: "profiles cannot be imported"},
+		{name: "other document", data: `{"component-definition":{}}`, wantErr: "missing catalog object"},
+		{name: "no title", data: `{"catalog":{"uuid":"x","metadata":{"title":" "}}}`, wantErr: "no title"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCatalog([]byte(tt.data))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ParseCatalog() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseCatalog() error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCatalogQuestionsFromThirdPartyCatalog(t *testing.T) {
+	catalog := &Catalog{
+		Metadata: Metadata{Title: "NIST 800-53"},
+		Groups: []Group{{
+			Title: "Access Control",
+			Controls: []Control{
+				{
+					ID:     "ac-1",
+					Title:  "Policy and Procedures",
+					Props:  []Property{{Name: "label", Value: "AC-1"}},
+					Params: []Param{{ID: "ac-01_odp.01", Label: "personnel or roles"}},
+					Parts: []Part{{
+						Name: PartStatement,
+						Parts: []Part{{
+							Props: []Property{{Name: "label", Value: "a."}},
+							Prose: "Develop a policy and disseminate it to {{ insert: param, ac-01_odp.01 }};",
+						}},
+					}},
+					Controls: []Control{{
+						ID:    "ac-1.1",
+						Title: "Enhancement",
+						Props: []Property{{Name: "label", Value: "AC-1(1)"}},
+					}},
+				},
+				{
+					ID:    "ac-2",
+					Title: "Withdrawn control",
+					Props: []Property{{Name: "status", Value: "withdrawn"}},
+				},
+			},
+		}},
+	}
+
+	got, err := catalog.Questions()
+	if err != nil {
+		t.Fatalf("Questions() error = %v", err)
+	}
+
+	var ids, texts []string
+	for _, q := range got {
+		ids = append(ids, q.ControlID)
+		texts = append(texts, q.QuestionText)
+	}
+	if want := []string{"AC-1", "AC-1(1)"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("control IDs = %v, want %v", ids, want)
+	}
+	wantTexts := []string{"a. Develop a policy and disseminate it to [Assignment: personnel or roles];", "Enhancement"}
+	if !reflect.DeepEqual(texts, wantTexts) {
+		t.Errorf("question texts = %q, want %q", texts, wantTexts)
+	}
+	if got[0].Type != importer.TypeYesNo || !got[0].Mandatory {
+		t.Errorf("type = %q mandatory = %v, want mandatory yes/no questions", got[0].Type, got[0].Mandatory)
+	}
+}
+
+func TestCatalogQuestionsDuplicateLabels(t *testing.T) {
+	catalog := &Catalog{
+		Metadata: Metadata{Title: "Duplicates"},
+		Controls: []Control{
+			{ID: "a", Title: "First", Props: []Property{{Name: "label", Value: "1"}}},
+			{ID: "b", Title: "Second", Props: []Property{{Name: "label", Value: "1"}}},
+		},
+	}
+
+	if _, err := catalog.Questions(); err == nil || !strings.Contains(err.Error(), "already used") {
+		t.Errorf("Questions() error = %v, want a duplicate label error", err)
+	}
+}
+
+func TestControlToken(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "AC-1", want: "ac-1"},
+		{in: "1.2.3", want: "ctl-1.2.3"},
+		{in: "A 5(1)", want: "a-5-1-"},
+		{in: "_x", want: "_x"},
+		{in: "  ", want: "_"},
+	}
+
+	for _, tt := range tests {
+		if got := ControlToken(tt.in); got != tt.want {
+			t.Errorf("ControlToken(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+// Package oscal converts frameworks to and from NIST OSCAL catalogs and
+// profiles. Only the JSON format is supported, and only the parts of the
+// models that have an equivalent in a framework are read or written.
+package oscal
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Version is the OSCAL version of the documents written by this package
+const Version = "1.1.2"
+
+// Namespace qualifies the properties specific to this platform
+const Namespace = "urn:audity:oscal"
+
+// Media types of the OSCAL JSON documents
+const (
+	MediaTypeCatalog = "application/oscal.catalog+json"
+	MediaTypeProfile = "application/oscal.profile+json"
+)
+
+// Part names used for the questions of a framework
+const (
+	PartStatement         = "statement"
+	PartGuidance          = "guidance"
+	PartAssessmentMethod  = "assessment-method"
+	PartAssessmentObjects = "assessment-objects"
+)
+
+// Metadata describes an OSCAL document
+type Metadata struct {
+	Title        string     `json:"title"`
+	Published    string     `json:"published,omitempty"`
+	LastModified string     `json:"last-modified"`
+	Version      string     `json:"version"`
+	OSCALVersion string     `json:"oscal-version"`
+	Props        []Property `json:"props,omitempty"`
+	Remarks      string     `json:"remarks,omitempty"`
+}
+
+// Property is a name/value pair attached to most OSCAL objects
+type Property struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	NS      string `json:"ns,omitempty"`
+	Class   string `json:"class,omitempty"`
+	Remarks string `json:"remarks,omitempty"`
+}
+
+// Link references another document or resource
+type Link struct {
+	Href      string `json:"href"`
+	Rel       string `json:"rel,omitempty"`
+	MediaType string `json:"media-type,omitempty"`
+	Text      string `json:"text,omitempty"`
+}
+
+// Param is a value left to the organization, inserted into prose
+type Param struct {
+	ID     string     `json:"id"`
+	Class  string     `json:"class,omitempty"`
+	Props  []Property `json:"props,omitempty"`
+	Label  string     `json:"label,omitempty"`
+	Select *Selection `json:"select,omitempty"`
+}
+
+// Selection lists the allowed values of a parameter
+type Selection struct {
+	HowMany string   `json:"how-many,omitempty"`
+	Choice  []string `json:"choice,omitempty"`
+}
+
+// Part is a textual section of a control or group
+type Part struct {
+	ID    string     `json:"id,omitempty"`
+	Name  string     `json:"name"`
+	NS    string     `json:"ns,omitempty"`
+	Class string     `json:"class,omitempty"`
+	Title string     `json:"title,omitempty"`
+	Props []Property `json:"props,omitempty"`
+	Prose string     `json:"prose,omitempty"`
+	Parts []Part     `json:"parts,omitempty"`
+	Links []Link     `json:"links,omitempty"`
+}
+
+// Control is a catalog control; controls may nest as control enhancements
+type Control struct {
+	ID       string     `json:"id"`
+	Class    string     `json:"class,omitempty"`
+	Title    string     `json:"title"`
+	Params   []Param    `json:"params,omitempty"`
+	Props    []Property `json:"props,omitempty"`
+	Links    []Link     `json:"links,omitempty"`
+	Parts    []Part     `json:"parts,omitempty"`
+	Controls []Control  `json:"controls,omitempty"`
+}
+
+// Group collects related controls, such as a framework section
+type Group struct {
+	ID       string     `json:"id,omitempty"`
+	Class    string     `json:"class,omitempty"`
+	Title    string     `json:"title"`
+	Params   []Param    `json:"params,omitempty"`
+	Props    []Property `json:"props,omitempty"`
+	Parts    []Part     `json:"parts,omitempty"`
+	Groups   []Group    `json:"groups,omitempty"`
+	Controls []Control  `json:"controls,omitempty"`
+}
+
+// Resource is a back-matter entry that other objects can reference by UUID
+type Resource struct {
+	UUID        string  `json:"uuid"`
+	Title       string  `json:"title,omitempty"`
+	Description string  `json:"description,omitempty"`
+	RLinks      []RLink `json:"rlinks,omitempty"`
+}
+
+// RLink locates a back-matter resource
+type RLink struct {
+	Href      string `json:"href"`
+	MediaType string `json:"media-type,omitempty"`
+}
+
+// BackMatter holds the resources referenced by a document
+type BackMatter struct {
+	Resources []Resource `json:"resources,omitempty"`
+}
+
+// Catalog is an OSCAL catalog of controls
+type Catalog struct {
+	UUID       string      `json:"uuid"`
+	Metadata   Metadata    `json:"metadata"`
+	Params     []Param     `json:"params,omitempty"`
+	Controls   []Control   `json:"controls,omitempty"`
+	Groups     []Group     `json:"groups,omitempty"`
+	BackMatter *BackMatter `json:"back-matter,omitempty"`
+}
+
+// CatalogDocument is the root of an OSCAL catalog JSON file
+type CatalogDocument struct {
+	Catalog *Catalog `json:"catalog"`
+}
+
+// Profile is an OSCAL profile selecting controls from a catalog
+type Profile struct {
+	UUID       string      `json:"uuid"`
+	Metadata   Metadata    `json:"metadata"`
+	Imports    []Import    `json:"imports"`
+	Merge      *Merge      `json:"merge,omitempty"`
+	BackMatter *BackMatter `json:"back-matter,omitempty"`
+}
+
+// Import selects the controls of a profile from a catalog or profile
+type Import struct {
+	Href       string    `json:"href"`
+	IncludeAll *struct{} `json:"include-all,omitempty"`
+}
+
+// Merge tells how imported controls are combined
+type Merge struct {
+	AsIs bool `json:"as-is,omitempty"`
+}
+
+// ProfileDocument is the root of an OSCAL profile JSON file
+type ProfileDocument struct {
+	Profile *Profile `json:"profile"`
+}
+
+// ControlToken turns a framework control ID into an OSCAL control ID, which
+// must start with a letter or underscore and may only contain letters,
+// digits, periods, hyphens and underscores. IDs that start with a digit are
+// prefixed with "ctl-". Exports keep the original control ID in a label
+// property.
+func ControlToken(s string) string {
+	var b strings.Builder
+	for _, r := range strings.TrimSpace(s) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '-' || r == '_':
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			b.WriteRune('-')
+		}
+	}
+
+	token := b.String()
+	if token == "" {
+		return "_"
+	}
+	if first := []rune(token)[0]; !unicode.IsLetter(first) && first != '_' {
+		token = "ctl-" + token
+	}
+	return token
+}
@@ -54,6 +54,12 @@ func SetupRoutes(e *echo.Echo, h *handler.Handler, cfg *config.Config, st *store
 			rbac.PermissionMiddleware(st, log, "frameworks:list"),
 		)
 
+		// Full-text search over the questions of every framework
+		frameworks.GET("/questions/search",
+			h.SearchQuestions,
+			rbac.PermissionMiddleware(st, log, "frameworks:list"),
+		)
+
 		frameworks.GET("/:id",
 			h.GetFramework,
 			rbac.PermissionMiddleware(st, log, "frameworks:read"),
@@ -63,5 +69,110 @@ func SetupRoutes(e *echo.Echo, h *handler.Handler, cfg *config.Config, st *store
 			h.GetFrameworkChecklist,
 			rbac.PermissionMiddleware(st, log, "frameworks:read"),
 		)
+
+		// Framework versions: drafts are edited and then published, after
+		// which they are immutable
+		frameworks.GET("/:id/versions",
+			h.ListFrameworkVersions,
+			rbac.PermissionMiddleware(st, log, "frameworks:read"),
+		)
+
+		frameworks.POST("/:id/versions",
+			h.CreateFrameworkVersion,
+			rbac.PermissionMiddleware(st, log, "frameworks:update"),
+		)
+
+		frameworks.GET("/:id/versions/:versionId",
+			h.GetFrameworkVersion,
+			rbac.PermissionMiddleware(st, log, "frameworks:read"),
+		)
+
+		frameworks.PUT("/:id/versions/:versionId",
+			h.UpdateFrameworkVersion,
+			rbac.PermissionMiddleware(st, log, "frameworks:update"),
+		)
+
+		frameworks.DELETE("/:id/versions/:versionId",
+			h.DeleteFrameworkVersion,
+			rbac.PermissionMiddleware(st, log, "frameworks:update"),
+		)
+
+		frameworks.POST("/:id/versions/:versionId/publish",
+			h.PublishFrameworkVersion,
+			rbac.PermissionMiddleware(st, log, "frameworks:publish"),
+		)
+
+		// Translations of a version's questions and sections; they can be
+		// corrected after the version is published
+		frameworks.GET("/:id/versions/:versionId/translations",
+			h.ListVersionTranslations,
+			rbac.PermissionMiddleware(st, log, "frameworks:read"),
+		)
+
+		frameworks.PUT("/:id/versions/:versionId/translations/:locale",
+			h.SaveVersionTranslation,
+			rbac.PermissionMiddleware(st, log, "frameworks:update"),
+		)
+
+		frameworks.DELETE("/:id/versions/:versionId/translations/:locale",
+			h.DeleteVersionTranslation,
+			rbac.PermissionMiddleware(st, log, "frameworks:update"),
+		)
+
+		// Diff two framework versions as JSON or CSV
+		frameworks.GET("/:id/diff",
+			h.DiffFrameworkVersions,
+			rbac.PermissionMiddleware(st, log, "frameworks:read"),
+		)
+
+		// Import checklists from CSV or XLSX, as a new framework or into the
+		// draft version of an existing one
+		frameworks.POST("/import",
+			h.ImportFramework,
+			rbac.PermissionMiddleware(st, log, "frameworks:create"),
+		)
+
+		frameworks.POST("/:id/import",
+			h.ImportFrameworkVersion,
+			rbac.PermissionMiddleware(st, log, "frameworks:update"),
+		)
+
+		// OSCAL interchange: export catalogs and profiles, import catalogs
+		frameworks.GET("/:id/oscal/catalog",
+			h.ExportFrameworkCatalog,
+			rbac.PermissionMiddleware(st, log, "frameworks:read"),
+		)
+
+		frameworks.GET("/:id/oscal/profile",
+			h.ExportFrameworkProfile,
+			rbac.PermissionMiddleware(st, log, "frameworks:read"),
+		)
+
+		frameworks.POST("/import/oscal",
+			h.ImportOSCALCatalog,
+			rbac.PermissionMiddleware(st, log, "frameworks:create"),
+		)
+
+		// Control mappings: controls of different frameworks covering the same
+		// requirement; equivalent controls share answers in tenant audits
+		frameworks.GET("/:id/mappings",
+			h.ListControlMappings,
+			rbac.PermissionMiddleware(st, log, "frameworks:read"),
+		)
+
+		frameworks.POST("/:id/mappings",
+			h.CreateControlMapping,
+			rbac.PermissionMiddleware(st, log, "frameworks:update"),
+		)
+
+		frameworks.PUT("/:id/mappings/:mappingId",
+			h.UpdateControlMapping,
+			rbac.PermissionMiddleware(st, log, "frameworks:update"),
+		)
+
+		frameworks.DELETE("/:id/mappings/:mappingId",
+			h.DeleteControlMapping,
+			rbac.PermissionMiddleware(st, log, "frameworks:update"),
+		)
 	}
 }
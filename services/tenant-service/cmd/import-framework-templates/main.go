@@ -0,0 +1,176 @@
+// Command import-framework-templates loads the framework template files that
+// tenant-service used to read from disk into framework-service, which is now
+// the only source of framework questions. Each template becomes a framework
+// with a published first version. Frameworks that already exist in
+// framework-service are left untouched, so the import can safely be re-run.
+//
+// Usage:
+//
+//	import-framework-templates -token <admin JWT> [-dir templates/frameworks] [-dry-run]
+//
+// The framework-service URL and client settings come from the tenant-service
+// configuration. The token must belong to a user with the frameworks:list and
+// frameworks:create permissions; it can also be given as AUDITY_FRAMEWORK_TOKEN.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/config"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/framework"
+	"go.uber.org/zap"
+)
+
+// template is the format of the framework template files
+type template struct {
+	FrameworkName string              `json:"framework_name"`
+	Version       string              `json:"version"`
+	Sections      []framework.Section `json:"sections"`
+}
+
+func main() {
+	dir := flag.String("dir", "./templates/frameworks", "directory holding the *-template.json files")
+	token := flag.String("token", os.Getenv("AUDITY_FRAMEWORK_TOKEN"), "bearer token for framework-service")
+	dryRun := flag.Bool("dry-run", false, "validate the templates without creating frameworks")
+	flag.Parse()
+
+	logger, err := zap.NewProduction()
+	if err != nil {
+		fmt.Printf("Failed to initialize zap logger: %v\n", err)
+		os.Exit(1)
+	}
+	defer logger.Sync()
+	log := logger.Sugar()
+
+	if *token == "" && !*dryRun {
+		log.Fatal("A framework-service token is required: pass -token or set AUDITY_FRAMEWORK_TOKEN")
+	}
+
+	cfg, err := config.LoadConfig(".")
+	if err != nil {
+		log.Fatalw("Failed to load config", "error", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(*dir, "*-template.json"))
+	if err != nil || len(files) == 0 {
+		log.Fatalw("No framework templates found", "dir", *dir, "error", err)
+	}
+
+	frameworks := make([]framework.NewFramework, 0, len(files))
+	for _, file := range files {
+		fw, err := loadTemplate(file)
+		if err != nil {
+			log.Fatalw("Invalid framework template", "file", file, "error", err)
+		}
+		frameworks = append(frameworks, fw)
+	}
+
+	if *dryRun {
+		for _, fw := range frameworks {
+			log.Infow("Template is valid", "framework", fw.Name, "version", fw.Version, "questions", len(fw.Questions))
+		}
+		return
+	}
+
+	ctx := context.Background()
+	client := framework.NewClient(cfg.Services.FrameworkBaseURL, framework.ClientOptions{
+		Timeout:    cfg.Services.FrameworkTimeout,
+		MaxRetries: cfg.Services.FrameworkMaxRetries,
+	})
+
+	existing, err := client.ListFrameworks(ctx, *token)
+	if err != nil {
+		log.Fatalw("Failed to list frameworks", "error", err)
+	}
+	names := make(map[string]bool, len(existing))
+	for _, fw := range existing {
+		names[strings.ToLower(fw.Name)] = true
+	}
+
+	imported := 0
+	for _, fw := range frameworks {
+		if names[strings.ToLower(fw.Name)] {
+			log.Infow("Framework already exists, skipping", "framework", fw.Name)
+			continue
+		}
+
+		created, err := client.CreateFramework(ctx, *token, fw)
+		if err != nil {
+			log.Fatalw("Failed to import framework", "framework", fw.Name, "error", err)
+		}
+		imported++
+
+		log.Infow("Framework imported", "framework", created.Name, "id", created.ID, "version", fw.Version, "questions", len(fw.Questions))
+	}
+
+	log.Infow("Framework templates imported", "imported", imported, "skipped", len(frameworks)-imported)
+}
+
+// loadTemplate reads a template file as a framework to create, keeping the
+// order, answer type, options and mandatory flag of its questions
+func loadTemplate(file string) (framework.NewFramework, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return framework.NewFramework{}, fmt.Errorf("failed to read template: %w", err)
+	}
+
+	var t template
+	if err := json.Unmarshal(data, &t); err != nil {
+		return framework.NewFramework{}, fmt.Errorf("failed to parse template: %w", err)
+	}
+	if t.FrameworkName == "" || t.Version == "" {
+		return framework.NewFramework{}, fmt.Errorf("template needs a framework_name and a version")
+	}
+
+	fw := framework.NewFramework{
+		Name:        t.FrameworkName,
+		Description: fmt.Sprintf("%s compliance checklist", t.FrameworkName),
+		Version:     t.Version,
+	}
+
+	for _, section := range t.Sections {
+		for _, q := range section.Questions {
+			question := framework.NewQuestion{
+				ControlID:            q.Number,
+				QuestionText:         q.Text,
+				VisibilityCondition:  q.VisibilityCondition,
+				Options:              q.Options,
+				IsMandatory:          &q.IsMandatory,
+				EvidenceRequired:     q.EvidenceRequired,
+				AcceptableEvidence:   q.AcceptableEvidence,
+				EvidenceRequirements: q.EvidenceRequirements,
+			}
+			if section.Name != "" {
+				question.SectionTitle = &section.Name
+			}
+			if q.Subsection != "" {
+				question.SubsectionTitle = &q.Subsection
+			}
+			if q.HelpText != "" {
+				question.HelpText = &q.HelpText
+			}
+			if q.Type != "" {
+				question.QuestionType = &q.Type
+			}
+			if q.Weight != 0 {
+				question.Weight = &q.Weight
+			}
+			if q.Severity != "" {
+				question.Severity = &q.Severity
+			}
+			fw.Questions = append(fw.Questions, question)
+		}
+	}
+
+	if len(fw.Questions) == 0 {
+		return framework.NewFramework{}, fmt.Errorf("template has no questions")
+	}
+
+	return fw, nil
+}
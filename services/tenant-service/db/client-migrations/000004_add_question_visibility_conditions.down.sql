@@ -0,0 +1,3 @@
+-- Drop conditional question support
+
+ALTER TABLE questions DROP COLUMN IF EXISTS visibility_condition;
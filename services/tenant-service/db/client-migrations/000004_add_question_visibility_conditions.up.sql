@@ -0,0 +1,18 @@
+-- Conditional (branching) questions
+-- A question with a visibility condition is only shown, and only counts
+-- towards completion, when the answer to an earlier question matches.
+
+-- ============================================
+-- COLUMNS
+-- ============================================
+
+-- Condition on a prior answer, e.g.
+-- {"depends_on": "1.1", "operator": "equals", "values": ["yes"]}
+-- depends_on references questions.question_number within the same audit
+ALTER TABLE questions ADD COLUMN visibility_condition JSONB;
+
+-- ============================================
+-- COMMENTS
+-- ============================================
+
+COMMENT ON COLUMN questions.visibility_condition IS 'Condition on a prior answer that controls whether the question is shown';
@@ -0,0 +1,6 @@
+-- Drop structured answer support
+-- Enum values cannot be removed from question_type_enum; questions using the
+-- new types fall back to text handling.
+
+ALTER TABLE submissions DROP COLUMN IF EXISTS answer_data;
+ALTER TABLE questions DROP COLUMN IF EXISTS options;
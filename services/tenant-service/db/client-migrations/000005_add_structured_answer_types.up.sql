@@ -0,0 +1,34 @@
+-- Multiple-choice and structured answer types
+-- Questions can offer option lists (single or multi select) or ask for a
+-- number, a date or a table of values. Structured answers are stored as JSON
+-- next to the existing answer_value/answer_text columns.
+
+-- ============================================
+-- ENUMS
+-- ============================================
+
+-- 'multiple_choice' is the multi-select variant
+ALTER TYPE question_type_enum ADD VALUE IF NOT EXISTS 'single_choice';
+ALTER TYPE question_type_enum ADD VALUE IF NOT EXISTS 'numeric';
+ALTER TYPE question_type_enum ADD VALUE IF NOT EXISTS 'date';
+ALTER TYPE question_type_enum ADD VALUE IF NOT EXISTS 'table';
+
+-- ============================================
+-- COLUMNS
+-- ============================================
+
+-- Choice options, or column definitions for table questions:
+-- [{"value": "daily", "label": "Daily"}, ...]
+ALTER TABLE questions ADD COLUMN options JSONB;
+
+-- Structured answer, shape depends on the question type:
+-- {"selected": ["daily"]}, {"number": 42}, {"date": "2025-03-31"},
+-- {"rows": [{"system": "ERP", "owner": "IT"}]}
+ALTER TABLE submissions ADD COLUMN answer_data JSONB;
+
+-- ============================================
+-- COMMENTS
+-- ============================================
+
+COMMENT ON COLUMN questions.options IS 'Choice options or table column definitions';
+COMMENT ON COLUMN submissions.answer_data IS 'Structured answer for choice, numeric, date and table questions';
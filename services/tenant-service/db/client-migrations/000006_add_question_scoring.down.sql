@@ -0,0 +1,5 @@
+-- Remove question weighting and severity
+ALTER TABLE questions DROP COLUMN IF EXISTS severity;
+ALTER TABLE questions DROP COLUMN IF EXISTS weight;
+
+DROP TYPE IF EXISTS question_severity_enum;
@@ -0,0 +1,24 @@
+-- Question weighting and severity for compliance scoring
+-- Approved "yes" answers earn the question weight, "na" answers are excluded
+-- and "no" answers earn nothing while counting against the score in
+-- proportion to the question severity.
+
+-- ============================================
+-- ENUMS
+-- ============================================
+
+CREATE TYPE question_severity_enum AS ENUM ('low', 'medium', 'high', 'critical');
+
+-- ============================================
+-- COLUMNS
+-- ============================================
+
+ALTER TABLE questions ADD COLUMN weight INTEGER NOT NULL DEFAULT 1 CHECK (weight > 0);
+ALTER TABLE questions ADD COLUMN severity question_severity_enum NOT NULL DEFAULT 'medium';
+
+-- ============================================
+-- COMMENTS
+-- ============================================
+
+COMMENT ON COLUMN questions.weight IS 'Relative importance of the question in the compliance score';
+COMMENT ON COLUMN questions.severity IS 'How heavily a non-compliant answer counts against the score';
@@ -0,0 +1,5 @@
+-- Drop findings and remediation tracking
+DROP TABLE IF EXISTS finding_evidence;
+DROP TABLE IF EXISTS findings;
+
+DROP TYPE IF EXISTS finding_status_enum;
@@ -0,0 +1,81 @@
+-- Findings and remediation (CAPA) tracking
+-- A finding records a control gap raised against a question, usually after a
+-- "no" answer or a rejected submission, together with the remediation plan and
+-- the evidence that closed it.
+
+-- ============================================
+-- ENUMS
+-- ============================================
+
+-- Finding lifecycle
+CREATE TYPE finding_status_enum AS ENUM (
+    'open',
+    'in_remediation',
+    'verified',
+    'closed',
+    'risk_accepted'
+);
+
+-- ============================================
+-- TABLES
+-- ============================================
+
+-- Findings raised during an audit
+CREATE TABLE findings (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    audit_id UUID NOT NULL REFERENCES audits(id) ON DELETE CASCADE,
+    question_id UUID NOT NULL REFERENCES questions(id) ON DELETE CASCADE,
+    submission_id UUID REFERENCES submissions(id) ON DELETE SET NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    severity question_severity_enum NOT NULL DEFAULT 'medium',
+    status finding_status_enum NOT NULL DEFAULT 'open',
+    owner_id UUID, -- Client user responsible for remediation
+    target_date DATE, -- Remediation due date
+    remediation_plan TEXT,
+    raised_by UUID NOT NULL, -- Auditor who raised the finding
+    verified_by UUID, -- Auditor who verified the remediation
+    verified_at TIMESTAMP WITH TIME ZONE,
+    closed_by UUID,
+    closed_at TIMESTAMP WITH TIME ZONE,
+    closure_notes TEXT, -- Closure summary or risk acceptance justification
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Evidence that a finding was remediated
+CREATE TABLE finding_evidence (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    finding_id UUID NOT NULL REFERENCES findings(id) ON DELETE CASCADE,
+    file_name VARCHAR(255) NOT NULL,
+    file_path VARCHAR(500) NOT NULL, -- MinIO object path
+    file_size BIGINT NOT NULL, -- Size in bytes
+    file_type VARCHAR(100),
+    uploaded_by UUID NOT NULL,
+    uploaded_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    description TEXT
+);
+
+-- ============================================
+-- INDEXES
+-- ============================================
+
+CREATE INDEX idx_findings_audit_id ON findings(audit_id);
+CREATE INDEX idx_findings_question_id ON findings(question_id);
+CREATE INDEX idx_findings_status ON findings(status);
+CREATE INDEX idx_findings_owner_id ON findings(owner_id);
+CREATE INDEX idx_finding_evidence_finding_id ON finding_evidence(finding_id);
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+
+CREATE TRIGGER update_findings_updated_at BEFORE UPDATE ON findings
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- ============================================
+-- COMMENTS
+-- ============================================
+
+COMMENT ON TABLE findings IS 'Control gaps raised during audits and their remediation';
+COMMENT ON TABLE finding_evidence IS 'Evidence files supporting finding closure';
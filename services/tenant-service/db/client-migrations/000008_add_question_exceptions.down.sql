@@ -0,0 +1,4 @@
+-- Drop risk acceptance and exception management
+DROP TABLE IF EXISTS question_exceptions;
+
+DROP TYPE IF EXISTS exception_status_enum;
@@ -0,0 +1,65 @@
+-- Risk acceptance and exception management
+-- A client POC may request an exception for a control that is legitimately not
+-- implemented. Once an auditor approves it with an expiry date the question
+-- counts as satisfied for report gating until the exception expires.
+
+-- ============================================
+-- ENUMS
+-- ============================================
+
+-- Exception lifecycle
+CREATE TYPE exception_status_enum AS ENUM (
+    'requested',
+    'approved',
+    'rejected',
+    'revoked'
+);
+
+-- ============================================
+-- TABLES
+-- ============================================
+
+-- Exceptions requested against audit questions
+CREATE TABLE question_exceptions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    audit_id UUID NOT NULL REFERENCES audits(id) ON DELETE CASCADE,
+    question_id UUID NOT NULL REFERENCES questions(id) ON DELETE CASCADE,
+    justification TEXT NOT NULL, -- Why the control is not implemented
+    compensating_controls TEXT NOT NULL, -- Controls that mitigate the accepted risk
+    status exception_status_enum NOT NULL DEFAULT 'requested',
+    requested_by UUID NOT NULL, -- Client POC who requested the exception
+    requested_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    reviewed_by UUID, -- Auditor who approved, rejected or revoked the exception
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    review_notes TEXT,
+    expires_at DATE, -- Set on approval
+    carried_forward_from UUID REFERENCES question_exceptions(id) ON DELETE SET NULL, -- Exception of a previous audit
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT question_exceptions_approved_expiry CHECK (status <> 'approved' OR expires_at IS NOT NULL)
+);
+
+-- ============================================
+-- INDEXES
+-- ============================================
+
+CREATE INDEX idx_question_exceptions_audit_id ON question_exceptions(audit_id);
+CREATE INDEX idx_question_exceptions_question_id ON question_exceptions(question_id);
+CREATE INDEX idx_question_exceptions_expires_at ON question_exceptions(expires_at) WHERE status = 'approved';
+
+-- At most one open or approved exception per question
+CREATE UNIQUE INDEX idx_question_exceptions_active ON question_exceptions(question_id)
+    WHERE status IN ('requested', 'approved');
+
+-- ============================================
+-- TRIGGERS
+-- ============================================
+
+CREATE TRIGGER update_question_exceptions_updated_at BEFORE UPDATE ON question_exceptions
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- ============================================
+-- COMMENTS
+-- ============================================
+
+COMMENT ON TABLE question_exceptions IS 'Risk acceptance exceptions for audit questions';
@@ -0,0 +1,5 @@
+-- Drop carried forward answers
+DROP INDEX IF EXISTS idx_submissions_carried_forward;
+
+ALTER TABLE submissions DROP COLUMN IF EXISTS is_carried_forward;
+ALTER TABLE submissions DROP COLUMN IF EXISTS carried_forward_from;
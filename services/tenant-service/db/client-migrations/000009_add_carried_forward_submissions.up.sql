@@ -0,0 +1,24 @@
+-- Carry forward answers from a previous audit cycle
+-- Answers and evidence of the client's last completed audit of a framework can
+-- be prefilled into a new audit. Prefilled answers are flagged until the client
+-- confirms or updates them, and cannot be submitted before that.
+
+-- ============================================
+-- COLUMNS
+-- ============================================
+
+ALTER TABLE submissions ADD COLUMN carried_forward_from UUID REFERENCES submissions(id) ON DELETE SET NULL;
+ALTER TABLE submissions ADD COLUMN is_carried_forward BOOLEAN NOT NULL DEFAULT false;
+
+-- ============================================
+-- INDEXES
+-- ============================================
+
+CREATE INDEX idx_submissions_carried_forward ON submissions(question_id) WHERE is_carried_forward = true;
+
+-- ============================================
+-- COMMENTS
+-- ============================================
+
+COMMENT ON COLUMN submissions.carried_forward_from IS 'Submission of a previous audit this answer was prefilled from';
+COMMENT ON COLUMN submissions.is_carried_forward IS 'Prefilled answer awaiting client confirmation';
@@ -0,0 +1,4 @@
+-- Drop audit cycle framework link
+DROP INDEX IF EXISTS idx_audits_audit_cycle_framework_id;
+
+ALTER TABLE audits DROP COLUMN IF EXISTS audit_cycle_framework_id;
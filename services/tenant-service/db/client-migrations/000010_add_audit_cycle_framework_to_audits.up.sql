@@ -0,0 +1,21 @@
+-- Link audits to their framework assignment in tenant_db
+-- Audits provisioned from an audit cycle assignment record the
+-- audit_cycle_frameworks row they belong to.
+
+-- ============================================
+-- COLUMNS
+-- ============================================
+
+ALTER TABLE audits ADD COLUMN audit_cycle_framework_id UUID;
+
+-- ============================================
+-- INDEXES
+-- ============================================
+
+CREATE UNIQUE INDEX idx_audits_audit_cycle_framework_id ON audits(audit_cycle_framework_id);
+
+-- ============================================
+-- COMMENTS
+-- ============================================
+
+COMMENT ON COLUMN audits.audit_cycle_framework_id IS 'Framework assignment in tenant_db this audit was provisioned for';
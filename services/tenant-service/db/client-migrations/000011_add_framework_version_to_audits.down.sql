@@ -0,0 +1,3 @@
+-- Drop framework version pin
+ALTER TABLE audits DROP COLUMN IF EXISTS framework_version;
+ALTER TABLE audits DROP COLUMN IF EXISTS framework_version_id;
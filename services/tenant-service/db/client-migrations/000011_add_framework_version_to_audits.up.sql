@@ -0,0 +1,17 @@
+-- Pin audits to a framework version
+-- Questions are copied from a published framework version, which never
+-- changes afterwards; the audit records which version that was.
+
+-- ============================================
+-- COLUMNS
+-- ============================================
+
+ALTER TABLE audits ADD COLUMN framework_version_id UUID;
+ALTER TABLE audits ADD COLUMN framework_version VARCHAR(50);
+
+-- ============================================
+-- COMMENTS
+-- ============================================
+
+COMMENT ON COLUMN audits.framework_version_id IS 'Published framework version the questions were copied from';
+COMMENT ON COLUMN audits.framework_version IS 'Label of the framework version';
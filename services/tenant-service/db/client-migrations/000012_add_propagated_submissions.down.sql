@@ -0,0 +1,4 @@
+-- Drop answers shared across mapped controls
+DROP INDEX IF EXISTS idx_submissions_propagated_from;
+
+ALTER TABLE submissions DROP COLUMN IF EXISTS propagated_from;
@@ -0,0 +1,23 @@
+-- Share answers across mapped controls
+-- Framework-service maps equivalent controls of different frameworks. When a
+-- client submits an answer to a mapped question, it is copied to the
+-- equivalent questions of the client's other open audits so it only has to
+-- be answered once. Each audit's auditor still reviews the copy.
+
+-- ============================================
+-- COLUMNS
+-- ============================================
+
+ALTER TABLE submissions ADD COLUMN propagated_from UUID REFERENCES submissions(id) ON DELETE SET NULL;
+
+-- ============================================
+-- INDEXES
+-- ============================================
+
+CREATE INDEX idx_submissions_propagated_from ON submissions(propagated_from) WHERE propagated_from IS NOT NULL;
+
+-- ============================================
+-- COMMENTS
+-- ============================================
+
+COMMENT ON COLUMN submissions.propagated_from IS 'Submission to an equivalent mapped question this answer was copied from';
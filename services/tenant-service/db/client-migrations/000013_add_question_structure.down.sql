@@ -0,0 +1,4 @@
+-- Remove question structure from questions
+ALTER TABLE questions DROP COLUMN IF EXISTS acceptable_evidence;
+ALTER TABLE questions DROP COLUMN IF EXISTS evidence_required;
+ALTER TABLE questions DROP COLUMN IF EXISTS subsection;
@@ -0,0 +1,20 @@
+-- Question structure from framework-service
+-- Framework questions can be grouped into sub-sections and can require an
+-- answer to be backed by uploaded evidence. Audits keep both, along with the
+-- evidence the framework accepts, so the checklist shows them to the client.
+
+-- ============================================
+-- COLUMNS
+-- ============================================
+
+ALTER TABLE questions ADD COLUMN subsection VARCHAR(255);
+ALTER TABLE questions ADD COLUMN evidence_required BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE questions ADD COLUMN acceptable_evidence TEXT[];
+
+-- ============================================
+-- COMMENTS
+-- ============================================
+
+COMMENT ON COLUMN questions.subsection IS 'Sub-section within the section';
+COMMENT ON COLUMN questions.evidence_required IS 'Whether an answer must be backed by uploaded evidence';
+COMMENT ON COLUMN questions.acceptable_evidence IS 'Kinds of evidence the framework accepts for the question';
@@ -0,0 +1,3 @@
+-- Remove structured evidence requirements
+ALTER TABLE evidence DROP COLUMN IF EXISTS category;
+ALTER TABLE questions DROP COLUMN IF EXISTS evidence_requirements;
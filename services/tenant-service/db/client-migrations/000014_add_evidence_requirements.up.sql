@@ -0,0 +1,18 @@
+-- Structured evidence requirements from framework-service
+-- Questions keep the evidence requirements of their framework version, and
+-- evidence files are filed under a document category, so answers can be
+-- checked against the requirements when they are submitted.
+
+-- ============================================
+-- COLUMNS
+-- ============================================
+
+ALTER TABLE questions ADD COLUMN evidence_requirements JSONB;
+ALTER TABLE evidence ADD COLUMN category VARCHAR(50);
+
+-- ============================================
+-- COMMENTS
+-- ============================================
+
+COMMENT ON COLUMN questions.evidence_requirements IS 'Minimum files, allowed file types and required document categories of the evidence';
+COMMENT ON COLUMN evidence.category IS 'Document category of the file, e.g. policy or screenshot';
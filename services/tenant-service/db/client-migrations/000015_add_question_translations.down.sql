@@ -0,0 +1,2 @@
+-- Remove question translations
+DROP TABLE IF EXISTS question_translations;
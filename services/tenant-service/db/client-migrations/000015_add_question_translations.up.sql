@@ -0,0 +1,25 @@
+-- Question translations from framework-service
+-- Questions keep the translations of their framework version so client users
+-- can answer in their preferred locale. The questions table keeps the
+-- canonical text, which reports always render.
+
+-- ============================================
+-- TABLES
+-- ============================================
+
+CREATE TABLE question_translations (
+    question_id UUID NOT NULL REFERENCES questions(id) ON DELETE CASCADE,
+    locale VARCHAR(35) NOT NULL,
+    question_text TEXT,
+    help_text TEXT,
+    section VARCHAR(255),
+    subsection VARCHAR(255),
+    PRIMARY KEY (question_id, locale)
+);
+
+-- ============================================
+-- COMMENTS
+-- ============================================
+
+COMMENT ON TABLE question_translations IS 'Translated text of questions by locale; NULL fields fall back to the canonical text';
+COMMENT ON COLUMN question_translations.locale IS 'BCP 47 language tag, e.g. hi or mr';
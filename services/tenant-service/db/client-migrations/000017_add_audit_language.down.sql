@@ -0,0 +1,2 @@
+-- Remove the canonical language of audits
+ALTER TABLE audits DROP COLUMN IF EXISTS language;
@@ -0,0 +1,15 @@
+-- Canonical language of audits
+-- Audits keep the canonical language of their framework, so questions are
+-- only served translated for locales the user prefers over it.
+
+-- ============================================
+-- COLUMNS
+-- ============================================
+
+ALTER TABLE audits ADD COLUMN language VARCHAR(35) NOT NULL DEFAULT 'en';
+
+-- ============================================
+-- COMMENTS
+-- ============================================
+
+COMMENT ON COLUMN audits.language IS 'Canonical language of the framework as a BCP 47 tag; question text is in it';
@@ -5,15 +5,23 @@ INSERT INTO audits (
     assigned_by,
     assigned_to,
     due_date,
-    status
+    status,
+    audit_cycle_framework_id,
+    framework_version_id,
+    framework_version,
+    language
 ) VALUES (
-    $1, $2, $3, $4, $5, $6
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
 ) RETURNING *;
 
 -- name: GetAuditByID :one
 SELECT * FROM audits
 WHERE id = $1;
 
+-- name: GetAuditByCycleFramework :one
+SELECT * FROM audits
+WHERE audit_cycle_framework_id = $1;
+
 -- name: ListAudits :many
 SELECT * FROM audits
 ORDER BY created_at DESC;
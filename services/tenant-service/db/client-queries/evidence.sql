@@ -6,15 +6,25 @@ INSERT INTO evidence (
     file_size,
     file_type,
     uploaded_by,
-    description
+    description,
+    category
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7
+    $1, $2, $3, $4, $5, $6, $7, $8
 ) RETURNING *;
 
 -- name: GetEvidenceByID :one
 SELECT * FROM evidence
 WHERE id = $1 AND is_deleted = false;
 
+-- name: ListEvidenceByAudit :many
+-- Lists the evidence of every answer in an audit, for exports
+SELECT e.*, s.question_id
+FROM evidence e
+JOIN submissions s ON s.id = e.submission_id
+JOIN questions q ON q.id = s.question_id
+WHERE q.audit_id = $1 AND e.is_deleted = false
+ORDER BY e.uploaded_at;
+
 -- name: ListEvidenceBySubmission :many
 SELECT * FROM evidence
 WHERE submission_id = $1 AND is_deleted = false
@@ -47,3 +57,70 @@ SELECT
     COUNT(DISTINCT submission_id) as submissions_with_evidence
 FROM evidence
 WHERE is_deleted = false;
+
+-- name: CarryForwardEvidence :execrows
+-- Links the evidence of the previous audit to the answers carried forward
+-- into an audit. Files are shared, not copied; evidence is only soft deleted.
+INSERT INTO evidence (
+    submission_id,
+    file_name,
+    file_path,
+    file_size,
+    file_type,
+    uploaded_by,
+    uploaded_at,
+    description,
+    category
+)
+SELECT
+    s.id,
+    e.file_name,
+    e.file_path,
+    e.file_size,
+    e.file_type,
+    e.uploaded_by,
+    e.uploaded_at,
+    e.description,
+    e.category
+FROM submissions s
+JOIN questions q ON q.id = s.question_id
+JOIN evidence e ON e.submission_id = s.carried_forward_from AND e.is_deleted = false
+WHERE q.audit_id = $1
+  AND s.is_carried_forward = true
+  AND NOT EXISTS (
+      SELECT 1 FROM evidence existing WHERE existing.submission_id = s.id
+  );
+
+-- name: PropagateEvidence :execrows
+-- Links the evidence of a submission to the answers propagated from it that
+-- are not approved yet. Files are shared, not copied, and files already
+-- linked are skipped.
+INSERT INTO evidence (
+    submission_id,
+    file_name,
+    file_path,
+    file_size,
+    file_type,
+    uploaded_by,
+    uploaded_at,
+    description,
+    category
+)
+SELECT
+    p.id,
+    e.file_name,
+    e.file_path,
+    e.file_size,
+    e.file_type,
+    e.uploaded_by,
+    e.uploaded_at,
+    e.description,
+    e.category
+FROM submissions p
+JOIN evidence e ON e.submission_id = p.propagated_from AND e.is_deleted = false
+WHERE p.propagated_from = $1
+  AND p.status <> 'approved'
+  AND NOT EXISTS (
+      SELECT 1 FROM evidence existing
+      WHERE existing.submission_id = p.id AND existing.file_path = e.file_path
+  );
@@ -0,0 +1,107 @@
+-- name: CreateQuestionException :one
+INSERT INTO question_exceptions (
+    audit_id,
+    question_id,
+    justification,
+    compensating_controls,
+    requested_by
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetQuestionExceptionByID :one
+SELECT * FROM question_exceptions
+WHERE id = $1;
+
+-- name: ListQuestionExceptionsByAudit :many
+SELECT * FROM question_exceptions
+WHERE audit_id = $1
+ORDER BY created_at DESC;
+
+-- name: ApproveQuestionException :one
+UPDATE question_exceptions
+SET
+    status = 'approved',
+    reviewed_by = $2,
+    reviewed_at = NOW(),
+    review_notes = $3,
+    expires_at = $4
+WHERE id = $1 AND status = 'requested'
+RETURNING *;
+
+-- name: RejectQuestionException :one
+UPDATE question_exceptions
+SET
+    status = 'rejected',
+    reviewed_by = $2,
+    reviewed_at = NOW(),
+    review_notes = $3
+WHERE id = $1 AND status = 'requested'
+RETURNING *;
+
+-- name: RevokeQuestionException :one
+UPDATE question_exceptions
+SET
+    status = 'revoked',
+    reviewed_by = $2,
+    reviewed_at = NOW(),
+    review_notes = $3
+WHERE id = $1 AND status = 'approved'
+RETURNING *;
+
+-- name: ListExpiringExceptions :many
+-- Approved exceptions that expire on or before the given date, including
+-- those that have already lapsed. Used for renewal reminders.
+SELECT
+    x.*,
+    q.section,
+    q.question_number,
+    q.question_text,
+    a.framework_id,
+    a.framework_name
+FROM question_exceptions x
+JOIN questions q ON q.id = x.question_id
+JOIN audits a ON a.id = x.audit_id
+WHERE x.status = 'approved' AND x.expires_at <= $1
+ORDER BY x.expires_at ASC;
+
+-- name: CarryForwardExceptions :execrows
+-- Copies approved, unexpired exceptions from earlier audits of the same
+-- framework onto the matching questions of a new audit.
+INSERT INTO question_exceptions (
+    audit_id,
+    question_id,
+    justification,
+    compensating_controls,
+    status,
+    requested_by,
+    requested_at,
+    reviewed_by,
+    reviewed_at,
+    review_notes,
+    expires_at,
+    carried_forward_from
+)
+SELECT DISTINCT ON (nq.id)
+    nq.audit_id,
+    nq.id,
+    x.justification,
+    x.compensating_controls,
+    x.status,
+    x.requested_by,
+    x.requested_at,
+    x.reviewed_by,
+    x.reviewed_at,
+    x.review_notes,
+    x.expires_at,
+    x.id
+FROM question_exceptions x
+JOIN audits a ON a.id = x.audit_id
+JOIN questions oq ON oq.id = x.question_id
+JOIN questions nq ON nq.audit_id = $1 AND nq.question_number = oq.question_number
+WHERE a.framework_id = $2
+  AND x.audit_id <> $1
+  AND x.status = 'approved'
+  AND x.expires_at >= CURRENT_DATE
+ORDER BY nq.id, x.expires_at DESC
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,120 @@
+-- name: CreateFinding :one
+INSERT INTO findings (
+    audit_id,
+    question_id,
+    submission_id,
+    title,
+    description,
+    severity,
+    owner_id,
+    target_date,
+    remediation_plan,
+    raised_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING *;
+
+-- name: GetFindingByID :one
+SELECT * FROM findings
+WHERE id = $1;
+
+-- name: ListFindingsByAudit :many
+SELECT * FROM findings
+WHERE audit_id = $1
+ORDER BY created_at DESC;
+
+-- name: ListUnresolvedFindingsByAudit :many
+-- Findings that are not closed, with the question they were raised against.
+-- Risk accepted findings are included as residual risk.
+SELECT
+    f.*,
+    q.section,
+    q.question_number
+FROM findings f
+JOIN questions q ON q.id = f.question_id
+WHERE f.audit_id = $1 AND f.status <> 'closed'
+ORDER BY q.display_order ASC, f.created_at ASC;
+
+-- name: UpdateFinding :one
+UPDATE findings
+SET
+    title = $2,
+    description = $3,
+    severity = $4,
+    owner_id = $5,
+    target_date = $6,
+    remediation_plan = $7
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateFindingRemediation :one
+UPDATE findings
+SET
+    remediation_plan = $2,
+    target_date = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateFindingStatus :one
+UPDATE findings
+SET status = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: VerifyFinding :one
+UPDATE findings
+SET
+    status = 'verified',
+    verified_by = $2,
+    verified_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: CloseFinding :one
+-- Moves a finding to a terminal state (closed or risk_accepted)
+UPDATE findings
+SET
+    status = $2,
+    closed_by = $3,
+    closed_at = NOW(),
+    closure_notes = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: ReopenFinding :one
+UPDATE findings
+SET
+    status = 'open',
+    verified_by = NULL,
+    verified_at = NULL,
+    closed_by = NULL,
+    closed_at = NULL,
+    closure_notes = NULL
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteFinding :exec
+DELETE FROM findings
+WHERE id = $1;
+
+-- name: CreateFindingEvidence :one
+INSERT INTO finding_evidence (
+    finding_id,
+    file_name,
+    file_path,
+    file_size,
+    file_type,
+    uploaded_by,
+    description
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: ListFindingEvidence :many
+SELECT * FROM finding_evidence
+WHERE finding_id = $1
+ORDER BY uploaded_at ASC;
+
+-- name: CountFindingEvidence :one
+SELECT COUNT(*) FROM finding_evidence
+WHERE finding_id = $1;
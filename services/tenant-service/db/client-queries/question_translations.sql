@@ -0,0 +1,24 @@
+-- name: CreateQuestionTranslation :exec
+INSERT INTO question_translations (
+    question_id,
+    locale,
+    question_text,
+    help_text,
+    section,
+    subsection
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+);
+
+-- name: ListQuestionTranslationsByAudit :many
+-- Translations of the questions of an audit into any of the given locales
+SELECT qt.* FROM question_translations qt
+JOIN questions q ON q.id = qt.question_id
+WHERE q.audit_id = @audit_id AND qt.locale = ANY(@locales::text[]);
+
+-- name: ListAuditLocales :many
+-- Locales the questions of an audit are translated into
+SELECT DISTINCT qt.locale FROM question_translations qt
+JOIN questions q ON q.id = qt.question_id
+WHERE q.audit_id = $1
+ORDER BY qt.locale;
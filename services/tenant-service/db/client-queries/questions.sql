@@ -7,9 +7,17 @@ INSERT INTO questions (
     question_type,
     help_text,
     is_mandatory,
-    display_order
+    display_order,
+    visibility_condition,
+    options,
+    weight,
+    severity,
+    subsection,
+    evidence_required,
+    acceptable_evidence,
+    evidence_requirements
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16
 ) RETURNING *;
 
 -- name: GetQuestionByID :one
@@ -48,9 +56,17 @@ INSERT INTO questions (
     question_type,
     help_text,
     is_mandatory,
-    display_order
+    display_order,
+    visibility_condition,
+    options,
+    weight,
+    severity,
+    subsection,
+    evidence_required,
+    acceptable_evidence,
+    evidence_requirements
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16
 );
 
 -- name: GetQuestionWithSubmission :one
@@ -59,6 +75,7 @@ SELECT
     s.id as submission_id,
     s.answer_value,
     s.answer_text,
+    s.answer_data,
     s.explanation,
     s.status as submission_status,
     s.submitted_at,
@@ -73,6 +90,9 @@ WHERE q.id = $1;
 SELECT 
     q.*,
     s.id as submission_id,
+    s.answer_value,
+    s.answer_text,
+    s.answer_data,
     s.status as submission_status,
     s.submitted_at
 FROM questions q
@@ -88,10 +108,12 @@ SELECT DISTINCT
     s.id as submission_id,
     s.answer_value,
     s.answer_text,
+    s.answer_data,
     s.explanation,
     s.status as submission_status,
     s.submitted_at,
     s.submitted_by,
+    s.is_carried_forward,
     qa.assigned_to as assigned_user_id
 FROM questions q
 LEFT JOIN submissions s ON s.question_id = q.id
@@ -3,9 +3,10 @@ INSERT INTO reports (
     audit_id,
     unsigned_file_path,
     generated_by,
-    status
+    status,
+    metadata
 ) VALUES (
-    $1, $2, $3, $4
+    $1, $2, $3, $4, $5
 ) RETURNING *;
 
 -- name: GetReportByID :one
@@ -53,3 +54,80 @@ ORDER BY r.generated_at DESC;
 -- name: DeleteReport :exec
 DELETE FROM reports
 WHERE id = $1;
+
+-- name: ListQuestionReadiness :many
+-- Latest submission state, live evidence count and active exception for every
+-- question in an audit.
+-- Used to decide whether the audit is ready for report generation and to
+-- compute its compliance score.
+SELECT
+    q.id,
+    q.section,
+    q.question_number,
+    q.question_text,
+    q.is_mandatory,
+    q.visibility_condition,
+    q.weight,
+    q.severity,
+    s.id as submission_id,
+    s.answer_value,
+    s.answer_data,
+    s.status as submission_status,
+    (
+        SELECT COUNT(*) FROM evidence e
+        WHERE e.submission_id = s.id AND e.is_deleted = false
+    ) as evidence_count,
+    EXISTS (
+        SELECT 1 FROM question_exceptions x
+        WHERE x.question_id = q.id
+          AND x.status = 'approved'
+          AND x.expires_at >= CURRENT_DATE
+    ) as has_active_exception
+FROM questions q
+LEFT JOIN LATERAL (
+    SELECT sub.id, sub.answer_value, sub.answer_data, sub.status
+    FROM submissions sub
+    WHERE sub.question_id = q.id
+    ORDER BY sub.version DESC
+    LIMIT 1
+) s ON true
+WHERE q.audit_id = $1
+ORDER BY q.display_order ASC;
+
+-- name: ListQuestionReadinessByAudits :many
+-- ListQuestionReadiness of several audits in one query, e.g. to score every
+-- audit of a list.
+SELECT
+    q.audit_id,
+    q.id,
+    q.section,
+    q.question_number,
+    q.question_text,
+    q.is_mandatory,
+    q.visibility_condition,
+    q.weight,
+    q.severity,
+    s.id as submission_id,
+    s.answer_value,
+    s.answer_data,
+    s.status as submission_status,
+    (
+        SELECT COUNT(*) FROM evidence e
+        WHERE e.submission_id = s.id AND e.is_deleted = false
+    ) as evidence_count,
+    EXISTS (
+        SELECT 1 FROM question_exceptions x
+        WHERE x.question_id = q.id
+          AND x.status = 'approved'
+          AND x.expires_at >= CURRENT_DATE
+    ) as has_active_exception
+FROM questions q
+LEFT JOIN LATERAL (
+    SELECT sub.id, sub.answer_value, sub.answer_data, sub.status
+    FROM submissions sub
+    WHERE sub.question_id = q.id
+    ORDER BY sub.version DESC
+    LIMIT 1
+) s ON true
+WHERE q.audit_id = ANY(sqlc.arg(audit_ids)::uuid[])
+ORDER BY q.audit_id, q.display_order ASC;
@@ -5,9 +5,10 @@ INSERT INTO submissions (
     answer_value,
     answer_text,
     explanation,
-    status
+    status,
+    answer_data
 ) VALUES (
-    $1, $2, $3, $4, $5, $6
+    $1, $2, $3, $4, $5, $6, $7
 ) RETURNING *;
 
 -- name: GetSubmissionByID :one
@@ -40,7 +41,9 @@ SET
     answer_value = $2,
     answer_text = $3,
     explanation = $4,
-    status = 'in_progress'
+    answer_data = $5,
+    status = 'in_progress',
+    is_carried_forward = false
 WHERE id = $1
 RETURNING *;
 
@@ -91,10 +94,12 @@ INSERT INTO submissions (
     answer_text,
     explanation,
     status,
-    version
+    version,
+    answer_data
 ) VALUES (
     $1, $2, $3, $4, $5, 'submitted',
-    (SELECT COALESCE(MAX(version), 0) + 1 FROM submissions WHERE question_id = $1)
+    (SELECT COALESCE(MAX(version), 0) + 1 FROM submissions WHERE question_id = $1),
+    $6
 )
 RETURNING *;
 
@@ -122,3 +127,106 @@ JOIN questions q ON q.id = s.question_id
 JOIN audits a ON a.id = q.audit_id
 WHERE s.status = 'submitted'
 ORDER BY s.submitted_at ASC;
+
+-- name: CarryForwardSubmissions :execrows
+-- Prefills the unanswered questions of an audit with the latest approved
+-- answers of the most recent completed audit of the same framework, matched
+-- by question number. Carried forward answers stay in progress until the
+-- client confirms or updates them.
+INSERT INTO submissions (
+    question_id,
+    submitted_by,
+    answer_value,
+    answer_text,
+    explanation,
+    status,
+    answer_data,
+    carried_forward_from,
+    is_carried_forward
+)
+SELECT
+    nq.id,
+    s.submitted_by,
+    s.answer_value,
+    s.answer_text,
+    s.explanation,
+    'in_progress',
+    s.answer_data,
+    s.id,
+    true
+FROM questions nq
+JOIN questions oq ON oq.question_number = nq.question_number
+    AND oq.audit_id = (
+        SELECT a.id FROM audits a
+        WHERE a.framework_id = $2 AND a.status = 'completed' AND a.id <> $1
+        ORDER BY a.completed_at DESC NULLS LAST
+        LIMIT 1
+    )
+JOIN LATERAL (
+    SELECT sub.id, sub.submitted_by, sub.answer_value, sub.answer_text, sub.explanation, sub.answer_data, sub.status
+    FROM submissions sub
+    WHERE sub.question_id = oq.id
+    ORDER BY sub.version DESC
+    LIMIT 1
+) s ON s.status = 'approved'
+WHERE nq.audit_id = $1
+  AND NOT EXISTS (
+      SELECT 1 FROM submissions existing WHERE existing.question_id = nq.id
+  );
+
+-- name: ConfirmCarriedForwardSubmission :one
+UPDATE submissions
+SET is_carried_forward = false
+WHERE id = $1 AND is_carried_forward = true
+RETURNING *;
+
+-- name: PropagateSubmission :execrows
+-- Copies a submitted answer to the questions of a mapped control in the
+-- client's other open audits of the mapped framework, as a new submitted
+-- version awaiting review. Only questions of the same type and options
+-- receive it, and answers given directly to those questions or already
+-- approved are never replaced.
+INSERT INTO submissions (
+    question_id,
+    submitted_by,
+    answer_value,
+    answer_text,
+    explanation,
+    status,
+    submitted_at,
+    version,
+    answer_data,
+    propagated_from
+)
+SELECT
+    tq.id,
+    s.submitted_by,
+    s.answer_value,
+    s.answer_text,
+    s.explanation,
+    'submitted',
+    NOW(),
+    COALESCE(latest.version, 0) + 1,
+    s.answer_data,
+    s.id
+FROM submissions s
+JOIN questions sq ON sq.id = s.question_id
+JOIN questions tq ON tq.question_type = sq.question_type
+    AND tq.options IS NOT DISTINCT FROM sq.options
+JOIN audits ta ON ta.id = tq.audit_id
+LEFT JOIN LATERAL (
+    SELECT sub.id, sub.version, sub.status, sub.propagated_from
+    FROM submissions sub
+    WHERE sub.question_id = tq.id
+    ORDER BY sub.created_at DESC, sub.version DESC
+    LIMIT 1
+) latest ON true
+WHERE s.id = @submission_id::uuid
+  AND ta.framework_id = @framework_id::uuid
+  AND tq.question_number = @control_id::text
+  AND ta.id <> sq.audit_id
+  AND ta.status <> 'completed'
+  AND (
+      latest.id IS NULL
+      OR (latest.propagated_from IS NOT NULL AND latest.propagated_from <> s.id AND latest.status <> 'approved')
+  );
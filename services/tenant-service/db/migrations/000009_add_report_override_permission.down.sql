@@ -0,0 +1,8 @@
+-- Remove role permissions for reports:override
+DELETE FROM role_permissions
+WHERE permission_id IN (
+    SELECT id FROM permissions WHERE name = 'reports:override'
+);
+
+-- Remove reports:override permission
+DELETE FROM permissions WHERE name = 'reports:override';
@@ -0,0 +1,16 @@
+-- Permission to generate a report while questions are still blocking readiness.
+-- Overrides require a justification that is stored in the report metadata.
+INSERT INTO permissions (name, resource, action, description) VALUES
+    ('reports:override', 'reports', 'override', 'Generate reports despite unresolved readiness blockers')
+ON CONFLICT (name) DO NOTHING;
+
+-- Assign override permission to nishaj_admin and auditor roles
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON p.name = 'reports:override'
+WHERE r.id IN (
+    '11111111-1111-1111-1111-111111111111',
+    '22222222-2222-2222-2222-222222222222'
+)
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,8 @@
+-- Remove role permissions for findings
+DELETE FROM role_permissions
+WHERE permission_id IN (
+    SELECT id FROM permissions WHERE resource = 'findings'
+);
+
+-- Remove findings permissions
+DELETE FROM permissions WHERE resource = 'findings';
@@ -0,0 +1,20 @@
+-- Permissions for raising and tracking audit findings.
+-- Client users work on findings through the client-audit routes, which are
+-- covered by the existing audit:read and audit:submit permissions.
+INSERT INTO permissions (name, resource, action, description) VALUES
+    ('findings:create', 'findings', 'create', 'Raise findings against audit questions'),
+    ('findings:read',   'findings', 'read',   'View findings and their closure evidence'),
+    ('findings:update', 'findings', 'update', 'Update findings and move them through remediation'),
+    ('findings:delete', 'findings', 'delete', 'Delete findings raised in error')
+ON CONFLICT (name) DO NOTHING;
+
+-- Assign findings permissions to nishaj_admin and auditor roles
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON p.resource = 'findings'
+WHERE r.id IN (
+    '11111111-1111-1111-1111-111111111111',
+    '22222222-2222-2222-2222-222222222222'
+)
+ON CONFLICT DO NOTHING;
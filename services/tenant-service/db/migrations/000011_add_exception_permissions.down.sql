@@ -0,0 +1,8 @@
+-- Remove role permissions for exceptions
+DELETE FROM role_permissions
+WHERE permission_id IN (
+    SELECT id FROM permissions WHERE resource = 'exceptions'
+);
+
+-- Remove exceptions permissions
+DELETE FROM permissions WHERE resource = 'exceptions';
@@ -0,0 +1,18 @@
+-- Permissions for reviewing risk acceptance exceptions.
+-- Client POCs request exceptions through the client-audit routes, which are
+-- covered by the existing audit:read and audit:submit permissions.
+INSERT INTO permissions (name, resource, action, description) VALUES
+    ('exceptions:read',    'exceptions', 'read',    'View exceptions and expiring risk acceptances'),
+    ('exceptions:approve', 'exceptions', 'approve', 'Approve, reject and revoke exceptions')
+ON CONFLICT (name) DO NOTHING;
+
+-- Assign exceptions permissions to nishaj_admin and auditor roles
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON p.resource = 'exceptions'
+WHERE r.id IN (
+    '11111111-1111-1111-1111-111111111111',
+    '22222222-2222-2222-2222-222222222222'
+)
+ON CONFLICT DO NOTHING;
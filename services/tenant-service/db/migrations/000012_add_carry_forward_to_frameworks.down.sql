@@ -0,0 +1,2 @@
+-- Remove carry_forward column from audit_cycle_frameworks table
+ALTER TABLE audit_cycle_frameworks DROP COLUMN IF EXISTS carry_forward;
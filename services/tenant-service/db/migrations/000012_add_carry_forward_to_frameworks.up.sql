@@ -0,0 +1,6 @@
+-- Add carry_forward column to audit_cycle_frameworks table
+ALTER TABLE audit_cycle_frameworks
+ADD COLUMN carry_forward BOOLEAN NOT NULL DEFAULT false;
+
+-- Add comment to explain the column
+COMMENT ON COLUMN audit_cycle_frameworks.carry_forward IS 'Prefill answers and evidence from the client''s previous completed audit of the framework';
@@ -0,0 +1,2 @@
+-- Remove client_audit_id column from audit_cycle_frameworks table
+ALTER TABLE audit_cycle_frameworks DROP COLUMN IF EXISTS client_audit_id;
@@ -0,0 +1,7 @@
+-- Add client_audit_id column to audit_cycle_frameworks table
+-- The audit lives in the client's own database, so there is no foreign key
+ALTER TABLE audit_cycle_frameworks
+ADD COLUMN client_audit_id UUID;
+
+-- Add comment to explain the column
+COMMENT ON COLUMN audit_cycle_frameworks.client_audit_id IS 'The audit provisioned in the client database for this assignment';
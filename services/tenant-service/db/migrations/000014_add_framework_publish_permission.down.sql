@@ -0,0 +1,8 @@
+-- Remove role permissions for frameworks:publish
+DELETE FROM role_permissions
+WHERE permission_id IN (
+    SELECT id FROM permissions WHERE name = 'frameworks:publish'
+);
+
+-- Remove frameworks:publish permission
+DELETE FROM permissions WHERE name = 'frameworks:publish';
@@ -0,0 +1,13 @@
+-- Permission to publish framework versions. Published versions are immutable
+-- and become the version new audits are created from.
+INSERT INTO permissions (name, resource, action, description) VALUES
+    ('frameworks:publish', 'frameworks', 'publish', 'Publish compliance framework versions')
+ON CONFLICT (name) DO NOTHING;
+
+-- Assign publish permission to nishaj_admin role
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON p.name = 'frameworks:publish'
+WHERE r.id = '11111111-1111-1111-1111-111111111111'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,4 @@
+-- Remove framework version columns from audit_cycle_frameworks table
+ALTER TABLE audit_cycle_frameworks
+DROP COLUMN IF EXISTS framework_version,
+DROP COLUMN IF EXISTS framework_version_id;
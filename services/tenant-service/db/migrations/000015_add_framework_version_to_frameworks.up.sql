@@ -0,0 +1,9 @@
+-- Pin each framework assignment to the published framework version its audit
+-- was created from. Versions live in framework-service, so there is no foreign key.
+ALTER TABLE audit_cycle_frameworks
+ADD COLUMN framework_version_id UUID,
+ADD COLUMN framework_version VARCHAR(50);
+
+-- Add comments to explain the columns
+COMMENT ON COLUMN audit_cycle_frameworks.framework_version_id IS 'The framework version the client audit was created from';
+COMMENT ON COLUMN audit_cycle_frameworks.framework_version IS 'Label of the pinned framework version';
@@ -0,0 +1,3 @@
+-- Remove preferred locale from users table
+ALTER TABLE users
+DROP COLUMN IF EXISTS preferred_locale;
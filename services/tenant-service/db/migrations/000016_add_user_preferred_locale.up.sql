@@ -0,0 +1,6 @@
+-- Users can prefer a locale other than the canonical language of frameworks.
+-- Questions are served in it when the framework has a translation.
+ALTER TABLE users
+ADD COLUMN preferred_locale VARCHAR(35);
+
+COMMENT ON COLUMN users.preferred_locale IS 'BCP 47 language tag questions are served in, e.g. hi or mr; NULL for the canonical language';
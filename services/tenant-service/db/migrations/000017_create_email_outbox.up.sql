@@ -0,0 +1,28 @@
+-- Outbox of emails waiting to be sent. Handlers only insert here; a background
+-- dispatcher delivers them through the configured mail provider and retries
+-- with backoff, so emails survive provider outages and restarts.
+CREATE TABLE email_outbox (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    recipients TEXT[] NOT NULL,
+    subject TEXT NOT NULL,
+    html_body TEXT,
+    text_body TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sending', 'sent', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL DEFAULT 10,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    locked_until TIMESTAMP WITH TIME ZONE,
+    last_error TEXT,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT email_outbox_has_body CHECK (html_body IS NOT NULL OR text_body IS NOT NULL)
+);
+
+CREATE INDEX idx_email_outbox_due ON email_outbox(next_attempt_at) WHERE status IN ('pending', 'sending');
+CREATE INDEX idx_email_outbox_status ON email_outbox(status);
+
+CREATE TRIGGER update_email_outbox_updated_at BEFORE UPDATE ON email_outbox
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON COLUMN email_outbox.locked_until IS 'Lease of the dispatcher sending the email; expired leases of crashed dispatchers are picked up again';
@@ -0,0 +1,8 @@
+-- Remove email templates support
+DROP TABLE IF EXISTS due_date_reminders;
+DROP TRIGGER IF EXISTS update_client_branding_updated_at ON client_branding;
+DROP TABLE IF EXISTS client_branding;
+
+ALTER TABLE email_outbox
+DROP COLUMN IF EXISTS template_version,
+DROP COLUMN IF EXISTS template;
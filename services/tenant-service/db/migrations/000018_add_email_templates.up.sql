@@ -0,0 +1,28 @@
+-- Template and template version an outbox email was rendered from
+ALTER TABLE email_outbox
+ADD COLUMN template VARCHAR(100),
+ADD COLUMN template_version VARCHAR(20);
+
+-- Branding of the emails sent on behalf of a client
+CREATE TABLE client_branding (
+    client_id UUID PRIMARY KEY REFERENCES clients(id) ON DELETE CASCADE,
+    display_name VARCHAR(255),
+    logo_url TEXT,
+    primary_color VARCHAR(7) CHECK (primary_color ~ '^#([0-9a-fA-F]{3}){1,2}$'),
+    footer_text TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER update_client_branding_updated_at BEFORE UPDATE ON client_branding
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Due date reminders already sent, so each framework assignment gets every
+-- reminder once per due date
+CREATE TABLE due_date_reminders (
+    audit_cycle_framework_id UUID NOT NULL REFERENCES audit_cycle_frameworks(id) ON DELETE CASCADE,
+    due_date DATE NOT NULL,
+    days_before INTEGER NOT NULL,
+    sent_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (audit_cycle_framework_id, due_date, days_before)
+);
@@ -0,0 +1,2 @@
+-- Drop event outbox
+DROP TABLE IF EXISTS event_outbox;
@@ -0,0 +1,20 @@
+-- Outbox of domain events. Events are inserted in the transaction of the
+-- change they describe; the event relay of tenant-service publishes them to
+-- RabbitMQ and retries until the broker confirms them.
+CREATE TABLE event_outbox (
+    id UUID PRIMARY KEY,
+    event_type VARCHAR(100) NOT NULL,
+    envelope JSONB NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    locked_until TIMESTAMP WITH TIME ZONE,
+    last_error TEXT,
+    published_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_event_outbox_due ON event_outbox(next_attempt_at) WHERE published_at IS NULL;
+CREATE INDEX idx_event_outbox_published_at ON event_outbox(published_at) WHERE published_at IS NOT NULL;
+
+COMMENT ON COLUMN event_outbox.envelope IS 'Event envelope as published, see packages/go/eventbus';
+COMMENT ON COLUMN event_outbox.locked_until IS 'Lease of the relay publishing the event; expired leases of crashed relays are picked up again';
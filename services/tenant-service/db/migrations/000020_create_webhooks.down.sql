@@ -0,0 +1,6 @@
+-- Drop webhooks
+DROP TABLE IF EXISTS webhook_delivery_attempts;
+DROP TRIGGER IF EXISTS update_webhook_deliveries_updated_at ON webhook_deliveries;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TRIGGER IF EXISTS update_webhook_subscriptions_updated_at ON webhook_subscriptions;
+DROP TABLE IF EXISTS webhook_subscriptions;
@@ -0,0 +1,72 @@
+-- Webhook subscriptions of clients. Events of the subscribed types are
+-- delivered to the URL as signed HTTP POST requests.
+CREATE TABLE webhook_subscriptions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    client_id UUID NOT NULL REFERENCES clients(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    description TEXT,
+    encrypted_secret TEXT NOT NULL,
+    event_types TEXT[] NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT webhook_subscriptions_has_event_types CHECK (cardinality(event_types) > 0)
+);
+
+CREATE INDEX idx_webhook_subscriptions_client_id ON webhook_subscriptions(client_id);
+
+CREATE TRIGGER update_webhook_subscriptions_updated_at BEFORE UPDATE ON webhook_subscriptions
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON COLUMN webhook_subscriptions.encrypted_secret IS 'HMAC-SHA256 signing secret, encrypted with the tenant-service encryption key';
+
+-- Deliveries of events to webhook subscriptions. The webhook dispatcher sends
+-- them and retries with backoff until they succeed or run out of attempts.
+CREATE TABLE webhook_deliveries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    subscription_id UUID NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sending', 'succeeded', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL DEFAULT 8,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    locked_until TIMESTAMP WITH TIME ZONE,
+    last_status_code INTEGER,
+    last_error TEXT,
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    redelivery_of UUID REFERENCES webhook_deliveries(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Events are consumed at least once; an event is delivered once per
+-- subscription unless redelivered on request
+CREATE UNIQUE INDEX idx_webhook_deliveries_event ON webhook_deliveries(subscription_id, event_id) WHERE redelivery_of IS NULL;
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status IN ('pending', 'sending');
+CREATE INDEX idx_webhook_deliveries_subscription ON webhook_deliveries(subscription_id, created_at DESC);
+
+CREATE TRIGGER update_webhook_deliveries_updated_at BEFORE UPDATE ON webhook_deliveries
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON COLUMN webhook_deliveries.payload IS 'Request body, the event envelope';
+COMMENT ON COLUMN webhook_deliveries.locked_until IS 'Lease of the dispatcher sending the delivery; expired leases of crashed dispatchers are picked up again';
+COMMENT ON COLUMN webhook_deliveries.redelivery_of IS 'Delivery this one was manually redelivered from';
+
+-- Log of the HTTP requests of webhook deliveries
+CREATE TABLE webhook_delivery_attempts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    delivery_id UUID NOT NULL REFERENCES webhook_deliveries(id) ON DELETE CASCADE,
+    attempt INTEGER NOT NULL,
+    status_code INTEGER,
+    response_body TEXT,
+    error TEXT,
+    duration_ms INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_webhook_delivery_attempts_delivery ON webhook_delivery_attempts(delivery_id, attempt);
+
+COMMENT ON COLUMN webhook_delivery_attempts.response_body IS 'Start of the response body';
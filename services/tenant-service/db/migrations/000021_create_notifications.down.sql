@@ -0,0 +1,4 @@
+-- Drop notifications
+DROP TRIGGER IF EXISTS update_notification_preferences_updated_at ON notification_preferences;
+DROP TABLE IF EXISTS notification_preferences;
+DROP TABLE IF EXISTS notifications;
@@ -0,0 +1,30 @@
+-- In-app notifications of users, listed in their notification inbox
+CREATE TABLE notifications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    client_id UUID REFERENCES clients(id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL CHECK (type IN ('assignment', 'review', 'mention', 'referral', 'due_date')),
+    title TEXT NOT NULL,
+    body TEXT NOT NULL,
+    link TEXT,
+    read_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_notifications_user_created ON notifications(user_id, created_at DESC);
+CREATE INDEX idx_notifications_user_unread ON notifications(user_id) WHERE read_at IS NULL;
+
+COMMENT ON COLUMN notifications.link IS 'Path of the frontend page the notification is about, e.g. /audit/<id>';
+
+-- Channels users receive each type of notification on. Types without a row
+-- go to both the inbox and email.
+CREATE TABLE notification_preferences (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL CHECK (type IN ('assignment', 'review', 'mention', 'referral', 'due_date')),
+    channel VARCHAR(20) NOT NULL CHECK (channel IN ('inbox', 'email', 'both')),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, type)
+);
+
+CREATE TRIGGER update_notification_preferences_updated_at BEFORE UPDATE ON notification_preferences
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
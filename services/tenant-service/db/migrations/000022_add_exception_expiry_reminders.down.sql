@@ -0,0 +1,12 @@
+-- Remove exception expiry reminders
+DELETE FROM notification_preferences WHERE type = 'exception_expiry';
+ALTER TABLE notification_preferences DROP CONSTRAINT notification_preferences_type_check;
+ALTER TABLE notification_preferences ADD CONSTRAINT notification_preferences_type_check
+    CHECK (type IN ('assignment', 'review', 'mention', 'referral', 'due_date'));
+
+DELETE FROM notifications WHERE type = 'exception_expiry';
+ALTER TABLE notifications DROP CONSTRAINT notifications_type_check;
+ALTER TABLE notifications ADD CONSTRAINT notifications_type_check
+    CHECK (type IN ('assignment', 'review', 'mention', 'referral', 'due_date'));
+
+DROP TABLE IF EXISTS exception_expiry_reminders;
@@ -0,0 +1,20 @@
+-- Expiry reminders already sent for approved exceptions, so each exception
+-- gets every reminder once per expiry date. Exceptions live in the client
+-- databases, hence no foreign key.
+CREATE TABLE exception_expiry_reminders (
+    client_id UUID NOT NULL REFERENCES clients(id) ON DELETE CASCADE,
+    exception_id UUID NOT NULL,
+    expires_at DATE NOT NULL,
+    days_before INTEGER NOT NULL,
+    sent_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (client_id, exception_id, expires_at, days_before)
+);
+
+-- Expiring exceptions are a notification type of their own
+ALTER TABLE notifications DROP CONSTRAINT notifications_type_check;
+ALTER TABLE notifications ADD CONSTRAINT notifications_type_check
+    CHECK (type IN ('assignment', 'review', 'mention', 'referral', 'due_date', 'exception_expiry'));
+
+ALTER TABLE notification_preferences DROP CONSTRAINT notification_preferences_type_check;
+ALTER TABLE notification_preferences ADD CONSTRAINT notification_preferences_type_check
+    CHECK (type IN ('assignment', 'review', 'mention', 'referral', 'due_date', 'exception_expiry'));
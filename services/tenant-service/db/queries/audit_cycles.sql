@@ -35,6 +35,14 @@ INSERT INTO audit_cycle_clients (audit_cycle_id, client_id)
 VALUES ($1, $2)
 RETURNING *;
 
+-- name: EnsureClientInAuditCycle :one
+-- Adds a client to an audit cycle, or returns the existing membership
+INSERT INTO audit_cycle_clients (audit_cycle_id, client_id)
+VALUES ($1, $2)
+ON CONFLICT (audit_cycle_id, client_id) DO UPDATE
+SET client_id = EXCLUDED.client_id
+RETURNING *;
+
 -- name: RemoveClientFromAuditCycle :exec
 DELETE FROM audit_cycle_clients
 WHERE audit_cycle_id = $1 AND client_id = $2;
@@ -61,11 +69,31 @@ INSERT INTO audit_cycle_frameworks (
     assigned_by,
     due_date,
     status,
-    auditor_id
+    auditor_id,
+    carry_forward
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 RETURNING *;
 
+-- name: EnsureFrameworkAssignedToAuditCycleClient :one
+-- Assigns a framework to a client in a cycle, or returns the existing
+-- assignment unchanged so retries never alter a provisioned audit. inserted
+-- tells whether this call created the assignment.
+INSERT INTO audit_cycle_frameworks (
+    audit_cycle_client_id,
+    framework_id,
+    framework_name,
+    assigned_by,
+    due_date,
+    status,
+    auditor_id,
+    carry_forward
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT ON CONSTRAINT unique_framework_per_client_cycle DO UPDATE
+SET framework_id = EXCLUDED.framework_id
+RETURNING *, (xmax = 0)::bool AS inserted;
+
 -- name: GetAuditCycleFrameworks :many
 SELECT 
     acf.id,
@@ -77,6 +105,10 @@ SELECT
     acf.due_date,
     acf.status,
     acf.auditor_id,
+    acf.carry_forward,
+    acf.client_audit_id,
+    acf.framework_version_id,
+    acf.framework_version,
     acf.created_at,
     acf.updated_at,
     acc.client_id,
@@ -98,6 +130,10 @@ SELECT
     acf.due_date,
     acf.status,
     acf.auditor_id,
+    acf.carry_forward,
+    acf.client_audit_id,
+    acf.framework_version_id,
+    acf.framework_version,
     acf.created_at,
     acf.updated_at
 FROM audit_cycle_frameworks acf
@@ -110,6 +146,17 @@ SET status = $2
 WHERE id = $1
 RETURNING *;
 
+-- name: GetAuditCycleClient :one
+SELECT * FROM audit_cycle_clients
+WHERE id = $1 LIMIT 1;
+
+-- name: LinkAuditCycleFrameworkClientAudit :one
+-- Records the audit provisioned in the client database for an assignment
+UPDATE audit_cycle_frameworks
+SET client_audit_id = $2, framework_version_id = $3, framework_version = $4
+WHERE id = $1
+RETURNING *;
+
 -- name: DeleteAuditCycleFramework :exec
 DELETE FROM audit_cycle_frameworks
 WHERE id = $1;
@@ -0,0 +1,13 @@
+-- name: GetClientBranding :one
+SELECT * FROM client_branding
+WHERE client_id = $1;
+
+-- name: UpsertClientBranding :one
+INSERT INTO client_branding (client_id, display_name, logo_url, primary_color, footer_text)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (client_id) DO UPDATE
+SET display_name = EXCLUDED.display_name,
+    logo_url = EXCLUDED.logo_url,
+    primary_color = EXCLUDED.primary_color,
+    footer_text = EXCLUDED.footer_text
+RETURNING *;
@@ -0,0 +1,33 @@
+-- name: ListFrameworksDueForReminder :many
+-- Provisioned framework assignments of active cycles due within the given
+-- number of days, falling back to the end of the cycle, that have not been
+-- reminded of their current due date in this or a closer window yet
+SELECT
+    acf.id,
+    acf.framework_name,
+    acf.client_audit_id,
+    acc.client_id,
+    c.name AS client_name,
+    c.poc_email,
+    ac.name AS audit_cycle_name,
+    COALESCE(acf.due_date, ac.end_date)::date AS due_date
+FROM audit_cycle_frameworks acf
+JOIN audit_cycle_clients acc ON acf.audit_cycle_client_id = acc.id
+JOIN audit_cycles ac ON acc.audit_cycle_id = ac.id
+JOIN clients c ON acc.client_id = c.id
+WHERE ac.status = 'active'
+  AND COALESCE(acf.status, 'pending') <> 'completed'
+  AND acf.client_audit_id IS NOT NULL
+  AND COALESCE(acf.due_date, ac.end_date) BETWEEN CURRENT_DATE AND CURRENT_DATE + sqlc.arg(days_before)::int
+  AND NOT EXISTS (
+      SELECT 1 FROM due_date_reminders r
+      WHERE r.audit_cycle_framework_id = acf.id
+        AND r.due_date = COALESCE(acf.due_date, ac.end_date)
+        AND r.days_before <= sqlc.arg(days_before)::int
+  )
+ORDER BY due_date, acf.id;
+
+-- name: CreateDueDateReminder :execrows
+INSERT INTO due_date_reminders (audit_cycle_framework_id, due_date, days_before)
+VALUES ($1, $2, $3)
+ON CONFLICT DO NOTHING;
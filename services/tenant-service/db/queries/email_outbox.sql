@@ -0,0 +1,37 @@
+-- name: EnqueueEmail :one
+INSERT INTO email_outbox (recipients, subject, html_body, text_body, max_attempts, template, template_version)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: ClaimDueEmails :many
+-- Leases a batch of emails that are due, including emails whose dispatcher
+-- died mid-send, and counts the attempt. SKIP LOCKED lets several dispatchers
+-- share the outbox.
+UPDATE email_outbox
+SET status = 'sending',
+    attempts = attempts + 1,
+    locked_until = NOW() + make_interval(secs => sqlc.arg(lease_seconds)::int)
+WHERE id IN (
+    SELECT id FROM email_outbox
+    WHERE (status = 'pending' AND next_attempt_at <= NOW())
+       OR (status = 'sending' AND locked_until < NOW())
+    ORDER BY next_attempt_at
+    LIMIT sqlc.arg(batch_size)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: MarkEmailSent :exec
+UPDATE email_outbox
+SET status = 'sent', sent_at = NOW(), locked_until = NULL, last_error = NULL
+WHERE id = $1;
+
+-- name: RescheduleEmail :exec
+UPDATE email_outbox
+SET status = 'pending', next_attempt_at = $2, last_error = $3, locked_until = NULL
+WHERE id = $1;
+
+-- name: MarkEmailFailed :exec
+UPDATE email_outbox
+SET status = 'failed', last_error = $2, locked_until = NULL
+WHERE id = $1;
@@ -0,0 +1,35 @@
+-- name: EnqueueEvent :exec
+INSERT INTO event_outbox (id, event_type, envelope)
+VALUES ($1, $2, $3);
+
+-- name: ClaimDueEvents :many
+-- Leases a batch of unpublished events that are due, oldest first, including
+-- events whose relay died mid-publish, and counts the attempt. SKIP LOCKED
+-- lets several relays share the outbox.
+UPDATE event_outbox
+SET attempts = attempts + 1,
+    locked_until = NOW() + make_interval(secs => sqlc.arg(lease_seconds)::int)
+WHERE id IN (
+    SELECT id FROM event_outbox
+    WHERE published_at IS NULL
+      AND next_attempt_at <= NOW()
+      AND (locked_until IS NULL OR locked_until < NOW())
+    ORDER BY created_at
+    LIMIT sqlc.arg(batch_size)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: MarkEventPublished :exec
+UPDATE event_outbox
+SET published_at = NOW(), locked_until = NULL, last_error = NULL
+WHERE id = $1;
+
+-- name: RescheduleEvent :exec
+UPDATE event_outbox
+SET next_attempt_at = $2, last_error = $3, locked_until = NULL
+WHERE id = $1;
+
+-- name: DeletePublishedEvents :execrows
+DELETE FROM event_outbox
+WHERE published_at < $1;
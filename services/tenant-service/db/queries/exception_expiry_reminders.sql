@@ -0,0 +1,13 @@
+-- name: CreateExceptionExpiryReminder :execrows
+-- Records the reminder of an exception for a window unless it was already
+-- reminded of the same expiry date in this or a closer window
+INSERT INTO exception_expiry_reminders (client_id, exception_id, expires_at, days_before)
+SELECT sqlc.arg(client_id)::uuid, sqlc.arg(exception_id)::uuid, sqlc.arg(expires_at)::date, sqlc.arg(days_before)::int
+WHERE NOT EXISTS (
+    SELECT 1 FROM exception_expiry_reminders r
+    WHERE r.client_id = sqlc.arg(client_id)::uuid
+      AND r.exception_id = sqlc.arg(exception_id)::uuid
+      AND r.expires_at = sqlc.arg(expires_at)::date
+      AND r.days_before <= sqlc.arg(days_before)::int
+)
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,44 @@
+-- name: CreateNotification :one
+INSERT INTO notifications (user_id, client_id, type, title, body, link)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: ListNotifications :many
+-- Notifications of a user, newest first, optionally only the unread ones
+SELECT * FROM notifications
+WHERE user_id = sqlc.arg(user_id)
+  AND (NOT sqlc.arg(unread_only)::bool OR read_at IS NULL)
+ORDER BY created_at DESC
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- name: CountUnreadNotifications :one
+SELECT COUNT(*) FROM notifications
+WHERE user_id = $1 AND read_at IS NULL;
+
+-- name: MarkNotificationRead :one
+-- Keeps the first read time of notifications that were already read
+UPDATE notifications
+SET read_at = COALESCE(read_at, NOW())
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: MarkAllNotificationsRead :execrows
+UPDATE notifications
+SET read_at = NOW()
+WHERE user_id = $1 AND read_at IS NULL;
+
+-- name: GetNotificationPreference :one
+SELECT * FROM notification_preferences
+WHERE user_id = $1 AND type = $2;
+
+-- name: ListNotificationPreferences :many
+SELECT * FROM notification_preferences
+WHERE user_id = $1
+ORDER BY type;
+
+-- name: UpsertNotificationPreference :one
+INSERT INTO notification_preferences (user_id, type, channel)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id, type) DO UPDATE
+SET channel = EXCLUDED.channel
+RETURNING *;
@@ -40,6 +40,12 @@ SET name = $2, email = $3, designation = $4
 WHERE id = $1
 RETURNING *;
 
+-- name: UpdateUserPreferredLocale :one
+UPDATE users
+SET preferred_locale = $2
+WHERE id = $1
+RETURNING *;
+
 -- name: UpdateUserLastLogin :exec
 UPDATE users
 SET last_login = NOW()
@@ -0,0 +1,108 @@
+-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscriptions (client_id, url, description, encrypted_secret, event_types, created_by)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetWebhookSubscription :one
+SELECT * FROM webhook_subscriptions
+WHERE id = $1 AND client_id = $2;
+
+-- name: GetWebhookSubscriptionByID :one
+SELECT * FROM webhook_subscriptions
+WHERE id = $1;
+
+-- name: ListWebhookSubscriptions :many
+SELECT * FROM webhook_subscriptions
+WHERE client_id = $1
+ORDER BY created_at;
+
+-- name: ListWebhookSubscriptionsForEvent :many
+-- Active subscriptions of a client to an event type
+SELECT * FROM webhook_subscriptions
+WHERE client_id = sqlc.arg(client_id)
+  AND is_active = TRUE
+  AND sqlc.arg(event_type)::text = ANY(event_types);
+
+-- name: UpdateWebhookSubscription :one
+UPDATE webhook_subscriptions
+SET url = $3, description = $4, event_types = $5, is_active = $6
+WHERE id = $1 AND client_id = $2
+RETURNING *;
+
+-- name: DeleteWebhookSubscription :execrows
+DELETE FROM webhook_subscriptions
+WHERE id = $1 AND client_id = $2;
+
+-- name: EnqueueWebhookDelivery :execrows
+-- Queues the delivery of an event to a subscription, once per event
+INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload, max_attempts)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (subscription_id, event_id) WHERE redelivery_of IS NULL DO NOTHING;
+
+-- name: CreateWebhookDelivery :one
+-- Queues a redelivery or a test ping
+INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload, max_attempts, redelivery_of)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetWebhookDelivery :one
+SELECT * FROM webhook_deliveries
+WHERE id = $1 AND subscription_id = $2;
+
+-- name: ListWebhookDeliveries :many
+SELECT * FROM webhook_deliveries
+WHERE subscription_id = sqlc.arg(subscription_id)
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)::text)
+ORDER BY created_at DESC
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- name: ClaimDueWebhookDeliveries :many
+-- Leases a batch of deliveries that are due, including deliveries whose
+-- dispatcher died mid-send, and counts the attempt. SKIP LOCKED lets several
+-- dispatchers share the queue.
+UPDATE webhook_deliveries
+SET status = 'sending',
+    attempts = attempts + 1,
+    locked_until = NOW() + make_interval(secs => sqlc.arg(lease_seconds)::int)
+WHERE id IN (
+    SELECT id FROM webhook_deliveries
+    WHERE (status = 'pending' AND next_attempt_at <= NOW())
+       OR (status = 'sending' AND locked_until < NOW())
+    ORDER BY next_attempt_at
+    LIMIT sqlc.arg(batch_size)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: ClaimWebhookDelivery :one
+-- Leases a pending delivery to send it right away
+UPDATE webhook_deliveries
+SET status = 'sending',
+    attempts = attempts + 1,
+    locked_until = NOW() + make_interval(secs => sqlc.arg(lease_seconds)::int)
+WHERE id = sqlc.arg(id) AND status = 'pending'
+RETURNING *;
+
+-- name: MarkWebhookDeliverySucceeded :exec
+UPDATE webhook_deliveries
+SET status = 'succeeded', delivered_at = NOW(), last_status_code = $2, last_error = NULL, locked_until = NULL
+WHERE id = $1;
+
+-- name: RescheduleWebhookDelivery :exec
+UPDATE webhook_deliveries
+SET status = 'pending', next_attempt_at = $2, last_status_code = $3, last_error = $4, locked_until = NULL
+WHERE id = $1;
+
+-- name: MarkWebhookDeliveryFailed :exec
+UPDATE webhook_deliveries
+SET status = 'failed', last_status_code = $2, last_error = $3, locked_until = NULL
+WHERE id = $1;
+
+-- name: CreateWebhookDeliveryAttempt :exec
+INSERT INTO webhook_delivery_attempts (delivery_id, attempt, status_code, response_body, error, duration_ms)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: ListWebhookDeliveryAttempts :many
+SELECT * FROM webhook_delivery_attempts
+WHERE delivery_id = $1
+ORDER BY attempt;
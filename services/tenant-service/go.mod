@@ -4,8 +4,14 @@ go 1.25
 
 require (
 	github.com/NormaTech-AI/audity/packages/go/auth v0.0.0
+	github.com/NormaTech-AI/audity/packages/go/emailtemplates v0.0.0
+	github.com/NormaTech-AI/audity/packages/go/eventbus v0.0.0
+	github.com/NormaTech-AI/audity/packages/go/evidence v0.0.0
 	github.com/NormaTech-AI/audity/packages/go/microsoft-mail v0.0.0
+	github.com/NormaTech-AI/audity/packages/go/notifier v0.0.0
 	github.com/NormaTech-AI/audity/packages/go/rbac v0.0.0
+	github.com/NormaTech-AI/audity/packages/go/retry v0.0.0
+	github.com/NormaTech-AI/audity/packages/go/spreadsheet v0.0.0
 	github.com/go-playground/validator/v10 v10.24.0
 	github.com/golang-migrate/migrate/v4 v4.19.0
 	github.com/google/uuid v1.6.0
@@ -16,12 +22,19 @@ require (
 	github.com/swaggo/echo-swagger v1.4.1
 	github.com/swaggo/swag v1.16.4
 	go.uber.org/zap v1.27.0
+	golang.org/x/text v0.25.0
 )
 
 replace (
 	github.com/NormaTech-AI/audity/packages/go/auth => ../../packages/go/auth
+	github.com/NormaTech-AI/audity/packages/go/emailtemplates => ../../packages/go/emailtemplates
+	github.com/NormaTech-AI/audity/packages/go/eventbus => ../../packages/go/eventbus
+	github.com/NormaTech-AI/audity/packages/go/evidence => ../../packages/go/evidence
 	github.com/NormaTech-AI/audity/packages/go/microsoft-mail => ../../packages/go/microsoft-mail
+	github.com/NormaTech-AI/audity/packages/go/notifier => ../../packages/go/notifier
 	github.com/NormaTech-AI/audity/packages/go/rbac => ../../packages/go/rbac
+	github.com/NormaTech-AI/audity/packages/go/retry => ../../packages/go/retry
+	github.com/NormaTech-AI/audity/packages/go/spreadsheet => ../../packages/go/spreadsheet
 )
 
 require (
@@ -61,6 +74,7 @@ require (
 	github.com/minio/md5-simd v1.1.2 // indirect
 	github.com/mitchellh/mapstructure v1.5.0 // indirect
 	github.com/pelletier/go-toml/v2 v2.2.3 // indirect
+	github.com/rabbitmq/amqp091-go v1.10.0 // indirect
 	github.com/rogpeppe/go-internal v1.13.1 // indirect
 	github.com/rs/xid v1.6.0 // indirect
 	github.com/sagikazarmark/locafero v0.6.0 // indirect
@@ -79,7 +93,6 @@ require (
 	golang.org/x/net v0.40.0 // indirect
 	golang.org/x/sync v0.14.0 // indirect
 	golang.org/x/sys v0.33.0 // indirect
-	golang.org/x/text v0.25.0 // indirect
 	golang.org/x/time v0.11.0 // indirect
 	golang.org/x/tools v0.28.0 // indirect
 	gopkg.in/ini.v1 v1.67.0 // indirect
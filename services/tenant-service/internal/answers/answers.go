@@ -0,0 +1,360 @@
+package answers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientdb"
+)
+
+// DateFormat is the layout expected for date answers
+const DateFormat = "2006-01-02"
+
+// ErrIncomplete is returned for answers that cannot be submitted for review yet
+var ErrIncomplete = errors.New("answer is incomplete")
+
+// Option is a selectable choice, or a column of a table question
+type Option struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+}
+
+// Data is the structured part of an answer; which field is used depends on
+// the question type
+type Data struct {
+	Selected []string            `json:"selected,omitempty"`
+	Number   *float64            `json:"number,omitempty"`
+	Date     string              `json:"date,omitempty"`
+	Rows     []map[string]string `json:"rows,omitempty"`
+}
+
+// IsStructured reports whether the question type stores its answer in Data
+func IsStructured(questionType clientdb.QuestionTypeEnum) bool {
+	switch questionType {
+	case clientdb.QuestionTypeEnumSingleChoice,
+		clientdb.QuestionTypeEnumMultipleChoice,
+		clientdb.QuestionTypeEnumNumeric,
+		clientdb.QuestionTypeEnumDate,
+		clientdb.QuestionTypeEnumTable:
+		return true
+	}
+	return false
+}
+
+// ParseOptions decodes the options stored on a question
+func ParseOptions(raw []byte) ([]Option, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	var options []Option
+	if err := json.Unmarshal(raw, &options); err != nil {
+		return nil, fmt.Errorf("failed to parse question options: %w", err)
+	}
+
+	return options, nil
+}
+
+// ParseData decodes a stored structured answer; it returns nil when there is none
+func ParseData(raw []byte) (*Data, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	var data Data
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse answer data: %w", err)
+	}
+
+	return &data, nil
+}
+
+// Marshal encodes a structured answer for storage
+func Marshal(data *Data) ([]byte, error) {
+	if data == nil {
+		return nil, nil
+	}
+	return json.Marshal(data)
+}
+
+// Validate checks that an answer fits the question type and options.
+// Answers may be partial while in draft, so a missing structured answer is
+// accepted; anything that is provided must be well formed.
+func Validate(question clientdb.Question, answerValue *string, data *Data) error {
+	if answerValue != nil {
+		value := clientdb.AnswerValueEnum(*answerValue)
+		if !value.Valid() {
+			return fmt.Errorf("invalid answer value %q", *answerValue)
+		}
+		if question.QuestionType != clientdb.QuestionTypeEnumYesNo && value != clientdb.AnswerValueEnumNa {
+			return fmt.Errorf("only %q can be used as answer value for %s questions", clientdb.AnswerValueEnumNa, question.QuestionType)
+		}
+	}
+
+	if data == nil {
+		return nil
+	}
+
+	if !IsStructured(question.QuestionType) {
+		return fmt.Errorf("%s questions do not accept structured answers", question.QuestionType)
+	}
+
+	options, err := ParseOptions(question.Options)
+	if err != nil {
+		return err
+	}
+
+	switch question.QuestionType {
+	case clientdb.QuestionTypeEnumSingleChoice, clientdb.QuestionTypeEnumMultipleChoice:
+		if data.Number != nil || data.Date != "" || len(data.Rows) > 0 {
+			return fmt.Errorf("choice answers only accept selected options")
+		}
+		if question.QuestionType == clientdb.QuestionTypeEnumSingleChoice && len(data.Selected) != 1 {
+			return fmt.Errorf("exactly one option must be selected")
+		}
+		if len(data.Selected) == 0 {
+			return fmt.Errorf("at least one option must be selected")
+		}
+		allowed := optionValues(options)
+		seen := make(map[string]bool, len(data.Selected))
+		for _, value := range data.Selected {
+			if !allowed[value] {
+				return fmt.Errorf("unknown option %q", value)
+			}
+			if seen[value] {
+				return fmt.Errorf("option %q selected more than once", value)
+			}
+			seen[value] = true
+		}
+
+	case clientdb.QuestionTypeEnumNumeric:
+		if len(data.Selected) > 0 || data.Date != "" || len(data.Rows) > 0 {
+			return fmt.Errorf("numeric answers only accept a number")
+		}
+		if data.Number == nil {
+			return fmt.Errorf("a number is required")
+		}
+
+	case clientdb.QuestionTypeEnumDate:
+		if len(data.Selected) > 0 || data.Number != nil || len(data.Rows) > 0 {
+			return fmt.Errorf("date answers only accept a date")
+		}
+		if _, err := time.Parse(DateFormat, data.Date); err != nil {
+			return fmt.Errorf("date must use the YYYY-MM-DD format")
+		}
+
+	case clientdb.QuestionTypeEnumTable:
+		if len(data.Selected) > 0 || data.Number != nil || data.Date != "" {
+			return fmt.Errorf("table answers only accept rows")
+		}
+		if len(data.Rows) == 0 {
+			return fmt.Errorf("at least one row is required")
+		}
+		if len(options) > 0 {
+			columns := optionValues(options)
+			for i, row := range data.Rows {
+				for column := range row {
+					if !columns[column] {
+						return fmt.Errorf("row %d: unknown column %q", i+1, column)
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Complete checks that a saved answer can be submitted for review. Choice,
+// numeric, date and table questions need a well formed structured answer,
+// and table rows cannot be blank, unless the question is answered not
+// applicable. Errors wrap ErrIncomplete.
+func Complete(question clientdb.Question, answerValue clientdb.NullAnswerValueEnum, rawData []byte) error {
+	if !IsStructured(question.QuestionType) {
+		return nil
+	}
+	if answerValue.Valid && answerValue.AnswerValueEnum == clientdb.AnswerValueEnumNa {
+		return nil
+	}
+
+	data, err := ParseData(rawData)
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		return fmt.Errorf("%w: %s questions need a %s", ErrIncomplete, question.QuestionType, structuredAnswerName(question.QuestionType))
+	}
+	if err := Validate(question, nil, data); err != nil {
+		return fmt.Errorf("%w: %v", ErrIncomplete, err)
+	}
+
+	if question.QuestionType == clientdb.QuestionTypeEnumTable {
+		for i, row := range data.Rows {
+			blank := true
+			for _, cell := range row {
+				if strings.TrimSpace(cell) != "" {
+					blank = false
+					break
+				}
+			}
+			if blank {
+				return fmt.Errorf("%w: row %d is empty", ErrIncomplete, i+1)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Values returns the answer values a visibility condition can match on:
+// the yes/no/na value and any selected options
+func Values(answerValue clientdb.NullAnswerValueEnum, rawData []byte) []string {
+	var values []string
+	if answerValue.Valid {
+		values = append(values, string(answerValue.AnswerValueEnum))
+	}
+	if data, err := ParseData(rawData); err == nil && data != nil {
+		values = append(values, data.Selected...)
+	}
+	return values
+}
+
+// Render formats an answer as plain text for reports and exports
+func Render(questionType clientdb.QuestionTypeEnum, rawOptions []byte, answerValue clientdb.NullAnswerValueEnum, answerText *string, rawData []byte) string {
+	var parts []string
+
+	if answerValue.Valid {
+		parts = append(parts, renderAnswerValue(answerValue.AnswerValueEnum))
+	}
+
+	data, err := ParseData(rawData)
+	if err == nil && data != nil {
+		options, _ := ParseOptions(rawOptions)
+		labels := optionLabels(options)
+
+		switch questionType {
+		case clientdb.QuestionTypeEnumSingleChoice, clientdb.QuestionTypeEnumMultipleChoice:
+			selected := make([]string, 0, len(data.Selected))
+			for _, value := range data.Selected {
+				selected = append(selected, labelFor(labels, value))
+			}
+			parts = append(parts, strings.Join(selected, ", "))
+		case clientdb.QuestionTypeEnumNumeric:
+			if data.Number != nil {
+				parts = append(parts, strconv.FormatFloat(*data.Number, 'f', -1, 64))
+			}
+		case clientdb.QuestionTypeEnumDate:
+			parts = append(parts, data.Date)
+		case clientdb.QuestionTypeEnumTable:
+			columns := TableColumns(questionType, rawOptions, data)
+			for _, row := range TableRows(columns, data) {
+				cells := make([]string, 0, len(row))
+				for i, cell := range row {
+					cells = append(cells, fmt.Sprintf("%s: %s", columns[i].Label, cell))
+				}
+				parts = append(parts, strings.Join(cells, ", "))
+			}
+		}
+	}
+
+	if answerText != nil && *answerText != "" {
+		parts = append(parts, *answerText)
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// TableColumns returns the columns of a table answer, using the question
+// options when defined and falling back to the keys found in the rows
+func TableColumns(questionType clientdb.QuestionTypeEnum, rawOptions []byte, data *Data) []Option {
+	if questionType != clientdb.QuestionTypeEnumTable || data == nil {
+		return nil
+	}
+
+	options, _ := ParseOptions(rawOptions)
+	if len(options) > 0 {
+		return options
+	}
+
+	var columns []Option
+	seen := make(map[string]bool)
+	for _, row := range data.Rows {
+		for key := range row {
+			if !seen[key] {
+				seen[key] = true
+				columns = append(columns, Option{Value: key, Label: key})
+			}
+		}
+	}
+
+	return columns
+}
+
+// TableRows lays out the rows of a table answer in column order
+func TableRows(columns []Option, data *Data) [][]string {
+	if data == nil {
+		return nil
+	}
+
+	rows := make([][]string, 0, len(data.Rows))
+	for _, row := range data.Rows {
+		cells := make([]string, 0, len(columns))
+		for _, column := range columns {
+			cells = append(cells, row[column.Value])
+		}
+		rows = append(rows, cells)
+	}
+
+	return rows
+}
+
+func structuredAnswerName(questionType clientdb.QuestionTypeEnum) string {
+	switch questionType {
+	case clientdb.QuestionTypeEnumSingleChoice, clientdb.QuestionTypeEnumMultipleChoice:
+		return "selected option"
+	case clientdb.QuestionTypeEnumNumeric:
+		return "number"
+	case clientdb.QuestionTypeEnumDate:
+		return "date"
+	}
+	return "row"
+}
+
+func renderAnswerValue(value clientdb.AnswerValueEnum) string {
+	switch value {
+	case clientdb.AnswerValueEnumYes:
+		return "Yes"
+	case clientdb.AnswerValueEnumNo:
+		return "No"
+	case clientdb.AnswerValueEnumNa:
+		return "Not Applicable"
+	}
+	return string(value)
+}
+
+func optionValues(options []Option) map[string]bool {
+	values := make(map[string]bool, len(options))
+	for _, option := range options {
+		values[option.Value] = true
+	}
+	return values
+}
+
+func optionLabels(options []Option) map[string]string {
+	labels := make(map[string]string, len(options))
+	for _, option := range options {
+		labels[option.Value] = option.Label
+	}
+	return labels
+}
+
+func labelFor(labels map[string]string, value string) string {
+	if label, ok := labels[value]; ok && label != "" {
+		return label
+	}
+	return value
+}
@@ -0,0 +1,148 @@
+package answers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientdb"
+)
+
+func strPtr(s string) *string { return &s }
+
+func floatPtr(f float64) *float64 { return &f }
+
+var (
+	yesNoQuestion  = clientdb.Question{QuestionType: clientdb.QuestionTypeEnumYesNo}
+	textQuestion   = clientdb.Question{QuestionType: clientdb.QuestionTypeEnumText}
+	singleQuestion = clientdb.Question{
+		QuestionType: clientdb.QuestionTypeEnumSingleChoice,
+		Options:      []byte(`[{"value":"monthly","label":"Monthly"},{"value":"yearly","label":"Yearly"}]`),
+	}
+	multipleQuestion = clientdb.Question{
+		QuestionType: clientdb.QuestionTypeEnumMultipleChoice,
+		Options:      []byte(`[{"value":"email","label":"Email"},{"value":"sms","label":"SMS"}]`),
+	}
+	numericQuestion = clientdb.Question{QuestionType: clientdb.QuestionTypeEnumNumeric}
+	dateQuestion    = clientdb.Question{QuestionType: clientdb.QuestionTypeEnumDate}
+	tableQuestion   = clientdb.Question{
+		QuestionType: clientdb.QuestionTypeEnumTable,
+		Options:      []byte(`[{"value":"system","label":"System"},{"value":"owner","label":"Owner"}]`),
+	}
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		question    clientdb.Question
+		answerValue *string
+		data        *Data
+		wantErr     bool
+	}{
+		{name: "yes/no answer", question: yesNoQuestion, answerValue: strPtr("yes")},
+		{name: "unknown answer value", question: yesNoQuestion, answerValue: strPtr("maybe"), wantErr: true},
+		{name: "not applicable choice", question: singleQuestion, answerValue: strPtr("na")},
+		{name: "yes on a choice question", question: singleQuestion, answerValue: strPtr("yes"), wantErr: true},
+		{name: "draft without structured answer", question: singleQuestion},
+		{name: "structured answer to text question", question: textQuestion, data: &Data{Selected: []string{"x"}}, wantErr: true},
+		{name: "single choice", question: singleQuestion, data: &Data{Selected: []string{"monthly"}}},
+		{name: "single choice with two options", question: singleQuestion, data: &Data{Selected: []string{"monthly", "yearly"}}, wantErr: true},
+		{name: "unknown option", question: singleQuestion, data: &Data{Selected: []string{"weekly"}}, wantErr: true},
+		{name: "multiple choice", question: multipleQuestion, data: &Data{Selected: []string{"email", "sms"}}},
+		{name: "multiple choice without selection", question: multipleQuestion, data: &Data{}, wantErr: true},
+		{name: "option selected twice", question: multipleQuestion, data: &Data{Selected: []string{"sms", "sms"}}, wantErr: true},
+		{name: "choice with a number", question: singleQuestion, data: &Data{Selected: []string{"monthly"}, Number: floatPtr(1)}, wantErr: true},
+		{name: "number", question: numericQuestion, data: &Data{Number: floatPtr(0)}},
+		{name: "missing number", question: numericQuestion, data: &Data{}, wantErr: true},
+		{name: "date", question: dateQuestion, data: &Data{Date: "2026-03-31"}},
+		{name: "malformed date", question: dateQuestion, data: &Data{Date: "31/03/2026"}, wantErr: true},
+		{name: "table", question: tableQuestion, data: &Data{Rows: []map[string]string{{"system": "ERP", "owner": "IT"}}}},
+		{name: "table without rows", question: tableQuestion, data: &Data{}, wantErr: true},
+		{name: "unknown column", question: tableQuestion, data: &Data{Rows: []map[string]string{{"location": "EU"}}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.question, tt.answerValue, tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestComplete(t *testing.T) {
+	na := clientdb.NullAnswerValueEnum{AnswerValueEnum: clientdb.AnswerValueEnumNa, Valid: true}
+
+	tests := []struct {
+		name           string
+		question       clientdb.Question
+		answerValue    clientdb.NullAnswerValueEnum
+		data           string
+		wantIncomplete bool
+	}{
+		{name: "yes/no questions are not structured", question: yesNoQuestion},
+		{name: "text questions are not structured", question: textQuestion},
+		{name: "choice without answer", question: singleQuestion, wantIncomplete: true},
+		{name: "choice answered not applicable", question: singleQuestion, answerValue: na},
+		{name: "choice with empty data", question: multipleQuestion, data: `{}`, wantIncomplete: true},
+		{name: "choice with selection", question: multipleQuestion, data: `{"selected":["email"]}`},
+		{name: "numeric without number", question: numericQuestion, data: `null`, wantIncomplete: true},
+		{name: "numeric with number", question: numericQuestion, data: `{"number":12.5}`},
+		{name: "date without date", question: dateQuestion, data: `{}`, wantIncomplete: true},
+		{name: "table with blank row", question: tableQuestion, data: `{"rows":[{"system":"ERP"},{"system":" ","owner":""}]}`, wantIncomplete: true},
+		{name: "table with rows", question: tableQuestion, data: `{"rows":[{"system":"ERP"}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var raw []byte
+			if tt.data != "" {
+				raw = []byte(tt.data)
+			}
+			err := Complete(tt.question, tt.answerValue, raw)
+			if tt.wantIncomplete {
+				if !errors.Is(err, ErrIncomplete) {
+					t.Errorf("Complete() error = %v, want ErrIncomplete", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Complete() error = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	yes := clientdb.NullAnswerValueEnum{AnswerValueEnum: clientdb.AnswerValueEnumYes, Valid: true}
+
+	tests := []struct {
+		name        string
+		question    clientdb.Question
+		answerValue clientdb.NullAnswerValueEnum
+		answerText  *string
+		data        string
+		want        string
+	}{
+		{name: "yes with comment", question: yesNoQuestion, answerValue: yes, answerText: strPtr("Signed off"), want: "Yes; Signed off"},
+		{name: "choice labels", question: multipleQuestion, data: `{"selected":["sms","email"]}`, want: "SMS, Email"},
+		{name: "unknown option value", question: singleQuestion, data: `{"selected":["weekly"]}`, want: "weekly"},
+		{name: "number", question: numericQuestion, data: `{"number":12.5}`, want: "12.5"},
+		{name: "date", question: dateQuestion, data: `{"date":"2026-03-31"}`, want: "2026-03-31"},
+		{name: "table rows", question: tableQuestion, data: `{"rows":[{"system":"ERP","owner":"IT"}]}`, want: "System: ERP, Owner: IT"},
+		{name: "no answer", question: textQuestion, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var raw []byte
+			if tt.data != "" {
+				raw = []byte(tt.data)
+			}
+			got := Render(tt.question.QuestionType, tt.question.Options, tt.answerValue, tt.answerText, raw)
+			if got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
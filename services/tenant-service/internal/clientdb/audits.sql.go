@@ -19,19 +19,27 @@ INSERT INTO audits (
     assigned_by,
     assigned_to,
     due_date,
-    status
+    status,
+    audit_cycle_framework_id,
+    framework_version_id,
+    framework_version,
+    language
 ) VALUES (
-    $1, $2, $3, $4, $5, $6
-) RETURNING id, framework_id, framework_name, assigned_by, assigned_to, due_date, status, created_at, updated_at, completed_at
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING id, framework_id, framework_name, assigned_by, assigned_to, due_date, status, created_at, updated_at, completed_at, audit_cycle_framework_id, framework_version_id, framework_version, language
 `
 
 type CreateAuditParams struct {
-	FrameworkID   uuid.UUID       `json:"framework_id"`
-	FrameworkName string          `json:"framework_name"`
-	AssignedBy    uuid.UUID       `json:"assigned_by"`
-	AssignedTo    pgtype.UUID     `json:"assigned_to"`
-	DueDate       pgtype.Date     `json:"due_date"`
-	Status        AuditStatusEnum `json:"status"`
+	FrameworkID           uuid.UUID       `json:"framework_id"`
+	FrameworkName         string          `json:"framework_name"`
+	AssignedBy            uuid.UUID       `json:"assigned_by"`
+	AssignedTo            pgtype.UUID     `json:"assigned_to"`
+	DueDate               pgtype.Date     `json:"due_date"`
+	Status                AuditStatusEnum `json:"status"`
+	AuditCycleFrameworkID pgtype.UUID     `json:"audit_cycle_framework_id"`
+	FrameworkVersionID    pgtype.UUID     `json:"framework_version_id"`
+	FrameworkVersion      *string         `json:"framework_version"`
+	Language              string          `json:"language"`
 }
 
 func (q *Queries) CreateAudit(ctx context.Context, arg CreateAuditParams) (Audit, error) {
@@ -42,6 +50,10 @@ func (q *Queries) CreateAudit(ctx context.Context, arg CreateAuditParams) (Audit
 		arg.AssignedTo,
 		arg.DueDate,
 		arg.Status,
+		arg.AuditCycleFrameworkID,
+		arg.FrameworkVersionID,
+		arg.FrameworkVersion,
+		arg.Language,
 	)
 	var i Audit
 	err := row.Scan(
@@ -55,6 +67,10 @@ func (q *Queries) CreateAudit(ctx context.Context, arg CreateAuditParams) (Audit
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.CompletedAt,
+		&i.AuditCycleFrameworkID,
+		&i.FrameworkVersionID,
+		&i.FrameworkVersion,
+		&i.Language,
 	)
 	return i, err
 }
@@ -124,8 +140,35 @@ func (q *Queries) GetAllAuditsProgress(ctx context.Context) ([]GetAllAuditsProgr
 	return items, nil
 }
 
+const GetAuditByCycleFramework = `-- name: GetAuditByCycleFramework :one
+SELECT id, framework_id, framework_name, assigned_by, assigned_to, due_date, status, created_at, updated_at, completed_at, audit_cycle_framework_id, framework_version_id, framework_version, language FROM audits
+WHERE audit_cycle_framework_id = $1
+`
+
+func (q *Queries) GetAuditByCycleFramework(ctx context.Context, auditCycleFrameworkID pgtype.UUID) (Audit, error) {
+	row := q.db.QueryRow(ctx, GetAuditByCycleFramework, auditCycleFrameworkID)
+	var i Audit
+	err := row.Scan(
+		&i.ID,
+		&i.FrameworkID,
+		&i.FrameworkName,
+		&i.AssignedBy,
+		&i.AssignedTo,
+		&i.DueDate,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CompletedAt,
+		&i.AuditCycleFrameworkID,
+		&i.FrameworkVersionID,
+		&i.FrameworkVersion,
+		&i.Language,
+	)
+	return i, err
+}
+
 const GetAuditByID = `-- name: GetAuditByID :one
-SELECT id, framework_id, framework_name, assigned_by, assigned_to, due_date, status, created_at, updated_at, completed_at FROM audits
+SELECT id, framework_id, framework_name, assigned_by, assigned_to, due_date, status, created_at, updated_at, completed_at, audit_cycle_framework_id, framework_version_id, framework_version, language FROM audits
 WHERE id = $1
 `
 
@@ -143,6 +186,10 @@ func (q *Queries) GetAuditByID(ctx context.Context, id uuid.UUID) (Audit, error)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.CompletedAt,
+		&i.AuditCycleFrameworkID,
+		&i.FrameworkVersionID,
+		&i.FrameworkVersion,
+		&i.Language,
 	)
 	return i, err
 }
@@ -192,7 +239,7 @@ func (q *Queries) GetAuditProgress(ctx context.Context, id uuid.UUID) (GetAuditP
 }
 
 const ListAudits = `-- name: ListAudits :many
-SELECT id, framework_id, framework_name, assigned_by, assigned_to, due_date, status, created_at, updated_at, completed_at FROM audits
+SELECT id, framework_id, framework_name, assigned_by, assigned_to, due_date, status, created_at, updated_at, completed_at, audit_cycle_framework_id, framework_version_id, framework_version, language FROM audits
 ORDER BY created_at DESC
 `
 
@@ -216,6 +263,10 @@ func (q *Queries) ListAudits(ctx context.Context) ([]Audit, error) {
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.CompletedAt,
+			&i.AuditCycleFrameworkID,
+			&i.FrameworkVersionID,
+			&i.FrameworkVersion,
+			&i.Language,
 		); err != nil {
 			return nil, err
 		}
@@ -228,7 +279,7 @@ func (q *Queries) ListAudits(ctx context.Context) ([]Audit, error) {
 }
 
 const ListAuditsByStatus = `-- name: ListAuditsByStatus :many
-SELECT id, framework_id, framework_name, assigned_by, assigned_to, due_date, status, created_at, updated_at, completed_at FROM audits
+SELECT id, framework_id, framework_name, assigned_by, assigned_to, due_date, status, created_at, updated_at, completed_at, audit_cycle_framework_id, framework_version_id, framework_version, language FROM audits
 WHERE status = $1
 ORDER BY due_date ASC
 `
@@ -253,6 +304,10 @@ func (q *Queries) ListAuditsByStatus(ctx context.Context, status AuditStatusEnum
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.CompletedAt,
+			&i.AuditCycleFrameworkID,
+			&i.FrameworkVersionID,
+			&i.FrameworkVersion,
+			&i.Language,
 		); err != nil {
 			return nil, err
 		}
@@ -268,7 +323,7 @@ const UpdateAuditAssignee = `-- name: UpdateAuditAssignee :one
 UPDATE audits
 SET assigned_to = $1
 WHERE id = $2
-RETURNING id, framework_id, framework_name, assigned_by, assigned_to, due_date, status, created_at, updated_at, completed_at
+RETURNING id, framework_id, framework_name, assigned_by, assigned_to, due_date, status, created_at, updated_at, completed_at, audit_cycle_framework_id, framework_version_id, framework_version, language
 `
 
 type UpdateAuditAssigneeParams struct {
@@ -290,6 +345,10 @@ func (q *Queries) UpdateAuditAssignee(ctx context.Context, arg UpdateAuditAssign
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.CompletedAt,
+		&i.AuditCycleFrameworkID,
+		&i.FrameworkVersionID,
+		&i.FrameworkVersion,
+		&i.Language,
 	)
 	return i, err
 }
@@ -299,7 +358,7 @@ UPDATE audits
 SET status = $1,
     completed_at = CASE WHEN $1 = 'completed' THEN NOW() ELSE completed_at END
 WHERE id = $2
-RETURNING id, framework_id, framework_name, assigned_by, assigned_to, due_date, status, created_at, updated_at, completed_at
+RETURNING id, framework_id, framework_name, assigned_by, assigned_to, due_date, status, created_at, updated_at, completed_at, audit_cycle_framework_id, framework_version_id, framework_version, language
 `
 
 type UpdateAuditStatusParams struct {
@@ -321,6 +380,10 @@ func (q *Queries) UpdateAuditStatus(ctx context.Context, arg UpdateAuditStatusPa
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.CompletedAt,
+		&i.AuditCycleFrameworkID,
+		&i.FrameworkVersionID,
+		&i.FrameworkVersion,
+		&i.Language,
 	)
 	return i, err
 }
@@ -72,6 +72,14 @@ func (r iteratorForBulkCreateQuestions) Values() ([]interface{}, error) {
 		r.rows[0].HelpText,
 		r.rows[0].IsMandatory,
 		r.rows[0].DisplayOrder,
+		r.rows[0].VisibilityCondition,
+		r.rows[0].Options,
+		r.rows[0].Weight,
+		r.rows[0].Severity,
+		r.rows[0].Subsection,
+		r.rows[0].EvidenceRequired,
+		r.rows[0].AcceptableEvidence,
+		r.rows[0].EvidenceRequirements,
 	}, nil
 }
 
@@ -80,5 +88,5 @@ func (r iteratorForBulkCreateQuestions) Err() error {
 }
 
 func (q *Queries) BulkCreateQuestions(ctx context.Context, arg []BulkCreateQuestionsParams) (int64, error) {
-	return q.db.CopyFrom(ctx, []string{"questions"}, []string{"audit_id", "section", "question_number", "question_text", "question_type", "help_text", "is_mandatory", "display_order"}, &iteratorForBulkCreateQuestions{rows: arg})
+	return q.db.CopyFrom(ctx, []string{"questions"}, []string{"audit_id", "section", "question_number", "question_text", "question_type", "help_text", "is_mandatory", "display_order", "visibility_condition", "options", "weight", "severity", "subsection", "evidence_required", "acceptable_evidence", "evidence_requirements"}, &iteratorForBulkCreateQuestions{rows: arg})
 }
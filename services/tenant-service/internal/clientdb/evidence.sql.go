@@ -12,6 +12,48 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const CarryForwardEvidence = `-- name: CarryForwardEvidence :execrows
+INSERT INTO evidence (
+    submission_id,
+    file_name,
+    file_path,
+    file_size,
+    file_type,
+    uploaded_by,
+    uploaded_at,
+    description,
+    category
+)
+SELECT
+    s.id,
+    e.file_name,
+    e.file_path,
+    e.file_size,
+    e.file_type,
+    e.uploaded_by,
+    e.uploaded_at,
+    e.description,
+    e.category
+FROM submissions s
+JOIN questions q ON q.id = s.question_id
+JOIN evidence e ON e.submission_id = s.carried_forward_from AND e.is_deleted = false
+WHERE q.audit_id = $1
+  AND s.is_carried_forward = true
+  AND NOT EXISTS (
+      SELECT 1 FROM evidence existing WHERE existing.submission_id = s.id
+  )
+`
+
+// Links the evidence of the previous audit to the answers carried forward
+// into an audit. Files are shared, not copied; evidence is only soft deleted.
+func (q *Queries) CarryForwardEvidence(ctx context.Context, auditID uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, CarryForwardEvidence, auditID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const CreateEvidence = `-- name: CreateEvidence :one
 INSERT INTO evidence (
     submission_id,
@@ -20,10 +62,11 @@ INSERT INTO evidence (
     file_size,
     file_type,
     uploaded_by,
-    description
+    description,
+    category
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7
-) RETURNING id, submission_id, file_name, file_path, file_size, file_type, uploaded_by, uploaded_at, description, is_deleted, deleted_at, deleted_by
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING id, submission_id, file_name, file_path, file_size, file_type, uploaded_by, uploaded_at, description, is_deleted, deleted_at, deleted_by, category
 `
 
 type CreateEvidenceParams struct {
@@ -34,6 +77,7 @@ type CreateEvidenceParams struct {
 	FileType     *string   `json:"file_type"`
 	UploadedBy   uuid.UUID `json:"uploaded_by"`
 	Description  *string   `json:"description"`
+	Category     *string   `json:"category"`
 }
 
 func (q *Queries) CreateEvidence(ctx context.Context, arg CreateEvidenceParams) (Evidence, error) {
@@ -45,6 +89,7 @@ func (q *Queries) CreateEvidence(ctx context.Context, arg CreateEvidenceParams)
 		arg.FileType,
 		arg.UploadedBy,
 		arg.Description,
+		arg.Category,
 	)
 	var i Evidence
 	err := row.Scan(
@@ -60,6 +105,7 @@ func (q *Queries) CreateEvidence(ctx context.Context, arg CreateEvidenceParams)
 		&i.IsDeleted,
 		&i.DeletedAt,
 		&i.DeletedBy,
+		&i.Category,
 	)
 	return i, err
 }
@@ -85,6 +131,7 @@ func (q *Queries) GetEvidenceByID(ctx context.Context, id uuid.UUID) (Evidence,
 		&i.IsDeleted,
 		&i.DeletedAt,
 		&i.DeletedBy,
+		&i.Category,
 	)
 	return i, err
 }
@@ -121,6 +168,68 @@ func (q *Queries) HardDeleteEvidence(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
+const ListEvidenceByAudit = `-- name: ListEvidenceByAudit :many
+SELECT e.id, e.submission_id, e.file_name, e.file_path, e.file_size, e.file_type, e.uploaded_by, e.uploaded_at, e.description, e.is_deleted, e.deleted_at, e.deleted_by, e.category, s.question_id
+FROM evidence e
+JOIN submissions s ON s.id = e.submission_id
+JOIN questions q ON q.id = s.question_id
+WHERE q.audit_id = $1 AND e.is_deleted = false
+ORDER BY e.uploaded_at
+`
+
+type ListEvidenceByAuditRow struct {
+	ID           uuid.UUID          `json:"id"`
+	SubmissionID uuid.UUID          `json:"submission_id"`
+	FileName     string             `json:"file_name"`
+	FilePath     string             `json:"file_path"`
+	FileSize     int64              `json:"file_size"`
+	FileType     *string            `json:"file_type"`
+	UploadedBy   uuid.UUID          `json:"uploaded_by"`
+	UploadedAt   pgtype.Timestamptz `json:"uploaded_at"`
+	Description  *string            `json:"description"`
+	IsDeleted    bool               `json:"is_deleted"`
+	DeletedAt    pgtype.Timestamptz `json:"deleted_at"`
+	DeletedBy    pgtype.UUID        `json:"deleted_by"`
+	Category     *string            `json:"category"`
+	QuestionID   uuid.UUID          `json:"question_id"`
+}
+
+// Lists the evidence of every answer in an audit, for exports
+func (q *Queries) ListEvidenceByAudit(ctx context.Context, auditID uuid.UUID) ([]ListEvidenceByAuditRow, error) {
+	rows, err := q.db.Query(ctx, ListEvidenceByAudit, auditID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListEvidenceByAuditRow{}
+	for rows.Next() {
+		var i ListEvidenceByAuditRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.SubmissionID,
+			&i.FileName,
+			&i.FilePath,
+			&i.FileSize,
+			&i.FileType,
+			&i.UploadedBy,
+			&i.UploadedAt,
+			&i.Description,
+			&i.IsDeleted,
+			&i.DeletedAt,
+			&i.DeletedBy,
+			&i.Category,
+			&i.QuestionID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const ListEvidenceBySubmission = `-- name: ListEvidenceBySubmission :many
 SELECT id, submission_id, file_name, file_path, file_size, file_type, uploaded_by, uploaded_at, description, is_deleted, deleted_at, deleted_by FROM evidence
 WHERE submission_id = $1 AND is_deleted = false
@@ -149,6 +258,7 @@ func (q *Queries) ListEvidenceBySubmission(ctx context.Context, submissionID uui
 			&i.IsDeleted,
 			&i.DeletedAt,
 			&i.DeletedBy,
+			&i.Category,
 		); err != nil {
 			return nil, err
 		}
@@ -161,7 +271,7 @@ func (q *Queries) ListEvidenceBySubmission(ctx context.Context, submissionID uui
 }
 
 const ListEvidenceByUser = `-- name: ListEvidenceByUser :many
-SELECT e.id, e.submission_id, e.file_name, e.file_path, e.file_size, e.file_type, e.uploaded_by, e.uploaded_at, e.description, e.is_deleted, e.deleted_at, e.deleted_by, s.question_id
+SELECT e.id, e.submission_id, e.file_name, e.file_path, e.file_size, e.file_type, e.uploaded_by, e.uploaded_at, e.description, e.is_deleted, e.deleted_at, e.deleted_by, e.category, s.question_id
 FROM evidence e
 JOIN submissions s ON s.id = e.submission_id
 WHERE e.uploaded_by = $1 AND e.is_deleted = false
@@ -181,6 +291,7 @@ type ListEvidenceByUserRow struct {
 	IsDeleted    bool               `json:"is_deleted"`
 	DeletedAt    pgtype.Timestamptz `json:"deleted_at"`
 	DeletedBy    pgtype.UUID        `json:"deleted_by"`
+	Category     *string            `json:"category"`
 	QuestionID   uuid.UUID          `json:"question_id"`
 }
 
@@ -206,6 +317,7 @@ func (q *Queries) ListEvidenceByUser(ctx context.Context, uploadedBy uuid.UUID)
 			&i.IsDeleted,
 			&i.DeletedAt,
 			&i.DeletedBy,
+			&i.Category,
 			&i.QuestionID,
 		); err != nil {
 			return nil, err
@@ -218,6 +330,49 @@ func (q *Queries) ListEvidenceByUser(ctx context.Context, uploadedBy uuid.UUID)
 	return items, nil
 }
 
+const PropagateEvidence = `-- name: PropagateEvidence :execrows
+INSERT INTO evidence (
+    submission_id,
+    file_name,
+    file_path,
+    file_size,
+    file_type,
+    uploaded_by,
+    uploaded_at,
+    description,
+    category
+)
+SELECT
+    p.id,
+    e.file_name,
+    e.file_path,
+    e.file_size,
+    e.file_type,
+    e.uploaded_by,
+    e.uploaded_at,
+    e.description,
+    e.category
+FROM submissions p
+JOIN evidence e ON e.submission_id = p.propagated_from AND e.is_deleted = false
+WHERE p.propagated_from = $1
+  AND p.status <> 'approved'
+  AND NOT EXISTS (
+      SELECT 1 FROM evidence existing
+      WHERE existing.submission_id = p.id AND existing.file_path = e.file_path
+  )
+`
+
+// Links the evidence of a submission to the answers propagated from it that
+// are not approved yet. Files are shared, not copied, and files already
+// linked are skipped.
+func (q *Queries) PropagateEvidence(ctx context.Context, propagatedFrom pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, PropagateEvidence, propagatedFrom)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const SoftDeleteEvidence = `-- name: SoftDeleteEvidence :one
 UPDATE evidence
 SET 
@@ -225,7 +380,7 @@ SET
     deleted_at = NOW(),
     deleted_by = $2
 WHERE id = $1
-RETURNING id, submission_id, file_name, file_path, file_size, file_type, uploaded_by, uploaded_at, description, is_deleted, deleted_at, deleted_by
+RETURNING id, submission_id, file_name, file_path, file_size, file_type, uploaded_by, uploaded_at, description, is_deleted, deleted_at, deleted_by, category
 `
 
 type SoftDeleteEvidenceParams struct {
@@ -249,6 +404,7 @@ func (q *Queries) SoftDeleteEvidence(ctx context.Context, arg SoftDeleteEvidence
 		&i.IsDeleted,
 		&i.DeletedAt,
 		&i.DeletedBy,
+		&i.Category,
 	)
 	return i, err
 }
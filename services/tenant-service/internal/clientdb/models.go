@@ -7,6 +7,7 @@ package clientdb
 import (
 	"database/sql/driver"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -204,12 +205,211 @@ func AllClientUserRoleEnumValues() []ClientUserRoleEnum {
 	}
 }
 
+type ExceptionStatusEnum string
+
+const (
+	ExceptionStatusEnumRequested ExceptionStatusEnum = "requested"
+	ExceptionStatusEnumApproved  ExceptionStatusEnum = "approved"
+	ExceptionStatusEnumRejected  ExceptionStatusEnum = "rejected"
+	ExceptionStatusEnumRevoked   ExceptionStatusEnum = "revoked"
+)
+
+func (e *ExceptionStatusEnum) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ExceptionStatusEnum(s)
+	case string:
+		*e = ExceptionStatusEnum(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ExceptionStatusEnum: %T", src)
+	}
+	return nil
+}
+
+type NullExceptionStatusEnum struct {
+	ExceptionStatusEnum ExceptionStatusEnum `json:"exception_status_enum"`
+	Valid               bool                `json:"valid"` // Valid is true if ExceptionStatusEnum is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullExceptionStatusEnum) Scan(value interface{}) error {
+	if value == nil {
+		ns.ExceptionStatusEnum, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ExceptionStatusEnum.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullExceptionStatusEnum) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ExceptionStatusEnum), nil
+}
+
+func (e ExceptionStatusEnum) Valid() bool {
+	switch e {
+	case ExceptionStatusEnumRequested,
+		ExceptionStatusEnumApproved,
+		ExceptionStatusEnumRejected,
+		ExceptionStatusEnumRevoked:
+		return true
+	}
+	return false
+}
+
+func AllExceptionStatusEnumValues() []ExceptionStatusEnum {
+	return []ExceptionStatusEnum{
+		ExceptionStatusEnumRequested,
+		ExceptionStatusEnumApproved,
+		ExceptionStatusEnumRejected,
+		ExceptionStatusEnumRevoked,
+	}
+}
+
+type FindingStatusEnum string
+
+const (
+	FindingStatusEnumOpen          FindingStatusEnum = "open"
+	FindingStatusEnumInRemediation FindingStatusEnum = "in_remediation"
+	FindingStatusEnumVerified      FindingStatusEnum = "verified"
+	FindingStatusEnumClosed        FindingStatusEnum = "closed"
+	FindingStatusEnumRiskAccepted  FindingStatusEnum = "risk_accepted"
+)
+
+func (e *FindingStatusEnum) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = FindingStatusEnum(s)
+	case string:
+		*e = FindingStatusEnum(s)
+	default:
+		return fmt.Errorf("unsupported scan type for FindingStatusEnum: %T", src)
+	}
+	return nil
+}
+
+type NullFindingStatusEnum struct {
+	FindingStatusEnum FindingStatusEnum `json:"finding_status_enum"`
+	Valid             bool              `json:"valid"` // Valid is true if FindingStatusEnum is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullFindingStatusEnum) Scan(value interface{}) error {
+	if value == nil {
+		ns.FindingStatusEnum, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.FindingStatusEnum.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullFindingStatusEnum) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.FindingStatusEnum), nil
+}
+
+func (e FindingStatusEnum) Valid() bool {
+	switch e {
+	case FindingStatusEnumOpen,
+		FindingStatusEnumInRemediation,
+		FindingStatusEnumVerified,
+		FindingStatusEnumClosed,
+		FindingStatusEnumRiskAccepted:
+		return true
+	}
+	return false
+}
+
+func AllFindingStatusEnumValues() []FindingStatusEnum {
+	return []FindingStatusEnum{
+		FindingStatusEnumOpen,
+		FindingStatusEnumInRemediation,
+		FindingStatusEnumVerified,
+		FindingStatusEnumClosed,
+		FindingStatusEnumRiskAccepted,
+	}
+}
+
+type QuestionSeverityEnum string
+
+const (
+	QuestionSeverityEnumLow      QuestionSeverityEnum = "low"
+	QuestionSeverityEnumMedium   QuestionSeverityEnum = "medium"
+	QuestionSeverityEnumHigh     QuestionSeverityEnum = "high"
+	QuestionSeverityEnumCritical QuestionSeverityEnum = "critical"
+)
+
+func (e *QuestionSeverityEnum) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = QuestionSeverityEnum(s)
+	case string:
+		*e = QuestionSeverityEnum(s)
+	default:
+		return fmt.Errorf("unsupported scan type for QuestionSeverityEnum: %T", src)
+	}
+	return nil
+}
+
+type NullQuestionSeverityEnum struct {
+	QuestionSeverityEnum QuestionSeverityEnum `json:"question_severity_enum"`
+	Valid                bool                 `json:"valid"` // Valid is true if QuestionSeverityEnum is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullQuestionSeverityEnum) Scan(value interface{}) error {
+	if value == nil {
+		ns.QuestionSeverityEnum, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.QuestionSeverityEnum.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullQuestionSeverityEnum) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.QuestionSeverityEnum), nil
+}
+
+func (e QuestionSeverityEnum) Valid() bool {
+	switch e {
+	case QuestionSeverityEnumLow,
+		QuestionSeverityEnumMedium,
+		QuestionSeverityEnumHigh,
+		QuestionSeverityEnumCritical:
+		return true
+	}
+	return false
+}
+
+func AllQuestionSeverityEnumValues() []QuestionSeverityEnum {
+	return []QuestionSeverityEnum{
+		QuestionSeverityEnumLow,
+		QuestionSeverityEnumMedium,
+		QuestionSeverityEnumHigh,
+		QuestionSeverityEnumCritical,
+	}
+}
+
 type QuestionTypeEnum string
 
 const (
 	QuestionTypeEnumYesNo          QuestionTypeEnum = "yes_no"
 	QuestionTypeEnumText           QuestionTypeEnum = "text"
 	QuestionTypeEnumMultipleChoice QuestionTypeEnum = "multiple_choice"
+	QuestionTypeEnumSingleChoice   QuestionTypeEnum = "single_choice"
+	QuestionTypeEnumNumeric        QuestionTypeEnum = "numeric"
+	QuestionTypeEnumDate           QuestionTypeEnum = "date"
+	QuestionTypeEnumTable          QuestionTypeEnum = "table"
 )
 
 func (e *QuestionTypeEnum) Scan(src interface{}) error {
@@ -251,7 +451,11 @@ func (e QuestionTypeEnum) Valid() bool {
 	switch e {
 	case QuestionTypeEnumYesNo,
 		QuestionTypeEnumText,
-		QuestionTypeEnumMultipleChoice:
+		QuestionTypeEnumMultipleChoice,
+		QuestionTypeEnumSingleChoice,
+		QuestionTypeEnumNumeric,
+		QuestionTypeEnumDate,
+		QuestionTypeEnumTable:
 		return true
 	}
 	return false
@@ -262,6 +466,10 @@ func AllQuestionTypeEnumValues() []QuestionTypeEnum {
 		QuestionTypeEnumYesNo,
 		QuestionTypeEnumText,
 		QuestionTypeEnumMultipleChoice,
+		QuestionTypeEnumSingleChoice,
+		QuestionTypeEnumNumeric,
+		QuestionTypeEnumDate,
+		QuestionTypeEnumTable,
 	}
 }
 
@@ -425,6 +633,14 @@ type Audit struct {
 	CreatedAt     pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
 	CompletedAt   pgtype.Timestamptz `json:"completed_at"`
+	// Framework assignment in tenant_db this audit was provisioned for
+	AuditCycleFrameworkID pgtype.UUID `json:"audit_cycle_framework_id"`
+	// Published framework version the questions were copied from
+	FrameworkVersionID pgtype.UUID `json:"framework_version_id"`
+	// Label of the framework version
+	FrameworkVersion *string `json:"framework_version"`
+	// Canonical language of the framework as a BCP 47 tag; question text is in it
+	Language string `json:"language"`
 }
 
 // Client-specific RBAC permissions
@@ -483,6 +699,20 @@ type Comment struct {
 }
 
 // Evidence files uploaded by client
+type EventOutbox struct {
+	ID        uuid.UUID `json:"id"`
+	EventType string    `json:"event_type"`
+	// Event envelope as published, see packages/go/eventbus
+	Envelope      []byte    `json:"envelope"`
+	Attempts      int32     `json:"attempts"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	// Lease of the relay publishing the event; expired leases of crashed relays are picked up again
+	LockedUntil pgtype.Timestamptz `json:"locked_until"`
+	LastError   *string            `json:"last_error"`
+	PublishedAt pgtype.Timestamptz `json:"published_at"`
+	CreatedAt   time.Time          `json:"created_at"`
+}
+
 type Evidence struct {
 	ID           uuid.UUID          `json:"id"`
 	SubmissionID uuid.UUID          `json:"submission_id"`
@@ -496,6 +726,44 @@ type Evidence struct {
 	IsDeleted    bool               `json:"is_deleted"`
 	DeletedAt    pgtype.Timestamptz `json:"deleted_at"`
 	DeletedBy    pgtype.UUID        `json:"deleted_by"`
+	// Document category of the file, e.g. policy or screenshot
+	Category *string `json:"category"`
+}
+
+// Control gaps raised during audits and their remediation
+type Finding struct {
+	ID              uuid.UUID            `json:"id"`
+	AuditID         uuid.UUID            `json:"audit_id"`
+	QuestionID      uuid.UUID            `json:"question_id"`
+	SubmissionID    pgtype.UUID          `json:"submission_id"`
+	Title           string               `json:"title"`
+	Description     *string              `json:"description"`
+	Severity        QuestionSeverityEnum `json:"severity"`
+	Status          FindingStatusEnum    `json:"status"`
+	OwnerID         pgtype.UUID          `json:"owner_id"`
+	TargetDate      pgtype.Date          `json:"target_date"`
+	RemediationPlan *string              `json:"remediation_plan"`
+	RaisedBy        uuid.UUID            `json:"raised_by"`
+	VerifiedBy      pgtype.UUID          `json:"verified_by"`
+	VerifiedAt      pgtype.Timestamptz   `json:"verified_at"`
+	ClosedBy        pgtype.UUID          `json:"closed_by"`
+	ClosedAt        pgtype.Timestamptz   `json:"closed_at"`
+	ClosureNotes    *string              `json:"closure_notes"`
+	CreatedAt       pgtype.Timestamptz   `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz   `json:"updated_at"`
+}
+
+// Evidence files supporting finding closure
+type FindingEvidence struct {
+	ID          uuid.UUID          `json:"id"`
+	FindingID   uuid.UUID          `json:"finding_id"`
+	FileName    string             `json:"file_name"`
+	FilePath    string             `json:"file_path"`
+	FileSize    int64              `json:"file_size"`
+	FileType    *string            `json:"file_type"`
+	UploadedBy  uuid.UUID          `json:"uploaded_by"`
+	UploadedAt  pgtype.Timestamptz `json:"uploaded_at"`
+	Description *string            `json:"description"`
 }
 
 // Questions from compliance frameworks
@@ -511,6 +779,22 @@ type Question struct {
 	DisplayOrder   int32              `json:"display_order"`
 	CreatedAt      pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	// Condition on a prior answer that controls whether the question is shown
+	VisibilityCondition []byte `json:"visibility_condition"`
+	// Choice options or table column definitions
+	Options []byte `json:"options"`
+	// Relative importance of the question in the compliance score
+	Weight int32 `json:"weight"`
+	// How heavily a non-compliant answer counts against the score
+	Severity QuestionSeverityEnum `json:"severity"`
+	// Sub-section within the section
+	Subsection *string `json:"subsection"`
+	// Whether an answer must be backed by uploaded evidence
+	EvidenceRequired bool `json:"evidence_required"`
+	// Kinds of evidence the framework accepts for the question
+	AcceptableEvidence []string `json:"acceptable_evidence"`
+	// Minimum files, allowed file types and required document categories of the evidence
+	EvidenceRequirements []byte `json:"evidence_requirements"`
 }
 
 // Delegation of questions to stakeholders
@@ -523,6 +807,36 @@ type QuestionAssignment struct {
 	Notes      *string            `json:"notes"`
 }
 
+// Risk acceptance exceptions for audit questions
+type QuestionException struct {
+	ID                   uuid.UUID           `json:"id"`
+	AuditID              uuid.UUID           `json:"audit_id"`
+	QuestionID           uuid.UUID           `json:"question_id"`
+	Justification        string              `json:"justification"`
+	CompensatingControls string              `json:"compensating_controls"`
+	Status               ExceptionStatusEnum `json:"status"`
+	RequestedBy          uuid.UUID           `json:"requested_by"`
+	RequestedAt          pgtype.Timestamptz  `json:"requested_at"`
+	ReviewedBy           pgtype.UUID         `json:"reviewed_by"`
+	ReviewedAt           pgtype.Timestamptz  `json:"reviewed_at"`
+	ReviewNotes          *string             `json:"review_notes"`
+	ExpiresAt            pgtype.Date         `json:"expires_at"`
+	CarriedForwardFrom   pgtype.UUID         `json:"carried_forward_from"`
+	CreatedAt            pgtype.Timestamptz  `json:"created_at"`
+	UpdatedAt            pgtype.Timestamptz  `json:"updated_at"`
+}
+
+// Translated text of questions by locale; NULL fields fall back to the canonical text
+type QuestionTranslation struct {
+	QuestionID uuid.UUID `json:"question_id"`
+	// BCP 47 language tag, e.g. hi or mr
+	Locale       string  `json:"locale"`
+	QuestionText *string `json:"question_text"`
+	HelpText     *string `json:"help_text"`
+	Section      *string `json:"section"`
+	Subsection   *string `json:"subsection"`
+}
+
 // Generated audit reports
 type Report struct {
 	ID               uuid.UUID          `json:"id"`
@@ -556,4 +870,12 @@ type Submission struct {
 	Version         int32                `json:"version"`
 	CreatedAt       pgtype.Timestamptz   `json:"created_at"`
 	UpdatedAt       pgtype.Timestamptz   `json:"updated_at"`
+	// Structured answer for choice, numeric, date and table questions
+	AnswerData []byte `json:"answer_data"`
+	// Submission of a previous audit this answer was prefilled from
+	CarriedForwardFrom pgtype.UUID `json:"carried_forward_from"`
+	// Prefilled answer awaiting client confirmation
+	IsCarriedForward bool `json:"is_carried_forward"`
+	// Submission to an equivalent mapped question this answer was copied from
+	PropagatedFrom pgtype.UUID `json:"propagated_from"`
 }
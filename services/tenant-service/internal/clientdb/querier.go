@@ -12,33 +12,63 @@ import (
 )
 
 type Querier interface {
+	ApproveQuestionException(ctx context.Context, arg ApproveQuestionExceptionParams) (QuestionException, error)
 	ApproveSubmission(ctx context.Context, arg ApproveSubmissionParams) (Submission, error)
 	AssignQuestionToUser(ctx context.Context, arg AssignQuestionToUserParams) (QuestionAssignment, error)
 	BulkAssignQuestions(ctx context.Context, arg []BulkAssignQuestionsParams) (int64, error)
 	BulkCreateQuestions(ctx context.Context, arg []BulkCreateQuestionsParams) (int64, error)
+	// Links the evidence of the previous audit to the answers carried forward
+	// into an audit. Files are shared, not copied; evidence is only soft deleted.
+	CarryForwardEvidence(ctx context.Context, auditID uuid.UUID) (int64, error)
+	// Copies approved, unexpired exceptions from earlier audits of the same
+	// framework onto the matching questions of a new audit.
+	CarryForwardExceptions(ctx context.Context, arg CarryForwardExceptionsParams) (int64, error)
+	// Prefills the unanswered questions of an audit with the latest approved
+	// answers of the most recent completed audit of the same framework, matched
+	// by question number. Carried forward answers stay in progress until the
+	// client confirms or updates them.
+	CarryForwardSubmissions(ctx context.Context, arg CarryForwardSubmissionsParams) (int64, error)
+	// Leases a batch of unpublished events that are due, oldest first, including
+	// events whose relay died mid-publish, and counts the attempt. SKIP LOCKED
+	// lets several relays share the outbox.
+	ClaimDueEvents(ctx context.Context, arg ClaimDueEventsParams) ([]EventOutbox, error)
+	// Moves a finding to a terminal state (closed or risk_accepted)
+	CloseFinding(ctx context.Context, arg CloseFindingParams) (Finding, error)
+	ConfirmCarriedForwardSubmission(ctx context.Context, id uuid.UUID) (Submission, error)
+	CountFindingEvidence(ctx context.Context, findingID uuid.UUID) (int64, error)
 	CreateActivityLog(ctx context.Context, arg CreateActivityLogParams) (ActivityLog, error)
 	CreateAudit(ctx context.Context, arg CreateAuditParams) (Audit, error)
 	CreateComment(ctx context.Context, arg CreateCommentParams) (Comment, error)
 	CreateEvidence(ctx context.Context, arg CreateEvidenceParams) (Evidence, error)
+	CreateFinding(ctx context.Context, arg CreateFindingParams) (Finding, error)
+	CreateFindingEvidence(ctx context.Context, arg CreateFindingEvidenceParams) (FindingEvidence, error)
 	CreateQuestion(ctx context.Context, arg CreateQuestionParams) (Question, error)
 	CreateQuestionAssignment(ctx context.Context, arg CreateQuestionAssignmentParams) (QuestionAssignment, error)
+	CreateQuestionException(ctx context.Context, arg CreateQuestionExceptionParams) (QuestionException, error)
+	CreateQuestionTranslation(ctx context.Context, arg CreateQuestionTranslationParams) error
 	CreateReport(ctx context.Context, arg CreateReportParams) (Report, error)
 	CreateSubmission(ctx context.Context, arg CreateSubmissionParams) (Submission, error)
 	DeleteAudit(ctx context.Context, id uuid.UUID) error
 	DeleteComment(ctx context.Context, id uuid.UUID) error
+	DeleteFinding(ctx context.Context, id uuid.UUID) error
 	DeleteOldActivityLogs(ctx context.Context, createdAt pgtype.Timestamptz) error
+	DeletePublishedEvents(ctx context.Context, publishedAt pgtype.Timestamptz) (int64, error)
 	DeleteQuestion(ctx context.Context, id uuid.UUID) error
 	DeleteQuestionAssignment(ctx context.Context, arg DeleteQuestionAssignmentParams) error
 	DeleteReport(ctx context.Context, id uuid.UUID) error
+	EnqueueEvent(ctx context.Context, arg EnqueueEventParams) error
 	// Get progress for all audits (frameworks) - for dashboard analytics
 	GetAllAuditsProgress(ctx context.Context) ([]GetAllAuditsProgressRow, error)
+	GetAuditByCycleFramework(ctx context.Context, auditCycleFrameworkID pgtype.UUID) (Audit, error)
 	GetAuditByID(ctx context.Context, id uuid.UUID) (Audit, error)
 	GetAuditProgress(ctx context.Context, id uuid.UUID) (GetAuditProgressRow, error)
 	GetCommentByID(ctx context.Context, id uuid.UUID) (Comment, error)
 	GetEvidenceByID(ctx context.Context, id uuid.UUID) (Evidence, error)
 	GetEvidenceStats(ctx context.Context) (GetEvidenceStatsRow, error)
+	GetFindingByID(ctx context.Context, id uuid.UUID) (Finding, error)
 	GetQuestionAssignment(ctx context.Context, arg GetQuestionAssignmentParams) (QuestionAssignment, error)
 	GetQuestionByID(ctx context.Context, id uuid.UUID) (Question, error)
+	GetQuestionExceptionByID(ctx context.Context, id uuid.UUID) (QuestionException, error)
 	GetQuestionWithSubmission(ctx context.Context, id uuid.UUID) (GetQuestionWithSubmissionRow, error)
 	GetRecentActivity(ctx context.Context, limit int32) ([]ActivityLog, error)
 	GetReportByAuditID(ctx context.Context, auditID uuid.UUID) (Report, error)
@@ -53,15 +83,35 @@ type Querier interface {
 	ListActivityLogsByUser(ctx context.Context, arg ListActivityLogsByUserParams) ([]ActivityLog, error)
 	ListAssignmentsByQuestion(ctx context.Context, questionID uuid.UUID) ([]QuestionAssignment, error)
 	ListAssignmentsByUser(ctx context.Context, assignedTo uuid.UUID) ([]ListAssignmentsByUserRow, error)
+	// Locales the questions of an audit are translated into
+	ListAuditLocales(ctx context.Context, auditID uuid.UUID) ([]string, error)
 	ListAudits(ctx context.Context) ([]Audit, error)
 	ListAuditsByStatus(ctx context.Context, status AuditStatusEnum) ([]Audit, error)
 	ListCommentsBySubmission(ctx context.Context, submissionID uuid.UUID) ([]Comment, error)
+	// Lists the evidence of every answer in an audit, for exports
+	ListEvidenceByAudit(ctx context.Context, auditID uuid.UUID) ([]ListEvidenceByAuditRow, error)
 	ListEvidenceBySubmission(ctx context.Context, submissionID uuid.UUID) ([]Evidence, error)
 	ListEvidenceByUser(ctx context.Context, uploadedBy uuid.UUID) ([]ListEvidenceByUserRow, error)
+	// Approved exceptions that expire on or before the given date, including
+	// those that have already lapsed. Used for renewal reminders.
+	ListExpiringExceptions(ctx context.Context, expiresAt pgtype.Date) ([]ListExpiringExceptionsRow, error)
 	ListExternalComments(ctx context.Context, submissionID uuid.UUID) ([]Comment, error)
+	ListFindingEvidence(ctx context.Context, findingID uuid.UUID) ([]FindingEvidence, error)
+	ListFindingsByAudit(ctx context.Context, auditID uuid.UUID) ([]Finding, error)
 	ListInternalComments(ctx context.Context, submissionID uuid.UUID) ([]Comment, error)
 	ListPendingReviews(ctx context.Context) ([]ListPendingReviewsRow, error)
 	ListQuestionAssignments(ctx context.Context, questionID uuid.UUID) ([]QuestionAssignment, error)
+	ListQuestionExceptionsByAudit(ctx context.Context, auditID uuid.UUID) ([]QuestionException, error)
+	// Latest submission state, live evidence count and active exception for every
+	// question in an audit.
+	// Used to decide whether the audit is ready for report generation and to
+	// compute its compliance score.
+	ListQuestionReadiness(ctx context.Context, auditID uuid.UUID) ([]ListQuestionReadinessRow, error)
+	// ListQuestionReadiness of several audits in one query, e.g. to score every
+	// audit of a list.
+	ListQuestionReadinessByAudits(ctx context.Context, auditIds []uuid.UUID) ([]ListQuestionReadinessByAuditsRow, error)
+	// Translations of the questions of an audit into any of the given locales
+	ListQuestionTranslationsByAudit(ctx context.Context, arg ListQuestionTranslationsByAuditParams) ([]QuestionTranslation, error)
 	ListQuestionsByAudit(ctx context.Context, auditID uuid.UUID) ([]Question, error)
 	ListQuestionsBySection(ctx context.Context, arg ListQuestionsBySectionParams) ([]Question, error)
 	// Get questions for a specific user based on their role
@@ -71,21 +121,43 @@ type Querier interface {
 	ListReportsByStatus(ctx context.Context, status ReportStatusEnum) ([]ListReportsByStatusRow, error)
 	ListSubmissionsByStatus(ctx context.Context, status SubmissionStatusEnum) ([]ListSubmissionsByStatusRow, error)
 	ListSubmissionsByUser(ctx context.Context, submittedBy uuid.UUID) ([]ListSubmissionsByUserRow, error)
+	// Findings that are not closed, with the question they were raised against.
+	// Risk accepted findings are included as residual risk.
+	ListUnresolvedFindingsByAudit(ctx context.Context, auditID uuid.UUID) ([]ListUnresolvedFindingsByAuditRow, error)
 	ListUserAssignments(ctx context.Context, assignedTo uuid.UUID) ([]ListUserAssignmentsRow, error)
+	MarkEventPublished(ctx context.Context, id uuid.UUID) error
 	MarkReportDelivered(ctx context.Context, id uuid.UUID) (Report, error)
+	// Links the evidence of a submission to the answers propagated from it that
+	// are not approved yet. Files are shared, not copied, and files already
+	// linked are skipped.
+	PropagateEvidence(ctx context.Context, propagatedFrom pgtype.UUID) (int64, error)
+	// Copies a submitted answer to the questions of a mapped control in the
+	// client's other open audits of the mapped framework, as a new submitted
+	// version awaiting review. Only questions of the same type and options
+	// receive it, and answers given directly to those questions or already
+	// approved are never replaced.
+	PropagateSubmission(ctx context.Context, arg PropagateSubmissionParams) (int64, error)
 	ReferSubmission(ctx context.Context, arg ReferSubmissionParams) (Submission, error)
+	RejectQuestionException(ctx context.Context, arg RejectQuestionExceptionParams) (QuestionException, error)
 	RejectSubmission(ctx context.Context, arg RejectSubmissionParams) (Submission, error)
+	ReopenFinding(ctx context.Context, id uuid.UUID) (Finding, error)
+	RescheduleEvent(ctx context.Context, arg RescheduleEventParams) error
 	ResubmitSubmission(ctx context.Context, arg ResubmitSubmissionParams) (Submission, error)
+	RevokeQuestionException(ctx context.Context, arg RevokeQuestionExceptionParams) (QuestionException, error)
 	SoftDeleteEvidence(ctx context.Context, arg SoftDeleteEvidenceParams) (Evidence, error)
 	SubmitSubmission(ctx context.Context, id uuid.UUID) (Submission, error)
 	UnassignQuestionFromUser(ctx context.Context, arg UnassignQuestionFromUserParams) error
 	UpdateAuditAssignee(ctx context.Context, arg UpdateAuditAssigneeParams) (Audit, error)
 	UpdateAuditStatus(ctx context.Context, arg UpdateAuditStatusParams) (Audit, error)
 	UpdateComment(ctx context.Context, arg UpdateCommentParams) (Comment, error)
+	UpdateFinding(ctx context.Context, arg UpdateFindingParams) (Finding, error)
+	UpdateFindingRemediation(ctx context.Context, arg UpdateFindingRemediationParams) (Finding, error)
+	UpdateFindingStatus(ctx context.Context, arg UpdateFindingStatusParams) (Finding, error)
 	UpdateQuestion(ctx context.Context, arg UpdateQuestionParams) (Question, error)
 	UpdateReportSigned(ctx context.Context, arg UpdateReportSignedParams) (Report, error)
 	UpdateReportUnsigned(ctx context.Context, arg UpdateReportUnsignedParams) (Report, error)
 	UpdateSubmissionAnswer(ctx context.Context, arg UpdateSubmissionAnswerParams) (Submission, error)
+	VerifyFinding(ctx context.Context, arg VerifyFindingParams) (Finding, error)
 }
 
 var _ Querier = (*Queries)(nil)
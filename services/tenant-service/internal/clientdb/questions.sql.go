@@ -53,14 +53,22 @@ func (q *Queries) AssignQuestionToUser(ctx context.Context, arg AssignQuestionTo
 }
 
 type BulkCreateQuestionsParams struct {
-	AuditID        uuid.UUID        `json:"audit_id"`
-	Section        string           `json:"section"`
-	QuestionNumber string           `json:"question_number"`
-	QuestionText   string           `json:"question_text"`
-	QuestionType   QuestionTypeEnum `json:"question_type"`
-	HelpText       *string          `json:"help_text"`
-	IsMandatory    bool             `json:"is_mandatory"`
-	DisplayOrder   int32            `json:"display_order"`
+	AuditID              uuid.UUID            `json:"audit_id"`
+	Section              string               `json:"section"`
+	QuestionNumber       string               `json:"question_number"`
+	QuestionText         string               `json:"question_text"`
+	QuestionType         QuestionTypeEnum     `json:"question_type"`
+	HelpText             *string              `json:"help_text"`
+	IsMandatory          bool                 `json:"is_mandatory"`
+	DisplayOrder         int32                `json:"display_order"`
+	VisibilityCondition  []byte               `json:"visibility_condition"`
+	Options              []byte               `json:"options"`
+	Weight               int32                `json:"weight"`
+	Severity             QuestionSeverityEnum `json:"severity"`
+	Subsection           *string              `json:"subsection"`
+	EvidenceRequired     bool                 `json:"evidence_required"`
+	AcceptableEvidence   []string             `json:"acceptable_evidence"`
+	EvidenceRequirements []byte               `json:"evidence_requirements"`
 }
 
 const CreateQuestion = `-- name: CreateQuestion :one
@@ -72,21 +80,37 @@ INSERT INTO questions (
     question_type,
     help_text,
     is_mandatory,
-    display_order
+    display_order,
+    visibility_condition,
+    options,
+    weight,
+    severity,
+    subsection,
+    evidence_required,
+    acceptable_evidence,
+    evidence_requirements
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8
-) RETURNING id, audit_id, section, question_number, question_text, question_type, help_text, is_mandatory, display_order, created_at, updated_at
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16
+) RETURNING id, audit_id, section, question_number, question_text, question_type, help_text, is_mandatory, display_order, created_at, updated_at, visibility_condition, options, weight, severity, subsection, evidence_required, acceptable_evidence, evidence_requirements
 `
 
 type CreateQuestionParams struct {
-	AuditID        uuid.UUID        `json:"audit_id"`
-	Section        string           `json:"section"`
-	QuestionNumber string           `json:"question_number"`
-	QuestionText   string           `json:"question_text"`
-	QuestionType   QuestionTypeEnum `json:"question_type"`
-	HelpText       *string          `json:"help_text"`
-	IsMandatory    bool             `json:"is_mandatory"`
-	DisplayOrder   int32            `json:"display_order"`
+	AuditID              uuid.UUID            `json:"audit_id"`
+	Section              string               `json:"section"`
+	QuestionNumber       string               `json:"question_number"`
+	QuestionText         string               `json:"question_text"`
+	QuestionType         QuestionTypeEnum     `json:"question_type"`
+	HelpText             *string              `json:"help_text"`
+	IsMandatory          bool                 `json:"is_mandatory"`
+	DisplayOrder         int32                `json:"display_order"`
+	VisibilityCondition  []byte               `json:"visibility_condition"`
+	Options              []byte               `json:"options"`
+	Weight               int32                `json:"weight"`
+	Severity             QuestionSeverityEnum `json:"severity"`
+	Subsection           *string              `json:"subsection"`
+	EvidenceRequired     bool                 `json:"evidence_required"`
+	AcceptableEvidence   []string             `json:"acceptable_evidence"`
+	EvidenceRequirements []byte               `json:"evidence_requirements"`
 }
 
 func (q *Queries) CreateQuestion(ctx context.Context, arg CreateQuestionParams) (Question, error) {
@@ -99,6 +123,14 @@ func (q *Queries) CreateQuestion(ctx context.Context, arg CreateQuestionParams)
 		arg.HelpText,
 		arg.IsMandatory,
 		arg.DisplayOrder,
+		arg.VisibilityCondition,
+		arg.Options,
+		arg.Weight,
+		arg.Severity,
+		arg.Subsection,
+		arg.EvidenceRequired,
+		arg.AcceptableEvidence,
+		arg.EvidenceRequirements,
 	)
 	var i Question
 	err := row.Scan(
@@ -113,6 +145,14 @@ func (q *Queries) CreateQuestion(ctx context.Context, arg CreateQuestionParams)
 		&i.DisplayOrder,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.VisibilityCondition,
+		&i.Options,
+		&i.Weight,
+		&i.Severity,
+		&i.Subsection,
+		&i.EvidenceRequired,
+		&i.AcceptableEvidence,
+		&i.EvidenceRequirements,
 	)
 	return i, err
 }
@@ -128,7 +168,7 @@ func (q *Queries) DeleteQuestion(ctx context.Context, id uuid.UUID) error {
 }
 
 const GetQuestionByID = `-- name: GetQuestionByID :one
-SELECT id, audit_id, section, question_number, question_text, question_type, help_text, is_mandatory, display_order, created_at, updated_at FROM questions
+SELECT id, audit_id, section, question_number, question_text, question_type, help_text, is_mandatory, display_order, created_at, updated_at, visibility_condition, options, weight, severity, subsection, evidence_required, acceptable_evidence, evidence_requirements FROM questions
 WHERE id = $1
 `
 
@@ -147,16 +187,25 @@ func (q *Queries) GetQuestionByID(ctx context.Context, id uuid.UUID) (Question,
 		&i.DisplayOrder,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.VisibilityCondition,
+		&i.Options,
+		&i.Weight,
+		&i.Severity,
+		&i.Subsection,
+		&i.EvidenceRequired,
+		&i.AcceptableEvidence,
+		&i.EvidenceRequirements,
 	)
 	return i, err
 }
 
 const GetQuestionWithSubmission = `-- name: GetQuestionWithSubmission :one
 SELECT 
-    q.id, q.audit_id, q.section, q.question_number, q.question_text, q.question_type, q.help_text, q.is_mandatory, q.display_order, q.created_at, q.updated_at,
+    q.id, q.audit_id, q.section, q.question_number, q.question_text, q.question_type, q.help_text, q.is_mandatory, q.display_order, q.created_at, q.updated_at, q.visibility_condition, q.options, q.weight, q.severity, q.subsection, q.evidence_required, q.acceptable_evidence, q.evidence_requirements,
     s.id as submission_id,
     s.answer_value,
     s.answer_text,
+    s.answer_data,
     s.explanation,
     s.status as submission_status,
     s.submitted_at,
@@ -169,26 +218,35 @@ WHERE q.id = $1
 `
 
 type GetQuestionWithSubmissionRow struct {
-	ID               uuid.UUID                `json:"id"`
-	AuditID          uuid.UUID                `json:"audit_id"`
-	Section          string                   `json:"section"`
-	QuestionNumber   string                   `json:"question_number"`
-	QuestionText     string                   `json:"question_text"`
-	QuestionType     QuestionTypeEnum         `json:"question_type"`
-	HelpText         *string                  `json:"help_text"`
-	IsMandatory      bool                     `json:"is_mandatory"`
-	DisplayOrder     int32                    `json:"display_order"`
-	CreatedAt        pgtype.Timestamptz       `json:"created_at"`
-	UpdatedAt        pgtype.Timestamptz       `json:"updated_at"`
-	SubmissionID     pgtype.UUID              `json:"submission_id"`
-	AnswerValue      NullAnswerValueEnum      `json:"answer_value"`
-	AnswerText       *string                  `json:"answer_text"`
-	Explanation      *string                  `json:"explanation"`
-	SubmissionStatus NullSubmissionStatusEnum `json:"submission_status"`
-	SubmittedAt      pgtype.Timestamptz       `json:"submitted_at"`
-	ReviewedBy       pgtype.UUID              `json:"reviewed_by"`
-	ReviewedAt       pgtype.Timestamptz       `json:"reviewed_at"`
-	ReviewNotes      *string                  `json:"review_notes"`
+	ID                   uuid.UUID                `json:"id"`
+	AuditID              uuid.UUID                `json:"audit_id"`
+	Section              string                   `json:"section"`
+	QuestionNumber       string                   `json:"question_number"`
+	QuestionText         string                   `json:"question_text"`
+	QuestionType         QuestionTypeEnum         `json:"question_type"`
+	HelpText             *string                  `json:"help_text"`
+	IsMandatory          bool                     `json:"is_mandatory"`
+	DisplayOrder         int32                    `json:"display_order"`
+	CreatedAt            pgtype.Timestamptz       `json:"created_at"`
+	UpdatedAt            pgtype.Timestamptz       `json:"updated_at"`
+	VisibilityCondition  []byte                   `json:"visibility_condition"`
+	Options              []byte                   `json:"options"`
+	Weight               int32                    `json:"weight"`
+	Severity             QuestionSeverityEnum     `json:"severity"`
+	Subsection           *string                  `json:"subsection"`
+	EvidenceRequired     bool                     `json:"evidence_required"`
+	AcceptableEvidence   []string                 `json:"acceptable_evidence"`
+	EvidenceRequirements []byte                   `json:"evidence_requirements"`
+	SubmissionID         pgtype.UUID              `json:"submission_id"`
+	AnswerValue          NullAnswerValueEnum      `json:"answer_value"`
+	AnswerText           *string                  `json:"answer_text"`
+	AnswerData           []byte                   `json:"answer_data"`
+	Explanation          *string                  `json:"explanation"`
+	SubmissionStatus     NullSubmissionStatusEnum `json:"submission_status"`
+	SubmittedAt          pgtype.Timestamptz       `json:"submitted_at"`
+	ReviewedBy           pgtype.UUID              `json:"reviewed_by"`
+	ReviewedAt           pgtype.Timestamptz       `json:"reviewed_at"`
+	ReviewNotes          *string                  `json:"review_notes"`
 }
 
 func (q *Queries) GetQuestionWithSubmission(ctx context.Context, id uuid.UUID) (GetQuestionWithSubmissionRow, error) {
@@ -206,9 +264,18 @@ func (q *Queries) GetQuestionWithSubmission(ctx context.Context, id uuid.UUID) (
 		&i.DisplayOrder,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.VisibilityCondition,
+		&i.Options,
+		&i.Weight,
+		&i.Severity,
+		&i.Subsection,
+		&i.EvidenceRequired,
+		&i.AcceptableEvidence,
+		&i.EvidenceRequirements,
 		&i.SubmissionID,
 		&i.AnswerValue,
 		&i.AnswerText,
+		&i.AnswerData,
 		&i.Explanation,
 		&i.SubmissionStatus,
 		&i.SubmittedAt,
@@ -252,7 +319,7 @@ func (q *Queries) ListQuestionAssignments(ctx context.Context, questionID uuid.U
 }
 
 const ListQuestionsByAudit = `-- name: ListQuestionsByAudit :many
-SELECT id, audit_id, section, question_number, question_text, question_type, help_text, is_mandatory, display_order, created_at, updated_at FROM questions
+SELECT id, audit_id, section, question_number, question_text, question_type, help_text, is_mandatory, display_order, created_at, updated_at, visibility_condition, options, weight, severity, subsection, evidence_required, acceptable_evidence, evidence_requirements FROM questions
 WHERE audit_id = $1
 ORDER BY display_order ASC
 `
@@ -278,6 +345,14 @@ func (q *Queries) ListQuestionsByAudit(ctx context.Context, auditID uuid.UUID) (
 			&i.DisplayOrder,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.VisibilityCondition,
+			&i.Options,
+			&i.Weight,
+			&i.Severity,
+			&i.Subsection,
+			&i.EvidenceRequired,
+			&i.AcceptableEvidence,
+			&i.EvidenceRequirements,
 		); err != nil {
 			return nil, err
 		}
@@ -290,7 +365,7 @@ func (q *Queries) ListQuestionsByAudit(ctx context.Context, auditID uuid.UUID) (
 }
 
 const ListQuestionsBySection = `-- name: ListQuestionsBySection :many
-SELECT id, audit_id, section, question_number, question_text, question_type, help_text, is_mandatory, display_order, created_at, updated_at FROM questions
+SELECT id, audit_id, section, question_number, question_text, question_type, help_text, is_mandatory, display_order, created_at, updated_at, visibility_condition, options, weight, severity, subsection, evidence_required, acceptable_evidence, evidence_requirements FROM questions
 WHERE audit_id = $1 AND section = $2
 ORDER BY display_order ASC
 `
@@ -321,6 +396,14 @@ func (q *Queries) ListQuestionsBySection(ctx context.Context, arg ListQuestionsB
 			&i.DisplayOrder,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.VisibilityCondition,
+			&i.Options,
+			&i.Weight,
+			&i.Severity,
+			&i.Subsection,
+			&i.EvidenceRequired,
+			&i.AcceptableEvidence,
+			&i.EvidenceRequirements,
 		); err != nil {
 			return nil, err
 		}
@@ -334,14 +417,16 @@ func (q *Queries) ListQuestionsBySection(ctx context.Context, arg ListQuestionsB
 
 const ListQuestionsForUser = `-- name: ListQuestionsForUser :many
 SELECT DISTINCT
-    q.id, q.audit_id, q.section, q.question_number, q.question_text, q.question_type, q.help_text, q.is_mandatory, q.display_order, q.created_at, q.updated_at,
+    q.id, q.audit_id, q.section, q.question_number, q.question_text, q.question_type, q.help_text, q.is_mandatory, q.display_order, q.created_at, q.updated_at, q.visibility_condition, q.options, q.weight, q.severity, q.subsection, q.evidence_required, q.acceptable_evidence, q.evidence_requirements,
     s.id as submission_id,
     s.answer_value,
     s.answer_text,
+    s.answer_data,
     s.explanation,
     s.status as submission_status,
     s.submitted_at,
     s.submitted_by,
+    s.is_carried_forward,
     qa.assigned_to as assigned_user_id
 FROM questions q
 LEFT JOIN submissions s ON s.question_id = q.id
@@ -361,25 +446,35 @@ type ListQuestionsForUserParams struct {
 }
 
 type ListQuestionsForUserRow struct {
-	ID               uuid.UUID                `json:"id"`
-	AuditID          uuid.UUID                `json:"audit_id"`
-	Section          string                   `json:"section"`
-	QuestionNumber   string                   `json:"question_number"`
-	QuestionText     string                   `json:"question_text"`
-	QuestionType     QuestionTypeEnum         `json:"question_type"`
-	HelpText         *string                  `json:"help_text"`
-	IsMandatory      bool                     `json:"is_mandatory"`
-	DisplayOrder     int32                    `json:"display_order"`
-	CreatedAt        pgtype.Timestamptz       `json:"created_at"`
-	UpdatedAt        pgtype.Timestamptz       `json:"updated_at"`
-	SubmissionID     pgtype.UUID              `json:"submission_id"`
-	AnswerValue      NullAnswerValueEnum      `json:"answer_value"`
-	AnswerText       *string                  `json:"answer_text"`
-	Explanation      *string                  `json:"explanation"`
-	SubmissionStatus NullSubmissionStatusEnum `json:"submission_status"`
-	SubmittedAt      pgtype.Timestamptz       `json:"submitted_at"`
-	SubmittedBy      pgtype.UUID              `json:"submitted_by"`
-	AssignedUserID   pgtype.UUID              `json:"assigned_user_id"`
+	ID                   uuid.UUID                `json:"id"`
+	AuditID              uuid.UUID                `json:"audit_id"`
+	Section              string                   `json:"section"`
+	QuestionNumber       string                   `json:"question_number"`
+	QuestionText         string                   `json:"question_text"`
+	QuestionType         QuestionTypeEnum         `json:"question_type"`
+	HelpText             *string                  `json:"help_text"`
+	IsMandatory          bool                     `json:"is_mandatory"`
+	DisplayOrder         int32                    `json:"display_order"`
+	CreatedAt            pgtype.Timestamptz       `json:"created_at"`
+	UpdatedAt            pgtype.Timestamptz       `json:"updated_at"`
+	VisibilityCondition  []byte                   `json:"visibility_condition"`
+	Options              []byte                   `json:"options"`
+	Weight               int32                    `json:"weight"`
+	Severity             QuestionSeverityEnum     `json:"severity"`
+	Subsection           *string                  `json:"subsection"`
+	EvidenceRequired     bool                     `json:"evidence_required"`
+	AcceptableEvidence   []string                 `json:"acceptable_evidence"`
+	EvidenceRequirements []byte                   `json:"evidence_requirements"`
+	SubmissionID         pgtype.UUID              `json:"submission_id"`
+	AnswerValue          NullAnswerValueEnum      `json:"answer_value"`
+	AnswerText           *string                  `json:"answer_text"`
+	AnswerData           []byte                   `json:"answer_data"`
+	Explanation          *string                  `json:"explanation"`
+	SubmissionStatus     NullSubmissionStatusEnum `json:"submission_status"`
+	SubmittedAt          pgtype.Timestamptz       `json:"submitted_at"`
+	SubmittedBy          pgtype.UUID              `json:"submitted_by"`
+	IsCarriedForward     *bool                    `json:"is_carried_forward"`
+	AssignedUserID       pgtype.UUID              `json:"assigned_user_id"`
 }
 
 // Get questions for a specific user based on their role
@@ -405,13 +500,23 @@ func (q *Queries) ListQuestionsForUser(ctx context.Context, arg ListQuestionsFor
 			&i.DisplayOrder,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.VisibilityCondition,
+			&i.Options,
+			&i.Weight,
+			&i.Severity,
+			&i.Subsection,
+			&i.EvidenceRequired,
+			&i.AcceptableEvidence,
+			&i.EvidenceRequirements,
 			&i.SubmissionID,
 			&i.AnswerValue,
 			&i.AnswerText,
+			&i.AnswerData,
 			&i.Explanation,
 			&i.SubmissionStatus,
 			&i.SubmittedAt,
 			&i.SubmittedBy,
+			&i.IsCarriedForward,
 			&i.AssignedUserID,
 		); err != nil {
 			return nil, err
@@ -426,8 +531,11 @@ func (q *Queries) ListQuestionsForUser(ctx context.Context, arg ListQuestionsFor
 
 const ListQuestionsWithSubmissions = `-- name: ListQuestionsWithSubmissions :many
 SELECT 
-    q.id, q.audit_id, q.section, q.question_number, q.question_text, q.question_type, q.help_text, q.is_mandatory, q.display_order, q.created_at, q.updated_at,
+    q.id, q.audit_id, q.section, q.question_number, q.question_text, q.question_type, q.help_text, q.is_mandatory, q.display_order, q.created_at, q.updated_at, q.visibility_condition, q.options, q.weight, q.severity, q.subsection, q.evidence_required, q.acceptable_evidence, q.evidence_requirements,
     s.id as submission_id,
+    s.answer_value,
+    s.answer_text,
+    s.answer_data,
     s.status as submission_status,
     s.submitted_at
 FROM questions q
@@ -437,20 +545,31 @@ ORDER BY q.display_order ASC
 `
 
 type ListQuestionsWithSubmissionsRow struct {
-	ID               uuid.UUID                `json:"id"`
-	AuditID          uuid.UUID                `json:"audit_id"`
-	Section          string                   `json:"section"`
-	QuestionNumber   string                   `json:"question_number"`
-	QuestionText     string                   `json:"question_text"`
-	QuestionType     QuestionTypeEnum         `json:"question_type"`
-	HelpText         *string                  `json:"help_text"`
-	IsMandatory      bool                     `json:"is_mandatory"`
-	DisplayOrder     int32                    `json:"display_order"`
-	CreatedAt        pgtype.Timestamptz       `json:"created_at"`
-	UpdatedAt        pgtype.Timestamptz       `json:"updated_at"`
-	SubmissionID     pgtype.UUID              `json:"submission_id"`
-	SubmissionStatus NullSubmissionStatusEnum `json:"submission_status"`
-	SubmittedAt      pgtype.Timestamptz       `json:"submitted_at"`
+	ID                   uuid.UUID                `json:"id"`
+	AuditID              uuid.UUID                `json:"audit_id"`
+	Section              string                   `json:"section"`
+	QuestionNumber       string                   `json:"question_number"`
+	QuestionText         string                   `json:"question_text"`
+	QuestionType         QuestionTypeEnum         `json:"question_type"`
+	HelpText             *string                  `json:"help_text"`
+	IsMandatory          bool                     `json:"is_mandatory"`
+	DisplayOrder         int32                    `json:"display_order"`
+	CreatedAt            pgtype.Timestamptz       `json:"created_at"`
+	UpdatedAt            pgtype.Timestamptz       `json:"updated_at"`
+	VisibilityCondition  []byte                   `json:"visibility_condition"`
+	Options              []byte                   `json:"options"`
+	Weight               int32                    `json:"weight"`
+	Severity             QuestionSeverityEnum     `json:"severity"`
+	Subsection           *string                  `json:"subsection"`
+	EvidenceRequired     bool                     `json:"evidence_required"`
+	AcceptableEvidence   []string                 `json:"acceptable_evidence"`
+	EvidenceRequirements []byte                   `json:"evidence_requirements"`
+	SubmissionID         pgtype.UUID              `json:"submission_id"`
+	AnswerValue          NullAnswerValueEnum      `json:"answer_value"`
+	AnswerText           *string                  `json:"answer_text"`
+	AnswerData           []byte                   `json:"answer_data"`
+	SubmissionStatus     NullSubmissionStatusEnum `json:"submission_status"`
+	SubmittedAt          pgtype.Timestamptz       `json:"submitted_at"`
 }
 
 func (q *Queries) ListQuestionsWithSubmissions(ctx context.Context, auditID uuid.UUID) ([]ListQuestionsWithSubmissionsRow, error) {
@@ -474,7 +593,18 @@ func (q *Queries) ListQuestionsWithSubmissions(ctx context.Context, auditID uuid
 			&i.DisplayOrder,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.VisibilityCondition,
+			&i.Options,
+			&i.Weight,
+			&i.Severity,
+			&i.Subsection,
+			&i.EvidenceRequired,
+			&i.AcceptableEvidence,
+			&i.EvidenceRequirements,
 			&i.SubmissionID,
+			&i.AnswerValue,
+			&i.AnswerText,
+			&i.AnswerData,
 			&i.SubmissionStatus,
 			&i.SubmittedAt,
 		); err != nil {
@@ -568,7 +698,7 @@ SET
     help_text = COALESCE($3, help_text),
     is_mandatory = COALESCE($4, is_mandatory)
 WHERE id = $1
-RETURNING id, audit_id, section, question_number, question_text, question_type, help_text, is_mandatory, display_order, created_at, updated_at
+RETURNING id, audit_id, section, question_number, question_text, question_type, help_text, is_mandatory, display_order, created_at, updated_at, visibility_condition, options, weight, severity, subsection, evidence_required, acceptable_evidence, evidence_requirements
 `
 
 type UpdateQuestionParams struct {
@@ -598,6 +728,14 @@ func (q *Queries) UpdateQuestion(ctx context.Context, arg UpdateQuestionParams)
 		&i.DisplayOrder,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.VisibilityCondition,
+		&i.Options,
+		&i.Weight,
+		&i.Severity,
+		&i.Subsection,
+		&i.EvidenceRequired,
+		&i.AcceptableEvidence,
+		&i.EvidenceRequirements,
 	)
 	return i, err
 }
@@ -17,9 +17,10 @@ INSERT INTO reports (
     audit_id,
     unsigned_file_path,
     generated_by,
-    status
+    status,
+    metadata
 ) VALUES (
-    $1, $2, $3, $4
+    $1, $2, $3, $4, $5
 ) RETURNING id, audit_id, unsigned_file_path, signed_file_path, generated_by, generated_at, signed_by, signed_at, status, metadata, created_at, updated_at
 `
 
@@ -28,6 +29,7 @@ type CreateReportParams struct {
 	UnsignedFilePath *string          `json:"unsigned_file_path"`
 	GeneratedBy      uuid.UUID        `json:"generated_by"`
 	Status           ReportStatusEnum `json:"status"`
+	Metadata         []byte           `json:"metadata"`
 }
 
 func (q *Queries) CreateReport(ctx context.Context, arg CreateReportParams) (Report, error) {
@@ -36,6 +38,7 @@ func (q *Queries) CreateReport(ctx context.Context, arg CreateReportParams) (Rep
 		arg.UnsignedFilePath,
 		arg.GeneratedBy,
 		arg.Status,
+		arg.Metadata,
 	)
 	var i Report
 	err := row.Scan(
@@ -115,6 +118,191 @@ func (q *Queries) GetReportByID(ctx context.Context, id uuid.UUID) (Report, erro
 	return i, err
 }
 
+const ListQuestionReadiness = `-- name: ListQuestionReadiness :many
+SELECT
+    q.id,
+    q.section,
+    q.question_number,
+    q.question_text,
+    q.is_mandatory,
+    q.visibility_condition,
+    q.weight,
+    q.severity,
+    s.id as submission_id,
+    s.answer_value,
+    s.answer_data,
+    s.status as submission_status,
+    (
+        SELECT COUNT(*) FROM evidence e
+        WHERE e.submission_id = s.id AND e.is_deleted = false
+    ) as evidence_count,
+    EXISTS (
+        SELECT 1 FROM question_exceptions x
+        WHERE x.question_id = q.id
+          AND x.status = 'approved'
+          AND x.expires_at >= CURRENT_DATE
+    ) as has_active_exception
+FROM questions q
+LEFT JOIN LATERAL (
+    SELECT sub.id, sub.answer_value, sub.answer_data, sub.status
+    FROM submissions sub
+    WHERE sub.question_id = q.id
+    ORDER BY sub.version DESC
+    LIMIT 1
+) s ON true
+WHERE q.audit_id = $1
+ORDER BY q.display_order ASC
+`
+
+type ListQuestionReadinessRow struct {
+	ID                  uuid.UUID                `json:"id"`
+	Section             string                   `json:"section"`
+	QuestionNumber      string                   `json:"question_number"`
+	QuestionText        string                   `json:"question_text"`
+	IsMandatory         bool                     `json:"is_mandatory"`
+	VisibilityCondition []byte                   `json:"visibility_condition"`
+	Weight              int32                    `json:"weight"`
+	Severity            QuestionSeverityEnum     `json:"severity"`
+	SubmissionID        pgtype.UUID              `json:"submission_id"`
+	AnswerValue         NullAnswerValueEnum      `json:"answer_value"`
+	AnswerData          []byte                   `json:"answer_data"`
+	SubmissionStatus    NullSubmissionStatusEnum `json:"submission_status"`
+	EvidenceCount       int64                    `json:"evidence_count"`
+	HasActiveException  bool                     `json:"has_active_exception"`
+}
+
+// Latest submission state, live evidence count and active exception for every
+// question in an audit.
+// Used to decide whether the audit is ready for report generation and to
+// compute its compliance score.
+func (q *Queries) ListQuestionReadiness(ctx context.Context, auditID uuid.UUID) ([]ListQuestionReadinessRow, error) {
+	rows, err := q.db.Query(ctx, ListQuestionReadiness, auditID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListQuestionReadinessRow{}
+	for rows.Next() {
+		var i ListQuestionReadinessRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Section,
+			&i.QuestionNumber,
+			&i.QuestionText,
+			&i.IsMandatory,
+			&i.VisibilityCondition,
+			&i.Weight,
+			&i.Severity,
+			&i.SubmissionID,
+			&i.AnswerValue,
+			&i.AnswerData,
+			&i.SubmissionStatus,
+			&i.EvidenceCount,
+			&i.HasActiveException,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListQuestionReadinessByAudits = `-- name: ListQuestionReadinessByAudits :many
+SELECT
+    q.audit_id,
+    q.id,
+    q.section,
+    q.question_number,
+    q.question_text,
+    q.is_mandatory,
+    q.visibility_condition,
+    q.weight,
+    q.severity,
+    s.id as submission_id,
+    s.answer_value,
+    s.answer_data,
+    s.status as submission_status,
+    (
+        SELECT COUNT(*) FROM evidence e
+        WHERE e.submission_id = s.id AND e.is_deleted = false
+    ) as evidence_count,
+    EXISTS (
+        SELECT 1 FROM question_exceptions x
+        WHERE x.question_id = q.id
+          AND x.status = 'approved'
+          AND x.expires_at >= CURRENT_DATE
+    ) as has_active_exception
+FROM questions q
+LEFT JOIN LATERAL (
+    SELECT sub.id, sub.answer_value, sub.answer_data, sub.status
+    FROM submissions sub
+    WHERE sub.question_id = q.id
+    ORDER BY sub.version DESC
+    LIMIT 1
+) s ON true
+WHERE q.audit_id = ANY($1::uuid[])
+ORDER BY q.audit_id, q.display_order ASC
+`
+
+type ListQuestionReadinessByAuditsRow struct {
+	AuditID             uuid.UUID                `json:"audit_id"`
+	ID                  uuid.UUID                `json:"id"`
+	Section             string                   `json:"section"`
+	QuestionNumber      string                   `json:"question_number"`
+	QuestionText        string                   `json:"question_text"`
+	IsMandatory         bool                     `json:"is_mandatory"`
+	VisibilityCondition []byte                   `json:"visibility_condition"`
+	Weight              int32                    `json:"weight"`
+	Severity            QuestionSeverityEnum     `json:"severity"`
+	SubmissionID        pgtype.UUID              `json:"submission_id"`
+	AnswerValue         NullAnswerValueEnum      `json:"answer_value"`
+	AnswerData          []byte                   `json:"answer_data"`
+	SubmissionStatus    NullSubmissionStatusEnum `json:"submission_status"`
+	EvidenceCount       int64                    `json:"evidence_count"`
+	HasActiveException  bool                     `json:"has_active_exception"`
+}
+
+// ListQuestionReadiness of several audits in one query, e.g. to score every
+// audit of a list.
+func (q *Queries) ListQuestionReadinessByAudits(ctx context.Context, auditIds []uuid.UUID) ([]ListQuestionReadinessByAuditsRow, error) {
+	rows, err := q.db.Query(ctx, ListQuestionReadinessByAudits, auditIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListQuestionReadinessByAuditsRow{}
+	for rows.Next() {
+		var i ListQuestionReadinessByAuditsRow
+		if err := rows.Scan(
+			&i.AuditID,
+			&i.ID,
+			&i.Section,
+			&i.QuestionNumber,
+			&i.QuestionText,
+			&i.IsMandatory,
+			&i.VisibilityCondition,
+			&i.Weight,
+			&i.Severity,
+			&i.SubmissionID,
+			&i.AnswerValue,
+			&i.AnswerData,
+			&i.SubmissionStatus,
+			&i.EvidenceCount,
+			&i.HasActiveException,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const ListReportsByStatus = `-- name: ListReportsByStatus :many
 SELECT 
     r.id, r.audit_id, r.unsigned_file_path, r.signed_file_path, r.generated_by, r.generated_at, r.signed_by, r.signed_at, r.status, r.metadata, r.created_at, r.updated_at,
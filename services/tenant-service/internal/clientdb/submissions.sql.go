@@ -20,7 +20,7 @@ SET
     reviewed_at = NOW(),
     review_notes = $3
 WHERE id = $1
-RETURNING id, question_id, submitted_by, answer_value, answer_text, explanation, status, submitted_at, reviewed_by, reviewed_at, review_notes, rejection_reason, version, created_at, updated_at
+RETURNING id, question_id, submitted_by, answer_value, answer_text, explanation, status, submitted_at, reviewed_by, reviewed_at, review_notes, rejection_reason, version, created_at, updated_at, answer_data, carried_forward_from, is_carried_forward, propagated_from
 `
 
 type ApproveSubmissionParams struct {
@@ -48,6 +48,104 @@ func (q *Queries) ApproveSubmission(ctx context.Context, arg ApproveSubmissionPa
 		&i.Version,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AnswerData,
+		&i.CarriedForwardFrom,
+		&i.IsCarriedForward,
+		&i.PropagatedFrom,
+	)
+	return i, err
+}
+
+const CarryForwardSubmissions = `-- name: CarryForwardSubmissions :execrows
+INSERT INTO submissions (
+    question_id,
+    submitted_by,
+    answer_value,
+    answer_text,
+    explanation,
+    status,
+    answer_data,
+    carried_forward_from,
+    is_carried_forward
+)
+SELECT
+    nq.id,
+    s.submitted_by,
+    s.answer_value,
+    s.answer_text,
+    s.explanation,
+    'in_progress',
+    s.answer_data,
+    s.id,
+    true
+FROM questions nq
+JOIN questions oq ON oq.question_number = nq.question_number
+    AND oq.audit_id = (
+        SELECT a.id FROM audits a
+        WHERE a.framework_id = $2 AND a.status = 'completed' AND a.id <> $1
+        ORDER BY a.completed_at DESC NULLS LAST
+        LIMIT 1
+    )
+JOIN LATERAL (
+    SELECT sub.id, sub.submitted_by, sub.answer_value, sub.answer_text, sub.explanation, sub.answer_data, sub.status
+    FROM submissions sub
+    WHERE sub.question_id = oq.id
+    ORDER BY sub.version DESC
+    LIMIT 1
+) s ON s.status = 'approved'
+WHERE nq.audit_id = $1
+  AND NOT EXISTS (
+      SELECT 1 FROM submissions existing WHERE existing.question_id = nq.id
+  )
+`
+
+type CarryForwardSubmissionsParams struct {
+	AuditID     uuid.UUID `json:"audit_id"`
+	FrameworkID uuid.UUID `json:"framework_id"`
+}
+
+// Prefills the unanswered questions of an audit with the latest approved
+// answers of the most recent completed audit of the same framework, matched
+// by question number. Carried forward answers stay in progress until the
+// client confirms or updates them.
+func (q *Queries) CarryForwardSubmissions(ctx context.Context, arg CarryForwardSubmissionsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, CarryForwardSubmissions, arg.AuditID, arg.FrameworkID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const ConfirmCarriedForwardSubmission = `-- name: ConfirmCarriedForwardSubmission :one
+UPDATE submissions
+SET is_carried_forward = false
+WHERE id = $1 AND is_carried_forward = true
+RETURNING id, question_id, submitted_by, answer_value, answer_text, explanation, status, submitted_at, reviewed_by, reviewed_at, review_notes, rejection_reason, version, created_at, updated_at, answer_data, carried_forward_from, is_carried_forward, propagated_from
+`
+
+func (q *Queries) ConfirmCarriedForwardSubmission(ctx context.Context, id uuid.UUID) (Submission, error) {
+	row := q.db.QueryRow(ctx, ConfirmCarriedForwardSubmission, id)
+	var i Submission
+	err := row.Scan(
+		&i.ID,
+		&i.QuestionID,
+		&i.SubmittedBy,
+		&i.AnswerValue,
+		&i.AnswerText,
+		&i.Explanation,
+		&i.Status,
+		&i.SubmittedAt,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.ReviewNotes,
+		&i.RejectionReason,
+		&i.Version,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.AnswerData,
+		&i.CarriedForwardFrom,
+		&i.IsCarriedForward,
+		&i.PropagatedFrom,
 	)
 	return i, err
 }
@@ -59,10 +157,11 @@ INSERT INTO submissions (
     answer_value,
     answer_text,
     explanation,
-    status
+    status,
+    answer_data
 ) VALUES (
-    $1, $2, $3, $4, $5, $6
-) RETURNING id, question_id, submitted_by, answer_value, answer_text, explanation, status, submitted_at, reviewed_by, reviewed_at, review_notes, rejection_reason, version, created_at, updated_at
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING id, question_id, submitted_by, answer_value, answer_text, explanation, status, submitted_at, reviewed_by, reviewed_at, review_notes, rejection_reason, version, created_at, updated_at, answer_data, carried_forward_from, is_carried_forward, propagated_from
 `
 
 type CreateSubmissionParams struct {
@@ -72,6 +171,7 @@ type CreateSubmissionParams struct {
 	AnswerText  *string              `json:"answer_text"`
 	Explanation string               `json:"explanation"`
 	Status      SubmissionStatusEnum `json:"status"`
+	AnswerData  []byte               `json:"answer_data"`
 }
 
 func (q *Queries) CreateSubmission(ctx context.Context, arg CreateSubmissionParams) (Submission, error) {
@@ -82,6 +182,7 @@ func (q *Queries) CreateSubmission(ctx context.Context, arg CreateSubmissionPara
 		arg.AnswerText,
 		arg.Explanation,
 		arg.Status,
+		arg.AnswerData,
 	)
 	var i Submission
 	err := row.Scan(
@@ -100,12 +201,16 @@ func (q *Queries) CreateSubmission(ctx context.Context, arg CreateSubmissionPara
 		&i.Version,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AnswerData,
+		&i.CarriedForwardFrom,
+		&i.IsCarriedForward,
+		&i.PropagatedFrom,
 	)
 	return i, err
 }
 
 const GetSubmissionByID = `-- name: GetSubmissionByID :one
-SELECT id, question_id, submitted_by, answer_value, answer_text, explanation, status, submitted_at, reviewed_by, reviewed_at, review_notes, rejection_reason, version, created_at, updated_at FROM submissions
+SELECT id, question_id, submitted_by, answer_value, answer_text, explanation, status, submitted_at, reviewed_by, reviewed_at, review_notes, rejection_reason, version, created_at, updated_at, answer_data, carried_forward_from, is_carried_forward, propagated_from FROM submissions
 WHERE id = $1
 `
 
@@ -128,12 +233,16 @@ func (q *Queries) GetSubmissionByID(ctx context.Context, id uuid.UUID) (Submissi
 		&i.Version,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AnswerData,
+		&i.CarriedForwardFrom,
+		&i.IsCarriedForward,
+		&i.PropagatedFrom,
 	)
 	return i, err
 }
 
 const GetSubmissionByQuestionID = `-- name: GetSubmissionByQuestionID :one
-SELECT id, question_id, submitted_by, answer_value, answer_text, explanation, status, submitted_at, reviewed_by, reviewed_at, review_notes, rejection_reason, version, created_at, updated_at FROM submissions
+SELECT id, question_id, submitted_by, answer_value, answer_text, explanation, status, submitted_at, reviewed_by, reviewed_at, review_notes, rejection_reason, version, created_at, updated_at, answer_data, carried_forward_from, is_carried_forward, propagated_from FROM submissions
 WHERE question_id = $1
 ORDER BY version DESC
 LIMIT 1
@@ -158,13 +267,17 @@ func (q *Queries) GetSubmissionByQuestionID(ctx context.Context, questionID uuid
 		&i.Version,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AnswerData,
+		&i.CarriedForwardFrom,
+		&i.IsCarriedForward,
+		&i.PropagatedFrom,
 	)
 	return i, err
 }
 
 const GetSubmissionWithEvidence = `-- name: GetSubmissionWithEvidence :one
 SELECT 
-    s.id, s.question_id, s.submitted_by, s.answer_value, s.answer_text, s.explanation, s.status, s.submitted_at, s.reviewed_by, s.reviewed_at, s.review_notes, s.rejection_reason, s.version, s.created_at, s.updated_at,
+    s.id, s.question_id, s.submitted_by, s.answer_value, s.answer_text, s.explanation, s.status, s.submitted_at, s.reviewed_by, s.reviewed_at, s.review_notes, s.rejection_reason, s.version, s.created_at, s.updated_at, s.answer_data, s.carried_forward_from, s.is_carried_forward, s.propagated_from,
     q.question_text,
     q.section,
     COUNT(e.id) as evidence_count
@@ -176,24 +289,28 @@ GROUP BY s.id, q.id
 `
 
 type GetSubmissionWithEvidenceRow struct {
-	ID              uuid.UUID            `json:"id"`
-	QuestionID      uuid.UUID            `json:"question_id"`
-	SubmittedBy     uuid.UUID            `json:"submitted_by"`
-	AnswerValue     NullAnswerValueEnum  `json:"answer_value"`
-	AnswerText      *string              `json:"answer_text"`
-	Explanation     string               `json:"explanation"`
-	Status          SubmissionStatusEnum `json:"status"`
-	SubmittedAt     pgtype.Timestamptz   `json:"submitted_at"`
-	ReviewedBy      pgtype.UUID          `json:"reviewed_by"`
-	ReviewedAt      pgtype.Timestamptz   `json:"reviewed_at"`
-	ReviewNotes     *string              `json:"review_notes"`
-	RejectionReason *string              `json:"rejection_reason"`
-	Version         int32                `json:"version"`
-	CreatedAt       pgtype.Timestamptz   `json:"created_at"`
-	UpdatedAt       pgtype.Timestamptz   `json:"updated_at"`
-	QuestionText    string               `json:"question_text"`
-	Section         string               `json:"section"`
-	EvidenceCount   int64                `json:"evidence_count"`
+	ID                 uuid.UUID            `json:"id"`
+	QuestionID         uuid.UUID            `json:"question_id"`
+	SubmittedBy        uuid.UUID            `json:"submitted_by"`
+	AnswerValue        NullAnswerValueEnum  `json:"answer_value"`
+	AnswerText         *string              `json:"answer_text"`
+	Explanation        string               `json:"explanation"`
+	Status             SubmissionStatusEnum `json:"status"`
+	SubmittedAt        pgtype.Timestamptz   `json:"submitted_at"`
+	ReviewedBy         pgtype.UUID          `json:"reviewed_by"`
+	ReviewedAt         pgtype.Timestamptz   `json:"reviewed_at"`
+	ReviewNotes        *string              `json:"review_notes"`
+	RejectionReason    *string              `json:"rejection_reason"`
+	Version            int32                `json:"version"`
+	CreatedAt          pgtype.Timestamptz   `json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz   `json:"updated_at"`
+	AnswerData         []byte               `json:"answer_data"`
+	CarriedForwardFrom pgtype.UUID          `json:"carried_forward_from"`
+	IsCarriedForward   bool                 `json:"is_carried_forward"`
+	PropagatedFrom     pgtype.UUID          `json:"propagated_from"`
+	QuestionText       string               `json:"question_text"`
+	Section            string               `json:"section"`
+	EvidenceCount      int64                `json:"evidence_count"`
 }
 
 func (q *Queries) GetSubmissionWithEvidence(ctx context.Context, id uuid.UUID) (GetSubmissionWithEvidenceRow, error) {
@@ -215,6 +332,10 @@ func (q *Queries) GetSubmissionWithEvidence(ctx context.Context, id uuid.UUID) (
 		&i.Version,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AnswerData,
+		&i.CarriedForwardFrom,
+		&i.IsCarriedForward,
+		&i.PropagatedFrom,
 		&i.QuestionText,
 		&i.Section,
 		&i.EvidenceCount,
@@ -224,7 +345,7 @@ func (q *Queries) GetSubmissionWithEvidence(ctx context.Context, id uuid.UUID) (
 
 const ListPendingReviews = `-- name: ListPendingReviews :many
 SELECT 
-    s.id, s.question_id, s.submitted_by, s.answer_value, s.answer_text, s.explanation, s.status, s.submitted_at, s.reviewed_by, s.reviewed_at, s.review_notes, s.rejection_reason, s.version, s.created_at, s.updated_at,
+    s.id, s.question_id, s.submitted_by, s.answer_value, s.answer_text, s.explanation, s.status, s.submitted_at, s.reviewed_by, s.reviewed_at, s.review_notes, s.rejection_reason, s.version, s.created_at, s.updated_at, s.answer_data, s.carried_forward_from, s.is_carried_forward, s.propagated_from,
     q.question_text,
     q.section,
     q.audit_id,
@@ -237,25 +358,29 @@ ORDER BY s.submitted_at ASC
 `
 
 type ListPendingReviewsRow struct {
-	ID              uuid.UUID            `json:"id"`
-	QuestionID      uuid.UUID            `json:"question_id"`
-	SubmittedBy     uuid.UUID            `json:"submitted_by"`
-	AnswerValue     NullAnswerValueEnum  `json:"answer_value"`
-	AnswerText      *string              `json:"answer_text"`
-	Explanation     string               `json:"explanation"`
-	Status          SubmissionStatusEnum `json:"status"`
-	SubmittedAt     pgtype.Timestamptz   `json:"submitted_at"`
-	ReviewedBy      pgtype.UUID          `json:"reviewed_by"`
-	ReviewedAt      pgtype.Timestamptz   `json:"reviewed_at"`
-	ReviewNotes     *string              `json:"review_notes"`
-	RejectionReason *string              `json:"rejection_reason"`
-	Version         int32                `json:"version"`
-	CreatedAt       pgtype.Timestamptz   `json:"created_at"`
-	UpdatedAt       pgtype.Timestamptz   `json:"updated_at"`
-	QuestionText    string               `json:"question_text"`
-	Section         string               `json:"section"`
-	AuditID         uuid.UUID            `json:"audit_id"`
-	FrameworkName   string               `json:"framework_name"`
+	ID                 uuid.UUID            `json:"id"`
+	QuestionID         uuid.UUID            `json:"question_id"`
+	SubmittedBy        uuid.UUID            `json:"submitted_by"`
+	AnswerValue        NullAnswerValueEnum  `json:"answer_value"`
+	AnswerText         *string              `json:"answer_text"`
+	Explanation        string               `json:"explanation"`
+	Status             SubmissionStatusEnum `json:"status"`
+	SubmittedAt        pgtype.Timestamptz   `json:"submitted_at"`
+	ReviewedBy         pgtype.UUID          `json:"reviewed_by"`
+	ReviewedAt         pgtype.Timestamptz   `json:"reviewed_at"`
+	ReviewNotes        *string              `json:"review_notes"`
+	RejectionReason    *string              `json:"rejection_reason"`
+	Version            int32                `json:"version"`
+	CreatedAt          pgtype.Timestamptz   `json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz   `json:"updated_at"`
+	AnswerData         []byte               `json:"answer_data"`
+	CarriedForwardFrom pgtype.UUID          `json:"carried_forward_from"`
+	IsCarriedForward   bool                 `json:"is_carried_forward"`
+	PropagatedFrom     pgtype.UUID          `json:"propagated_from"`
+	QuestionText       string               `json:"question_text"`
+	Section            string               `json:"section"`
+	AuditID            uuid.UUID            `json:"audit_id"`
+	FrameworkName      string               `json:"framework_name"`
 }
 
 func (q *Queries) ListPendingReviews(ctx context.Context) ([]ListPendingReviewsRow, error) {
@@ -283,6 +408,10 @@ func (q *Queries) ListPendingReviews(ctx context.Context) ([]ListPendingReviewsR
 			&i.Version,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.AnswerData,
+			&i.CarriedForwardFrom,
+			&i.IsCarriedForward,
+			&i.PropagatedFrom,
 			&i.QuestionText,
 			&i.Section,
 			&i.AuditID,
@@ -299,7 +428,7 @@ func (q *Queries) ListPendingReviews(ctx context.Context) ([]ListPendingReviewsR
 }
 
 const ListSubmissionsByStatus = `-- name: ListSubmissionsByStatus :many
-SELECT s.id, s.question_id, s.submitted_by, s.answer_value, s.answer_text, s.explanation, s.status, s.submitted_at, s.reviewed_by, s.reviewed_at, s.review_notes, s.rejection_reason, s.version, s.created_at, s.updated_at, q.question_text, q.section, q.audit_id
+SELECT s.id, s.question_id, s.submitted_by, s.answer_value, s.answer_text, s.explanation, s.status, s.submitted_at, s.reviewed_by, s.reviewed_at, s.review_notes, s.rejection_reason, s.version, s.created_at, s.updated_at, s.answer_data, s.carried_forward_from, s.is_carried_forward, s.propagated_from, q.question_text, q.section, q.audit_id
 FROM submissions s
 JOIN questions q ON q.id = s.question_id
 WHERE s.status = $1
@@ -307,24 +436,28 @@ ORDER BY s.submitted_at DESC
 `
 
 type ListSubmissionsByStatusRow struct {
-	ID              uuid.UUID            `json:"id"`
-	QuestionID      uuid.UUID            `json:"question_id"`
-	SubmittedBy     uuid.UUID            `json:"submitted_by"`
-	AnswerValue     NullAnswerValueEnum  `json:"answer_value"`
-	AnswerText      *string              `json:"answer_text"`
-	Explanation     string               `json:"explanation"`
-	Status          SubmissionStatusEnum `json:"status"`
-	SubmittedAt     pgtype.Timestamptz   `json:"submitted_at"`
-	ReviewedBy      pgtype.UUID          `json:"reviewed_by"`
-	ReviewedAt      pgtype.Timestamptz   `json:"reviewed_at"`
-	ReviewNotes     *string              `json:"review_notes"`
-	RejectionReason *string              `json:"rejection_reason"`
-	Version         int32                `json:"version"`
-	CreatedAt       pgtype.Timestamptz   `json:"created_at"`
-	UpdatedAt       pgtype.Timestamptz   `json:"updated_at"`
-	QuestionText    string               `json:"question_text"`
-	Section         string               `json:"section"`
-	AuditID         uuid.UUID            `json:"audit_id"`
+	ID                 uuid.UUID            `json:"id"`
+	QuestionID         uuid.UUID            `json:"question_id"`
+	SubmittedBy        uuid.UUID            `json:"submitted_by"`
+	AnswerValue        NullAnswerValueEnum  `json:"answer_value"`
+	AnswerText         *string              `json:"answer_text"`
+	Explanation        string               `json:"explanation"`
+	Status             SubmissionStatusEnum `json:"status"`
+	SubmittedAt        pgtype.Timestamptz   `json:"submitted_at"`
+	ReviewedBy         pgtype.UUID          `json:"reviewed_by"`
+	ReviewedAt         pgtype.Timestamptz   `json:"reviewed_at"`
+	ReviewNotes        *string              `json:"review_notes"`
+	RejectionReason    *string              `json:"rejection_reason"`
+	Version            int32                `json:"version"`
+	CreatedAt          pgtype.Timestamptz   `json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz   `json:"updated_at"`
+	AnswerData         []byte               `json:"answer_data"`
+	CarriedForwardFrom pgtype.UUID          `json:"carried_forward_from"`
+	IsCarriedForward   bool                 `json:"is_carried_forward"`
+	PropagatedFrom     pgtype.UUID          `json:"propagated_from"`
+	QuestionText       string               `json:"question_text"`
+	Section            string               `json:"section"`
+	AuditID            uuid.UUID            `json:"audit_id"`
 }
 
 func (q *Queries) ListSubmissionsByStatus(ctx context.Context, status SubmissionStatusEnum) ([]ListSubmissionsByStatusRow, error) {
@@ -352,6 +485,10 @@ func (q *Queries) ListSubmissionsByStatus(ctx context.Context, status Submission
 			&i.Version,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.AnswerData,
+			&i.CarriedForwardFrom,
+			&i.IsCarriedForward,
+			&i.PropagatedFrom,
 			&i.QuestionText,
 			&i.Section,
 			&i.AuditID,
@@ -367,7 +504,7 @@ func (q *Queries) ListSubmissionsByStatus(ctx context.Context, status Submission
 }
 
 const ListSubmissionsByUser = `-- name: ListSubmissionsByUser :many
-SELECT s.id, s.question_id, s.submitted_by, s.answer_value, s.answer_text, s.explanation, s.status, s.submitted_at, s.reviewed_by, s.reviewed_at, s.review_notes, s.rejection_reason, s.version, s.created_at, s.updated_at, q.question_text, q.section
+SELECT s.id, s.question_id, s.submitted_by, s.answer_value, s.answer_text, s.explanation, s.status, s.submitted_at, s.reviewed_by, s.reviewed_at, s.review_notes, s.rejection_reason, s.version, s.created_at, s.updated_at, s.answer_data, s.carried_forward_from, s.is_carried_forward, s.propagated_from, q.question_text, q.section
 FROM submissions s
 JOIN questions q ON q.id = s.question_id
 WHERE s.submitted_by = $1
@@ -375,23 +512,27 @@ ORDER BY s.submitted_at DESC
 `
 
 type ListSubmissionsByUserRow struct {
-	ID              uuid.UUID            `json:"id"`
-	QuestionID      uuid.UUID            `json:"question_id"`
-	SubmittedBy     uuid.UUID            `json:"submitted_by"`
-	AnswerValue     NullAnswerValueEnum  `json:"answer_value"`
-	AnswerText      *string              `json:"answer_text"`
-	Explanation     string               `json:"explanation"`
-	Status          SubmissionStatusEnum `json:"status"`
-	SubmittedAt     pgtype.Timestamptz   `json:"submitted_at"`
-	ReviewedBy      pgtype.UUID          `json:"reviewed_by"`
-	ReviewedAt      pgtype.Timestamptz   `json:"reviewed_at"`
-	ReviewNotes     *string              `json:"review_notes"`
-	RejectionReason *string              `json:"rejection_reason"`
-	Version         int32                `json:"version"`
-	CreatedAt       pgtype.Timestamptz   `json:"created_at"`
-	UpdatedAt       pgtype.Timestamptz   `json:"updated_at"`
-	QuestionText    string               `json:"question_text"`
-	Section         string               `json:"section"`
+	ID                 uuid.UUID            `json:"id"`
+	QuestionID         uuid.UUID            `json:"question_id"`
+	SubmittedBy        uuid.UUID            `json:"submitted_by"`
+	AnswerValue        NullAnswerValueEnum  `json:"answer_value"`
+	AnswerText         *string              `json:"answer_text"`
+	Explanation        string               `json:"explanation"`
+	Status             SubmissionStatusEnum `json:"status"`
+	SubmittedAt        pgtype.Timestamptz   `json:"submitted_at"`
+	ReviewedBy         pgtype.UUID          `json:"reviewed_by"`
+	ReviewedAt         pgtype.Timestamptz   `json:"reviewed_at"`
+	ReviewNotes        *string              `json:"review_notes"`
+	RejectionReason    *string              `json:"rejection_reason"`
+	Version            int32                `json:"version"`
+	CreatedAt          pgtype.Timestamptz   `json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz   `json:"updated_at"`
+	AnswerData         []byte               `json:"answer_data"`
+	CarriedForwardFrom pgtype.UUID          `json:"carried_forward_from"`
+	IsCarriedForward   bool                 `json:"is_carried_forward"`
+	PropagatedFrom     pgtype.UUID          `json:"propagated_from"`
+	QuestionText       string               `json:"question_text"`
+	Section            string               `json:"section"`
 }
 
 func (q *Queries) ListSubmissionsByUser(ctx context.Context, submittedBy uuid.UUID) ([]ListSubmissionsByUserRow, error) {
@@ -419,6 +560,10 @@ func (q *Queries) ListSubmissionsByUser(ctx context.Context, submittedBy uuid.UU
 			&i.Version,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.AnswerData,
+			&i.CarriedForwardFrom,
+			&i.IsCarriedForward,
+			&i.PropagatedFrom,
 			&i.QuestionText,
 			&i.Section,
 		); err != nil {
@@ -432,6 +577,72 @@ func (q *Queries) ListSubmissionsByUser(ctx context.Context, submittedBy uuid.UU
 	return items, nil
 }
 
+const PropagateSubmission = `-- name: PropagateSubmission :execrows
+INSERT INTO submissions (
+    question_id,
+    submitted_by,
+    answer_value,
+    answer_text,
+    explanation,
+    status,
+    submitted_at,
+    version,
+    answer_data,
+    propagated_from
+)
+SELECT
+    tq.id,
+    s.submitted_by,
+    s.answer_value,
+    s.answer_text,
+    s.explanation,
+    'submitted',
+    NOW(),
+    COALESCE(latest.version, 0) + 1,
+    s.answer_data,
+    s.id
+FROM submissions s
+JOIN questions sq ON sq.id = s.question_id
+JOIN questions tq ON tq.question_type = sq.question_type
+    AND tq.options IS NOT DISTINCT FROM sq.options
+JOIN audits ta ON ta.id = tq.audit_id
+LEFT JOIN LATERAL (
+    SELECT sub.id, sub.version, sub.status, sub.propagated_from
+    FROM submissions sub
+    WHERE sub.question_id = tq.id
+    ORDER BY sub.created_at DESC, sub.version DESC
+    LIMIT 1
+) latest ON true
+WHERE s.id = $1::uuid
+  AND ta.framework_id = $2::uuid
+  AND tq.question_number = $3::text
+  AND ta.id <> sq.audit_id
+  AND ta.status <> 'completed'
+  AND (
+      latest.id IS NULL
+      OR (latest.propagated_from IS NOT NULL AND latest.propagated_from <> s.id AND latest.status <> 'approved')
+  )
+`
+
+type PropagateSubmissionParams struct {
+	SubmissionID uuid.UUID `json:"submission_id"`
+	FrameworkID  uuid.UUID `json:"framework_id"`
+	ControlID    string    `json:"control_id"`
+}
+
+// Copies a submitted answer to the questions of a mapped control in the
+// client's other open audits of the mapped framework, as a new submitted
+// version awaiting review. Only questions of the same type and options
+// receive it, and answers given directly to those questions or already
+// approved are never replaced.
+func (q *Queries) PropagateSubmission(ctx context.Context, arg PropagateSubmissionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, PropagateSubmission, arg.SubmissionID, arg.FrameworkID, arg.ControlID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const ReferSubmission = `-- name: ReferSubmission :one
 UPDATE submissions
 SET 
@@ -440,7 +651,7 @@ SET
     reviewed_at = NOW(),
     review_notes = $3
 WHERE id = $1
-RETURNING id, question_id, submitted_by, answer_value, answer_text, explanation, status, submitted_at, reviewed_by, reviewed_at, review_notes, rejection_reason, version, created_at, updated_at
+RETURNING id, question_id, submitted_by, answer_value, answer_text, explanation, status, submitted_at, reviewed_by, reviewed_at, review_notes, rejection_reason, version, created_at, updated_at, answer_data, carried_forward_from, is_carried_forward, propagated_from
 `
 
 type ReferSubmissionParams struct {
@@ -468,6 +679,10 @@ func (q *Queries) ReferSubmission(ctx context.Context, arg ReferSubmissionParams
 		&i.Version,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AnswerData,
+		&i.CarriedForwardFrom,
+		&i.IsCarriedForward,
+		&i.PropagatedFrom,
 	)
 	return i, err
 }
@@ -481,7 +696,7 @@ SET
     rejection_reason = $3,
     review_notes = $4
 WHERE id = $1
-RETURNING id, question_id, submitted_by, answer_value, answer_text, explanation, status, submitted_at, reviewed_by, reviewed_at, review_notes, rejection_reason, version, created_at, updated_at
+RETURNING id, question_id, submitted_by, answer_value, answer_text, explanation, status, submitted_at, reviewed_by, reviewed_at, review_notes, rejection_reason, version, created_at, updated_at, answer_data, carried_forward_from, is_carried_forward, propagated_from
 `
 
 type RejectSubmissionParams struct {
@@ -515,6 +730,10 @@ func (q *Queries) RejectSubmission(ctx context.Context, arg RejectSubmissionPara
 		&i.Version,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AnswerData,
+		&i.CarriedForwardFrom,
+		&i.IsCarriedForward,
+		&i.PropagatedFrom,
 	)
 	return i, err
 }
@@ -527,12 +746,14 @@ INSERT INTO submissions (
     answer_text,
     explanation,
     status,
-    version
+    version,
+    answer_data
 ) VALUES (
     $1, $2, $3, $4, $5, 'submitted',
-    (SELECT COALESCE(MAX(version), 0) + 1 FROM submissions WHERE question_id = $1)
+    (SELECT COALESCE(MAX(version), 0) + 1 FROM submissions WHERE question_id = $1),
+    $6
 )
-RETURNING id, question_id, submitted_by, answer_value, answer_text, explanation, status, submitted_at, reviewed_by, reviewed_at, review_notes, rejection_reason, version, created_at, updated_at
+RETURNING id, question_id, submitted_by, answer_value, answer_text, explanation, status, submitted_at, reviewed_by, reviewed_at, review_notes, rejection_reason, version, created_at, updated_at, answer_data, carried_forward_from, is_carried_forward, propagated_from
 `
 
 type ResubmitSubmissionParams struct {
@@ -541,6 +762,7 @@ type ResubmitSubmissionParams struct {
 	AnswerValue NullAnswerValueEnum `json:"answer_value"`
 	AnswerText  *string             `json:"answer_text"`
 	Explanation string              `json:"explanation"`
+	AnswerData  []byte              `json:"answer_data"`
 }
 
 func (q *Queries) ResubmitSubmission(ctx context.Context, arg ResubmitSubmissionParams) (Submission, error) {
@@ -550,6 +772,7 @@ func (q *Queries) ResubmitSubmission(ctx context.Context, arg ResubmitSubmission
 		arg.AnswerValue,
 		arg.AnswerText,
 		arg.Explanation,
+		arg.AnswerData,
 	)
 	var i Submission
 	err := row.Scan(
@@ -568,6 +791,10 @@ func (q *Queries) ResubmitSubmission(ctx context.Context, arg ResubmitSubmission
 		&i.Version,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AnswerData,
+		&i.CarriedForwardFrom,
+		&i.IsCarriedForward,
+		&i.PropagatedFrom,
 	)
 	return i, err
 }
@@ -578,7 +805,7 @@ SET
     status = 'submitted',
     submitted_at = NOW()
 WHERE id = $1
-RETURNING id, question_id, submitted_by, answer_value, answer_text, explanation, status, submitted_at, reviewed_by, reviewed_at, review_notes, rejection_reason, version, created_at, updated_at
+RETURNING id, question_id, submitted_by, answer_value, answer_text, explanation, status, submitted_at, reviewed_by, reviewed_at, review_notes, rejection_reason, version, created_at, updated_at, answer_data, carried_forward_from, is_carried_forward, propagated_from
 `
 
 func (q *Queries) SubmitSubmission(ctx context.Context, id uuid.UUID) (Submission, error) {
@@ -600,6 +827,10 @@ func (q *Queries) SubmitSubmission(ctx context.Context, id uuid.UUID) (Submissio
 		&i.Version,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AnswerData,
+		&i.CarriedForwardFrom,
+		&i.IsCarriedForward,
+		&i.PropagatedFrom,
 	)
 	return i, err
 }
@@ -610,9 +841,11 @@ SET
     answer_value = $2,
     answer_text = $3,
     explanation = $4,
-    status = 'in_progress'
+    answer_data = $5,
+    status = 'in_progress',
+    is_carried_forward = false
 WHERE id = $1
-RETURNING id, question_id, submitted_by, answer_value, answer_text, explanation, status, submitted_at, reviewed_by, reviewed_at, review_notes, rejection_reason, version, created_at, updated_at
+RETURNING id, question_id, submitted_by, answer_value, answer_text, explanation, status, submitted_at, reviewed_by, reviewed_at, review_notes, rejection_reason, version, created_at, updated_at, answer_data, carried_forward_from, is_carried_forward, propagated_from
 `
 
 type UpdateSubmissionAnswerParams struct {
@@ -620,6 +853,7 @@ type UpdateSubmissionAnswerParams struct {
 	AnswerValue NullAnswerValueEnum `json:"answer_value"`
 	AnswerText  *string             `json:"answer_text"`
 	Explanation string              `json:"explanation"`
+	AnswerData  []byte              `json:"answer_data"`
 }
 
 func (q *Queries) UpdateSubmissionAnswer(ctx context.Context, arg UpdateSubmissionAnswerParams) (Submission, error) {
@@ -628,6 +862,7 @@ func (q *Queries) UpdateSubmissionAnswer(ctx context.Context, arg UpdateSubmissi
 		arg.AnswerValue,
 		arg.AnswerText,
 		arg.Explanation,
+		arg.AnswerData,
 	)
 	var i Submission
 	err := row.Scan(
@@ -646,6 +881,10 @@ func (q *Queries) UpdateSubmissionAnswer(ctx context.Context, arg UpdateSubmissi
 		&i.Version,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AnswerData,
+		&i.CarriedForwardFrom,
+		&i.IsCarriedForward,
+		&i.PropagatedFrom,
 	)
 	return i, err
 }
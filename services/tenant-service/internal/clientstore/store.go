@@ -3,6 +3,7 @@ package clientstore
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientdb"
 	"github.com/NormaTech-AI/audity/services/tenant-service/internal/crypto"
@@ -17,7 +18,8 @@ type ClientStore struct {
 	tenantStore *db.Queries
 	encryptor   *crypto.Encryptor
 	logger      *zap.SugaredLogger
-	// Cache for client database connections
+	// Cache for client database connections, guarded by mu
+	mu              sync.Mutex
 	connectionCache map[uuid.UUID]*pgxpool.Pool
 }
 
@@ -34,7 +36,10 @@ func NewClientStore(tenantStore *db.Queries, encryptor *crypto.Encryptor, logger
 // GetClientQueries returns a Queries instance for a specific client's database
 func (cs *ClientStore) GetClientQueries(ctx context.Context, clientID uuid.UUID) (*clientdb.Queries, *pgxpool.Pool, error) {
 	// Check cache first
-	if pool, exists := cs.connectionCache[clientID]; exists {
+	cs.mu.Lock()
+	pool, exists := cs.connectionCache[clientID]
+	cs.mu.Unlock()
+	if exists {
 		return clientdb.New(pool), pool, nil
 	}
 
@@ -63,7 +68,7 @@ func (cs *ClientStore) GetClientQueries(ctx context.Context, clientID uuid.UUID)
 	poolConfig.MaxConns = 10
 	poolConfig.MinConns = 2
 
-	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	pool, err = pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create connection pool: %w", err)
 	}
@@ -74,8 +79,15 @@ func (cs *ClientStore) GetClientQueries(ctx context.Context, clientID uuid.UUID)
 		return nil, nil, fmt.Errorf("failed to ping client database: %w", err)
 	}
 
-	// Cache the connection
+	// Cache the connection, unless a concurrent caller got there first
+	cs.mu.Lock()
+	if cached, exists := cs.connectionCache[clientID]; exists {
+		cs.mu.Unlock()
+		pool.Close()
+		return clientdb.New(cached), cached, nil
+	}
 	cs.connectionCache[clientID] = pool
+	cs.mu.Unlock()
 
 	cs.logger.Infow("Connected to client database", "client_id", clientID, "db_name", clientDB.DbName)
 
@@ -84,6 +96,9 @@ func (cs *ClientStore) GetClientQueries(ctx context.Context, clientID uuid.UUID)
 
 // CloseClientConnection closes and removes a cached connection
 func (cs *ClientStore) CloseClientConnection(clientID uuid.UUID) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
 	if pool, exists := cs.connectionCache[clientID]; exists {
 		pool.Close()
 		delete(cs.connectionCache, clientID)
@@ -93,6 +108,9 @@ func (cs *ClientStore) CloseClientConnection(clientID uuid.UUID) {
 
 // CloseAll closes all cached connections
 func (cs *ClientStore) CloseAll() {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
 	for clientID, pool := range cs.connectionCache {
 		pool.Close()
 		cs.logger.Infow("Closed client database connection", "client_id", clientID)
@@ -43,10 +43,11 @@ INSERT INTO audit_cycle_frameworks (
     assigned_by,
     due_date,
     status,
-    auditor_id
+    auditor_id,
+    carry_forward
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7)
-RETURNING id, audit_cycle_client_id, framework_id, framework_name, assigned_by, assigned_at, due_date, status, created_at, updated_at, auditor_id
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, audit_cycle_client_id, framework_id, framework_name, assigned_by, assigned_at, due_date, status, created_at, updated_at, auditor_id, carry_forward, client_audit_id, framework_version_id, framework_version
 `
 
 type AssignFrameworkToAuditCycleClientParams struct {
@@ -57,6 +58,7 @@ type AssignFrameworkToAuditCycleClientParams struct {
 	DueDate            pgtype.Date `json:"due_date"`
 	Status             *string     `json:"status"`
 	AuditorID          pgtype.UUID `json:"auditor_id"`
+	CarryForward       bool        `json:"carry_forward"`
 }
 
 func (q *Queries) AssignFrameworkToAuditCycleClient(ctx context.Context, arg AssignFrameworkToAuditCycleClientParams) (AuditCycleFramework, error) {
@@ -68,6 +70,7 @@ func (q *Queries) AssignFrameworkToAuditCycleClient(ctx context.Context, arg Ass
 		arg.DueDate,
 		arg.Status,
 		arg.AuditorID,
+		arg.CarryForward,
 	)
 	var i AuditCycleFramework
 	err := row.Scan(
@@ -82,6 +85,10 @@ func (q *Queries) AssignFrameworkToAuditCycleClient(ctx context.Context, arg Ass
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.AuditorID,
+		&i.CarryForward,
+		&i.ClientAuditID,
+		&i.FrameworkVersionID,
+		&i.FrameworkVersion,
 	)
 	return i, err
 }
@@ -143,6 +150,115 @@ func (q *Queries) DeleteAuditCycleFramework(ctx context.Context, id uuid.UUID) e
 	return err
 }
 
+const EnsureClientInAuditCycle = `-- name: EnsureClientInAuditCycle :one
+INSERT INTO audit_cycle_clients (audit_cycle_id, client_id)
+VALUES ($1, $2)
+ON CONFLICT (audit_cycle_id, client_id) DO UPDATE
+SET client_id = EXCLUDED.client_id
+RETURNING id, audit_cycle_id, client_id, created_at
+`
+
+type EnsureClientInAuditCycleParams struct {
+	AuditCycleID uuid.UUID `json:"audit_cycle_id"`
+	ClientID     uuid.UUID `json:"client_id"`
+}
+
+// Adds a client to an audit cycle, or returns the existing membership
+func (q *Queries) EnsureClientInAuditCycle(ctx context.Context, arg EnsureClientInAuditCycleParams) (AuditCycleClient, error) {
+	row := q.db.QueryRow(ctx, EnsureClientInAuditCycle, arg.AuditCycleID, arg.ClientID)
+	var i AuditCycleClient
+	err := row.Scan(
+		&i.ID,
+		&i.AuditCycleID,
+		&i.ClientID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const EnsureFrameworkAssignedToAuditCycleClient = `-- name: EnsureFrameworkAssignedToAuditCycleClient :one
+INSERT INTO audit_cycle_frameworks (
+    audit_cycle_client_id,
+    framework_id,
+    framework_name,
+    assigned_by,
+    due_date,
+    status,
+    auditor_id,
+    carry_forward
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT ON CONSTRAINT unique_framework_per_client_cycle DO UPDATE
+SET framework_id = EXCLUDED.framework_id
+RETURNING id, audit_cycle_client_id, framework_id, framework_name, assigned_by, assigned_at, due_date, status, created_at, updated_at, auditor_id, carry_forward, client_audit_id, framework_version_id, framework_version, (xmax = 0)::bool AS inserted
+`
+
+type EnsureFrameworkAssignedToAuditCycleClientParams struct {
+	AuditCycleClientID uuid.UUID   `json:"audit_cycle_client_id"`
+	FrameworkID        uuid.UUID   `json:"framework_id"`
+	FrameworkName      string      `json:"framework_name"`
+	AssignedBy         pgtype.UUID `json:"assigned_by"`
+	DueDate            pgtype.Date `json:"due_date"`
+	Status             *string     `json:"status"`
+	AuditorID          pgtype.UUID `json:"auditor_id"`
+	CarryForward       bool        `json:"carry_forward"`
+}
+
+type EnsureFrameworkAssignedToAuditCycleClientRow struct {
+	ID                 uuid.UUID          `json:"id"`
+	AuditCycleClientID uuid.UUID          `json:"audit_cycle_client_id"`
+	FrameworkID        uuid.UUID          `json:"framework_id"`
+	FrameworkName      string             `json:"framework_name"`
+	AssignedBy         pgtype.UUID        `json:"assigned_by"`
+	AssignedAt         pgtype.Timestamptz `json:"assigned_at"`
+	DueDate            pgtype.Date        `json:"due_date"`
+	Status             *string            `json:"status"`
+	CreatedAt          pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
+	AuditorID          pgtype.UUID        `json:"auditor_id"`
+	CarryForward       bool               `json:"carry_forward"`
+	ClientAuditID      pgtype.UUID        `json:"client_audit_id"`
+	FrameworkVersionID pgtype.UUID        `json:"framework_version_id"`
+	FrameworkVersion   *string            `json:"framework_version"`
+	Inserted           bool               `json:"inserted"`
+}
+
+// Assigns a framework to a client in a cycle, or returns the existing
+// assignment unchanged so retries never alter a provisioned audit. inserted
+// tells whether this call created the assignment.
+func (q *Queries) EnsureFrameworkAssignedToAuditCycleClient(ctx context.Context, arg EnsureFrameworkAssignedToAuditCycleClientParams) (EnsureFrameworkAssignedToAuditCycleClientRow, error) {
+	row := q.db.QueryRow(ctx, EnsureFrameworkAssignedToAuditCycleClient,
+		arg.AuditCycleClientID,
+		arg.FrameworkID,
+		arg.FrameworkName,
+		arg.AssignedBy,
+		arg.DueDate,
+		arg.Status,
+		arg.AuditorID,
+		arg.CarryForward,
+	)
+	var i EnsureFrameworkAssignedToAuditCycleClientRow
+	err := row.Scan(
+		&i.ID,
+		&i.AuditCycleClientID,
+		&i.FrameworkID,
+		&i.FrameworkName,
+		&i.AssignedBy,
+		&i.AssignedAt,
+		&i.DueDate,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.AuditorID,
+		&i.CarryForward,
+		&i.ClientAuditID,
+		&i.FrameworkVersionID,
+		&i.FrameworkVersion,
+		&i.Inserted,
+	)
+	return i, err
+}
+
 const GetAuditCycle = `-- name: GetAuditCycle :one
 SELECT id, name, description, start_date, end_date, status, created_by, created_at, updated_at FROM audit_cycles
 WHERE id = $1 LIMIT 1
@@ -165,6 +281,23 @@ func (q *Queries) GetAuditCycle(ctx context.Context, id uuid.UUID) (AuditCycle,
 	return i, err
 }
 
+const GetAuditCycleClient = `-- name: GetAuditCycleClient :one
+SELECT id, audit_cycle_id, client_id, created_at FROM audit_cycle_clients
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetAuditCycleClient(ctx context.Context, id uuid.UUID) (AuditCycleClient, error) {
+	row := q.db.QueryRow(ctx, GetAuditCycleClient, id)
+	var i AuditCycleClient
+	err := row.Scan(
+		&i.ID,
+		&i.AuditCycleID,
+		&i.ClientID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const GetAuditCycleClients = `-- name: GetAuditCycleClients :many
 SELECT 
     acc.id,
@@ -229,6 +362,10 @@ SELECT
     acf.due_date,
     acf.status,
     acf.auditor_id,
+    acf.carry_forward,
+    acf.client_audit_id,
+    acf.framework_version_id,
+    acf.framework_version,
     acf.created_at,
     acf.updated_at,
     acc.client_id,
@@ -250,6 +387,10 @@ type GetAuditCycleFrameworksRow struct {
 	DueDate            pgtype.Date        `json:"due_date"`
 	Status             *string            `json:"status"`
 	AuditorID          pgtype.UUID        `json:"auditor_id"`
+	CarryForward       bool               `json:"carry_forward"`
+	ClientAuditID      pgtype.UUID        `json:"client_audit_id"`
+	FrameworkVersionID pgtype.UUID        `json:"framework_version_id"`
+	FrameworkVersion   *string            `json:"framework_version"`
 	CreatedAt          pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
 	ClientID           uuid.UUID          `json:"client_id"`
@@ -275,6 +416,10 @@ func (q *Queries) GetAuditCycleFrameworks(ctx context.Context, auditCycleID uuid
 			&i.DueDate,
 			&i.Status,
 			&i.AuditorID,
+			&i.CarryForward,
+			&i.ClientAuditID,
+			&i.FrameworkVersionID,
+			&i.FrameworkVersion,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.ClientID,
@@ -348,6 +493,10 @@ SELECT
     acf.due_date,
     acf.status,
     acf.auditor_id,
+    acf.carry_forward,
+    acf.client_audit_id,
+    acf.framework_version_id,
+    acf.framework_version,
     acf.created_at,
     acf.updated_at
 FROM audit_cycle_frameworks acf
@@ -365,6 +514,10 @@ type GetClientFrameworksInCycleRow struct {
 	DueDate            pgtype.Date        `json:"due_date"`
 	Status             *string            `json:"status"`
 	AuditorID          pgtype.UUID        `json:"auditor_id"`
+	CarryForward       bool               `json:"carry_forward"`
+	ClientAuditID      pgtype.UUID        `json:"client_audit_id"`
+	FrameworkVersionID pgtype.UUID        `json:"framework_version_id"`
+	FrameworkVersion   *string            `json:"framework_version"`
 	CreatedAt          pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
 }
@@ -388,6 +541,10 @@ func (q *Queries) GetClientFrameworksInCycle(ctx context.Context, auditCycleClie
 			&i.DueDate,
 			&i.Status,
 			&i.AuditorID,
+			&i.CarryForward,
+			&i.ClientAuditID,
+			&i.FrameworkVersionID,
+			&i.FrameworkVersion,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -401,6 +558,49 @@ func (q *Queries) GetClientFrameworksInCycle(ctx context.Context, auditCycleClie
 	return items, nil
 }
 
+const LinkAuditCycleFrameworkClientAudit = `-- name: LinkAuditCycleFrameworkClientAudit :one
+UPDATE audit_cycle_frameworks
+SET client_audit_id = $2, framework_version_id = $3, framework_version = $4
+WHERE id = $1
+RETURNING id, audit_cycle_client_id, framework_id, framework_name, assigned_by, assigned_at, due_date, status, created_at, updated_at, auditor_id, carry_forward, client_audit_id, framework_version_id, framework_version
+`
+
+type LinkAuditCycleFrameworkClientAuditParams struct {
+	ID                 uuid.UUID   `json:"id"`
+	ClientAuditID      pgtype.UUID `json:"client_audit_id"`
+	FrameworkVersionID pgtype.UUID `json:"framework_version_id"`
+	FrameworkVersion   *string     `json:"framework_version"`
+}
+
+// Records the audit provisioned in the client database for an assignment
+func (q *Queries) LinkAuditCycleFrameworkClientAudit(ctx context.Context, arg LinkAuditCycleFrameworkClientAuditParams) (AuditCycleFramework, error) {
+	row := q.db.QueryRow(ctx, LinkAuditCycleFrameworkClientAudit,
+		arg.ID,
+		arg.ClientAuditID,
+		arg.FrameworkVersionID,
+		arg.FrameworkVersion,
+	)
+	var i AuditCycleFramework
+	err := row.Scan(
+		&i.ID,
+		&i.AuditCycleClientID,
+		&i.FrameworkID,
+		&i.FrameworkName,
+		&i.AssignedBy,
+		&i.AssignedAt,
+		&i.DueDate,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.AuditorID,
+		&i.CarryForward,
+		&i.ClientAuditID,
+		&i.FrameworkVersionID,
+		&i.FrameworkVersion,
+	)
+	return i, err
+}
+
 const ListAuditCycles = `-- name: ListAuditCycles :many
 SELECT id, name, description, start_date, end_date, status, created_by, created_at, updated_at FROM audit_cycles
 ORDER BY created_at DESC
@@ -535,7 +735,7 @@ const UpdateAuditCycleFrameworkStatus = `-- name: UpdateAuditCycleFrameworkStatu
 UPDATE audit_cycle_frameworks
 SET status = $2
 WHERE id = $1
-RETURNING id, audit_cycle_client_id, framework_id, framework_name, assigned_by, assigned_at, due_date, status, created_at, updated_at, auditor_id
+RETURNING id, audit_cycle_client_id, framework_id, framework_name, assigned_by, assigned_at, due_date, status, created_at, updated_at, auditor_id, carry_forward, client_audit_id, framework_version_id, framework_version
 `
 
 type UpdateAuditCycleFrameworkStatusParams struct {
@@ -558,6 +758,10 @@ func (q *Queries) UpdateAuditCycleFrameworkStatus(ctx context.Context, arg Updat
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.AuditorID,
+		&i.CarryForward,
+		&i.ClientAuditID,
+		&i.FrameworkVersionID,
+		&i.FrameworkVersion,
 	)
 	return i, err
 }
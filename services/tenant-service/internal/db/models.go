@@ -8,6 +8,7 @@ import (
 	"database/sql/driver"
 	"fmt"
 	"net/netip"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -240,6 +241,14 @@ type AuditCycleFramework struct {
 	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
 	// The auditor assigned to review this framework for the client
 	AuditorID pgtype.UUID `json:"auditor_id"`
+	// Prefill answers and evidence from the client's previous completed audit of the framework
+	CarryForward bool `json:"carry_forward"`
+	// The audit provisioned in the client database for this assignment
+	ClientAuditID pgtype.UUID `json:"client_audit_id"`
+	// The framework version the client audit was created from
+	FrameworkVersionID pgtype.UUID `json:"framework_version_id"`
+	// Label of the pinned framework version
+	FrameworkVersion *string `json:"framework_version"`
 }
 
 type AuditLog struct {
@@ -265,6 +274,16 @@ type Client struct {
 	EmailDomain *string `json:"email_domain"`
 }
 
+type ClientBranding struct {
+	ClientID     uuid.UUID `json:"client_id"`
+	DisplayName  *string   `json:"display_name"`
+	LogoUrl      *string   `json:"logo_url"`
+	PrimaryColor *string   `json:"primary_color"`
+	FooterText   *string   `json:"footer_text"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
 type ClientBucket struct {
 	ID         uuid.UUID          `json:"id"`
 	ClientID   uuid.UUID          `json:"client_id"`
@@ -293,6 +312,75 @@ type ClientFramework struct {
 	UpdatedAt   pgtype.Timestamptz  `json:"updated_at"`
 }
 
+type DueDateReminder struct {
+	AuditCycleFrameworkID uuid.UUID   `json:"audit_cycle_framework_id"`
+	DueDate               pgtype.Date `json:"due_date"`
+	DaysBefore            int32       `json:"days_before"`
+	SentAt                time.Time   `json:"sent_at"`
+}
+
+type EmailOutbox struct {
+	ID            uuid.UUID `json:"id"`
+	Recipients    []string  `json:"recipients"`
+	Subject       string    `json:"subject"`
+	HtmlBody      *string   `json:"html_body"`
+	TextBody      *string   `json:"text_body"`
+	Status        string    `json:"status"`
+	Attempts      int32     `json:"attempts"`
+	MaxAttempts   int32     `json:"max_attempts"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	// Lease of the dispatcher sending the email; expired leases of crashed dispatchers are picked up again
+	LockedUntil     pgtype.Timestamptz `json:"locked_until"`
+	LastError       *string            `json:"last_error"`
+	SentAt          pgtype.Timestamptz `json:"sent_at"`
+	CreatedAt       time.Time          `json:"created_at"`
+	UpdatedAt       time.Time          `json:"updated_at"`
+	Template        *string            `json:"template"`
+	TemplateVersion *string            `json:"template_version"`
+}
+
+type EventOutbox struct {
+	ID        uuid.UUID `json:"id"`
+	EventType string    `json:"event_type"`
+	// Event envelope as published, see packages/go/eventbus
+	Envelope      []byte    `json:"envelope"`
+	Attempts      int32     `json:"attempts"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	// Lease of the relay publishing the event; expired leases of crashed relays are picked up again
+	LockedUntil pgtype.Timestamptz `json:"locked_until"`
+	LastError   *string            `json:"last_error"`
+	PublishedAt pgtype.Timestamptz `json:"published_at"`
+	CreatedAt   time.Time          `json:"created_at"`
+}
+
+type ExceptionExpiryReminder struct {
+	ClientID    uuid.UUID   `json:"client_id"`
+	ExceptionID uuid.UUID   `json:"exception_id"`
+	ExpiresAt   pgtype.Date `json:"expires_at"`
+	DaysBefore  int32       `json:"days_before"`
+	SentAt      time.Time   `json:"sent_at"`
+}
+
+type Notification struct {
+	ID       uuid.UUID   `json:"id"`
+	UserID   uuid.UUID   `json:"user_id"`
+	ClientID pgtype.UUID `json:"client_id"`
+	Type     string      `json:"type"`
+	Title    string      `json:"title"`
+	Body     string      `json:"body"`
+	// Path of the frontend page the notification is about, e.g. /audit/<id>
+	Link      *string            `json:"link"`
+	ReadAt    pgtype.Timestamptz `json:"read_at"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+type NotificationPreference struct {
+	UserID    uuid.UUID `json:"user_id"`
+	Type      string    `json:"type"`
+	Channel   string    `json:"channel"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
 type Permission struct {
 	ID          uuid.UUID          `json:"id"`
 	Name        string             `json:"name"`
@@ -325,9 +413,59 @@ type User struct {
 	CreatedAt    pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
 	LastLogin    pgtype.Timestamptz `json:"last_login"`
+	// BCP 47 language tag questions are served in, e.g. hi or mr; NULL for the canonical language
+	PreferredLocale *string `json:"preferred_locale"`
 }
 
 type UserRole struct {
 	UserID uuid.UUID `json:"user_id"`
 	RoleID uuid.UUID `json:"role_id"`
 }
+
+type WebhookDelivery struct {
+	ID             uuid.UUID `json:"id"`
+	SubscriptionID uuid.UUID `json:"subscription_id"`
+	EventID        uuid.UUID `json:"event_id"`
+	EventType      string    `json:"event_type"`
+	// Request body, the event envelope
+	Payload       []byte    `json:"payload"`
+	Status        string    `json:"status"`
+	Attempts      int32     `json:"attempts"`
+	MaxAttempts   int32     `json:"max_attempts"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	// Lease of the dispatcher sending the delivery; expired leases of crashed dispatchers are picked up again
+	LockedUntil    pgtype.Timestamptz `json:"locked_until"`
+	LastStatusCode *int32             `json:"last_status_code"`
+	LastError      *string            `json:"last_error"`
+	DeliveredAt    pgtype.Timestamptz `json:"delivered_at"`
+	// Delivery this one was manually redelivered from
+	RedeliveryOf pgtype.UUID `json:"redelivery_of"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+}
+
+type WebhookDeliveryAttempt struct {
+	ID         uuid.UUID `json:"id"`
+	DeliveryID uuid.UUID `json:"delivery_id"`
+	Attempt    int32     `json:"attempt"`
+	StatusCode *int32    `json:"status_code"`
+	// Start of the response body
+	ResponseBody *string   `json:"response_body"`
+	Error        *string   `json:"error"`
+	DurationMs   int32     `json:"duration_ms"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type WebhookSubscription struct {
+	ID          uuid.UUID `json:"id"`
+	ClientID    uuid.UUID `json:"client_id"`
+	Url         string    `json:"url"`
+	Description *string   `json:"description"`
+	// HMAC-SHA256 signing secret, encrypted with the tenant-service encryption key
+	EncryptedSecret string      `json:"encrypted_secret"`
+	EventTypes      []string    `json:"event_types"`
+	IsActive        bool        `json:"is_active"`
+	CreatedBy       pgtype.UUID `json:"created_by"`
+	CreatedAt       time.Time   `json:"created_at"`
+	UpdatedAt       time.Time   `json:"updated_at"`
+}
@@ -17,6 +17,20 @@ type Querier interface {
 	AssignFrameworkToClient(ctx context.Context, arg AssignFrameworkToClientParams) (ClientFramework, error)
 	AssignRoleToUser(ctx context.Context, arg AssignRoleToUserParams) error
 	CheckUserPermission(ctx context.Context, arg CheckUserPermissionParams) (bool, error)
+	// Leases a batch of emails that are due, including emails whose dispatcher
+	// died mid-send, and counts the attempt. SKIP LOCKED lets several dispatchers
+	// share the outbox.
+	ClaimDueEmails(ctx context.Context, arg ClaimDueEmailsParams) ([]EmailOutbox, error)
+	// Leases a batch of unpublished events that are due, oldest first, including
+	// events whose relay died mid-publish, and counts the attempt. SKIP LOCKED
+	// lets several relays share the outbox.
+	ClaimDueEvents(ctx context.Context, arg ClaimDueEventsParams) ([]EventOutbox, error)
+	// Leases a batch of deliveries that are due, including deliveries whose
+	// dispatcher died mid-send, and counts the attempt. SKIP LOCKED lets several
+	// dispatchers share the queue.
+	ClaimDueWebhookDeliveries(ctx context.Context, arg ClaimDueWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	// Leases a pending delivery to send it right away
+	ClaimWebhookDelivery(ctx context.Context, arg ClaimWebhookDeliveryParams) (WebhookDelivery, error)
 	CountAuditLogs(ctx context.Context) (int64, error)
 	// Count active audit cycles for a specific client
 	CountClientActiveAuditCycles(ctx context.Context, clientID uuid.UUID) (int64, error)
@@ -26,20 +40,43 @@ type Querier interface {
 	CountClients(ctx context.Context) (int64, error)
 	CountClientsByStatus(ctx context.Context, status NullClientStatusEnum) (int64, error)
 	CountTotalUsers(ctx context.Context) (int64, error)
+	CountUnreadNotifications(ctx context.Context, userID uuid.UUID) (int64, error)
 	CreateAuditCycle(ctx context.Context, arg CreateAuditCycleParams) (AuditCycle, error)
 	CreateAuditLog(ctx context.Context, arg CreateAuditLogParams) (AuditLog, error)
 	CreateClient(ctx context.Context, arg CreateClientParams) (Client, error)
 	CreateClientBucket(ctx context.Context, arg CreateClientBucketParams) (ClientBucket, error)
 	CreateClientDatabase(ctx context.Context, arg CreateClientDatabaseParams) (ClientDatabase, error)
+	CreateDueDateReminder(ctx context.Context, arg CreateDueDateReminderParams) (int64, error)
+	// Records the reminder of an exception for a window unless it was already
+	// reminded of the same expiry date in this or a closer window
+	CreateExceptionExpiryReminder(ctx context.Context, arg CreateExceptionExpiryReminderParams) (int64, error)
+	CreateNotification(ctx context.Context, arg CreateNotificationParams) (Notification, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	// Queues a redelivery or a test ping
+	CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) (WebhookDelivery, error)
+	CreateWebhookDeliveryAttempt(ctx context.Context, arg CreateWebhookDeliveryAttemptParams) error
+	CreateWebhookSubscription(ctx context.Context, arg CreateWebhookSubscriptionParams) (WebhookSubscription, error)
 	DeleteAuditCycle(ctx context.Context, id uuid.UUID) error
 	DeleteAuditCycleFramework(ctx context.Context, id uuid.UUID) error
 	DeleteClient(ctx context.Context, id uuid.UUID) error
 	DeleteClientBucket(ctx context.Context, clientID uuid.UUID) error
 	DeleteClientDatabase(ctx context.Context, clientID uuid.UUID) error
 	DeleteClientFramework(ctx context.Context, id uuid.UUID) error
+	DeletePublishedEvents(ctx context.Context, publishedAt pgtype.Timestamptz) (int64, error)
 	DeleteUser(ctx context.Context, id uuid.UUID) error
+	DeleteWebhookSubscription(ctx context.Context, arg DeleteWebhookSubscriptionParams) (int64, error)
+	EnqueueEmail(ctx context.Context, arg EnqueueEmailParams) (EmailOutbox, error)
+	EnqueueEvent(ctx context.Context, arg EnqueueEventParams) error
+	// Queues the delivery of an event to a subscription, once per event
+	EnqueueWebhookDelivery(ctx context.Context, arg EnqueueWebhookDeliveryParams) (int64, error)
+	// Adds a client to an audit cycle, or returns the existing membership
+	EnsureClientInAuditCycle(ctx context.Context, arg EnsureClientInAuditCycleParams) (AuditCycleClient, error)
+	// Assigns a framework to a client in a cycle, or returns the existing
+	// assignment unchanged so retries never alter a provisioned audit. inserted
+	// tells whether this call created the assignment.
+	EnsureFrameworkAssignedToAuditCycleClient(ctx context.Context, arg EnsureFrameworkAssignedToAuditCycleClientParams) (EnsureFrameworkAssignedToAuditCycleClientRow, error)
 	GetAuditCycle(ctx context.Context, id uuid.UUID) (AuditCycle, error)
+	GetAuditCycleClient(ctx context.Context, id uuid.UUID) (AuditCycleClient, error)
 	GetAuditCycleClients(ctx context.Context, auditCycleID uuid.UUID) ([]GetAuditCycleClientsRow, error)
 	GetAuditCycleFrameworks(ctx context.Context, auditCycleID uuid.UUID) ([]GetAuditCycleFrameworksRow, error)
 	GetAuditCycleStats(ctx context.Context, id uuid.UUID) (GetAuditCycleStatsRow, error)
@@ -48,6 +85,7 @@ type Querier interface {
 	// Client-specific dashboard queries
 	// Get all audit cycles a specific client is enrolled in with framework details
 	GetClientAuditCycleEnrollments(ctx context.Context, clientID uuid.UUID) ([]GetClientAuditCycleEnrollmentsRow, error)
+	GetClientBranding(ctx context.Context, clientID uuid.UUID) (ClientBranding, error)
 	GetClientBucket(ctx context.Context, clientID uuid.UUID) (ClientBucket, error)
 	GetClientBucketByName(ctx context.Context, bucketName string) (ClientBucket, error)
 	GetClientByEmail(ctx context.Context, pocEmail string) (Client, error)
@@ -56,6 +94,7 @@ type Querier interface {
 	GetClientDatabaseByName(ctx context.Context, dbName string) (ClientDatabase, error)
 	GetClientFramework(ctx context.Context, id uuid.UUID) (GetClientFrameworkRow, error)
 	GetClientFrameworksInCycle(ctx context.Context, auditCycleClientID uuid.UUID) ([]GetClientFrameworksInCycleRow, error)
+	GetNotificationPreference(ctx context.Context, arg GetNotificationPreferenceParams) (NotificationPreference, error)
 	GetPermission(ctx context.Context, id uuid.UUID) (Permission, error)
 	GetPermissionByName(ctx context.Context, name string) (Permission, error)
 	GetRole(ctx context.Context, id uuid.UUID) (Role, error)
@@ -66,6 +105,11 @@ type Querier interface {
 	GetUserByOIDC(ctx context.Context, arg GetUserByOIDCParams) (User, error)
 	GetUserPermissions(ctx context.Context, userID uuid.UUID) ([]Permission, error)
 	GetUserRoles(ctx context.Context, userID uuid.UUID) ([]Role, error)
+	GetWebhookDelivery(ctx context.Context, arg GetWebhookDeliveryParams) (WebhookDelivery, error)
+	GetWebhookSubscription(ctx context.Context, arg GetWebhookSubscriptionParams) (WebhookSubscription, error)
+	GetWebhookSubscriptionByID(ctx context.Context, id uuid.UUID) (WebhookSubscription, error)
+	// Records the audit provisioned in the client database for an assignment
+	LinkAuditCycleFrameworkClientAudit(ctx context.Context, arg LinkAuditCycleFrameworkClientAuditParams) (AuditCycleFramework, error)
 	ListActiveClients(ctx context.Context) ([]Client, error)
 	ListAuditCycles(ctx context.Context) ([]AuditCycle, error)
 	ListAuditCyclesByStatus(ctx context.Context, status *string) ([]AuditCycle, error)
@@ -78,20 +122,47 @@ type Querier interface {
 	ListClientFrameworks(ctx context.Context, clientID uuid.UUID) ([]ListClientFrameworksRow, error)
 	ListClients(ctx context.Context) ([]Client, error)
 	ListFrameworksByStatus(ctx context.Context, status NullAuditStatusEnum) ([]ListFrameworksByStatusRow, error)
+	// Provisioned framework assignments of active cycles due within the given
+	// number of days, falling back to the end of the cycle, that have not been
+	// reminded of their current due date in this or a closer window yet
+	ListFrameworksDueForReminder(ctx context.Context, daysBefore int32) ([]ListFrameworksDueForReminderRow, error)
+	ListNotificationPreferences(ctx context.Context, userID uuid.UUID) ([]NotificationPreference, error)
+	// Notifications of a user, newest first, optionally only the unread ones
+	ListNotifications(ctx context.Context, arg ListNotificationsParams) ([]Notification, error)
 	ListPermissions(ctx context.Context) ([]Permission, error)
 	ListRoles(ctx context.Context) ([]Role, error)
 	ListTenantUsers(ctx context.Context) ([]User, error)
 	ListUsers(ctx context.Context) ([]User, error)
 	ListUsersByClient(ctx context.Context, clientID pgtype.UUID) ([]User, error)
 	ListUsersByRole(ctx context.Context, designation string) ([]User, error)
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	ListWebhookDeliveryAttempts(ctx context.Context, deliveryID uuid.UUID) ([]WebhookDeliveryAttempt, error)
+	ListWebhookSubscriptions(ctx context.Context, clientID uuid.UUID) ([]WebhookSubscription, error)
+	// Active subscriptions of a client to an event type
+	ListWebhookSubscriptionsForEvent(ctx context.Context, arg ListWebhookSubscriptionsForEventParams) ([]WebhookSubscription, error)
+	MarkAllNotificationsRead(ctx context.Context, userID uuid.UUID) (int64, error)
+	MarkEmailFailed(ctx context.Context, arg MarkEmailFailedParams) error
+	MarkEmailSent(ctx context.Context, id uuid.UUID) error
+	MarkEventPublished(ctx context.Context, id uuid.UUID) error
+	// Keeps the first read time of notifications that were already read
+	MarkNotificationRead(ctx context.Context, arg MarkNotificationReadParams) (Notification, error)
+	MarkWebhookDeliveryFailed(ctx context.Context, arg MarkWebhookDeliveryFailedParams) error
+	MarkWebhookDeliverySucceeded(ctx context.Context, arg MarkWebhookDeliverySucceededParams) error
 	RemoveClientFromAuditCycle(ctx context.Context, arg RemoveClientFromAuditCycleParams) error
 	RemoveRoleFromUser(ctx context.Context, arg RemoveRoleFromUserParams) error
+	RescheduleEmail(ctx context.Context, arg RescheduleEmailParams) error
+	RescheduleEvent(ctx context.Context, arg RescheduleEventParams) error
+	RescheduleWebhookDelivery(ctx context.Context, arg RescheduleWebhookDeliveryParams) error
 	UpdateAuditCycle(ctx context.Context, arg UpdateAuditCycleParams) (AuditCycle, error)
 	UpdateAuditCycleFrameworkStatus(ctx context.Context, arg UpdateAuditCycleFrameworkStatusParams) (AuditCycleFramework, error)
 	UpdateClient(ctx context.Context, arg UpdateClientParams) (Client, error)
 	UpdateClientFrameworkStatus(ctx context.Context, arg UpdateClientFrameworkStatusParams) (ClientFramework, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
 	UpdateUserLastLogin(ctx context.Context, id uuid.UUID) error
+	UpdateUserPreferredLocale(ctx context.Context, arg UpdateUserPreferredLocaleParams) (User, error)
+	UpdateWebhookSubscription(ctx context.Context, arg UpdateWebhookSubscriptionParams) (WebhookSubscription, error)
+	UpsertClientBranding(ctx context.Context, arg UpsertClientBrandingParams) (ClientBranding, error)
+	UpsertNotificationPreference(ctx context.Context, arg UpsertNotificationPreferenceParams) (NotificationPreference, error)
 }
 
 var _ Querier = (*Queries)(nil)
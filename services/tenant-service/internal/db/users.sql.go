@@ -15,7 +15,7 @@ import (
 const CreateUser = `-- name: CreateUser :one
 INSERT INTO users (email, name, oidc_provider, oidc_sub, designation, client_id)
 VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, email, name, oidc_provider, oidc_sub, designation, client_id, created_at, updated_at, last_login
+RETURNING id, email, name, oidc_provider, oidc_sub, designation, client_id, created_at, updated_at, last_login, preferred_locale
 `
 
 type CreateUserParams struct {
@@ -48,6 +48,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLogin,
+		&i.PreferredLocale,
 	)
 	return i, err
 }
@@ -63,7 +64,7 @@ func (q *Queries) DeleteUser(ctx context.Context, id uuid.UUID) error {
 }
 
 const GetUser = `-- name: GetUser :one
-SELECT id, email, name, oidc_provider, oidc_sub, designation, client_id, created_at, updated_at, last_login FROM users
+SELECT id, email, name, oidc_provider, oidc_sub, designation, client_id, created_at, updated_at, last_login, preferred_locale FROM users
 WHERE id = $1 LIMIT 1
 `
 
@@ -81,12 +82,13 @@ func (q *Queries) GetUser(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLogin,
+		&i.PreferredLocale,
 	)
 	return i, err
 }
 
 const GetUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, email, name, oidc_provider, oidc_sub, designation, client_id, created_at, updated_at, last_login FROM users
+SELECT id, email, name, oidc_provider, oidc_sub, designation, client_id, created_at, updated_at, last_login, preferred_locale FROM users
 WHERE email = $1 LIMIT 1
 `
 
@@ -104,12 +106,13 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLogin,
+		&i.PreferredLocale,
 	)
 	return i, err
 }
 
 const GetUserByOIDC = `-- name: GetUserByOIDC :one
-SELECT id, email, name, oidc_provider, oidc_sub, designation, client_id, created_at, updated_at, last_login FROM users
+SELECT id, email, name, oidc_provider, oidc_sub, designation, client_id, created_at, updated_at, last_login, preferred_locale FROM users
 WHERE oidc_provider = $1 AND oidc_sub = $2 LIMIT 1
 `
 
@@ -132,12 +135,13 @@ func (q *Queries) GetUserByOIDC(ctx context.Context, arg GetUserByOIDCParams) (U
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLogin,
+		&i.PreferredLocale,
 	)
 	return i, err
 }
 
 const ListTenantUsers = `-- name: ListTenantUsers :many
-SELECT id, email, name, oidc_provider, oidc_sub, designation, client_id, created_at, updated_at, last_login FROM users
+SELECT id, email, name, oidc_provider, oidc_sub, designation, client_id, created_at, updated_at, last_login, preferred_locale FROM users
 WHERE client_id IS NULL
 ORDER BY created_at DESC
 `
@@ -162,6 +166,7 @@ func (q *Queries) ListTenantUsers(ctx context.Context) ([]User, error) {
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.LastLogin,
+			&i.PreferredLocale,
 		); err != nil {
 			return nil, err
 		}
@@ -174,7 +179,7 @@ func (q *Queries) ListTenantUsers(ctx context.Context) ([]User, error) {
 }
 
 const ListUsers = `-- name: ListUsers :many
-SELECT id, email, name, oidc_provider, oidc_sub, designation, client_id, created_at, updated_at, last_login FROM users
+SELECT id, email, name, oidc_provider, oidc_sub, designation, client_id, created_at, updated_at, last_login, preferred_locale FROM users
 ORDER BY created_at DESC
 `
 
@@ -198,6 +203,7 @@ func (q *Queries) ListUsers(ctx context.Context) ([]User, error) {
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.LastLogin,
+			&i.PreferredLocale,
 		); err != nil {
 			return nil, err
 		}
@@ -210,7 +216,7 @@ func (q *Queries) ListUsers(ctx context.Context) ([]User, error) {
 }
 
 const ListUsersByClient = `-- name: ListUsersByClient :many
-SELECT id, email, name, oidc_provider, oidc_sub, designation, client_id, created_at, updated_at, last_login FROM users
+SELECT id, email, name, oidc_provider, oidc_sub, designation, client_id, created_at, updated_at, last_login, preferred_locale FROM users
 WHERE client_id = $1
 ORDER BY created_at DESC
 `
@@ -235,6 +241,7 @@ func (q *Queries) ListUsersByClient(ctx context.Context, clientID pgtype.UUID) (
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.LastLogin,
+			&i.PreferredLocale,
 		); err != nil {
 			return nil, err
 		}
@@ -247,7 +254,7 @@ func (q *Queries) ListUsersByClient(ctx context.Context, clientID pgtype.UUID) (
 }
 
 const ListUsersByRole = `-- name: ListUsersByRole :many
-SELECT id, email, name, oidc_provider, oidc_sub, designation, client_id, created_at, updated_at, last_login FROM users
+SELECT id, email, name, oidc_provider, oidc_sub, designation, client_id, created_at, updated_at, last_login, preferred_locale FROM users
 WHERE designation = $1
 ORDER BY created_at DESC
 `
@@ -272,6 +279,7 @@ func (q *Queries) ListUsersByRole(ctx context.Context, designation string) ([]Us
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.LastLogin,
+			&i.PreferredLocale,
 		); err != nil {
 			return nil, err
 		}
@@ -287,7 +295,7 @@ const UpdateUser = `-- name: UpdateUser :one
 UPDATE users
 SET name = $2, email = $3, designation = $4
 WHERE id = $1
-RETURNING id, email, name, oidc_provider, oidc_sub, designation, client_id, created_at, updated_at, last_login
+RETURNING id, email, name, oidc_provider, oidc_sub, designation, client_id, created_at, updated_at, last_login, preferred_locale
 `
 
 type UpdateUserParams struct {
@@ -316,6 +324,7 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLogin,
+		&i.PreferredLocale,
 	)
 	return i, err
 }
@@ -330,3 +339,34 @@ func (q *Queries) UpdateUserLastLogin(ctx context.Context, id uuid.UUID) error {
 	_, err := q.db.Exec(ctx, UpdateUserLastLogin, id)
 	return err
 }
+
+const UpdateUserPreferredLocale = `-- name: UpdateUserPreferredLocale :one
+UPDATE users
+SET preferred_locale = $2
+WHERE id = $1
+RETURNING id, email, name, oidc_provider, oidc_sub, designation, client_id, created_at, updated_at, last_login, preferred_locale
+`
+
+type UpdateUserPreferredLocaleParams struct {
+	ID              uuid.UUID `json:"id"`
+	PreferredLocale *string   `json:"preferred_locale"`
+}
+
+func (q *Queries) UpdateUserPreferredLocale(ctx context.Context, arg UpdateUserPreferredLocaleParams) (User, error) {
+	row := q.db.QueryRow(ctx, UpdateUserPreferredLocale, arg.ID, arg.PreferredLocale)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Name,
+		&i.OidcProvider,
+		&i.OidcSub,
+		&i.Designation,
+		&i.ClientID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.LastLogin,
+		&i.PreferredLocale,
+	)
+	return i, err
+}
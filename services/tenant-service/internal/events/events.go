@@ -0,0 +1,189 @@
+// Package events writes the domain events of tenant-service to the event
+// outboxes and exposes the outboxes to the eventbus relay publishing them.
+//
+// Events about client data are written to the event outbox of the client
+// database in the transaction of the change, events about tenant data to the
+// one of tenant_db.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/NormaTech-AI/audity/packages/go/eventbus"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientdb"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientstore"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/db"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+// Source is the source of the events emitted by this service
+const Source = "tenant-service"
+
+// Enqueue writes an event about a client to the outbox of its database. Call
+// it with the queries of the transaction of the change.
+func Enqueue(ctx context.Context, q *clientdb.Queries, clientID uuid.UUID, payload eventbus.Payload) error {
+	env, body, err := encode(clientID, payload)
+	if err != nil {
+		return err
+	}
+	if err := q.EnqueueEvent(ctx, clientdb.EnqueueEventParams{
+		ID:        env.ID,
+		EventType: string(env.Type),
+		Envelope:  body,
+	}); err != nil {
+		return fmt.Errorf("failed to enqueue %s event: %w", env.Type, err)
+	}
+	return nil
+}
+
+// EnqueueTenant writes an event to the outbox of tenant_db
+func EnqueueTenant(ctx context.Context, q *db.Queries, clientID uuid.UUID, payload eventbus.Payload) error {
+	env, body, err := encode(clientID, payload)
+	if err != nil {
+		return err
+	}
+	if err := q.EnqueueEvent(ctx, db.EnqueueEventParams{
+		ID:        env.ID,
+		EventType: string(env.Type),
+		Envelope:  body,
+	}); err != nil {
+		return fmt.Errorf("failed to enqueue %s event: %w", env.Type, err)
+	}
+	return nil
+}
+
+func encode(clientID uuid.UUID, payload eventbus.Payload) (eventbus.Envelope, []byte, error) {
+	env, err := eventbus.New(Source, clientID, payload)
+	if err != nil {
+		return eventbus.Envelope{}, nil, err
+	}
+	body, err := json.Marshal(env)
+	if err != nil {
+		return eventbus.Envelope{}, nil, fmt.Errorf("failed to encode %s event: %w", env.Type, err)
+	}
+	return env, body, nil
+}
+
+// Outboxes returns the outbox of tenant_db followed by the outboxes of the
+// client databases. Client databases that cannot be reached are skipped until
+// the next poll.
+func Outboxes(tenant *db.Queries, clientStore *clientstore.ClientStore, log *zap.SugaredLogger) eventbus.OutboxSource {
+	return func(ctx context.Context) ([]eventbus.Outbox, error) {
+		outboxes := []eventbus.Outbox{tenantOutbox{q: tenant}}
+
+		databases, err := tenant.ListClientDatabases(ctx)
+		if err != nil {
+			return outboxes, fmt.Errorf("failed to list client databases: %w", err)
+		}
+		for _, cdb := range databases {
+			q, _, err := clientStore.GetClientQueries(ctx, cdb.ClientID)
+			if err != nil {
+				if ctx.Err() != nil {
+					return outboxes, ctx.Err()
+				}
+				log.Warnw("Skipping event outbox of unreachable client database", "error", err, "client_id", cdb.ClientID)
+				continue
+			}
+			outboxes = append(outboxes, clientOutbox{q: q})
+		}
+		return outboxes, nil
+	}
+}
+
+// claimed is a claimed outbox row of either database
+type claimed struct {
+	envelope  []byte
+	attempts  int32
+	createdAt time.Time
+}
+
+// decode turns claimed rows into events, oldest first. Rows are written by
+// Enqueue, so an envelope that does not decode fails the whole claim.
+func decode(rows []claimed) ([]eventbus.OutboxEvent, error) {
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].createdAt.Before(rows[j].createdAt) })
+
+	events := make([]eventbus.OutboxEvent, 0, len(rows))
+	for _, row := range rows {
+		var env eventbus.Envelope
+		if err := json.Unmarshal(row.envelope, &env); err != nil {
+			return nil, fmt.Errorf("failed to decode outbox event: %w", err)
+		}
+		events = append(events, eventbus.OutboxEvent{Envelope: env, Attempts: int(row.attempts)})
+	}
+	return events, nil
+}
+
+func lastError(msg string) *string {
+	if msg == "" {
+		return nil
+	}
+	return &msg
+}
+
+type tenantOutbox struct {
+	q *db.Queries
+}
+
+func (o tenantOutbox) Claim(ctx context.Context, limit int, lease time.Duration) ([]eventbus.OutboxEvent, error) {
+	rows, err := o.q.ClaimDueEvents(ctx, db.ClaimDueEventsParams{
+		LeaseSeconds: int32(lease.Seconds()),
+		BatchSize:    int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]claimed, len(rows))
+	for i, row := range rows {
+		out[i] = claimed{envelope: row.Envelope, attempts: row.Attempts, createdAt: row.CreatedAt}
+	}
+	return decode(out)
+}
+
+func (o tenantOutbox) MarkPublished(ctx context.Context, id uuid.UUID) error {
+	return o.q.MarkEventPublished(ctx, id)
+}
+
+func (o tenantOutbox) Reschedule(ctx context.Context, id uuid.UUID, next time.Time, msg string) error {
+	return o.q.RescheduleEvent(ctx, db.RescheduleEventParams{ID: id, NextAttemptAt: next, LastError: lastError(msg)})
+}
+
+func (o tenantOutbox) DeletePublished(ctx context.Context, before time.Time) (int64, error) {
+	return o.q.DeletePublishedEvents(ctx, pgtype.Timestamptz{Time: before, Valid: true})
+}
+
+type clientOutbox struct {
+	q *clientdb.Queries
+}
+
+func (o clientOutbox) Claim(ctx context.Context, limit int, lease time.Duration) ([]eventbus.OutboxEvent, error) {
+	rows, err := o.q.ClaimDueEvents(ctx, clientdb.ClaimDueEventsParams{
+		LeaseSeconds: int32(lease.Seconds()),
+		BatchSize:    int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]claimed, len(rows))
+	for i, row := range rows {
+		out[i] = claimed{envelope: row.Envelope, attempts: row.Attempts, createdAt: row.CreatedAt}
+	}
+	return decode(out)
+}
+
+func (o clientOutbox) MarkPublished(ctx context.Context, id uuid.UUID) error {
+	return o.q.MarkEventPublished(ctx, id)
+}
+
+func (o clientOutbox) Reschedule(ctx context.Context, id uuid.UUID, next time.Time, msg string) error {
+	return o.q.RescheduleEvent(ctx, clientdb.RescheduleEventParams{ID: id, NextAttemptAt: next, LastError: lastError(msg)})
+}
+
+func (o clientOutbox) DeletePublished(ctx context.Context, before time.Time) (int64, error) {
+	return o.q.DeletePublishedEvents(ctx, pgtype.Timestamptz{Time: before, Valid: true})
+}
@@ -0,0 +1,57 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NormaTech-AI/audity/packages/go/eventbus"
+	"github.com/google/uuid"
+)
+
+func TestDecode(t *testing.T) {
+	clientID := uuid.New()
+	now := time.Now()
+
+	var rows []claimed
+	var ids []uuid.UUID
+	for i, age := range []time.Duration{time.Minute, time.Hour, time.Second} {
+		env, body, err := encode(clientID, eventbus.ReportDeliveredEvent{ReportID: uuid.New()})
+		if err != nil {
+			t.Fatalf("encode() error = %v", err)
+		}
+		ids = append(ids, env.ID)
+		rows = append(rows, claimed{envelope: body, attempts: int32(i + 1), createdAt: now.Add(-age)})
+	}
+
+	events, err := decode(rows)
+	if err != nil {
+		t.Fatalf("decode() error = %v", err)
+	}
+
+	// oldest first: the event written an hour ago, then a minute and a second ago
+	wantOrder := []int{1, 0, 2}
+	for i, want := range wantOrder {
+		e := events[i]
+		if e.Envelope.ID != ids[want] || e.Attempts != want+1 {
+			t.Errorf("event %d = %s attempt %d, want %s attempt %d", i, e.Envelope.ID, e.Attempts, ids[want], want+1)
+		}
+		if e.Envelope.Source != Source || e.Envelope.ClientID != clientID || e.Envelope.Type != eventbus.ReportDelivered {
+			t.Errorf("event %d envelope = %+v", i, e.Envelope)
+		}
+	}
+}
+
+func TestDecodeRejectsMalformedEnvelope(t *testing.T) {
+	if _, err := decode([]claimed{{envelope: []byte(`{"id":`)}}); err == nil {
+		t.Error("decode() of a malformed envelope succeeded")
+	}
+}
+
+func TestLastError(t *testing.T) {
+	if lastError("") != nil {
+		t.Error(`lastError("") != nil`)
+	}
+	if got := lastError("broker down"); got == nil || *got != "broker down" {
+		t.Errorf("lastError() = %v, want broker down", got)
+	}
+}
@@ -0,0 +1,137 @@
+// Package evidencereq checks the evidence of an answer against the evidence
+// requirements of its question.
+package evidencereq
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientdb"
+)
+
+// Requirements describe the evidence an answer must be backed by: at least
+// MinFiles files, only of the AllowedFileTypes extensions when any are given,
+// with at least one file of each of the RequiredCategories
+type Requirements struct {
+	MinFiles           int      `json:"min_files,omitempty"`
+	AllowedFileTypes   []string `json:"allowed_file_types,omitempty"`
+	RequiredCategories []string `json:"required_categories,omitempty"`
+}
+
+// ForQuestion returns the evidence requirements of a question, or nil when
+// it needs no evidence. Questions that only require evidence need one file.
+func ForQuestion(required bool, raw []byte) (*Requirements, error) {
+	if len(raw) > 0 && string(raw) != "null" {
+		var req Requirements
+		if err := json.Unmarshal(raw, &req); err != nil {
+			return nil, fmt.Errorf("failed to parse evidence requirements: %w", err)
+		}
+		return &req, nil
+	}
+	if required {
+		return &Requirements{MinFiles: 1}, nil
+	}
+	return nil, nil
+}
+
+// File is an evidence file checked against requirements
+type File struct {
+	Name     string
+	Type     *string
+	Category *string
+}
+
+// FromEvidence converts evidence records to files
+func FromEvidence(evidence []clientdb.Evidence) []File {
+	files := make([]File, 0, len(evidence))
+	for _, e := range evidence {
+		files = append(files, File{Name: e.FileName, Type: e.FileType, Category: e.Category})
+	}
+	return files
+}
+
+// Check is an item of the evidence checklist of an answer
+type Check struct {
+	Requirement string `json:"requirement"`
+	Satisfied   bool   `json:"satisfied"`
+	Detail      string `json:"detail,omitempty"`
+}
+
+// Evaluate checks files against the requirements, one check per requirement
+func Evaluate(req *Requirements, files []File) []Check {
+	if req == nil {
+		return nil
+	}
+
+	var checks []Check
+
+	if req.MinFiles > 0 {
+		checks = append(checks, Check{
+			Requirement: fmt.Sprintf("At least %d %s", req.MinFiles, plural(req.MinFiles, "file", "files")),
+			Satisfied:   len(files) >= req.MinFiles,
+			Detail:      fmt.Sprintf("%d uploaded", len(files)),
+		})
+	}
+
+	if len(req.AllowedFileTypes) > 0 {
+		var disallowed []string
+		for _, f := range files {
+			if !slices.Contains(req.AllowedFileTypes, fileType(f)) {
+				disallowed = append(disallowed, f.Name)
+			}
+		}
+		check := Check{
+			Requirement: "File types " + strings.Join(req.AllowedFileTypes, ", "),
+			Satisfied:   len(disallowed) == 0,
+		}
+		if len(disallowed) > 0 {
+			check.Detail = "Not allowed: " + strings.Join(disallowed, ", ")
+		}
+		checks = append(checks, check)
+	}
+
+	for _, category := range req.RequiredCategories {
+		count := 0
+		for _, f := range files {
+			if f.Category != nil && *f.Category == category {
+				count++
+			}
+		}
+		checks = append(checks, Check{
+			Requirement: "Category " + category,
+			Satisfied:   count > 0,
+			Detail:      fmt.Sprintf("%d uploaded", count),
+		})
+	}
+
+	return checks
+}
+
+// Unmet returns the checks that are not satisfied
+func Unmet(checks []Check) []Check {
+	var unmet []Check
+	for _, check := range checks {
+		if !check.Satisfied {
+			unmet = append(unmet, check)
+		}
+	}
+	return unmet
+}
+
+// fileType returns the lower case extension of a file, with a dot
+func fileType(f File) string {
+	if f.Type != nil && strings.HasPrefix(*f.Type, ".") {
+		return strings.ToLower(*f.Type)
+	}
+	return strings.ToLower(filepath.Ext(f.Name))
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
@@ -0,0 +1,111 @@
+package evidencereq
+
+import (
+	"reflect"
+	"testing"
+)
+
+func strPtr(s string) *string { return &s }
+
+func TestForQuestion(t *testing.T) {
+	tests := []struct {
+		name     string
+		required bool
+		raw      []byte
+		want     *Requirements
+		wantErr  bool
+	}{
+		{name: "no evidence", required: false, raw: nil, want: nil},
+		{name: "null requirements", required: false, raw: []byte("null"), want: nil},
+		{name: "evidence required", required: true, raw: nil, want: &Requirements{MinFiles: 1}},
+		{
+			name:     "structured requirements",
+			required: true,
+			raw:      []byte(`{"min_files":2,"allowed_file_types":[".pdf"],"required_categories":["policy"]}`),
+			want:     &Requirements{MinFiles: 2, AllowedFileTypes: []string{".pdf"}, RequiredCategories: []string{"policy"}},
+		},
+		{name: "invalid requirements", required: true, raw: []byte(`{"min_files":"two"}`), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ForQuestion(tt.required, tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ForQuestion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ForQuestion() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	policy := File{Name: "policy.PDF", Category: strPtr("policy")}
+	screenshot := File{Name: "console.png", Category: strPtr("screenshot")}
+	typed := File{Name: "export", Type: strPtr(".pdf")}
+
+	tests := []struct {
+		name  string
+		req   *Requirements
+		files []File
+		want  []Check
+	}{
+		{name: "no requirements", req: nil, files: []File{policy}, want: nil},
+		{
+			name:  "too few files",
+			req:   &Requirements{MinFiles: 2},
+			files: []File{policy},
+			want:  []Check{{Requirement: "At least 2 files", Satisfied: false, Detail: "1 uploaded"}},
+		},
+		{
+			name:  "one file",
+			req:   &Requirements{MinFiles: 1},
+			files: []File{policy},
+			want:  []Check{{Requirement: "At least 1 file", Satisfied: true, Detail: "1 uploaded"}},
+		},
+		{
+			name:  "allowed file types by extension or type",
+			req:   &Requirements{AllowedFileTypes: []string{".pdf"}},
+			files: []File{policy, typed},
+			want:  []Check{{Requirement: "File types .pdf", Satisfied: true}},
+		},
+		{
+			name:  "disallowed file type",
+			req:   &Requirements{AllowedFileTypes: []string{".pdf", ".docx"}},
+			files: []File{policy, screenshot},
+			want:  []Check{{Requirement: "File types .pdf, .docx", Satisfied: false, Detail: "Not allowed: console.png"}},
+		},
+		{
+			name:  "required categories",
+			req:   &Requirements{RequiredCategories: []string{"policy", "log_extract"}},
+			files: []File{policy, screenshot},
+			want: []Check{
+				{Requirement: "Category policy", Satisfied: true, Detail: "1 uploaded"},
+				{Requirement: "Category log_extract", Satisfied: false, Detail: "0 uploaded"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Evaluate(tt.req, tt.files); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Evaluate() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnmet(t *testing.T) {
+	checks := []Check{
+		{Requirement: "At least 1 file", Satisfied: true},
+		{Requirement: "Category policy", Satisfied: false},
+	}
+	want := []Check{{Requirement: "Category policy", Satisfied: false}}
+	if got := Unmet(checks); !reflect.DeepEqual(got, want) {
+		t.Errorf("Unmet() = %+v, want %+v", got, want)
+	}
+	if got := Unmet(checks[:1]); got != nil {
+		t.Errorf("Unmet() = %+v, want nil", got)
+	}
+}
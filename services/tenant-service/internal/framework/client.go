@@ -0,0 +1,490 @@
+package framework
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/answers"
+	"github.com/google/uuid"
+)
+
+// ChecklistQuestion is a question of a framework version as returned by
+// framework-service
+type ChecklistQuestion struct {
+	QuestionID         string   `json:"question_id"`
+	SectionTitle       *string  `json:"section_title"`
+	SubsectionTitle    *string  `json:"subsection_title"`
+	ControlID          string   `json:"control_id"`
+	QuestionText       string   `json:"question_text"`
+	HelpText           *string  `json:"help_text"`
+	AcceptableEvidence []string `json:"acceptable_evidence"`
+	EvidenceRequired   bool     `json:"evidence_required"`
+	// EvidenceRequirements are checked when the client submits an answer
+	EvidenceRequirements json.RawMessage      `json:"evidence_requirements,omitempty"`
+	VisibilityCondition  *VisibilityCondition `json:"visibility_condition,omitempty"`
+	Weight               int32                `json:"weight"`
+	Severity             string               `json:"severity"`
+	QuestionType         string               `json:"question_type"`
+	Options              []answers.Option     `json:"options,omitempty"`
+	IsMandatory          bool                 `json:"is_mandatory"`
+	DisplayOrder         int32                `json:"display_order"`
+	// Translations of the question by locale
+	Translations map[string]Translation `json:"translations,omitempty"`
+}
+
+// Translation is a question in another locale as returned by
+// framework-service. Empty fields fall back to the canonical text.
+type Translation struct {
+	QuestionText    string  `json:"question_text,omitempty"`
+	HelpText        *string `json:"help_text,omitempty"`
+	SectionTitle    *string `json:"section_title,omitempty"`
+	SubsectionTitle *string `json:"subsection_title,omitempty"`
+}
+
+// ClientOptions tunes how the client talks to framework-service
+type ClientOptions struct {
+	// Timeout bounds each request attempt
+	Timeout time.Duration
+	// MaxRetries is how many times a read that failed with a network error,
+	// a 429 or a 5xx response is retried, with exponential backoff
+	MaxRetries int
+	// CacheTTL is how long the latest version of a framework and control
+	// mappings are cached; zero disables caching them. Published versions
+	// never change and are cached until evicted.
+	CacheTTL time.Duration
+	// CacheSize bounds the number of cached responses; the oldest are
+	// evicted first
+	CacheSize int
+}
+
+// DefaultClientOptions returns the options used when none are configured
+func DefaultClientOptions() ClientOptions {
+	return ClientOptions{
+		Timeout:    30 * time.Second,
+		MaxRetries: 3,
+		CacheTTL:   time.Minute,
+		CacheSize:  1000,
+	}
+}
+
+// Client is the typed client of framework-service, the only source of
+// framework questions. Reads are retried and cached. Cached responses are
+// kept per caller token, so framework-service checks the permissions of
+// every caller before a response is served to them.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	maxRetries int
+	cacheTTL   time.Duration
+	cacheSize  int
+
+	mu    sync.Mutex
+	cache map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	value any
+	added time.Time
+	// expires is zero for entries that never expire
+	expires time.Time
+}
+
+// APIError is an error response from framework-service
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("framework-service returned %d: %s", e.StatusCode, e.Body)
+}
+
+// NewClient creates a framework-service client
+func NewClient(baseURL string, opts ClientOptions) *Client {
+	defaults := DefaultClientOptions()
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaults.Timeout
+	}
+	if opts.MaxRetries < 0 {
+		opts.MaxRetries = 0
+	}
+	if opts.CacheTTL < 0 {
+		opts.CacheTTL = 0
+	}
+	if opts.CacheSize <= 0 {
+		opts.CacheSize = defaults.CacheSize
+	}
+
+	return &Client{
+		baseURL:    baseURL,
+		httpClient: &http.Client{Timeout: opts.Timeout},
+		maxRetries: opts.MaxRetries,
+		cacheTTL:   opts.CacheTTL,
+		cacheSize:  opts.CacheSize,
+		cache:      make(map[string]cacheEntry),
+	}
+}
+
+// LatestVersion refers to the latest published version of a framework
+const LatestVersion = "latest"
+
+// Framework version statuses in framework-service
+const (
+	VersionStatusDraft     = "draft"
+	VersionStatusPublished = "published"
+)
+
+// FrameworkVersion is a framework version with its questions as returned by
+// framework-service. Language is the canonical language of the questions.
+type FrameworkVersion struct {
+	ID          uuid.UUID           `json:"id"`
+	FrameworkID uuid.UUID           `json:"framework_id"`
+	Version     string              `json:"version"`
+	Status      string              `json:"status"`
+	Language    string              `json:"language"`
+	Questions   []ChecklistQuestion `json:"questions"`
+}
+
+// GetVersion returns a version of a framework with its questions in checklist
+// order; version is a version ID or LatestVersion. The caller's token is
+// forwarded so framework-service applies its own permissions. The returned
+// version may be shared with other calls and must not be modified.
+func (c *Client) GetVersion(ctx context.Context, token string, frameworkID uuid.UUID, version string) (*FrameworkVersion, error) {
+	key := cacheKey(token, fmt.Sprintf("version:%s/%s", frameworkID, version))
+	if cached, ok := c.cached(key); ok {
+		return cached.(*FrameworkVersion), nil
+	}
+
+	var fv FrameworkVersion
+	path := fmt.Sprintf("/api/frameworks/%s/versions/%s", frameworkID, url.PathEscape(version))
+	if err := c.get(ctx, token, path, &fv); err != nil {
+		return nil, fmt.Errorf("failed to get framework version: %w", err)
+	}
+
+	// Drafts change until they are published, and the latest version moves
+	// when a new one is published
+	switch {
+	case version == LatestVersion:
+		c.store(key, &fv, false)
+	case fv.Status == VersionStatusPublished:
+		c.store(key, &fv, true)
+	}
+
+	return &fv, nil
+}
+
+// MappingStrengthEquivalent marks mapped controls that share answers
+const MappingStrengthEquivalent = "equivalent"
+
+// ControlMapping is a mapping of a framework control to a control of another
+// framework as returned by framework-service
+type ControlMapping struct {
+	ID                  uuid.UUID `json:"id"`
+	ControlID           string    `json:"control_id"`
+	MappedFrameworkID   uuid.UUID `json:"mapped_framework_id"`
+	MappedFrameworkName string    `json:"mapped_framework_name"`
+	MappedControlID     string    `json:"mapped_control_id"`
+	Strength            string    `json:"strength"`
+}
+
+// ListControlMappings returns the mappings of a framework control with the
+// given strength, or of any strength when strength is empty
+func (c *Client) ListControlMappings(ctx context.Context, token string, frameworkID uuid.UUID, controlID, strength string) ([]ControlMapping, error) {
+	query := url.Values{"control_id": {controlID}}
+	if strength != "" {
+		query.Set("strength", strength)
+	}
+
+	path := fmt.Sprintf("/api/frameworks/%s/mappings?%s", frameworkID, query.Encode())
+	key := cacheKey(token, path)
+	if cached, ok := c.cached(key); ok {
+		return cached.([]ControlMapping), nil
+	}
+
+	var mappings []ControlMapping
+	if err := c.get(ctx, token, path, &mappings); err != nil {
+		return nil, fmt.Errorf("failed to get control mappings: %w", err)
+	}
+
+	c.store(key, mappings, false)
+
+	return mappings, nil
+}
+
+// FrameworkSummary is a framework as listed by framework-service
+type FrameworkSummary struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Version     string    `json:"version"`
+}
+
+// ListFrameworks returns the frameworks defined in framework-service, reading
+// every page of the listing
+func (c *Client) ListFrameworks(ctx context.Context, token string) ([]FrameworkSummary, error) {
+	const pageSize = 100
+
+	var frameworks []FrameworkSummary
+	for offset := 0; ; offset += pageSize {
+		var page struct {
+			Data  []FrameworkSummary `json:"data"`
+			Total int                `json:"total"`
+		}
+		path := fmt.Sprintf("/api/frameworks?limit=%d&offset=%d", pageSize, offset)
+		if err := c.get(ctx, token, path, &page); err != nil {
+			return nil, fmt.Errorf("failed to list frameworks: %w", err)
+		}
+		frameworks = append(frameworks, page.Data...)
+		if len(page.Data) < pageSize || len(frameworks) >= page.Total {
+			return frameworks, nil
+		}
+	}
+}
+
+// NewFramework is a framework to create in framework-service. Its questions
+// become the first version, which is published right away.
+type NewFramework struct {
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Version     string        `json:"version"`
+	Questions   []NewQuestion `json:"questions"`
+}
+
+// NewQuestion is a question of a framework to create. Questions are listed
+// in checklist order.
+type NewQuestion struct {
+	SectionTitle         *string              `json:"section_title,omitempty"`
+	SubsectionTitle      *string              `json:"subsection_title,omitempty"`
+	ControlID            string               `json:"control_id"`
+	QuestionText         string               `json:"question_text"`
+	HelpText             *string              `json:"help_text,omitempty"`
+	AcceptableEvidence   []string             `json:"acceptable_evidence,omitempty"`
+	EvidenceRequired     bool                 `json:"evidence_required,omitempty"`
+	EvidenceRequirements json.RawMessage      `json:"evidence_requirements,omitempty"`
+	VisibilityCondition  *VisibilityCondition `json:"visibility_condition,omitempty"`
+	Weight               *int32               `json:"weight,omitempty"`
+	Severity             *string              `json:"severity,omitempty"`
+	QuestionType         *string              `json:"question_type,omitempty"`
+	Options              []answers.Option     `json:"options,omitempty"`
+	IsMandatory          *bool                `json:"is_mandatory,omitempty"`
+}
+
+// CreateFramework creates a framework with a published first version. It is
+// not retried, so a request that timed out may still have succeeded.
+func (c *Client) CreateFramework(ctx context.Context, token string, framework NewFramework) (*FrameworkSummary, error) {
+	body, err := json.Marshal(framework)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode framework: %w", err)
+	}
+
+	var created FrameworkSummary
+	if _, err := c.do(ctx, http.MethodPost, token, "/api/frameworks", body, &created); err != nil {
+		return nil, fmt.Errorf("failed to create framework: %w", err)
+	}
+	return &created, nil
+}
+
+// get performs a read, retrying network errors, 429 and 5xx responses with
+// exponential backoff
+func (c *Client) get(ctx context.Context, token, path string, out any) error {
+	var err error
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		if attempt > 0 {
+			timer := time.NewTimer(backoff(attempt))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+
+		var retry bool
+		retry, err = c.do(ctx, http.MethodGet, token, path, nil, out)
+		if !retry || ctx.Err() != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// do sends one request and decodes a successful response into out. It
+// reports whether a failed request may be retried.
+func (c *Client) do(ctx context.Context, method, token, path string, body []byte, out any) (bool, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("failed to call framework-service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		return retry, &APIError{StatusCode: resp.StatusCode, Body: string(data)}
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return false, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return false, nil
+}
+
+// backoff returns the delay before a retry: 200ms doubling per attempt up to
+// 5s, with up to 50% jitter so callers do not retry in lockstep
+func backoff(attempt int) time.Duration {
+	d := 200 * time.Millisecond << (attempt - 1)
+	if d <= 0 || d > 5*time.Second {
+		d = 5 * time.Second
+	}
+	return d + time.Duration(rand.Int63n(int64(d)/2))
+}
+
+// cacheKey scopes a cache key to the caller's token. The token is hashed so
+// the cache does not hold credentials.
+func cacheKey(token, key string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:16]) + ":" + key
+}
+
+func (c *Client) cached(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.cache[key]
+	if !ok {
+		return nil, false
+	}
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		delete(c.cache, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// store caches a value. Immutable values, such as published versions, are
+// kept until evicted; other values for the cache TTL, and not at all when it
+// is zero. A full cache first drops expired entries, then the oldest one.
+func (c *Client) store(key string, value any, immutable bool) {
+	if !immutable && c.cacheTTL <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if _, ok := c.cache[key]; !ok && len(c.cache) >= c.cacheSize {
+		c.evict(now)
+	}
+
+	entry := cacheEntry{value: value, added: now}
+	if !immutable {
+		entry.expires = now.Add(c.cacheTTL)
+	}
+	c.cache[key] = entry
+}
+
+// evict makes room for an entry. It must be called with c.mu held.
+func (c *Client) evict(now time.Time) {
+	for key, entry := range c.cache {
+		if !entry.expires.IsZero() && now.After(entry.expires) {
+			delete(c.cache, key)
+		}
+	}
+	if len(c.cache) < c.cacheSize {
+		return
+	}
+
+	var oldest string
+	var oldestAdded time.Time
+	for key, entry := range c.cache {
+		if oldest == "" || entry.added.Before(oldestAdded) {
+			oldest, oldestAdded = key, entry.added
+		}
+	}
+	delete(c.cache, oldest)
+}
+
+// ChecklistSections groups consecutive checklist questions of the same
+// section, in display order. Questions never move: when sections are
+// interleaved a section appears once per run, so a question still follows
+// the question its visibility condition depends on. Questions keep the
+// answer type, options and requirements defined in framework-service.
+func ChecklistSections(questions []ChecklistQuestion) []Section {
+	ordered := slices.Clone(questions)
+	slices.SortStableFunc(ordered, func(a, b ChecklistQuestion) int {
+		return cmp.Compare(a.DisplayOrder, b.DisplayOrder)
+	})
+
+	var sections []Section
+
+	for _, q := range ordered {
+		name := "General"
+		if q.SectionTitle != nil && *q.SectionTitle != "" {
+			name = *q.SectionTitle
+		}
+
+		if len(sections) == 0 || sections[len(sections)-1].Name != name {
+			sections = append(sections, Section{Name: name})
+		}
+		i := len(sections) - 1
+
+		var helpText, subsection string
+		if q.HelpText != nil {
+			helpText = *q.HelpText
+		}
+		if q.SubsectionTitle != nil {
+			subsection = *q.SubsectionTitle
+		}
+
+		questionType := q.QuestionType
+		if questionType == "" {
+			questionType = "yes_no"
+		}
+
+		sections[i].Questions = append(sections[i].Questions, Question{
+			Number:               q.ControlID,
+			Text:                 q.QuestionText,
+			Type:                 questionType,
+			HelpText:             helpText,
+			IsMandatory:          q.IsMandatory,
+			VisibilityCondition:  q.VisibilityCondition,
+			Options:              q.Options,
+			Weight:               q.Weight,
+			Severity:             q.Severity,
+			Subsection:           subsection,
+			EvidenceRequired:     q.EvidenceRequired,
+			AcceptableEvidence:   q.AcceptableEvidence,
+			EvidenceRequirements: q.EvidenceRequirements,
+			Translations:         q.Translations,
+		})
+	}
+
+	return sections
+}
@@ -0,0 +1,202 @@
+package framework
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// versionServer serves published framework versions and counts the requests
+// per token; requests with the token "denied" get a 403
+func versionServer(t *testing.T) (*httptest.Server, func(token string) int) {
+	t.Helper()
+
+	var mu sync.Mutex
+	requests := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		mu.Lock()
+		requests[token]++
+		mu.Unlock()
+
+		if token == "denied" {
+			http.Error(w, `{"error":"forbidden"}`, http.StatusForbidden)
+			return
+		}
+		json.NewEncoder(w).Encode(FrameworkVersion{ID: uuid.New(), Version: "1.0", Status: VersionStatusPublished})
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func(token string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests[token]
+	}
+}
+
+func TestGetVersionCachesPerToken(t *testing.T) {
+	server, requests := versionServer(t)
+	client := NewClient(server.URL, ClientOptions{MaxRetries: 0, CacheTTL: time.Minute})
+	ctx := context.Background()
+	frameworkID, versionID := uuid.New(), uuid.New().String()
+
+	tests := []struct {
+		name         string
+		token        string
+		wantErr      bool
+		wantRequests int
+	}{
+		{name: "first read", token: "alice", wantRequests: 1},
+		{name: "cached for the same token", token: "alice", wantRequests: 1},
+		{name: "fetched for another token", token: "bob", wantRequests: 1},
+		{name: "not served to a caller without access", token: "denied", wantErr: true, wantRequests: 1},
+		{name: "denied again", token: "denied", wantErr: true, wantRequests: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.GetVersion(ctx, tt.token, frameworkID, versionID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := requests(tt.token); got != tt.wantRequests {
+				t.Errorf("requests for %s = %d, want %d", tt.token, got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestCacheSize(t *testing.T) {
+	client := NewClient("http://framework-service", ClientOptions{CacheTTL: time.Minute, CacheSize: 2})
+
+	client.store("a", 1, true)
+	client.store("b", 2, false)
+	client.store("c", 3, true)
+
+	if len(client.cache) != 2 {
+		t.Fatalf("cache holds %d entries, want 2", len(client.cache))
+	}
+	if _, ok := client.cached("a"); ok {
+		t.Errorf("oldest entry was not evicted")
+	}
+	for _, key := range []string{"b", "c"} {
+		if _, ok := client.cached(key); !ok {
+			t.Errorf("entry %s was evicted", key)
+		}
+	}
+}
+
+func TestCacheEvictsExpiredEntriesFirst(t *testing.T) {
+	client := NewClient("http://framework-service", ClientOptions{CacheTTL: time.Minute, CacheSize: 2})
+
+	client.store("published", 1, true)
+	client.store("latest", 2, false)
+	// Expire the latest version
+	entry := client.cache["latest"]
+	entry.expires = time.Now().Add(-time.Second)
+	client.cache["latest"] = entry
+
+	client.store("mappings", 3, false)
+
+	if _, ok := client.cached("published"); !ok {
+		t.Errorf("live entry was evicted before the expired one")
+	}
+	if _, ok := client.cache["latest"]; ok {
+		t.Errorf("expired entry was kept")
+	}
+}
+
+func TestChecklistSections(t *testing.T) {
+	section := func(title string) *string { return &title }
+	question := func(controlID string, order int32, title *string) ChecklistQuestion {
+		return ChecklistQuestion{ControlID: controlID, DisplayOrder: order, SectionTitle: title}
+	}
+
+	tests := []struct {
+		name      string
+		questions []ChecklistQuestion
+		want      []string
+	}{
+		{
+			name: "grouped sections",
+			questions: []ChecklistQuestion{
+				question("A.1", 1, section("Access")),
+				question("A.2", 2, section("Access")),
+				question("B.1", 3, section("Backups")),
+			},
+			want: []string{"Access: A.1 A.2", "Backups: B.1"},
+		},
+		{
+			name: "sorted by display order",
+			questions: []ChecklistQuestion{
+				question("A.2", 2, section("Access")),
+				question("A.1", 1, section("Access")),
+			},
+			want: []string{"Access: A.1 A.2"},
+		},
+		{
+			name: "interleaved sections keep question order",
+			questions: []ChecklistQuestion{
+				question("A.1", 1, section("Access")),
+				question("B.1", 2, section("Backups")),
+				question("A.2", 3, section("Access")),
+			},
+			want: []string{"Access: A.1", "Backups: B.1", "Access: A.2"},
+		},
+		{
+			name: "questions without section",
+			questions: []ChecklistQuestion{
+				question("1", 1, nil),
+				question("2", 2, section("")),
+			},
+			want: []string{"General: 1 2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, s := range ChecklistSections(tt.questions) {
+				numbers := make([]string, 0, len(s.Questions))
+				for _, q := range s.Questions {
+					numbers = append(numbers, q.Number)
+				}
+				got = append(got, s.Name+": "+strings.Join(numbers, " "))
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("ChecklistSections() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChecklistSectionsKeepsDependencies(t *testing.T) {
+	access, backups := "Access", "Backups"
+	questions := []ChecklistQuestion{
+		{ControlID: "A.1", DisplayOrder: 1, SectionTitle: &access},
+		{ControlID: "B.1", DisplayOrder: 2, SectionTitle: &backups},
+		{
+			ControlID:           "A.2",
+			DisplayOrder:        3,
+			SectionTitle:        &access,
+			VisibilityCondition: &VisibilityCondition{DependsOn: "B.1", Operator: ConditionOperatorEquals, Values: []string{"yes"}},
+		},
+	}
+
+	seen := make(map[string]bool)
+	for _, s := range ChecklistSections(questions) {
+		for _, q := range s.Questions {
+			if q.VisibilityCondition != nil && !seen[q.VisibilityCondition.DependsOn] {
+				t.Errorf("question %s comes before %s it depends on", q.Number, q.VisibilityCondition.DependsOn)
+			}
+			seen[q.Number] = true
+		}
+	}
+}
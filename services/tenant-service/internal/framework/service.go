@@ -4,23 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
 	"time"
 
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/answers"
 	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientdb"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 	"go.uber.org/zap"
 )
 
-// FrameworkTemplate represents a compliance framework template
-type FrameworkTemplate struct {
-	FrameworkName string    `json:"framework_name"`
-	Version       string    `json:"version"`
-	Sections      []Section `json:"sections"`
-}
-
 // Section represents a section in a framework
 type Section struct {
 	Name      string     `json:"name"`
@@ -29,59 +21,102 @@ type Section struct {
 
 // Question represents a question in a framework
 type Question struct {
-	Number      string `json:"number"`
-	Text        string `json:"text"`
-	Type        string `json:"type"`
-	HelpText    string `json:"help_text"`
-	IsMandatory bool   `json:"is_mandatory"`
+	Number               string               `json:"number"`
+	Text                 string               `json:"text"`
+	Type                 string               `json:"type"`
+	HelpText             string               `json:"help_text"`
+	IsMandatory          bool                 `json:"is_mandatory"`
+	VisibilityCondition  *VisibilityCondition `json:"visibility_condition,omitempty"`
+	Options              []answers.Option     `json:"options,omitempty"`
+	Weight               int32                `json:"weight,omitempty"`
+	Severity             string               `json:"severity,omitempty"`
+	Subsection           string               `json:"subsection,omitempty"`
+	EvidenceRequired     bool                 `json:"evidence_required,omitempty"`
+	AcceptableEvidence   []string             `json:"acceptable_evidence,omitempty"`
+	EvidenceRequirements json.RawMessage      `json:"evidence_requirements,omitempty"`
+	// Translations of the question by locale; the audit keeps the canonical text
+	Translations map[string]Translation `json:"translations,omitempty"`
 }
 
-// Service handles framework template operations
+// Service provisions audit questions from framework definitions held by
+// framework-service
 type Service struct {
-	templatesDir string
-	logger       *zap.SugaredLogger
+	client *Client
+	logger *zap.SugaredLogger
+}
+
+// NewAudit describes an audit to provision in a client database
+type NewAudit struct {
+	FrameworkID   uuid.UUID
+	FrameworkName string
+	// AuditCycleFrameworkID links the audit to its assignment in tenant_db
+	AuditCycleFrameworkID uuid.UUID
+	// Checklist is the published framework version the audit is pinned to
+	Checklist  *Checklist
+	AssignedBy uuid.UUID
+	AssignedTo *uuid.UUID
+	DueDate    time.Time
+	// CarryForward prefills answers and evidence of the client's previous
+	// completed audit of the framework for confirmation
+	CarryForward bool
 }
 
 // NewService creates a new framework service
-func NewService(templatesDir string, logger *zap.SugaredLogger) *Service {
+func NewService(client *Client, logger *zap.SugaredLogger) *Service {
 	return &Service{
-		templatesDir: templatesDir,
-		logger:       logger,
+		client: client,
+		logger: logger,
 	}
 }
 
-// LoadTemplate loads a framework template from a JSON file
-func (s *Service) LoadTemplate(frameworkName string) (*FrameworkTemplate, error) {
-	filename := filepath.Join(s.templatesDir, fmt.Sprintf("%s-template.json", frameworkName))
-	
-	data, err := os.ReadFile(filename)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read template file: %w", err)
-	}
+// Checklist is the questions of a published framework version, grouped into
+// sections. Language is the canonical language of the questions.
+type Checklist struct {
+	VersionID uuid.UUID
+	Version   string
+	Language  string
+	Sections  []Section
+}
 
-	var template FrameworkTemplate
-	if err := json.Unmarshal(data, &template); err != nil {
-		return nil, fmt.Errorf("failed to parse template: %w", err)
+// FetchChecklist fetches a published version of a framework from
+// framework-service. Without a version ID the latest published version is used.
+// Drafts are rejected so every audit is pinned to a version that never changes.
+func (s *Service) FetchChecklist(ctx context.Context, token string, frameworkID uuid.UUID, versionID *uuid.UUID) (*Checklist, error) {
+	version := LatestVersion
+	if versionID != nil {
+		version = versionID.String()
 	}
 
-	return &template, nil
-}
+	fv, err := s.client.GetVersion(ctx, token, frameworkID, version)
+	if err != nil {
+		return nil, err
+	}
 
-// PopulateQuestions creates questions in a client database from a framework template
-func (s *Service) PopulateQuestions(ctx context.Context, queries *clientdb.Queries, auditID uuid.UUID, frameworkName string) error {
-	s.logger.Infow("Populating questions from template", "framework", frameworkName, "audit_id", auditID)
+	if fv.Status != VersionStatusPublished {
+		return nil, fmt.Errorf("framework version %s is not published", fv.Version)
+	}
 
-	// Load template
-	template, err := s.LoadTemplate(frameworkName)
-	if err != nil {
-		return fmt.Errorf("failed to load template: %w", err)
+	if len(fv.Questions) == 0 {
+		return nil, fmt.Errorf("framework %s has no questions", frameworkID)
 	}
 
+	return &Checklist{
+		VersionID: fv.ID,
+		Version:   fv.Version,
+		Language:  fv.Language,
+		Sections:  ChecklistSections(fv.Questions),
+	}, nil
+}
+
+// createQuestions creates the questions of the given sections in an audit
+func (s *Service) createQuestions(ctx context.Context, queries *clientdb.Queries, auditID uuid.UUID, frameworkName string, sections []Section) error {
+	var err error
 	displayOrder := 1
 	questionCount := 0
+	seen := make(map[string]bool)
 
 	// Iterate through sections and create questions
-	for _, section := range template.Sections {
+	for _, section := range sections {
 		for _, q := range section.Questions {
 			// Convert question type
 			var qType clientdb.QuestionTypeEnum
@@ -92,6 +127,14 @@ func (s *Service) PopulateQuestions(ctx context.Context, queries *clientdb.Queri
 				qType = clientdb.QuestionTypeEnumText
 			case "multiple_choice":
 				qType = clientdb.QuestionTypeEnumMultipleChoice
+			case "single_choice":
+				qType = clientdb.QuestionTypeEnumSingleChoice
+			case "numeric":
+				qType = clientdb.QuestionTypeEnumNumeric
+			case "date":
+				qType = clientdb.QuestionTypeEnumDate
+			case "table":
+				qType = clientdb.QuestionTypeEnumTable
 			default:
 				qType = clientdb.QuestionTypeEnumYesNo
 			}
@@ -102,96 +145,241 @@ func (s *Service) PopulateQuestions(ctx context.Context, queries *clientdb.Queri
 				helpText = &q.HelpText
 			}
 
+			var subsection *string
+			if q.Subsection != "" {
+				subsection = &q.Subsection
+			}
+
+			// Conditions may only depend on questions created before this one
+			var condition []byte
+			if q.VisibilityCondition != nil {
+				if err := q.VisibilityCondition.Validate(); err != nil {
+					return fmt.Errorf("invalid visibility condition on question %s: %w", q.Number, err)
+				}
+				if !seen[q.VisibilityCondition.DependsOn] {
+					return fmt.Errorf("question %s depends on %s which does not precede it", q.Number, q.VisibilityCondition.DependsOn)
+				}
+				condition, err = json.Marshal(q.VisibilityCondition)
+				if err != nil {
+					return fmt.Errorf("failed to encode visibility condition for question %s: %w", q.Number, err)
+				}
+			}
+
+			// Choice questions need options to pick from; tables use them as columns
+			var options []byte
+			if len(q.Options) > 0 {
+				options, err = json.Marshal(q.Options)
+				if err != nil {
+					return fmt.Errorf("failed to encode options for question %s: %w", q.Number, err)
+				}
+			} else if qType == clientdb.QuestionTypeEnumSingleChoice || qType == clientdb.QuestionTypeEnumMultipleChoice {
+				return fmt.Errorf("choice question %s has no options", q.Number)
+			}
+
+			// Scoring defaults to weight 1 and medium severity
+			weight := q.Weight
+			if weight == 0 {
+				weight = 1
+			} else if weight < 0 {
+				return fmt.Errorf("question %s has a negative weight", q.Number)
+			}
+			severity := clientdb.QuestionSeverityEnumMedium
+			if q.Severity != "" {
+				severity = clientdb.QuestionSeverityEnum(q.Severity)
+				if !severity.Valid() {
+					return fmt.Errorf("question %s has unsupported severity %q", q.Number, q.Severity)
+				}
+			}
+
 			// Create question
-			_, err := queries.CreateQuestion(ctx, clientdb.CreateQuestionParams{
-				AuditID:        auditID,
-				Section:        section.Name,
-				QuestionNumber: q.Number,
-				QuestionText:   q.Text,
-				QuestionType:   qType,
-				HelpText:       helpText,
-				IsMandatory:    q.IsMandatory,
-				DisplayOrder:   int32(displayOrder),
+			question, err := queries.CreateQuestion(ctx, clientdb.CreateQuestionParams{
+				AuditID:              auditID,
+				Section:              section.Name,
+				QuestionNumber:       q.Number,
+				QuestionText:         q.Text,
+				QuestionType:         qType,
+				HelpText:             helpText,
+				IsMandatory:          q.IsMandatory,
+				DisplayOrder:         int32(displayOrder),
+				VisibilityCondition:  condition,
+				Options:              options,
+				Weight:               weight,
+				Severity:             severity,
+				Subsection:           subsection,
+				EvidenceRequired:     q.EvidenceRequired || len(q.EvidenceRequirements) > 0,
+				AcceptableEvidence:   q.AcceptableEvidence,
+				EvidenceRequirements: q.EvidenceRequirements,
 			})
 			if err != nil {
 				return fmt.Errorf("failed to create question %s: %w", q.Number, err)
 			}
 
+			if err := createQuestionTranslations(ctx, queries, question.ID, q.Translations); err != nil {
+				return fmt.Errorf("failed to create translations of question %s: %w", q.Number, err)
+			}
+
+			seen[q.Number] = true
 			displayOrder++
 			questionCount++
 		}
 	}
 
-	s.logger.Infow("Questions populated successfully", 
-		"framework", frameworkName, 
-		"audit_id", auditID, 
+	s.logger.Infow("Questions populated successfully",
+		"framework", frameworkName,
+		"audit_id", auditID,
 		"question_count", questionCount)
 
 	return nil
 }
 
-// CreateAuditWithQuestions creates an audit and populates its questions
-func (s *Service) CreateAuditWithQuestions(
-	ctx context.Context,
-	queries *clientdb.Queries,
-	frameworkID uuid.UUID,
-	frameworkName string,
-	assignedBy uuid.UUID,
-	assignedTo *uuid.UUID,
-	dueDate time.Time,
-) (uuid.UUID, error) {
+// createQuestionTranslations stores the translations of a question. Empty
+// fields are stored as NULL so they fall back to the canonical text.
+func createQuestionTranslations(ctx context.Context, queries *clientdb.Queries, questionID uuid.UUID, translations map[string]Translation) error {
+	for locale, t := range translations {
+		var questionText *string
+		if t.QuestionText != "" {
+			questionText = &t.QuestionText
+		}
+
+		if err := queries.CreateQuestionTranslation(ctx, clientdb.CreateQuestionTranslationParams{
+			QuestionID:   questionID,
+			Locale:       locale,
+			QuestionText: questionText,
+			HelpText:     t.HelpText,
+			Section:      t.SectionTitle,
+			Subsection:   t.SubsectionTitle,
+		}); err != nil {
+			return fmt.Errorf("locale %s: %w", locale, err)
+		}
+	}
+	return nil
+}
+
+// CreateAuditWithQuestions creates an audit and its questions in a client
+// database, linked to its assignment in tenant_db
+func (s *Service) CreateAuditWithQuestions(ctx context.Context, queries *clientdb.Queries, spec NewAudit) (uuid.UUID, error) {
 	// Convert types for pgtype
 	var assignedToPgtype pgtype.UUID
-	if assignedTo != nil {
+	if spec.AssignedTo != nil {
 		assignedToPgtype = pgtype.UUID{
-			Bytes: *assignedTo,
+			Bytes: *spec.AssignedTo,
 			Valid: true,
 		}
 	}
 
 	dueDatePgtype := pgtype.Date{
-		Time:  dueDate,
+		Time:  spec.DueDate,
 		Valid: true,
 	}
 
 	// Create audit
 	audit, err := queries.CreateAudit(ctx, clientdb.CreateAuditParams{
-		FrameworkID:   frameworkID,
-		FrameworkName: frameworkName,
-		AssignedBy:    assignedBy,
-		AssignedTo:    assignedToPgtype,
-		DueDate:       dueDatePgtype,
-		Status:        clientdb.AuditStatusEnumNotStarted,
+		FrameworkID:           spec.FrameworkID,
+		FrameworkName:         spec.FrameworkName,
+		AssignedBy:            spec.AssignedBy,
+		AssignedTo:            assignedToPgtype,
+		DueDate:               dueDatePgtype,
+		Status:                clientdb.AuditStatusEnumNotStarted,
+		AuditCycleFrameworkID: pgtype.UUID{Bytes: spec.AuditCycleFrameworkID, Valid: spec.AuditCycleFrameworkID != uuid.Nil},
+		FrameworkVersionID:    pgtype.UUID{Bytes: spec.Checklist.VersionID, Valid: true},
+		FrameworkVersion:      &spec.Checklist.Version,
+		Language:              spec.Checklist.Language,
 	})
 	if err != nil {
 		return uuid.Nil, fmt.Errorf("failed to create audit: %w", err)
 	}
 
-	// Populate questions from template
-	if err := s.PopulateQuestions(ctx, queries, audit.ID, frameworkName); err != nil {
+	if err := s.createQuestions(ctx, queries, audit.ID, spec.FrameworkName, spec.Checklist.Sections); err != nil {
 		return uuid.Nil, fmt.Errorf("failed to populate questions: %w", err)
 	}
 
+	// Exceptions approved in earlier audits of the framework stay in force until they expire
+	carried, err := queries.CarryForwardExceptions(ctx, clientdb.CarryForwardExceptionsParams{
+		AuditID:     audit.ID,
+		FrameworkID: spec.FrameworkID,
+	})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to carry forward exceptions: %w", err)
+	}
+	if carried > 0 {
+		s.logger.Infow("Carried forward exceptions", "audit_id", audit.ID, "count", carried)
+	}
+
+	if spec.CarryForward {
+		if _, err := s.CarryForwardAnswers(ctx, queries, audit.ID, spec.FrameworkID); err != nil {
+			return uuid.Nil, err
+		}
+	}
+
 	return audit.ID, nil
 }
 
-// ListAvailableTemplates returns a list of available framework templates
-func (s *Service) ListAvailableTemplates() ([]string, error) {
-	files, err := os.ReadDir(s.templatesDir)
+// CarryForwardAnswers prefills the unanswered questions of an audit with the
+// approved answers and evidence of the client's most recent completed audit of
+// the same framework, matched by question number. It returns the number of
+// answers carried forward.
+func (s *Service) CarryForwardAnswers(ctx context.Context, queries *clientdb.Queries, auditID, frameworkID uuid.UUID) (int64, error) {
+	answerCount, err := queries.CarryForwardSubmissions(ctx, clientdb.CarryForwardSubmissionsParams{
+		AuditID:     auditID,
+		FrameworkID: frameworkID,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to read templates directory: %w", err)
+		return 0, fmt.Errorf("failed to carry forward answers: %w", err)
 	}
 
-	templates := []string{}
-	for _, file := range files {
-		if !file.IsDir() && filepath.Ext(file.Name()) == ".json" {
-			name := file.Name()
-			// Remove "-template.json" suffix
-			if len(name) > 14 {
-				templates = append(templates, name[:len(name)-14])
-			}
+	evidenceCount, err := queries.CarryForwardEvidence(ctx, auditID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to carry forward evidence: %w", err)
+	}
+
+	s.logger.Infow("Carried forward answers",
+		"audit_id", auditID,
+		"framework_id", frameworkID,
+		"answer_count", answerCount,
+		"evidence_count", evidenceCount)
+
+	return answerCount, nil
+}
+
+// PropagateAnswer copies a submitted answer to the questions of equivalent
+// mapped controls in the client's other open audits, so an answer given once
+// counts for every framework that asks the same thing. Copies are submitted
+// for review in their own audits and share the answer's evidence. It returns
+// the number of questions the answer was copied to.
+func (s *Service) PropagateAnswer(ctx context.Context, token string, queries *clientdb.Queries, submissionID, frameworkID uuid.UUID, controlID string) (int64, error) {
+	mappings, err := s.client.ListControlMappings(ctx, token, frameworkID, controlID, MappingStrengthEquivalent)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get control mappings: %w", err)
+	}
+
+	var answerCount int64
+	for _, m := range mappings {
+		count, err := queries.PropagateSubmission(ctx, clientdb.PropagateSubmissionParams{
+			SubmissionID: submissionID,
+			FrameworkID:  m.MappedFrameworkID,
+			ControlID:    m.MappedControlID,
+		})
+		if err != nil {
+			return answerCount, fmt.Errorf("failed to propagate answer to %s %s: %w", m.MappedFrameworkName, m.MappedControlID, err)
 		}
+		answerCount += count
+	}
+
+	if answerCount == 0 {
+		return 0, nil
 	}
 
-	return templates, nil
+	evidenceCount, err := queries.PropagateEvidence(ctx, pgtype.UUID{Bytes: submissionID, Valid: true})
+	if err != nil {
+		return answerCount, fmt.Errorf("failed to propagate evidence: %w", err)
+	}
+
+	s.logger.Infow("Propagated answer to mapped controls",
+		"submission_id", submissionID,
+		"framework_id", frameworkID,
+		"control_id", controlID,
+		"answer_count", answerCount,
+		"evidence_count", evidenceCount)
+
+	return answerCount, nil
 }
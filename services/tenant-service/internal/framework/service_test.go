@@ -0,0 +1,134 @@
+package framework
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientdb"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+// fakeClientDB answers statements with the number of rows affected per
+// mapped control and records the propagation statements it executes
+type fakeClientDB struct {
+	// affected maps a control ID to the number of questions it answers
+	affected  map[string]int64
+	submitted []string
+	evidence  int
+}
+
+func (f *fakeClientDB) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	switch sql {
+	case clientdb.PropagateSubmission:
+		controlID := args[2].(string)
+		f.submitted = append(f.submitted, controlID)
+		return pgconn.NewCommandTag(fmt.Sprintf("INSERT 0 %d", f.affected[controlID])), nil
+	case clientdb.PropagateEvidence:
+		f.evidence++
+		return pgconn.NewCommandTag("INSERT 0 1"), nil
+	}
+	return pgconn.CommandTag{}, errors.New("unexpected statement")
+}
+
+func (f *fakeClientDB) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return nil, errors.New("unexpected query")
+}
+
+func (f *fakeClientDB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return nil
+}
+
+func (f *fakeClientDB) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	return 0, errors.New("unexpected copy")
+}
+
+// mappingServer serves the given equivalent mappings and fails every request
+// when mappings is nil
+func mappingServer(t *testing.T, mappings []ControlMapping) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("strength") != MappingStrengthEquivalent {
+			t.Errorf("mappings requested with strength %q", r.URL.Query().Get("strength"))
+		}
+		if mappings == nil {
+			http.Error(w, `{"error":"not found"}`, http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(mappings)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestPropagateAnswer(t *testing.T) {
+	mappings := []ControlMapping{
+		{MappedFrameworkID: uuid.New(), MappedFrameworkName: "BSE", MappedControlID: "B.1", Strength: MappingStrengthEquivalent},
+		{MappedFrameworkID: uuid.New(), MappedFrameworkName: "MCX", MappedControlID: "M.4", Strength: MappingStrengthEquivalent},
+	}
+
+	tests := []struct {
+		name          string
+		mappings      []ControlMapping
+		affected      map[string]int64
+		want          int64
+		wantSubmitted []string
+		wantEvidence  int
+		wantErr       bool
+	}{
+		{
+			name:          "copied to the open audits of mapped controls",
+			mappings:      mappings,
+			affected:      map[string]int64{"B.1": 1, "M.4": 2},
+			want:          3,
+			wantSubmitted: []string{"B.1", "M.4"},
+			wantEvidence:  1,
+		},
+		{
+			name:          "no open audit asks a mapped control",
+			mappings:      mappings,
+			wantSubmitted: []string{"B.1", "M.4"},
+		},
+		{
+			name:     "control without mappings",
+			mappings: []ControlMapping{},
+		},
+		{
+			name:    "mappings unavailable",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := mappingServer(t, tt.mappings)
+			service := NewService(NewClient(server.URL, ClientOptions{MaxRetries: 0, CacheTTL: time.Minute}), zap.NewNop().Sugar())
+			clientDB := &fakeClientDB{affected: tt.affected}
+
+			got, err := service.PropagateAnswer(context.Background(), "token", clientdb.New(clientDB), uuid.New(), uuid.New(), "N.1")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PropagateAnswer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("PropagateAnswer() = %d, want %d", got, tt.want)
+			}
+			if !slices.Equal(clientDB.submitted, tt.wantSubmitted) {
+				t.Errorf("propagated to %v, want %v", clientDB.submitted, tt.wantSubmitted)
+			}
+			if clientDB.evidence != tt.wantEvidence {
+				t.Errorf("evidence propagated %d times, want %d", clientDB.evidence, tt.wantEvidence)
+			}
+		})
+	}
+}
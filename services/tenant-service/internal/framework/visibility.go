@@ -0,0 +1,125 @@
+package framework
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Visibility condition operators
+const (
+	ConditionOperatorEquals = "equals"
+	ConditionOperatorAnyOf  = "any_of"
+)
+
+// VisibilityCondition shows a question only when the answer to an earlier
+// question (referenced by question number) matches one of the given values
+type VisibilityCondition struct {
+	DependsOn string   `json:"depends_on"`
+	Operator  string   `json:"operator"`
+	Values    []string `json:"values"`
+}
+
+// ConditionalQuestion is the minimal view of a question needed to resolve visibility
+type ConditionalQuestion struct {
+	QuestionNumber string
+	Condition      []byte
+	Answers        []string
+}
+
+// ParseVisibilityCondition decodes a stored condition; it returns nil when the
+// question has no condition
+func ParseVisibilityCondition(raw []byte) (*VisibilityCondition, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	var cond VisibilityCondition
+	if err := json.Unmarshal(raw, &cond); err != nil {
+		return nil, fmt.Errorf("failed to parse visibility condition: %w", err)
+	}
+
+	return &cond, nil
+}
+
+// Validate checks that the condition is well formed
+func (c *VisibilityCondition) Validate() error {
+	if c.DependsOn == "" {
+		return fmt.Errorf("visibility condition must reference a question")
+	}
+
+	switch c.Operator {
+	case ConditionOperatorEquals:
+		if len(c.Values) != 1 {
+			return fmt.Errorf("%q condition takes exactly one value", c.Operator)
+		}
+	case ConditionOperatorAnyOf:
+		if len(c.Values) == 0 {
+			return fmt.Errorf("%q condition needs at least one value", c.Operator)
+		}
+	default:
+		return fmt.Errorf("unsupported visibility operator %q", c.Operator)
+	}
+
+	return nil
+}
+
+// Matches reports whether any of the given answers satisfies the condition
+func (c *VisibilityCondition) Matches(answers []string) bool {
+	for _, answer := range answers {
+		for _, value := range c.Values {
+			if answer == value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ResolveVisibility evaluates the visibility of every question and returns a
+// map keyed by question number.
+//
+// A question is visible when it has no condition, or when the question it
+// depends on is itself visible and its answer matches. Conditions that cannot
+// be evaluated (malformed, unknown reference, cycles) leave the question
+// visible so that it is never silently skipped by completion checks.
+func ResolveVisibility(questions []ConditionalQuestion) map[string]bool {
+	byNumber := make(map[string]ConditionalQuestion, len(questions))
+	for _, q := range questions {
+		byNumber[q.QuestionNumber] = q
+	}
+
+	visible := make(map[string]bool, len(questions))
+	visiting := make(map[string]bool)
+
+	var resolve func(number string) bool
+	resolve = func(number string) bool {
+		if v, ok := visible[number]; ok {
+			return v
+		}
+
+		q, ok := byNumber[number]
+		if !ok || visiting[number] {
+			return true
+		}
+
+		visiting[number] = true
+		defer delete(visiting, number)
+
+		result := true
+		cond, err := ParseVisibilityCondition(q.Condition)
+		if err == nil && cond != nil && cond.Validate() == nil {
+			if parent, ok := byNumber[cond.DependsOn]; ok {
+				result = resolve(cond.DependsOn) && cond.Matches(parent.Answers)
+			}
+		}
+
+		visible[number] = result
+		return result
+	}
+
+	for _, q := range questions {
+		resolve(q.QuestionNumber)
+	}
+
+	return visible
+}
@@ -0,0 +1,159 @@
+package framework
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParseVisibilityCondition(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    *VisibilityCondition
+		wantErr bool
+	}{
+		{name: "none", raw: ""},
+		{name: "null", raw: "null"},
+		{
+			name: "equals",
+			raw:  `{"depends_on":"1.1","operator":"equals","values":["yes"]}`,
+			want: &VisibilityCondition{DependsOn: "1.1", Operator: ConditionOperatorEquals, Values: []string{"yes"}},
+		},
+		{name: "malformed", raw: `{"depends_on":`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseVisibilityCondition([]byte(tt.raw))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseVisibilityCondition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseVisibilityCondition() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVisibilityConditionValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cond    VisibilityCondition
+		wantErr bool
+	}{
+		{name: "equals", cond: VisibilityCondition{DependsOn: "1.1", Operator: ConditionOperatorEquals, Values: []string{"yes"}}},
+		{name: "any of", cond: VisibilityCondition{DependsOn: "1.1", Operator: ConditionOperatorAnyOf, Values: []string{"a", "b"}}},
+		{name: "no reference", cond: VisibilityCondition{Operator: ConditionOperatorEquals, Values: []string{"yes"}}, wantErr: true},
+		{name: "equals with two values", cond: VisibilityCondition{DependsOn: "1.1", Operator: ConditionOperatorEquals, Values: []string{"a", "b"}}, wantErr: true},
+		{name: "any of without values", cond: VisibilityCondition{DependsOn: "1.1", Operator: ConditionOperatorAnyOf}, wantErr: true},
+		{name: "unknown operator", cond: VisibilityCondition{DependsOn: "1.1", Operator: "contains", Values: []string{"a"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cond.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResolveVisibility(t *testing.T) {
+	dependsOn := func(number, operator string, values ...string) []byte {
+		raw, err := json.Marshal(VisibilityCondition{DependsOn: number, Operator: operator, Values: values})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return raw
+	}
+
+	tests := []struct {
+		name      string
+		questions []ConditionalQuestion
+		want      map[string]bool
+	}{
+		{
+			name: "no conditions",
+			questions: []ConditionalQuestion{
+				{QuestionNumber: "1"},
+				{QuestionNumber: "2"},
+			},
+			want: map[string]bool{"1": true, "2": true},
+		},
+		{
+			name: "matching answer",
+			questions: []ConditionalQuestion{
+				{QuestionNumber: "1", Answers: []string{"yes"}},
+				{QuestionNumber: "2", Condition: dependsOn("1", ConditionOperatorEquals, "yes")},
+			},
+			want: map[string]bool{"1": true, "2": true},
+		},
+		{
+			name: "other answer",
+			questions: []ConditionalQuestion{
+				{QuestionNumber: "1", Answers: []string{"no"}},
+				{QuestionNumber: "2", Condition: dependsOn("1", ConditionOperatorEquals, "yes")},
+			},
+			want: map[string]bool{"1": true, "2": false},
+		},
+		{
+			name: "unanswered parent hides",
+			questions: []ConditionalQuestion{
+				{QuestionNumber: "1"},
+				{QuestionNumber: "2", Condition: dependsOn("1", ConditionOperatorAnyOf, "yes", "partial")},
+			},
+			want: map[string]bool{"1": true, "2": false},
+		},
+		{
+			name: "any of a multi-select answer",
+			questions: []ConditionalQuestion{
+				{QuestionNumber: "1", Answers: []string{"email", "sms"}},
+				{QuestionNumber: "2", Condition: dependsOn("1", ConditionOperatorAnyOf, "sms", "phone")},
+			},
+			want: map[string]bool{"1": true, "2": true},
+		},
+		{
+			name: "hidden parent hides its dependents",
+			questions: []ConditionalQuestion{
+				{QuestionNumber: "1", Answers: []string{"no"}},
+				{QuestionNumber: "2", Condition: dependsOn("1", ConditionOperatorEquals, "yes"), Answers: []string{"yes"}},
+				{QuestionNumber: "3", Condition: dependsOn("2", ConditionOperatorEquals, "yes")},
+			},
+			want: map[string]bool{"1": true, "2": false, "3": false},
+		},
+		{
+			name: "dependent listed before its parent",
+			questions: []ConditionalQuestion{
+				{QuestionNumber: "2", Condition: dependsOn("1", ConditionOperatorEquals, "yes")},
+				{QuestionNumber: "1", Answers: []string{"no"}},
+			},
+			want: map[string]bool{"1": true, "2": false},
+		},
+		{
+			name: "unknown reference and malformed condition stay visible",
+			questions: []ConditionalQuestion{
+				{QuestionNumber: "1", Condition: dependsOn("9", ConditionOperatorEquals, "yes")},
+				{QuestionNumber: "2", Condition: []byte(`{"depends_on":`)},
+				{QuestionNumber: "3", Condition: dependsOn("1", "contains", "yes")},
+			},
+			want: map[string]bool{"1": true, "2": true, "3": true},
+		},
+		{
+			name: "cycle terminates",
+			questions: []ConditionalQuestion{
+				{QuestionNumber: "1", Condition: dependsOn("2", ConditionOperatorEquals, "yes"), Answers: []string{"yes"}},
+				{QuestionNumber: "2", Condition: dependsOn("1", ConditionOperatorEquals, "yes"), Answers: []string{"yes"}},
+			},
+			want: map[string]bool{"1": true, "2": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolveVisibility(tt.questions); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResolveVisibility() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,9 +1,13 @@
 package handler
 
 import (
+	"encoding/json"
 	"net/http"
 
+	"github.com/NormaTech-AI/audity/packages/go/eventbus"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/answers"
 	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientdb"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/evidencereq"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/labstack/echo/v4"
@@ -34,18 +38,27 @@ type AuditDetailResponse struct {
 
 // QuestionWithSubmissionResponse represents a question with its submission status
 type QuestionWithSubmissionResponse struct {
-	ID             string  `json:"id"`
-	Section        string  `json:"section"`
-	QuestionNumber string  `json:"question_number"`
-	QuestionText   string  `json:"question_text"`
-	QuestionType   string  `json:"question_type"`
-	HelpText       *string `json:"help_text"`
-	IsMandatory    bool    `json:"is_mandatory"`
-	DisplayOrder   int32   `json:"display_order"`
-	SubmissionID   *string `json:"submission_id"`
-	Answer         *string `json:"answer"`
-	Status         *string `json:"status"`
-	SubmittedAt    *string `json:"submitted_at"`
+	ID                   string          `json:"id"`
+	Section              string          `json:"section"`
+	Subsection           *string         `json:"subsection"`
+	QuestionNumber       string          `json:"question_number"`
+	QuestionText         string          `json:"question_text"`
+	QuestionType         string          `json:"question_type"`
+	HelpText             *string         `json:"help_text"`
+	IsMandatory          bool            `json:"is_mandatory"`
+	DisplayOrder         int32           `json:"display_order"`
+	Options              json.RawMessage `json:"options,omitempty"`
+	EvidenceRequired     bool            `json:"evidence_required"`
+	AcceptableEvidence   []string        `json:"acceptable_evidence,omitempty"`
+	EvidenceRequirements json.RawMessage `json:"evidence_requirements,omitempty"`
+	// EvidenceChecklist checks the evidence of the answer against the
+	// requirements, for review
+	EvidenceChecklist []evidencereq.Check `json:"evidence_checklist,omitempty"`
+	SubmissionID      *string             `json:"submission_id"`
+	Answer            *string             `json:"answer"`
+	AnswerData        json.RawMessage     `json:"answer_data,omitempty"`
+	Status            *string             `json:"status"`
+	SubmittedAt       *string             `json:"submitted_at"`
 }
 
 // UpdateAuditRequest represents the request to update an audit
@@ -185,6 +198,23 @@ func (h *Handler) GetAudit(c echo.Context) error {
 		})
 	}
 
+	// Evidence of every answer, for the evidence checklists
+	auditEvidence, err := clientQueries.ListEvidenceByAudit(ctx, auditID)
+	if err != nil {
+		h.logger.Errorw("Failed to get evidence", "error", err, "audit_id", auditID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve evidence",
+		})
+	}
+	evidenceFiles := make(map[uuid.UUID][]evidencereq.File)
+	for _, e := range auditEvidence {
+		evidenceFiles[e.SubmissionID] = append(evidenceFiles[e.SubmissionID], evidencereq.File{
+			Name:     e.FileName,
+			Type:     e.FileType,
+			Category: e.Category,
+		})
+	}
+
 	// Convert questions to response format
 	questions := make([]QuestionWithSubmissionResponse, 0, len(questionsWithSubs))
 	for _, q := range questionsWithSubs {
@@ -199,9 +229,19 @@ func (h *Handler) GetAudit(c echo.Context) error {
 			submissionID = &sid
 		}
 
-		// Answer data would come from a separate submission query
-		// For now, we don't have the answer in this query
-		var answer *string = nil
+		var answer *string
+		if q.SubmissionID.Valid {
+			rendered := answers.Render(q.QuestionType, q.Options, q.AnswerValue, q.AnswerText, q.AnswerData)
+			answer = &rendered
+		}
+
+		var options, answerData json.RawMessage
+		if len(q.Options) > 0 {
+			options = q.Options
+		}
+		if len(q.AnswerData) > 0 {
+			answerData = q.AnswerData
+		}
 
 		var status *string
 		if q.SubmissionStatus.Valid {
@@ -215,19 +255,35 @@ func (h *Handler) GetAudit(c echo.Context) error {
 			submittedAt = &sa
 		}
 
+		var checklist []evidencereq.Check
+		if q.SubmissionID.Valid {
+			requirements, err := evidencereq.ForQuestion(q.EvidenceRequired, q.EvidenceRequirements)
+			if err != nil {
+				h.logger.Warnw("Failed to parse evidence requirements", "error", err, "question_id", q.ID)
+			}
+			checklist = evidencereq.Evaluate(requirements, evidenceFiles[uuid.UUID(q.SubmissionID.Bytes)])
+		}
+
 		questions = append(questions, QuestionWithSubmissionResponse{
-			ID:             q.ID.String(),
-			Section:        q.Section,
-			QuestionNumber: q.QuestionNumber,
-			QuestionText:   q.QuestionText,
-			QuestionType:   string(q.QuestionType),
-			HelpText:       helpText,
-			IsMandatory:    q.IsMandatory,
-			DisplayOrder:   q.DisplayOrder,
-			SubmissionID:   submissionID,
-			Answer:         answer,
-			Status:         status,
-			SubmittedAt:    submittedAt,
+			ID:                   q.ID.String(),
+			Section:              q.Section,
+			Subsection:           q.Subsection,
+			QuestionNumber:       q.QuestionNumber,
+			QuestionText:         q.QuestionText,
+			QuestionType:         string(q.QuestionType),
+			HelpText:             helpText,
+			IsMandatory:          q.IsMandatory,
+			DisplayOrder:         q.DisplayOrder,
+			Options:              options,
+			EvidenceRequired:     q.EvidenceRequired,
+			AcceptableEvidence:   q.AcceptableEvidence,
+			EvidenceRequirements: q.EvidenceRequirements,
+			EvidenceChecklist:    checklist,
+			SubmissionID:         submissionID,
+			Answer:               answer,
+			AnswerData:           answerData,
+			Status:               status,
+			SubmittedAt:          submittedAt,
 		})
 	}
 
@@ -319,12 +375,31 @@ func (h *Handler) UpdateAudit(c echo.Context) error {
 		}
 	}
 
-	// Update status if provided
+	// Update status if provided; completing the audit is recorded as an
+	// event in the same transaction
 	if req.Status != nil {
 		status := clientdb.AuditStatusEnum(*req.Status)
-		_, err = clientQueries.UpdateAuditStatus(ctx, clientdb.UpdateAuditStatusParams{
-			ID:     auditID,
-			Status: status,
+		err = h.clientStore.ExecClientTx(ctx, clientID, func(q *clientdb.Queries) error {
+			previous, err := q.GetAuditByID(ctx, auditID)
+			if err != nil {
+				return err
+			}
+			updated, err := q.UpdateAuditStatus(ctx, clientdb.UpdateAuditStatusParams{
+				ID:     auditID,
+				Status: status,
+			})
+			if err != nil {
+				return err
+			}
+			if updated.Status != clientdb.AuditStatusEnumCompleted || previous.Status == clientdb.AuditStatusEnumCompleted {
+				return nil
+			}
+			return h.emitEvent(ctx, q, clientID, eventbus.AuditCompletedEvent{
+				AuditID:       updated.ID,
+				FrameworkID:   updated.FrameworkID,
+				FrameworkName: updated.FrameworkName,
+				CompletedAt:   updated.CompletedAt.Time,
+			})
 		})
 		if err != nil {
 			h.logger.Errorw("Failed to update audit status", "error", err)
@@ -332,6 +407,7 @@ func (h *Handler) UpdateAudit(c echo.Context) error {
 				"error": "Failed to update audit",
 			})
 		}
+		h.publishEvents()
 	}
 
 	// Get updated audit
@@ -2,12 +2,14 @@ package handler
 
 import (
 	"database/sql"
+	"errors"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/NormaTech-AI/audity/services/tenant-service/internal/db"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/labstack/echo/v4"
 )
@@ -40,6 +42,12 @@ type AssignFrameworkRequest struct {
 	FrameworkName string  `json:"framework_name" validate:"required"`
 	DueDate       *string `json:"due_date"`
 	AuditorID     *string `json:"auditor_id"`
+	// CarryForward prefills answers and evidence from the client's previous
+	// completed audit of the same framework for the client to confirm
+	CarryForward bool `json:"carry_forward"`
+	// FrameworkVersionID pins the audit to a published framework version.
+	// Defaults to the latest published version.
+	FrameworkVersionID *string `json:"framework_version_id"`
 }
 
 type AuditCycleResponse struct {
@@ -76,6 +84,10 @@ type AuditCycleFrameworkResponse struct {
 	DueDate             *string    `json:"due_date"`
 	Status              string     `json:"status"`
 	AuditorID           *string    `json:"auditor_id"`
+	CarryForward        bool       `json:"carry_forward"`
+	ClientAuditID       *string    `json:"client_audit_id"`
+	FrameworkVersionID  *string    `json:"framework_version_id"`
+	FrameworkVersion    *string    `json:"framework_version"`
 	CreatedAt           time.Time  `json:"created_at"`
 	UpdatedAt           time.Time  `json:"updated_at"`
 }
@@ -447,6 +459,40 @@ func (h *Handler) AssignFrameworkToClient(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusUnauthorized, "User not authenticated")
 	}
 
+	ctx := c.Request().Context()
+
+	// The client database to provision in and the cycle end as fallback due date
+	cycleClient, err := h.store.Queries.GetAuditCycleClient(ctx, cycleClientID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return echo.NewHTTPError(http.StatusNotFound, "Audit cycle client not found")
+		}
+		h.logger.Errorw("Failed to get audit cycle client", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get audit cycle client")
+	}
+
+	cycle, err := h.store.Queries.GetAuditCycle(ctx, cycleClient.AuditCycleID)
+	if err != nil {
+		h.logger.Errorw("Failed to get audit cycle", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get audit cycle")
+	}
+
+	var versionID *uuid.UUID
+	if req.FrameworkVersionID != nil {
+		id, err := uuid.Parse(*req.FrameworkVersionID)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid framework_version_id format")
+		}
+		versionID = &id
+	}
+
+	// Fetch the questions before assigning so a framework-service outage leaves nothing behind
+	checklist, err := h.frameworkService.FetchChecklist(ctx, getAuthToken(c), frameworkID, versionID)
+	if err != nil {
+		h.logger.Errorw("Failed to fetch framework questions", "error", err, "framework_id", frameworkID)
+		return echo.NewHTTPError(http.StatusBadGateway, "Failed to fetch framework questions")
+	}
+
 	status := "pending"
 	params := db.AssignFrameworkToAuditCycleClientParams{
 		AuditCycleClientID: cycleClientID,
@@ -454,6 +500,7 @@ func (h *Handler) AssignFrameworkToClient(c echo.Context) error {
 		FrameworkName:      req.FrameworkName,
 		AssignedBy:         pgtype.UUID{Bytes: userID, Valid: true},
 		Status:             &status,
+		CarryForward:       req.CarryForward,
 	}
 
 	if req.DueDate != nil {
@@ -472,7 +519,7 @@ func (h *Handler) AssignFrameworkToClient(c echo.Context) error {
 		params.AuditorID = pgtype.UUID{Bytes: auditorID, Valid: true}
 	}
 
-	framework, err := h.store.Queries.AssignFrameworkToAuditCycleClient(c.Request().Context(), params)
+	framework, err := h.store.Queries.AssignFrameworkToAuditCycleClient(ctx, params)
 	if err != nil {
 		h.logger.Errorw("Failed to assign framework", "error", err)
 		// Check for unique constraint violation
@@ -483,6 +530,20 @@ func (h *Handler) AssignFrameworkToClient(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to assign framework")
 	}
 
+	// Create the audit and questions in the client database; the assignment is rolled back on failure
+	auditID, err := h.provisionFrameworkAudit(ctx, cycleClient.ClientID, framework, true, checklist, userID, cycle.EndDate)
+	if err != nil {
+		h.logger.Errorw("Failed to provision client audit",
+			"error", err,
+			"client_id", cycleClient.ClientID,
+			"framework_id", frameworkID)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to provision client audit")
+	}
+	h.notifyFrameworkAssigned(ctx, cycleClient.ClientID, cycle, framework, auditID)
+
+	clientAuditID := auditID.String()
+	versionIDStr := checklist.VersionID.String()
+
 	// Convert to response (simplified version without client details)
 	response := AuditCycleFrameworkResponse{
 		ID:                 framework.ID.String(),
@@ -490,6 +551,10 @@ func (h *Handler) AssignFrameworkToClient(c echo.Context) error {
 		FrameworkID:        framework.FrameworkID.String(),
 		FrameworkName:      framework.FrameworkName,
 		Status:             *framework.Status,
+		CarryForward:       framework.CarryForward,
+		ClientAuditID:      &clientAuditID,
+		FrameworkVersionID: &versionIDStr,
+		FrameworkVersion:   &checklist.Version,
 		CreatedAt:          framework.CreatedAt.Time,
 		UpdatedAt:          framework.UpdatedAt.Time,
 	}
@@ -548,6 +613,7 @@ func (h *Handler) GetAuditCycleFrameworks(c echo.Context) error {
 			ClientName:         fw.ClientName,
 			AssignedAt:         fw.AssignedAt.Time,
 			Status:             *fw.Status,
+			CarryForward:       fw.CarryForward,
 			CreatedAt:          fw.CreatedAt.Time,
 			UpdatedAt:          fw.UpdatedAt.Time,
 		}
@@ -568,6 +634,18 @@ func (h *Handler) GetAuditCycleFrameworks(c echo.Context) error {
 			auditorID := auditorUUID.String()
 			response[i].AuditorID = &auditorID
 		}
+
+		if fw.ClientAuditID.Valid {
+			clientAuditUUID := uuid.UUID(fw.ClientAuditID.Bytes)
+			clientAuditID := clientAuditUUID.String()
+			response[i].ClientAuditID = &clientAuditID
+		}
+
+		if fw.FrameworkVersionID.Valid {
+			versionID := uuid.UUID(fw.FrameworkVersionID.Bytes).String()
+			response[i].FrameworkVersionID = &versionID
+			response[i].FrameworkVersion = fw.FrameworkVersion
+		}
 	}
 
 	return c.JSON(http.StatusOK, response)
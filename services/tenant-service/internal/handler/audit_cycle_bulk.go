@@ -0,0 +1,354 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/db"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/framework"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+)
+
+// bulkAssignMaxParallel bounds how many client databases are provisioned at once
+const bulkAssignMaxParallel = 8
+
+// ============================================================================
+// Request/Response Types
+// ============================================================================
+
+// BulkAssignFrameworksRequest assigns every listed framework to every listed client
+type BulkAssignFrameworksRequest struct {
+	ClientIDs  []string                  `json:"client_ids" validate:"required,min=1"`
+	Frameworks []BulkFrameworkAssignment `json:"frameworks" validate:"required,min=1"`
+}
+
+type BulkFrameworkAssignment struct {
+	FrameworkID   string  `json:"framework_id"`
+	FrameworkName string  `json:"framework_name"`
+	DueDate       *string `json:"due_date"`
+	AuditorID     *string `json:"auditor_id"`
+	CarryForward  bool    `json:"carry_forward"`
+	// FrameworkVersionID defaults to the latest published version
+	FrameworkVersionID *string `json:"framework_version_id"`
+}
+
+type BulkAssignFrameworksResponse struct {
+	AuditCycleID     string             `json:"audit_cycle_id"`
+	ClientsSucceeded int                `json:"clients_succeeded"`
+	ClientsFailed    int                `json:"clients_failed"`
+	Results          []BulkClientResult `json:"results"`
+}
+
+// BulkClientResult reports the outcome of a bulk assignment for one client.
+// A client succeeds only when every framework was provisioned.
+type BulkClientResult struct {
+	ClientID   string                `json:"client_id"`
+	Status     string                `json:"status"`
+	Error      string                `json:"error,omitempty"`
+	Frameworks []BulkFrameworkResult `json:"frameworks"`
+}
+
+type BulkFrameworkResult struct {
+	FrameworkID           string  `json:"framework_id"`
+	FrameworkName         string  `json:"framework_name"`
+	FrameworkVersion      *string `json:"framework_version,omitempty"`
+	AuditCycleFrameworkID *string `json:"audit_cycle_framework_id,omitempty"`
+	AuditID               *string `json:"audit_id,omitempty"`
+	Status                string  `json:"status"`
+	Error                 string  `json:"error,omitempty"`
+}
+
+const (
+	bulkClientStatusSucceeded = "succeeded"
+	bulkClientStatusFailed    = "failed"
+)
+
+// bulkFramework is a validated framework column of the assignment matrix
+type bulkFramework struct {
+	id           uuid.UUID
+	name         string
+	dueDate      pgtype.Date
+	auditorID    pgtype.UUID
+	carryForward bool
+	versionID    *uuid.UUID
+	checklist    *framework.Checklist
+}
+
+// ============================================================================
+// Handlers
+// ============================================================================
+
+// BulkAssignFrameworks assigns a matrix of clients × frameworks in an audit
+// cycle and provisions the audits in each client database concurrently.
+// Clients are added to the cycle when needed. Assignments that already exist
+// are left unchanged, so a failed request can simply be retried.
+// @Summary Bulk assign frameworks to clients
+// @Tags audit-cycles
+// @Accept json
+// @Produce json
+// @Param id path string true "Audit Cycle ID"
+// @Param request body BulkAssignFrameworksRequest true "Clients and frameworks"
+// @Success 200 {object} BulkAssignFrameworksResponse
+// @Router /api/audit-cycles/{id}/frameworks/bulk [post]
+func (h *Handler) BulkAssignFrameworks(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	cycleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid audit cycle ID")
+	}
+
+	var req BulkAssignFrameworksRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User not authenticated")
+	}
+
+	// Validate the whole matrix before touching any database
+	clientIDs, frameworks, err := parseBulkAssignment(req)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	cycle, err := h.store.Queries.GetAuditCycle(ctx, cycleID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return echo.NewHTTPError(http.StatusNotFound, "Audit cycle not found")
+		}
+		h.logger.Errorw("Failed to get audit cycle", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get audit cycle")
+	}
+
+	// Every client gets the same questions, so fetch each framework once
+	token := getAuthToken(c)
+	for i := range frameworks {
+		frameworks[i].checklist, err = h.frameworkService.FetchChecklist(ctx, token, frameworks[i].id, frameworks[i].versionID)
+		if err != nil {
+			h.logger.Errorw("Failed to fetch framework questions", "error", err, "framework_id", frameworks[i].id)
+			return echo.NewHTTPError(http.StatusBadGateway, fmt.Sprintf("Failed to fetch questions for framework %s", frameworks[i].name))
+		}
+	}
+
+	// Provisioning carries on if the caller disconnects so no client is left half done
+	workCtx := context.WithoutCancel(ctx)
+
+	results := make([]BulkClientResult, len(clientIDs))
+	sem := make(chan struct{}, bulkAssignMaxParallel)
+	var wg sync.WaitGroup
+	for i, clientID := range clientIDs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, clientID uuid.UUID) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = h.bulkAssignClient(workCtx, cycle, clientID, frameworks, userID)
+		}(i, clientID)
+	}
+	wg.Wait()
+
+	response := BulkAssignFrameworksResponse{
+		AuditCycleID: cycleID.String(),
+		Results:      results,
+	}
+	for _, result := range results {
+		if result.Status == bulkClientStatusSucceeded {
+			response.ClientsSucceeded++
+		} else {
+			response.ClientsFailed++
+		}
+	}
+
+	h.logger.Infow("Bulk framework assignment finished",
+		"audit_cycle_id", cycleID,
+		"clients", len(clientIDs),
+		"frameworks", len(frameworks),
+		"succeeded", response.ClientsSucceeded,
+		"failed", response.ClientsFailed)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// parseBulkAssignment validates the clients and frameworks of a bulk
+// assignment. Clients listed twice are assigned once; a framework listed twice
+// is rejected because its two settings would conflict.
+func parseBulkAssignment(req BulkAssignFrameworksRequest) ([]uuid.UUID, []bulkFramework, error) {
+	var clientIDs []uuid.UUID
+	seenClients := make(map[uuid.UUID]bool)
+	for _, raw := range req.ClientIDs {
+		clientID, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Invalid client ID %q", raw)
+		}
+		if !seenClients[clientID] {
+			seenClients[clientID] = true
+			clientIDs = append(clientIDs, clientID)
+		}
+	}
+
+	frameworks := make([]bulkFramework, 0, len(req.Frameworks))
+	seenFrameworks := make(map[uuid.UUID]bool)
+	for _, fw := range req.Frameworks {
+		frameworkID, err := uuid.Parse(fw.FrameworkID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Invalid framework ID %q", fw.FrameworkID)
+		}
+		if seenFrameworks[frameworkID] {
+			return nil, nil, fmt.Errorf("Framework %s is listed more than once", frameworkID)
+		}
+		seenFrameworks[frameworkID] = true
+
+		if fw.FrameworkName == "" {
+			return nil, nil, fmt.Errorf("framework_name is required for framework %s", frameworkID)
+		}
+
+		spec := bulkFramework{
+			id:           frameworkID,
+			name:         fw.FrameworkName,
+			carryForward: fw.CarryForward,
+		}
+
+		if fw.DueDate != nil {
+			dueDate, err := time.Parse("2006-01-02", *fw.DueDate)
+			if err != nil {
+				return nil, nil, errors.New("Invalid due_date format. Use YYYY-MM-DD")
+			}
+			spec.dueDate = pgtype.Date{Time: dueDate, Valid: true}
+		}
+
+		if fw.AuditorID != nil {
+			auditorID, err := uuid.Parse(*fw.AuditorID)
+			if err != nil {
+				return nil, nil, errors.New("Invalid auditor_id format")
+			}
+			spec.auditorID = pgtype.UUID{Bytes: auditorID, Valid: true}
+		}
+
+		if fw.FrameworkVersionID != nil {
+			versionID, err := uuid.Parse(*fw.FrameworkVersionID)
+			if err != nil {
+				return nil, nil, errors.New("Invalid framework_version_id format")
+			}
+			spec.versionID = &versionID
+		}
+
+		frameworks = append(frameworks, spec)
+	}
+
+	return clientIDs, frameworks, nil
+}
+
+// bulkAssignClient adds a client to the cycle and assigns and provisions each
+// framework in turn. Failures are recorded per framework.
+func (h *Handler) bulkAssignClient(ctx context.Context, cycle db.AuditCycle, clientID uuid.UUID, frameworks []bulkFramework, assignedBy uuid.UUID) BulkClientResult {
+	result := BulkClientResult{
+		ClientID:   clientID.String(),
+		Status:     bulkClientStatusSucceeded,
+		Frameworks: make([]BulkFrameworkResult, 0, len(frameworks)),
+	}
+
+	cycleClient, err := h.store.Queries.EnsureClientInAuditCycle(ctx, db.EnsureClientInAuditCycleParams{
+		AuditCycleID: cycle.ID,
+		ClientID:     clientID,
+	})
+	if err != nil {
+		h.logger.Errorw("Failed to add client to audit cycle", "error", err, "client_id", clientID)
+		result.Status = bulkClientStatusFailed
+		result.Error = "Failed to add client to audit cycle"
+		return result
+	}
+
+	for _, fw := range frameworks {
+		fwResult := BulkFrameworkResult{
+			FrameworkID:   fw.id.String(),
+			FrameworkName: fw.name,
+		}
+
+		status := "pending"
+		ensured, err := h.store.Queries.EnsureFrameworkAssignedToAuditCycleClient(ctx, db.EnsureFrameworkAssignedToAuditCycleClientParams{
+			AuditCycleClientID: cycleClient.ID,
+			FrameworkID:        fw.id,
+			FrameworkName:      fw.name,
+			AssignedBy:         pgtype.UUID{Bytes: assignedBy, Valid: true},
+			DueDate:            fw.dueDate,
+			Status:             &status,
+			AuditorID:          fw.auditorID,
+			CarryForward:       fw.carryForward,
+		})
+		if err != nil {
+			h.logger.Errorw("Failed to assign framework", "error", err, "client_id", clientID, "framework_id", fw.id)
+			fwResult.Status = provisionStatusFailed
+			fwResult.Error = "Failed to assign framework"
+			result.Status = bulkClientStatusFailed
+			result.Frameworks = append(result.Frameworks, fwResult)
+			continue
+		}
+
+		assignment := db.AuditCycleFramework{
+			ID:                 ensured.ID,
+			AuditCycleClientID: ensured.AuditCycleClientID,
+			FrameworkID:        ensured.FrameworkID,
+			FrameworkName:      ensured.FrameworkName,
+			AssignedBy:         ensured.AssignedBy,
+			AssignedAt:         ensured.AssignedAt,
+			DueDate:            ensured.DueDate,
+			Status:             ensured.Status,
+			CreatedAt:          ensured.CreatedAt,
+			UpdatedAt:          ensured.UpdatedAt,
+			AuditorID:          ensured.AuditorID,
+			CarryForward:       ensured.CarryForward,
+			ClientAuditID:      ensured.ClientAuditID,
+			FrameworkVersionID: ensured.FrameworkVersionID,
+			FrameworkVersion:   ensured.FrameworkVersion,
+		}
+		assignmentID := assignment.ID.String()
+		fwResult.AuditCycleFrameworkID = &assignmentID
+
+		if assignment.ClientAuditID.Valid {
+			auditID := uuid.UUID(assignment.ClientAuditID.Bytes).String()
+			fwResult.AuditID = &auditID
+			fwResult.FrameworkVersion = assignment.FrameworkVersion
+			fwResult.Status = provisionStatusAlreadyProvisioned
+			result.Frameworks = append(result.Frameworks, fwResult)
+			continue
+		}
+
+		auditID, err := h.provisionFrameworkAudit(ctx, clientID, assignment, ensured.Inserted, fw.checklist, assignedBy, cycle.EndDate)
+		if err != nil {
+			h.logger.Errorw("Failed to provision client audit", "error", err, "client_id", clientID, "framework_id", fw.id)
+			// An assignment created by this request was rolled back
+			if ensured.Inserted {
+				fwResult.AuditCycleFrameworkID = nil
+			}
+			fwResult.Status = provisionStatusFailed
+			fwResult.Error = err.Error()
+			result.Status = bulkClientStatusFailed
+			result.Frameworks = append(result.Frameworks, fwResult)
+			continue
+		}
+
+		h.notifyFrameworkAssigned(ctx, clientID, cycle, assignment, auditID)
+
+		id := auditID.String()
+		fwResult.AuditID = &id
+		fwResult.FrameworkVersion = &fw.checklist.Version
+		fwResult.Status = provisionStatusProvisioned
+		result.Frameworks = append(result.Frameworks, fwResult)
+	}
+
+	return result
+}
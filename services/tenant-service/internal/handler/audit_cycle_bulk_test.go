@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/db"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/framework"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestParseBulkAssignment(t *testing.T) {
+	clientID, frameworkID := uuid.New().String(), uuid.New().String()
+	str := func(s string) *string { return &s }
+
+	tests := []struct {
+		name           string
+		req            BulkAssignFrameworksRequest
+		wantClients    int
+		wantFrameworks int
+		wantErr        bool
+	}{
+		{
+			name: "valid matrix",
+			req: BulkAssignFrameworksRequest{
+				ClientIDs: []string{clientID, uuid.New().String()},
+				Frameworks: []BulkFrameworkAssignment{
+					{FrameworkID: frameworkID, FrameworkName: "ISO 27001", DueDate: str("2026-06-30"), AuditorID: str(uuid.New().String())},
+					{FrameworkID: uuid.New().String(), FrameworkName: "SOC 2", FrameworkVersionID: str(uuid.New().String())},
+				},
+			},
+			wantClients:    2,
+			wantFrameworks: 2,
+		},
+		{
+			name: "duplicate clients are assigned once",
+			req: BulkAssignFrameworksRequest{
+				ClientIDs:  []string{clientID, clientID},
+				Frameworks: []BulkFrameworkAssignment{{FrameworkID: frameworkID, FrameworkName: "ISO 27001"}},
+			},
+			wantClients:    1,
+			wantFrameworks: 1,
+		},
+		{
+			name: "invalid client ID",
+			req: BulkAssignFrameworksRequest{
+				ClientIDs:  []string{"acme"},
+				Frameworks: []BulkFrameworkAssignment{{FrameworkID: frameworkID, FrameworkName: "ISO 27001"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate framework",
+			req: BulkAssignFrameworksRequest{
+				ClientIDs: []string{clientID},
+				Frameworks: []BulkFrameworkAssignment{
+					{FrameworkID: frameworkID, FrameworkName: "ISO 27001"},
+					{FrameworkID: frameworkID, FrameworkName: "ISO 27001", CarryForward: true},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing framework name",
+			req: BulkAssignFrameworksRequest{
+				ClientIDs:  []string{clientID},
+				Frameworks: []BulkFrameworkAssignment{{FrameworkID: frameworkID}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid due date",
+			req: BulkAssignFrameworksRequest{
+				ClientIDs:  []string{clientID},
+				Frameworks: []BulkFrameworkAssignment{{FrameworkID: frameworkID, FrameworkName: "ISO 27001", DueDate: str("30/06/2026")}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid framework version",
+			req: BulkAssignFrameworksRequest{
+				ClientIDs:  []string{clientID},
+				Frameworks: []BulkFrameworkAssignment{{FrameworkID: frameworkID, FrameworkName: "ISO 27001", FrameworkVersionID: str("latest")}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clients, frameworks, err := parseBulkAssignment(tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseBulkAssignment() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(clients) != tt.wantClients || len(frameworks) != tt.wantFrameworks {
+				t.Errorf("clients = %d frameworks = %d, want %d and %d", len(clients), len(frameworks), tt.wantClients, tt.wantFrameworks)
+			}
+		})
+	}
+}
+
+func TestBulkAssignClient(t *testing.T) {
+	tests := []struct {
+		name           string
+		inserted       bool
+		clientAuditID  pgtype.UUID
+		wantStatus     string
+		wantAssignment bool
+		wantRollback   bool
+	}{
+		{
+			name:           "already provisioned",
+			clientAuditID:  pgtype.UUID{Bytes: uuid.New(), Valid: true},
+			wantStatus:     provisionStatusAlreadyProvisioned,
+			wantAssignment: true,
+		},
+		{
+			name:         "new assignment is rolled back",
+			inserted:     true,
+			wantStatus:   provisionStatusFailed,
+			wantRollback: true,
+		},
+		{
+			name:           "existing assignment is kept",
+			wantStatus:     provisionStatusFailed,
+			wantAssignment: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assignmentID := uuid.New()
+			tenantDB := &fakeTenantDB{rows: map[string]rowFunc{
+				db.EnsureClientInAuditCycle: func(dest ...any) error { return nil },
+				db.EnsureFrameworkAssignedToAuditCycleClient: func(dest ...any) error {
+					*dest[0].(*uuid.UUID) = assignmentID
+					*dest[12].(*pgtype.UUID) = tt.clientAuditID
+					*dest[15].(*bool) = tt.inserted
+					return nil
+				},
+			}}
+			h := newTestHandler(tenantDB)
+
+			frameworks := []bulkFramework{{id: uuid.New(), name: "ISO 27001", checklist: &framework.Checklist{}}}
+			result := h.bulkAssignClient(context.Background(), db.AuditCycle{ID: uuid.New()}, uuid.New(), frameworks, uuid.New())
+
+			if len(result.Frameworks) != 1 {
+				t.Fatalf("frameworks = %+v, want one result", result.Frameworks)
+			}
+			got := result.Frameworks[0]
+			if got.Status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", got.Status, tt.wantStatus)
+			}
+			wantClient := bulkClientStatusSucceeded
+			if tt.wantStatus == provisionStatusFailed {
+				wantClient = bulkClientStatusFailed
+			}
+			if result.Status != wantClient {
+				t.Errorf("client status = %q, want %q", result.Status, wantClient)
+			}
+			if (got.AuditCycleFrameworkID != nil) != tt.wantAssignment {
+				t.Errorf("audit_cycle_framework_id = %v, want it reported: %v", got.AuditCycleFrameworkID, tt.wantAssignment)
+			}
+			if rolledBack := len(tenantDB.exec) > 0; rolledBack != tt.wantRollback {
+				t.Errorf("executed %q, want rollback: %v", tenantDB.exec, tt.wantRollback)
+			}
+		})
+	}
+}
@@ -0,0 +1,301 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/db"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/framework"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+)
+
+// ============================================================================
+// Request/Response Types
+// ============================================================================
+
+type CloneAuditCycleRequest struct {
+	Name        string `json:"name" validate:"required"`
+	Description string `json:"description"`
+	StartDate   string `json:"start_date" validate:"required"`
+	// EndDate defaults to the source end date shifted by the same number of days as the start date
+	EndDate *string `json:"end_date"`
+	// DueDateOffsetDays moves every framework due date on top of the cycle shift
+	DueDateOffsetDays int `json:"due_date_offset_days"`
+}
+
+type CloneAuditCycleResponse struct {
+	Cycle              AuditCycleResponse   `json:"cycle"`
+	SourceCycleID      string               `json:"source_cycle_id"`
+	ShiftDays          int                  `json:"shift_days"`
+	ClientsCloned      int                  `json:"clients_cloned"`
+	FrameworksCloned   int                  `json:"frameworks_cloned"`
+	AuditsProvisioned  int                  `json:"audits_provisioned"`
+	ProvisioningFailed int                  `json:"provisioning_failed"`
+	Results            []CloneCycleProgress `json:"results"`
+}
+
+// CloneCycleProgress reports the outcome of one cloned framework assignment
+type CloneCycleProgress struct {
+	ClientID              string  `json:"client_id"`
+	ClientName            string  `json:"client_name"`
+	AuditCycleFrameworkID string  `json:"audit_cycle_framework_id"`
+	FrameworkID           string  `json:"framework_id"`
+	FrameworkName         string  `json:"framework_name"`
+	FrameworkVersion      *string `json:"framework_version,omitempty"`
+	DueDate               *string `json:"due_date"`
+	AuditID               *string `json:"audit_id,omitempty"`
+	Status                string  `json:"status"`
+	Error                 string  `json:"error,omitempty"`
+}
+
+// ============================================================================
+// Handlers
+// ============================================================================
+
+// CloneAuditCycle copies the clients and framework assignments of an audit
+// cycle into a new cycle with shifted dates, then provisions the audits and
+// questions in each client database
+// @Summary Clone audit cycle
+// @Tags audit-cycles
+// @Accept json
+// @Produce json
+// @Param id path string true "Source Audit Cycle ID"
+// @Param request body CloneAuditCycleRequest true "New cycle details"
+// @Success 201 {object} CloneAuditCycleResponse
+// @Router /api/audit-cycles/{id}/clone [post]
+func (h *Handler) CloneAuditCycle(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	sourceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid audit cycle ID")
+	}
+
+	var req CloneAuditCycleRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := c.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User not authenticated")
+	}
+
+	source, err := h.store.Queries.GetAuditCycle(ctx, sourceID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return echo.NewHTTPError(http.StatusNotFound, "Audit cycle not found")
+		}
+		h.logger.Errorw("Failed to get audit cycle", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get audit cycle")
+	}
+
+	startDate, endDate, shiftDays, err := clonedCycleDates(source, req)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	description := source.Description
+	if req.Description != "" {
+		description = &req.Description
+	}
+
+	sourceClients, err := h.store.Queries.GetAuditCycleClients(ctx, sourceID)
+	if err != nil {
+		h.logger.Errorw("Failed to get audit cycle clients", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get audit cycle clients")
+	}
+
+	sourceFrameworks, err := h.store.Queries.GetAuditCycleFrameworks(ctx, sourceID)
+	if err != nil {
+		h.logger.Errorw("Failed to get audit cycle frameworks", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get audit cycle frameworks")
+	}
+
+	// Fetch every framework's questions up front so nothing is cloned when
+	// framework-service cannot provide them. The new period uses the latest
+	// published version of each framework.
+	token := getAuthToken(c)
+	checklists := make(map[uuid.UUID]*framework.Checklist)
+	for _, fw := range sourceFrameworks {
+		if _, ok := checklists[fw.FrameworkID]; ok {
+			continue
+		}
+		checklist, err := h.frameworkService.FetchChecklist(ctx, token, fw.FrameworkID, nil)
+		if err != nil {
+			h.logger.Errorw("Failed to fetch framework questions", "error", err, "framework_id", fw.FrameworkID)
+			return echo.NewHTTPError(http.StatusBadGateway, fmt.Sprintf("Failed to fetch questions for framework %s", fw.FrameworkName))
+		}
+		checklists[fw.FrameworkID] = checklist
+	}
+
+	// Clone the cycle, its clients and their framework assignments in one transaction
+	var cycle db.AuditCycle
+	var cloned []CloneCycleProgress
+	var assignments []db.AuditCycleFramework
+	clientsCloned := 0
+	err = h.store.ExecTx(ctx, func(q *db.Queries) error {
+		cycle, err = q.CreateAuditCycle(ctx, db.CreateAuditCycleParams{
+			Name:        req.Name,
+			Description: description,
+			StartDate:   pgtype.Date{Time: startDate, Valid: true},
+			EndDate:     pgtype.Date{Time: endDate, Valid: true},
+			CreatedBy:   pgtype.UUID{Bytes: userID, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create audit cycle: %w", err)
+		}
+
+		for _, sourceClient := range sourceClients {
+			cycleClient, err := q.AddClientToAuditCycle(ctx, db.AddClientToAuditCycleParams{
+				AuditCycleID: cycle.ID,
+				ClientID:     sourceClient.ClientID,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to add client %s: %w", sourceClient.ClientID, err)
+			}
+			clientsCloned++
+
+			frameworks, err := q.GetClientFrameworksInCycle(ctx, sourceClient.ID)
+			if err != nil {
+				return fmt.Errorf("failed to get frameworks of client %s: %w", sourceClient.ClientID, err)
+			}
+
+			for _, fw := range frameworks {
+				status := "pending"
+				params := db.AssignFrameworkToAuditCycleClientParams{
+					AuditCycleClientID: cycleClient.ID,
+					FrameworkID:        fw.FrameworkID,
+					FrameworkName:      fw.FrameworkName,
+					AssignedBy:         pgtype.UUID{Bytes: userID, Valid: true},
+					Status:             &status,
+					AuditorID:          fw.AuditorID,
+					CarryForward:       fw.CarryForward,
+				}
+				params.DueDate = shiftDate(fw.DueDate, shiftDays+req.DueDateOffsetDays)
+
+				assignment, err := q.AssignFrameworkToAuditCycleClient(ctx, params)
+				if err != nil {
+					return fmt.Errorf("failed to assign framework %s to client %s: %w", fw.FrameworkName, sourceClient.ClientID, err)
+				}
+
+				progress := CloneCycleProgress{
+					ClientID:              sourceClient.ClientID.String(),
+					ClientName:            sourceClient.ClientName,
+					AuditCycleFrameworkID: assignment.ID.String(),
+					FrameworkID:           assignment.FrameworkID.String(),
+					FrameworkName:         assignment.FrameworkName,
+				}
+				if assignment.DueDate.Valid {
+					dueDate := assignment.DueDate.Time.Format("2006-01-02")
+					progress.DueDate = &dueDate
+				}
+
+				cloned = append(cloned, progress)
+				assignments = append(assignments, assignment)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		h.logger.Errorw("Failed to clone audit cycle", "error", err, "source_cycle_id", sourceID)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to clone audit cycle")
+	}
+
+	// Provision the client audits; a failure is reported per assignment and
+	// removes only that assignment, which can then be assigned again
+	provisioned, failed := 0, 0
+	for i, assignment := range assignments {
+		clientID := uuid.MustParse(cloned[i].ClientID)
+
+		checklist := checklists[assignment.FrameworkID]
+		auditID, err := h.provisionFrameworkAudit(ctx, clientID, assignment, true, checklist, userID, cycle.EndDate)
+		if err != nil {
+			h.logger.Errorw("Failed to provision client audit",
+				"error", err,
+				"client_id", clientID,
+				"framework_id", assignment.FrameworkID,
+				"audit_cycle_id", cycle.ID)
+			cloned[i].Status = provisionStatusFailed
+			cloned[i].Error = err.Error()
+			failed++
+			continue
+		}
+
+		h.notifyFrameworkAssigned(ctx, clientID, cycle, assignment, auditID)
+
+		id := auditID.String()
+		cloned[i].AuditID = &id
+		cloned[i].FrameworkVersion = &checklist.Version
+		cloned[i].Status = provisionStatusProvisioned
+		provisioned++
+	}
+
+	h.logger.Infow("Audit cycle cloned",
+		"source_cycle_id", sourceID,
+		"audit_cycle_id", cycle.ID,
+		"clients", clientsCloned,
+		"frameworks", len(assignments),
+		"provisioned", provisioned,
+		"failed", failed)
+
+	if cloned == nil {
+		cloned = []CloneCycleProgress{}
+	}
+
+	return c.JSON(http.StatusCreated, CloneAuditCycleResponse{
+		Cycle:              convertToAuditCycleResponse(cycle),
+		SourceCycleID:      sourceID.String(),
+		ShiftDays:          shiftDays,
+		ClientsCloned:      clientsCloned,
+		FrameworksCloned:   len(assignments),
+		AuditsProvisioned:  provisioned,
+		ProvisioningFailed: failed,
+		Results:            cloned,
+	})
+}
+
+// clonedCycleDates returns the start and end date of a cycle cloned from
+// source and the number of days every date of the source cycle moves by: the
+// distance between the start dates. The end date defaults to the source end
+// date moved by the same number of days.
+func clonedCycleDates(source db.AuditCycle, req CloneAuditCycleRequest) (start, end time.Time, shiftDays int, err error) {
+	start, err = time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, 0, errors.New("Invalid start_date format. Use YYYY-MM-DD")
+	}
+
+	shiftDays = int(start.Sub(source.StartDate.Time).Hours() / 24)
+
+	end = source.EndDate.Time.AddDate(0, 0, shiftDays)
+	if req.EndDate != nil {
+		end, err = time.Parse("2006-01-02", *req.EndDate)
+		if err != nil {
+			return time.Time{}, time.Time{}, 0, errors.New("Invalid end_date format. Use YYYY-MM-DD")
+		}
+	}
+
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, 0, errors.New("end_date must be after start_date")
+	}
+
+	return start, end, shiftDays, nil
+}
+
+// shiftDate moves a date by the given number of days; a missing date stays missing
+func shiftDate(date pgtype.Date, days int) pgtype.Date {
+	if !date.Valid {
+		return date
+	}
+	return pgtype.Date{Time: date.Time.AddDate(0, 0, days), Valid: true}
+}
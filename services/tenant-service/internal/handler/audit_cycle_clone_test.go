@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func mustDate(s string) time.Time {
+	d, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func TestClonedCycleDates(t *testing.T) {
+	source := db.AuditCycle{
+		StartDate: pgtype.Date{Time: mustDate("2025-01-01"), Valid: true},
+		EndDate:   pgtype.Date{Time: mustDate("2025-03-31"), Valid: true},
+	}
+	endDate := func(s string) *string { return &s }
+
+	tests := []struct {
+		name      string
+		req       CloneAuditCycleRequest
+		wantStart string
+		wantEnd   string
+		wantShift int
+		wantErr   bool
+	}{
+		{
+			name:      "end date follows the shift",
+			req:       CloneAuditCycleRequest{StartDate: "2026-01-01"},
+			wantStart: "2026-01-01",
+			wantEnd:   "2026-03-31",
+			wantShift: 365,
+		},
+		{
+			name:      "explicit end date",
+			req:       CloneAuditCycleRequest{StartDate: "2025-04-01", EndDate: endDate("2025-06-30")},
+			wantStart: "2025-04-01",
+			wantEnd:   "2025-06-30",
+			wantShift: 90,
+		},
+		{
+			name:      "earlier period",
+			req:       CloneAuditCycleRequest{StartDate: "2024-12-01"},
+			wantStart: "2024-12-01",
+			wantEnd:   "2025-02-28",
+			wantShift: -31,
+		},
+		{name: "invalid start date", req: CloneAuditCycleRequest{StartDate: "01/01/2026"}, wantErr: true},
+		{name: "invalid end date", req: CloneAuditCycleRequest{StartDate: "2026-01-01", EndDate: endDate("tomorrow")}, wantErr: true},
+		{name: "end before start", req: CloneAuditCycleRequest{StartDate: "2026-01-01", EndDate: endDate("2025-12-31")}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, shift, err := clonedCycleDates(source, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("clonedCycleDates() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := start.Format("2006-01-02"); got != tt.wantStart {
+				t.Errorf("start = %s, want %s", got, tt.wantStart)
+			}
+			if got := end.Format("2006-01-02"); got != tt.wantEnd {
+				t.Errorf("end = %s, want %s", got, tt.wantEnd)
+			}
+			if shift != tt.wantShift {
+				t.Errorf("shift = %d, want %d", shift, tt.wantShift)
+			}
+		})
+	}
+}
+
+func TestShiftDate(t *testing.T) {
+	if got := shiftDate(pgtype.Date{}, 30); got.Valid {
+		t.Errorf("shiftDate() of a missing date = %v, want it missing", got.Time)
+	}
+
+	got := shiftDate(pgtype.Date{Time: mustDate("2025-02-15"), Valid: true}, 365+14)
+	if !got.Valid || !got.Time.Equal(mustDate("2026-03-01")) {
+		t.Errorf("shiftDate() = %v, want 2026-03-01", got.Time)
+	}
+}
@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"context"
+	"math"
+	"net/http"
+
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientdb"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// severityMultipliers scale the weight of a question answered "no", so that
+// failing a critical control costs more than failing a low severity one
+var severityMultipliers = map[clientdb.QuestionSeverityEnum]float64{
+	clientdb.QuestionSeverityEnumLow:      1,
+	clientdb.QuestionSeverityEnumMedium:   1.5,
+	clientdb.QuestionSeverityEnumHigh:     2,
+	clientdb.QuestionSeverityEnumCritical: 3,
+}
+
+// ScoreBreakdown holds the points and question counts behind a compliance score
+type ScoreBreakdown struct {
+	Score                 *float64 `json:"score"`
+	EarnedPoints          float64  `json:"earned_points"`
+	PossiblePoints        float64  `json:"possible_points"`
+	ScoredQuestions       int      `json:"scored_questions"`
+	NonCompliantQuestions int      `json:"non_compliant_questions"`
+	ExcludedQuestions     int      `json:"excluded_questions"`
+	PendingQuestions      int      `json:"pending_questions"`
+}
+
+// SectionScore is the compliance score of a single section
+type SectionScore struct {
+	Section string `json:"section"`
+	ScoreBreakdown
+}
+
+// AuditScoreResponse describes the compliance score of an audit
+type AuditScoreResponse struct {
+	AuditID string `json:"audit_id"`
+	ScoreBreakdown
+	Sections []SectionScore `json:"sections"`
+}
+
+// GetAuditScore returns the compliance score of an audit with a section breakdown
+func (h *Handler) GetAuditScore(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	clientID, err := uuid.Parse(c.Param("clientId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid client ID",
+		})
+	}
+
+	auditID, err := uuid.Parse(c.Param("auditId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid audit ID",
+		})
+	}
+
+	// Get client database queries
+	clientQueries, _, err := h.clientStore.GetClientQueries(ctx, clientID)
+	if err != nil {
+		h.logger.Errorw("Failed to get client queries", "error", err, "client_id", clientID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to access client data",
+		})
+	}
+
+	if _, err := clientQueries.GetAuditByID(ctx, auditID); err != nil {
+		h.logger.Errorw("Failed to get audit", "error", err, "audit_id", auditID)
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Audit not found",
+		})
+	}
+
+	rows, err := clientQueries.ListQuestionReadiness(ctx, auditID)
+	if err != nil {
+		h.logger.Errorw("Failed to get question scores", "error", err, "audit_id", auditID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to compute audit score",
+		})
+	}
+
+	return c.JSON(http.StatusOK, evaluateAuditScore(auditID, rows))
+}
+
+// evaluateAuditScore computes the compliance score of an audit from the
+// latest submission of every visible question.
+//
+// Only approved answers are scored: "yes" (or any approved non yes/no
+// answer) earns the full question weight, "na" is excluded and "no" earns
+// nothing while counting weight × severity multiplier against the score.
+// Questions without an approved answer are reported as pending.
+func evaluateAuditScore(auditID uuid.UUID, rows []clientdb.ListQuestionReadinessRow) AuditScoreResponse {
+	response := AuditScoreResponse{
+		AuditID:  auditID.String(),
+		Sections: make([]SectionScore, 0),
+	}
+
+	visible := resolveQuestionVisibility(rows)
+	sectionIndex := make(map[string]int)
+
+	for _, row := range rows {
+		if !visible[row.QuestionNumber] {
+			continue
+		}
+
+		idx, ok := sectionIndex[row.Section]
+		if !ok {
+			idx = len(response.Sections)
+			sectionIndex[row.Section] = idx
+			response.Sections = append(response.Sections, SectionScore{Section: row.Section})
+		}
+		section := &response.Sections[idx].ScoreBreakdown
+
+		approved := row.SubmissionStatus.Valid && row.SubmissionStatus.SubmissionStatusEnum == clientdb.SubmissionStatusEnumApproved
+		if !approved {
+			section.PendingQuestions++
+			response.PendingQuestions++
+			continue
+		}
+
+		weight := float64(row.Weight)
+		switch {
+		case row.AnswerValue.Valid && row.AnswerValue.AnswerValueEnum == clientdb.AnswerValueEnumNa:
+			section.ExcludedQuestions++
+			response.ExcludedQuestions++
+			continue
+		case row.AnswerValue.Valid && row.AnswerValue.AnswerValueEnum == clientdb.AnswerValueEnumNo:
+			multiplier, ok := severityMultipliers[row.Severity]
+			if !ok {
+				multiplier = 1
+			}
+			section.PossiblePoints += weight * multiplier
+			response.PossiblePoints += weight * multiplier
+			section.NonCompliantQuestions++
+			response.NonCompliantQuestions++
+		default:
+			section.EarnedPoints += weight
+			section.PossiblePoints += weight
+			response.EarnedPoints += weight
+			response.PossiblePoints += weight
+		}
+
+		section.ScoredQuestions++
+		response.ScoredQuestions++
+	}
+
+	for i := range response.Sections {
+		response.Sections[i].Score = scorePercent(response.Sections[i].EarnedPoints, response.Sections[i].PossiblePoints)
+	}
+	response.Score = scorePercent(response.EarnedPoints, response.PossiblePoints)
+
+	return response
+}
+
+// scorePercent converts points to a percentage rounded to two decimals; it
+// returns nil when nothing has been scored yet
+func scorePercent(earned, possible float64) *float64 {
+	if possible == 0 {
+		return nil
+	}
+	score := math.Round(earned/possible*10000) / 100
+	return &score
+}
+
+// auditScores computes the compliance scores of several audits with a single
+// query, e.g. for a list of audits. Audits without a score are left out.
+func auditScores(ctx context.Context, queries *clientdb.Queries, auditIDs []uuid.UUID) (map[uuid.UUID]*float64, error) {
+	rows, err := queries.ListQuestionReadinessByAudits(ctx, auditIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	byAudit := make(map[uuid.UUID][]clientdb.ListQuestionReadinessRow)
+	for _, row := range rows {
+		byAudit[row.AuditID] = append(byAudit[row.AuditID], clientdb.ListQuestionReadinessRow{
+			ID:                  row.ID,
+			Section:             row.Section,
+			QuestionNumber:      row.QuestionNumber,
+			QuestionText:        row.QuestionText,
+			IsMandatory:         row.IsMandatory,
+			VisibilityCondition: row.VisibilityCondition,
+			Weight:              row.Weight,
+			Severity:            row.Severity,
+			SubmissionID:        row.SubmissionID,
+			AnswerValue:         row.AnswerValue,
+			AnswerData:          row.AnswerData,
+			SubmissionStatus:    row.SubmissionStatus,
+			EvidenceCount:       row.EvidenceCount,
+			HasActiveException:  row.HasActiveException,
+		})
+	}
+
+	scores := make(map[uuid.UUID]*float64, len(byAudit))
+	for auditID, auditRows := range byAudit {
+		if score := evaluateAuditScore(auditID, auditRows).Score; score != nil {
+			scores[auditID] = score
+		}
+	}
+	return scores, nil
+}
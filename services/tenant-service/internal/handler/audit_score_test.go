@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientdb"
+	"github.com/google/uuid"
+)
+
+// scoredRow returns an approved answer to a question of the given section
+func scoredRow(section, number string, answer clientdb.AnswerValueEnum, weight int32, severity clientdb.QuestionSeverityEnum) clientdb.ListQuestionReadinessRow {
+	row := readinessRow(number, true, clientdb.SubmissionStatusEnumApproved, 1)
+	row.Section = section
+	row.Weight = weight
+	row.Severity = severity
+	row.AnswerValue = clientdb.NullAnswerValueEnum{AnswerValueEnum: answer, Valid: true}
+	return row
+}
+
+func TestEvaluateAuditScore(t *testing.T) {
+	low, critical := clientdb.QuestionSeverityEnumLow, clientdb.QuestionSeverityEnumCritical
+
+	tests := []struct {
+		name             string
+		rows             []clientdb.ListQuestionReadinessRow
+		wantScore        *float64
+		wantEarned       float64
+		wantPossible     float64
+		wantNonCompliant int
+		wantExcluded     int
+		wantPending      int
+	}{
+		{
+			name:        "nothing approved",
+			rows:        []clientdb.ListQuestionReadinessRow{readinessRow("1.1", true, clientdb.SubmissionStatusEnumSubmitted, 1)},
+			wantPending: 1,
+		},
+		{
+			name: "all compliant",
+			rows: []clientdb.ListQuestionReadinessRow{
+				scoredRow("A", "1.1", clientdb.AnswerValueEnumYes, 2, low),
+				scoredRow("A", "1.2", clientdb.AnswerValueEnumYes, 1, critical),
+			},
+			wantScore:    floatPtr(100),
+			wantEarned:   3,
+			wantPossible: 3,
+		},
+		{
+			name: "weight and severity of a failed control",
+			rows: []clientdb.ListQuestionReadinessRow{
+				scoredRow("A", "1.1", clientdb.AnswerValueEnumYes, 3, low),
+				scoredRow("A", "1.2", clientdb.AnswerValueEnumNo, 1, critical),
+			},
+			wantScore:        floatPtr(50),
+			wantEarned:       3,
+			wantPossible:     6,
+			wantNonCompliant: 1,
+		},
+		{
+			name: "not applicable is excluded",
+			rows: []clientdb.ListQuestionReadinessRow{
+				scoredRow("A", "1.1", clientdb.AnswerValueEnumYes, 1, low),
+				scoredRow("A", "1.2", clientdb.AnswerValueEnumNa, 5, critical),
+			},
+			wantScore:    floatPtr(100),
+			wantEarned:   1,
+			wantPossible: 1,
+			wantExcluded: 1,
+		},
+		{
+			name: "rounded to two decimals",
+			rows: []clientdb.ListQuestionReadinessRow{
+				scoredRow("A", "1.1", clientdb.AnswerValueEnumYes, 1, low),
+				scoredRow("A", "1.2", clientdb.AnswerValueEnumNo, 1, low),
+				scoredRow("A", "1.3", clientdb.AnswerValueEnumNo, 1, low),
+			},
+			wantScore:        floatPtr(33.33),
+			wantEarned:       1,
+			wantPossible:     3,
+			wantNonCompliant: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := evaluateAuditScore(uuid.New(), tt.rows)
+
+			if !equalScore(got.Score, tt.wantScore) {
+				t.Errorf("Score = %v, want %v", scoreString(got.Score), scoreString(tt.wantScore))
+			}
+			if got.EarnedPoints != tt.wantEarned || got.PossiblePoints != tt.wantPossible {
+				t.Errorf("points = %v/%v, want %v/%v", got.EarnedPoints, got.PossiblePoints, tt.wantEarned, tt.wantPossible)
+			}
+			if got.NonCompliantQuestions != tt.wantNonCompliant || got.ExcludedQuestions != tt.wantExcluded || got.PendingQuestions != tt.wantPending {
+				t.Errorf("non-compliant = %d excluded = %d pending = %d, want %d, %d and %d",
+					got.NonCompliantQuestions, got.ExcludedQuestions, got.PendingQuestions,
+					tt.wantNonCompliant, tt.wantExcluded, tt.wantPending)
+			}
+		})
+	}
+}
+
+func TestEvaluateAuditScoreSections(t *testing.T) {
+	hidden := scoredRow("B", "2.2", clientdb.AnswerValueEnumNo, 1, clientdb.QuestionSeverityEnumHigh)
+	hidden.VisibilityCondition = []byte(`{"depends_on":"2.1","operator":"equals","values":["no"]}`)
+
+	got := evaluateAuditScore(uuid.New(), []clientdb.ListQuestionReadinessRow{
+		scoredRow("A", "1.1", clientdb.AnswerValueEnumYes, 1, clientdb.QuestionSeverityEnumLow),
+		scoredRow("B", "2.1", clientdb.AnswerValueEnumYes, 1, clientdb.QuestionSeverityEnumLow),
+		hidden,
+		scoredRow("A", "1.2", clientdb.AnswerValueEnumNo, 1, clientdb.QuestionSeverityEnumLow),
+	})
+
+	want := map[string]float64{"A": 50, "B": 100}
+	if len(got.Sections) != len(want) {
+		t.Fatalf("sections = %+v, want %v", got.Sections, want)
+	}
+	for _, section := range got.Sections {
+		if !equalScore(section.Score, floatPtr(want[section.Section])) {
+			t.Errorf("section %s score = %v, want %v", section.Section, scoreString(section.Score), want[section.Section])
+		}
+	}
+	if got.Sections[0].Section != "A" {
+		t.Errorf("first section = %q, want sections in question order", got.Sections[0].Section)
+	}
+	if !equalScore(got.Score, floatPtr(66.67)) {
+		t.Errorf("Score = %v, want 66.67", scoreString(got.Score))
+	}
+}
+
+func floatPtr(f float64) *float64 { return &f }
+
+func equalScore(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func scoreString(score *float64) any {
+	if score == nil {
+		return "nil"
+	}
+	return *score
+}
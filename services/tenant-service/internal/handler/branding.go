@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/NormaTech-AI/audity/packages/go/emailtemplates"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/db"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+)
+
+// ClientBrandingRequest sets the branding of the emails sent on behalf of a
+// client. Empty fields fall back to the default branding.
+type ClientBrandingRequest struct {
+	DisplayName  *string `json:"display_name" validate:"omitempty,max=255"`
+	LogoURL      *string `json:"logo_url" validate:"omitempty,url"`
+	PrimaryColor *string `json:"primary_color"`
+	FooterText   *string `json:"footer_text"`
+}
+
+// ClientBrandingResponse represents the email branding of a client
+type ClientBrandingResponse struct {
+	ClientID     string     `json:"client_id"`
+	DisplayName  *string    `json:"display_name,omitempty"`
+	LogoURL      *string    `json:"logo_url,omitempty"`
+	PrimaryColor *string    `json:"primary_color,omitempty"`
+	FooterText   *string    `json:"footer_text,omitempty"`
+	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
+}
+
+// GetClientBranding returns the email branding of a client
+func (h *Handler) GetClientBranding(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	clientID, err := uuid.Parse(c.Param("clientId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid client ID",
+		})
+	}
+
+	branding, err := h.store.Queries.GetClientBranding(ctx, clientID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Not customized, the default branding is used
+		return c.JSON(http.StatusOK, ClientBrandingResponse{ClientID: clientID.String()})
+	}
+	if err != nil {
+		h.logger.Errorw("Failed to get client branding", "error", err, "client_id", clientID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get client branding",
+		})
+	}
+
+	return c.JSON(http.StatusOK, buildClientBrandingResponse(branding))
+}
+
+// UpdateClientBranding replaces the email branding of a client
+func (h *Handler) UpdateClientBranding(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	clientID, err := uuid.Parse(c.Param("clientId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid client ID",
+		})
+	}
+
+	var req ClientBrandingRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request payload",
+		})
+	}
+
+	req.DisplayName = trimmedOrNil(req.DisplayName)
+	req.LogoURL = trimmedOrNil(req.LogoURL)
+	req.PrimaryColor = trimmedOrNil(req.PrimaryColor)
+	req.FooterText = trimmedOrNil(req.FooterText)
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	if req.PrimaryColor != nil && !emailtemplates.ValidColor(*req.PrimaryColor) {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "primary_color must be a hex color such as #1d4ed8",
+		})
+	}
+
+	if _, err := h.store.Queries.GetClient(ctx, clientID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "Client not found",
+			})
+		}
+		h.logger.Errorw("Failed to get client", "error", err, "client_id", clientID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to update client branding",
+		})
+	}
+
+	branding, err := h.store.Queries.UpsertClientBranding(ctx, db.UpsertClientBrandingParams{
+		ClientID:     clientID,
+		DisplayName:  req.DisplayName,
+		LogoUrl:      req.LogoURL,
+		PrimaryColor: req.PrimaryColor,
+		FooterText:   req.FooterText,
+	})
+	if err != nil {
+		h.logger.Errorw("Failed to update client branding", "error", err, "client_id", clientID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to update client branding",
+		})
+	}
+
+	h.logger.Infow("Client branding updated", "client_id", clientID)
+
+	return c.JSON(http.StatusOK, buildClientBrandingResponse(branding))
+}
+
+func buildClientBrandingResponse(branding db.ClientBranding) ClientBrandingResponse {
+	return ClientBrandingResponse{
+		ClientID:     branding.ClientID.String(),
+		DisplayName:  branding.DisplayName,
+		LogoURL:      branding.LogoUrl,
+		PrimaryColor: branding.PrimaryColor,
+		FooterText:   branding.FooterText,
+		UpdatedAt:    &branding.UpdatedAt,
+	}
+}
+
+func trimmedOrNil(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*s)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
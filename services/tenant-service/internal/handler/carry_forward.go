@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientdb"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+)
+
+// CarryForwardResponse reports how many answers were prefilled into an audit
+type CarryForwardResponse struct {
+	AuditID               string `json:"audit_id"`
+	CarriedForwardAnswers int64  `json:"carried_forward_answers"`
+}
+
+// CarryForwardAuditAnswers prefills an audit's unanswered questions with the
+// approved answers and evidence of the client's previous completed audit of the
+// same framework. Carried forward answers must be confirmed by the client.
+func (h *Handler) CarryForwardAuditAnswers(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	clientID, err := uuid.Parse(c.Param("clientId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid client ID",
+		})
+	}
+
+	auditID, err := uuid.Parse(c.Param("auditId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid audit ID",
+		})
+	}
+
+	// Get client database queries
+	clientQueries, _, err := h.clientStore.GetClientQueries(ctx, clientID)
+	if err != nil {
+		h.logger.Errorw("Failed to get client queries", "error", err, "client_id", clientID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to access client data",
+		})
+	}
+
+	audit, err := clientQueries.GetAuditByID(ctx, auditID)
+	if err != nil {
+		h.logger.Errorw("Failed to get audit", "error", err, "audit_id", auditID)
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Audit not found",
+		})
+	}
+
+	if audit.Status == clientdb.AuditStatusEnumCompleted {
+		return c.JSON(http.StatusConflict, map[string]string{
+			"error": "Answers cannot be carried forward into a completed audit",
+		})
+	}
+
+	var carried int64
+	err = h.clientStore.ExecClientTx(ctx, clientID, func(q *clientdb.Queries) error {
+		carried, err = h.frameworkService.CarryForwardAnswers(ctx, q, audit.ID, audit.FrameworkID)
+		return err
+	})
+	if err != nil {
+		h.logger.Errorw("Failed to carry forward answers", "error", err, "audit_id", auditID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to carry forward answers",
+		})
+	}
+
+	return c.JSON(http.StatusOK, CarryForwardResponse{
+		AuditID:               auditID.String(),
+		CarriedForwardAnswers: carried,
+	})
+}
+
+// ConfirmCarriedForwardAnswer confirms a carried forward answer unchanged so it
+// can be submitted. Like answering, only the client POC and the users the
+// question is assigned to can confirm it.
+func (h *Handler) ConfirmCarriedForwardAnswer(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	submissionID, err := uuid.Parse(c.Param("submissionId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid submission ID",
+		})
+	}
+
+	// Get client ID from authenticated user
+	clientID, err := getClientIDFromUser(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "Client ID not found in context",
+		})
+	}
+
+	// Get client database queries
+	clientQueries, _, err := h.clientStore.GetClientQueries(ctx, clientID)
+	if err != nil {
+		h.logger.Errorw("Failed to get client queries", "error", err, "client_id", clientID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to access client data",
+		})
+	}
+
+	current, err := clientQueries.GetSubmissionByID(ctx, submissionID)
+	if err != nil || !current.IsCarriedForward {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "No carried forward answer awaiting confirmation",
+		})
+	}
+
+	// Confirming an answer vouches for it like submitting it does
+	allowed, err := canAnswerQuestion(ctx, c, clientQueries, current.QuestionID)
+	if err != nil {
+		h.logger.Errorw("Failed to check question assignment", "error", err, "question_id", current.QuestionID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to check question assignment",
+		})
+	}
+	if !allowed {
+		return c.JSON(http.StatusForbidden, map[string]string{
+			"error": "Only the client POC and users the question is assigned to can confirm its answer",
+		})
+	}
+
+	submission, err := clientQueries.ConfirmCarriedForwardSubmission(ctx, submissionID)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "No carried forward answer awaiting confirmation",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"id":          submission.ID.String(),
+		"question_id": submission.QuestionID.String(),
+		"status":      string(submission.Status),
+		"message":     "Carried forward answer confirmed",
+	})
+}
+
+// canAnswerQuestion reports whether the authenticated client user may answer
+// a question: the POC answers every question, other users only the questions
+// assigned to them
+func canAnswerQuestion(ctx context.Context, c echo.Context, queries *clientdb.Queries, questionID uuid.UUID) (bool, error) {
+	isPOC, err := isUserPOCRole(c)
+	if err != nil {
+		return false, err
+	}
+	if isPOC {
+		return true, nil
+	}
+
+	userID, err := getUserIDFromUser(c)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = queries.GetQuestionAssignment(ctx, clientdb.GetQuestionAssignmentParams{
+		QuestionID: questionID,
+		AssignedTo: userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
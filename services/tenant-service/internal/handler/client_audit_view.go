@@ -1,8 +1,11 @@
 package handler
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
 
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/answers"
 	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientdb"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -11,43 +14,54 @@ import (
 
 // ClientAuditListResponse represents an audit for client view
 type ClientAuditListResponse struct {
-	ID              string  `json:"id"`
-	FrameworkID     string  `json:"framework_id"`
-	FrameworkName   string  `json:"framework_name"`
-	DueDate         string  `json:"due_date"`
-	Status          string  `json:"status"`
-	TotalQuestions  int64   `json:"total_questions"`
-	AnsweredCount   int64   `json:"answered_count"`
-	ProgressPercent float64 `json:"progress_percent"`
-	CreatedAt       string  `json:"created_at"`
+	ID              string   `json:"id"`
+	FrameworkID     string   `json:"framework_id"`
+	FrameworkName   string   `json:"framework_name"`
+	DueDate         string   `json:"due_date"`
+	Status          string   `json:"status"`
+	TotalQuestions  int64    `json:"total_questions"`
+	AnsweredCount   int64    `json:"answered_count"`
+	ProgressPercent float64  `json:"progress_percent"`
+	ComplianceScore *float64 `json:"compliance_score"`
+	CreatedAt       string   `json:"created_at"`
 }
 
 // ClientQuestionResponse represents a question with submission for client view
 type ClientQuestionResponse struct {
-	ID               string  `json:"id"`
-	Section          string  `json:"section"`
-	QuestionNumber   string  `json:"question_number"`
-	QuestionText     string  `json:"question_text"`
-	QuestionType     string  `json:"question_type"`
-	HelpText         *string `json:"help_text"`
-	IsMandatory      bool    `json:"is_mandatory"`
-	DisplayOrder     int32   `json:"display_order"`
-	SubmissionID     *string `json:"submission_id"`
-	AnswerValue      *string `json:"answer_value"`
-	AnswerText       *string `json:"answer_text"`
-	Explanation      *string `json:"explanation"`
-	SubmissionStatus *string `json:"submission_status"`
-	SubmittedAt      *string `json:"submitted_at"`
-	SubmittedBy      *string `json:"submitted_by"`
-	IsAssignedToMe   bool    `json:"is_assigned_to_me"`
+	ID                   string          `json:"id"`
+	Section              string          `json:"section"`
+	Subsection           *string         `json:"subsection"`
+	QuestionNumber       string          `json:"question_number"`
+	QuestionText         string          `json:"question_text"`
+	QuestionType         string          `json:"question_type"`
+	HelpText             *string         `json:"help_text"`
+	IsMandatory          bool            `json:"is_mandatory"`
+	DisplayOrder         int32           `json:"display_order"`
+	Options              json.RawMessage `json:"options,omitempty"`
+	EvidenceRequired     bool            `json:"evidence_required"`
+	AcceptableEvidence   []string        `json:"acceptable_evidence,omitempty"`
+	EvidenceRequirements json.RawMessage `json:"evidence_requirements,omitempty"`
+	SubmissionID         *string         `json:"submission_id"`
+	AnswerValue          *string         `json:"answer_value"`
+	AnswerText           *string         `json:"answer_text"`
+	AnswerData           json.RawMessage `json:"answer_data,omitempty"`
+	Explanation          *string         `json:"explanation"`
+	SubmissionStatus     *string         `json:"submission_status"`
+	SubmittedAt          *string         `json:"submitted_at"`
+	SubmittedBy          *string         `json:"submitted_by"`
+	IsCarriedForward     bool            `json:"is_carried_forward"`
+	IsAssignedToMe       bool            `json:"is_assigned_to_me"`
+	// Locale the question is served in; nil for the canonical text
+	Locale *string `json:"locale"`
 }
 
 // ClientSubmissionRequest represents a submission payload from client
 type ClientSubmissionRequest struct {
-	QuestionID  string  `json:"question_id" validate:"required"`
-	AnswerValue *string `json:"answer_value"`
-	AnswerText  *string `json:"answer_text"`
-	Explanation string  `json:"explanation" validate:"required"`
+	QuestionID  string        `json:"question_id" validate:"required"`
+	AnswerValue *string       `json:"answer_value"`
+	AnswerText  *string       `json:"answer_text"`
+	AnswerData  *answers.Data `json:"answer_data"`
+	Explanation string        `json:"explanation" validate:"required"`
 }
 
 // ListClientAuditsView returns all audits for the authenticated client user
@@ -80,6 +94,16 @@ func (h *Handler) ListClientAuditsView(c echo.Context) error {
 		})
 	}
 
+	// Compliance scores are best effort; audits are still listed without them
+	auditIDs := make([]uuid.UUID, 0, len(audits))
+	for _, audit := range audits {
+		auditIDs = append(auditIDs, audit.ID)
+	}
+	scores, err := auditScores(ctx, clientQueries, auditIDs)
+	if err != nil {
+		h.logger.Warnw("Failed to compute compliance scores", "error", err, "client_id", clientID)
+	}
+
 	// Convert to response format with progress
 	responses := make([]ClientAuditListResponse, 0, len(audits))
 	for _, audit := range audits {
@@ -110,6 +134,7 @@ func (h *Handler) ListClientAuditsView(c echo.Context) error {
 			TotalQuestions:  progress.TotalQuestions,
 			AnsweredCount:   progress.ApprovedCount,
 			ProgressPercent: progressPercent,
+			ComplianceScore: scores[audit.ID],
 			CreatedAt:       createdAt.(string),
 		})
 	}
@@ -117,7 +142,11 @@ func (h *Handler) ListClientAuditsView(c echo.Context) error {
 	return c.JSON(http.StatusOK, responses)
 }
 
-// GetClientAuditDetailView returns detailed audit information with questions for client
+// GetClientAuditDetailView returns detailed audit information with questions
+// for client. Questions are served in the locale query parameter, the user's
+// preferred locale or the Accept-Language header, whichever is translated
+// first, falling back to the canonical text. Locales after the canonical
+// language of the audit are ignored.
 func (h *Handler) GetClientAuditDetailView(c echo.Context) error {
 	ctx := c.Request().Context()
 
@@ -183,9 +212,41 @@ func (h *Handler) GetClientAuditDetailView(c echo.Context) error {
 		})
 	}
 
+	// Resolve conditional questions against the answers of the whole audit,
+	// not just the questions visible to this user
+	readinessRows, err := clientQueries.ListQuestionReadiness(ctx, auditID)
+	if err != nil {
+		h.logger.Errorw("Failed to evaluate question visibility", "error", err, "audit_id", auditID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve questions",
+		})
+	}
+	visible := resolveQuestionVisibility(readinessRows)
+
+	locales := h.questionLocales(c, userID, audit.Language)
+	translations, err := auditQuestionTranslations(ctx, clientQueries, auditID, locales)
+	if err != nil {
+		h.logger.Errorw("Failed to get question translations", "error", err, "audit_id", auditID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve questions",
+		})
+	}
+
+	availableLocales, err := clientQueries.ListAuditLocales(ctx, auditID)
+	if err != nil {
+		h.logger.Errorw("Failed to list audit locales", "error", err, "audit_id", auditID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve questions",
+		})
+	}
+
 	// Convert questions to response format
 	questionResponses := make([]ClientQuestionResponse, 0, len(questions))
 	for _, q := range questions {
+		if !visible[q.QuestionNumber] {
+			continue
+		}
+
 		var submissionID, answerValue, answerText, explanation, submissionStatus, submittedAt, submittedBy *string
 
 		if q.SubmissionID.Valid {
@@ -228,6 +289,14 @@ func (h *Handler) GetClientAuditDetailView(c echo.Context) error {
 			submittedBy = &sbStr
 		}
 
+		var options, answerData json.RawMessage
+		if len(q.Options) > 0 {
+			options = q.Options
+		}
+		if len(q.AnswerData) > 0 {
+			answerData = q.AnswerData
+		}
+
 		isAssignedToMe := false
 		if q.AssignedUserID.Valid {
 			assignedID := q.AssignedUserID.Bytes
@@ -235,37 +304,48 @@ func (h *Handler) GetClientAuditDetailView(c echo.Context) error {
 			isAssignedToMe = assignedUUID == userID
 		}
 
-		questionResponses = append(questionResponses, ClientQuestionResponse{
-			ID:               q.ID.String(),
-			Section:          q.Section,
-			QuestionNumber:   q.QuestionNumber,
-			QuestionText:     q.QuestionText,
-			QuestionType:     string(q.QuestionType),
-			HelpText:         q.HelpText,
-			IsMandatory:      q.IsMandatory,
-			DisplayOrder:     q.DisplayOrder,
-			SubmissionID:     submissionID,
-			AnswerValue:      answerValue,
-			AnswerText:       answerText,
-			Explanation:      explanation,
-			SubmissionStatus: submissionStatus,
-			SubmittedAt:      submittedAt,
-			SubmittedBy:      submittedBy,
-			IsAssignedToMe:   isAssignedToMe,
-		})
+		response := ClientQuestionResponse{
+			ID:                   q.ID.String(),
+			Section:              q.Section,
+			Subsection:           q.Subsection,
+			QuestionNumber:       q.QuestionNumber,
+			QuestionText:         q.QuestionText,
+			QuestionType:         string(q.QuestionType),
+			HelpText:             q.HelpText,
+			IsMandatory:          q.IsMandatory,
+			DisplayOrder:         q.DisplayOrder,
+			Options:              options,
+			EvidenceRequired:     q.EvidenceRequired,
+			AcceptableEvidence:   q.AcceptableEvidence,
+			EvidenceRequirements: q.EvidenceRequirements,
+			SubmissionID:         submissionID,
+			AnswerValue:          answerValue,
+			AnswerText:           answerText,
+			AnswerData:           answerData,
+			Explanation:          explanation,
+			SubmissionStatus:     submissionStatus,
+			SubmittedAt:          submittedAt,
+			SubmittedBy:          submittedBy,
+			IsCarriedForward:     q.IsCarriedForward != nil && *q.IsCarriedForward,
+			IsAssignedToMe:       isAssignedToMe,
+		}
+		localizeClientQuestion(&response, translations[q.ID], locales)
+		questionResponses = append(questionResponses, response)
 	}
 
 	dueDate, _ := audit.DueDate.Value()
 	createdAt, _ := audit.CreatedAt.Value()
 
 	response := map[string]interface{}{
-		"id":             audit.ID.String(),
-		"framework_id":   audit.FrameworkID.String(),
-		"framework_name": audit.FrameworkName,
-		"due_date":       dueDate,
-		"status":         string(audit.Status),
-		"created_at":     createdAt,
-		"questions":      questionResponses,
+		"id":                audit.ID.String(),
+		"framework_id":      audit.FrameworkID.String(),
+		"framework_name":    audit.FrameworkName,
+		"due_date":          dueDate,
+		"status":            string(audit.Status),
+		"created_at":        createdAt,
+		"language":          audit.Language,
+		"available_locales": availableLocales,
+		"questions":         questionResponses,
 	}
 
 	return c.JSON(http.StatusOK, response)
@@ -313,11 +393,33 @@ func (h *Handler) SaveClientSubmission(c echo.Context) error {
 		})
 	}
 
+	// Validate the answer against the question type
+	question, err := clientQueries.GetQuestionByID(ctx, questionID)
+	if err != nil {
+		h.logger.Errorw("Failed to get question", "error", err, "question_id", questionID)
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Question not found",
+		})
+	}
+
+	if err := answers.Validate(question, req.AnswerValue, req.AnswerData); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	answerData, err := answers.Marshal(req.AnswerData)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid answer data",
+		})
+	}
+
 	// Check if submission already exists
 	existingSubmission, err := clientQueries.GetSubmissionByQuestionID(ctx, questionID)
-	
+
 	var submission clientdb.Submission
-	
+
 	if err == nil && existingSubmission.ID != uuid.Nil {
 		// Update existing submission
 		var answerValue clientdb.NullAnswerValueEnum
@@ -338,6 +440,7 @@ func (h *Handler) SaveClientSubmission(c echo.Context) error {
 			AnswerValue: answerValue,
 			AnswerText:  &answerText.String,
 			Explanation: req.Explanation,
+			AnswerData:  answerData,
 		})
 		if err != nil {
 			h.logger.Errorw("Failed to update submission", "error", err)
@@ -369,6 +472,7 @@ func (h *Handler) SaveClientSubmission(c echo.Context) error {
 			AnswerText:  answerTextPtr,
 			Explanation: req.Explanation,
 			Status:      clientdb.SubmissionStatusEnumInProgress,
+			AnswerData:  answerData,
 		})
 		if err != nil {
 			h.logger.Errorw("Failed to create submission", "error", err)
@@ -415,19 +519,81 @@ func (h *Handler) SubmitClientAnswer(c echo.Context) error {
 		})
 	}
 
-	// Submit the submission
-	submission, err := clientQueries.SubmitSubmission(ctx, submissionID)
+	current, err := clientQueries.GetSubmissionByID(ctx, submissionID)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Submission not found",
+		})
+	}
+
+	if current.IsCarriedForward {
+		return c.JSON(http.StatusConflict, map[string]string{
+			"error": "Carried forward answers must be confirmed or updated before submitting",
+		})
+	}
+
+	// Structured answers are only accepted for review once complete
+	if err := checkAnswerComplete(ctx, clientQueries, current); err != nil {
+		if errors.Is(err, answers.ErrIncomplete) {
+			return c.JSON(http.StatusUnprocessableEntity, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		h.logger.Errorw("Failed to check answer", "error", err, "submission_id", submissionID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to check answer",
+		})
+	}
+
+	// Answers are only accepted for review with the evidence the question requires
+	unmet, err := unmetEvidenceRequirements(ctx, clientQueries, current)
+	if err != nil {
+		h.logger.Errorw("Failed to check evidence requirements", "error", err, "submission_id", submissionID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to check evidence requirements",
+		})
+	}
+	if unmet != nil {
+		return c.JSON(http.StatusUnprocessableEntity, unmet)
+	}
+
+	// Submit the submission and record the event in the same transaction
+	var submission clientdb.Submission
+	err = h.clientStore.ExecClientTx(ctx, clientID, func(q *clientdb.Queries) error {
+		var err error
+		submission, err = q.SubmitSubmission(ctx, submissionID)
+		if err != nil {
+			return err
+		}
+		event, err := submissionSubmittedEvent(ctx, q, submission)
+		if err != nil {
+			return err
+		}
+		return h.emitEvent(ctx, q, clientID, event)
+	})
 	if err != nil {
 		h.logger.Errorw("Failed to submit submission", "error", err, "submission_id", submissionID)
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to submit answer",
 		})
 	}
+	h.publishEvents()
+
+	// Share the answer with the mapped controls of the client's other open
+	// audits. This is best effort: the submission itself has succeeded.
+	var propagated int64
+	if !submission.PropagatedFrom.Valid {
+		propagated, err = h.propagateSubmission(c, clientQueries, submission)
+		if err != nil {
+			h.logger.Errorw("Failed to propagate submission", "error", err, "submission_id", submissionID)
+		}
+	}
 
 	return c.JSON(http.StatusOK, map[string]interface{}{
-		"id":      submission.ID.String(),
-		"status":  string(submission.Status),
-		"message": "Answer submitted successfully for review",
+		"id":               submission.ID.String(),
+		"status":           string(submission.Status),
+		"propagated_count": propagated,
+		"message":          "Answer submitted successfully for review",
 	})
 }
 
@@ -438,17 +604,17 @@ func getClientIDFromUser(c echo.Context) (uuid.UUID, error) {
 	if user == nil {
 		return uuid.Nil, echo.NewHTTPError(http.StatusUnauthorized, "User not found in context")
 	}
-	
+
 	userMap, ok := user.(map[string]interface{})
 	if !ok {
 		return uuid.Nil, echo.NewHTTPError(http.StatusUnauthorized, "Invalid user data")
 	}
-	
+
 	clientIDStr, ok := userMap["client_id"].(string)
 	if !ok || clientIDStr == "" {
 		return uuid.Nil, echo.NewHTTPError(http.StatusUnauthorized, "Client ID not found")
 	}
-	
+
 	return uuid.Parse(clientIDStr)
 }
 
@@ -457,17 +623,17 @@ func getUserIDFromUser(c echo.Context) (uuid.UUID, error) {
 	if user == nil {
 		return uuid.Nil, echo.NewHTTPError(http.StatusUnauthorized, "User not found in context")
 	}
-	
+
 	userMap, ok := user.(map[string]interface{})
 	if !ok {
 		return uuid.Nil, echo.NewHTTPError(http.StatusUnauthorized, "Invalid user data")
 	}
-	
+
 	userIDStr, ok := userMap["user_id"].(string)
 	if !ok || userIDStr == "" {
 		return uuid.Nil, echo.NewHTTPError(http.StatusUnauthorized, "User ID not found")
 	}
-	
+
 	return uuid.Parse(userIDStr)
 }
 
@@ -476,17 +642,17 @@ func isUserPOCRole(c echo.Context) (bool, error) {
 	if user == nil {
 		return false, echo.NewHTTPError(http.StatusUnauthorized, "User not found in context")
 	}
-	
+
 	userMap, ok := user.(map[string]interface{})
 	if !ok {
 		return false, echo.NewHTTPError(http.StatusUnauthorized, "Invalid user data")
 	}
-	
+
 	designation, ok := userMap["designation"].(string)
 	if !ok {
 		return false, nil
 	}
-	
+
 	// POC users can see all questions
 	return designation == "poc_client" || designation == "poc_internal", nil
 }
@@ -60,15 +60,13 @@ func (h *Handler) CreateComment(c echo.Context) error {
 	}
 
 	// Get user info from context
-	userID := c.Get("user_id").(string)
-	userEmail := c.Get("user_email").(string)
-
-	userUUID, err := uuid.Parse(userID)
+	userUUID, err := getUserIDFromContext(c)
 	if err != nil {
 		return c.JSON(http.StatusUnauthorized, map[string]string{
 			"error": "Invalid user ID",
 		})
 	}
+	userEmail, _ := c.Get("user_email").(string)
 
 	// Get client database queries
 	clientQueries, _, err := h.clientStore.GetClientQueries(ctx, clientID)
@@ -98,7 +96,9 @@ func (h *Handler) CreateComment(c echo.Context) error {
 		"comment_id", comment.ID, 
 		"submission_id", submissionID, 
 		"client_id", clientID,
-		"user_id", userID)
+		"user_id", userUUID)
+
+	h.notifyCommentMentions(ctx, clientID, clientQueries, comment)
 
 	response := buildCommentResponse(comment)
 
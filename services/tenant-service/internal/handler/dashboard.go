@@ -172,6 +172,7 @@ type FrameworkAnalytics struct {
 	DueDate           time.Time  `json:"due_date"`
 	TotalQuestions    int64      `json:"total_questions"`
 	AnsweredQuestions int64      `json:"answered_questions"`
+	ComplianceScore   *float64   `json:"compliance_score"`
 }
 
 // ClientDashboardStats represents dashboard statistics for a client
@@ -322,6 +323,16 @@ func (h *Handler) GetClientDashboard(c echo.Context) error {
 		if err != nil {
 			h.logger.Errorw("Failed to get framework analytics", "error", err, "client_id", clientID)
 		} else {
+			// Compliance scores are best effort; the audits are still listed without them
+			auditIDs := make([]uuid.UUID, 0, len(analyticsRows))
+			for _, row := range analyticsRows {
+				auditIDs = append(auditIDs, row.ID)
+			}
+			scores, err := auditScores(ctx, clientQueries, auditIDs)
+			if err != nil {
+				h.logger.Errorw("Failed to compute compliance scores", "error", err, "client_id", clientID)
+			}
+
 			for _, row := range analyticsRows {
 				dueDate, _ := row.DueDate.Value()
 				frameworkAnalytics = append(frameworkAnalytics, FrameworkAnalytics{
@@ -332,6 +343,7 @@ func (h *Handler) GetClientDashboard(c echo.Context) error {
 					DueDate:           dueDate.(time.Time),
 					TotalQuestions:    row.TotalQuestions,
 					AnsweredQuestions: row.AnsweredQuestions,
+					ComplianceScore:   scores[row.ID],
 				})
 			}
 		}
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/NormaTech-AI/audity/packages/go/eventbus"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientdb"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/events"
+	"github.com/google/uuid"
+)
+
+// emitEvent writes an event to the outbox of the client database. Call it in
+// the transaction of the change so the event is published iff it commits.
+func (h *Handler) emitEvent(ctx context.Context, q *clientdb.Queries, clientID uuid.UUID, payload eventbus.Payload) error {
+	return events.Enqueue(ctx, q, clientID, payload)
+}
+
+// publishEvents makes the relay publish committed events right away instead
+// of at its next poll
+func (h *Handler) publishEvents() {
+	if h.relay != nil {
+		h.relay.Wake()
+	}
+}
+
+// submissionSubmittedEvent describes a submission that was submitted for review
+func submissionSubmittedEvent(ctx context.Context, q *clientdb.Queries, submission clientdb.Submission) (eventbus.SubmissionSubmittedEvent, error) {
+	question, err := q.GetQuestionByID(ctx, submission.QuestionID)
+	if err != nil {
+		return eventbus.SubmissionSubmittedEvent{}, err
+	}
+	return eventbus.SubmissionSubmittedEvent{
+		SubmissionID: submission.ID,
+		AuditID:      question.AuditID,
+		QuestionID:   submission.QuestionID,
+		SubmittedBy:  submission.SubmittedBy,
+		Version:      submission.Version,
+	}, nil
+}
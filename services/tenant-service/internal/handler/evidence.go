@@ -8,6 +8,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/NormaTech-AI/audity/packages/go/eventbus"
+	"github.com/NormaTech-AI/audity/packages/go/evidence"
 	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientdb"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -25,6 +27,7 @@ type EvidenceResponse struct {
 	StoragePath  string  `json:"storage_path"`
 	UploadedBy   string  `json:"uploaded_by"`
 	Description  *string `json:"description"`
+	Category     *string `json:"category"`
 	DownloadURL  *string `json:"download_url,omitempty"`
 	CreatedAt    string  `json:"created_at"`
 	UpdatedAt    string  `json:"updated_at"`
@@ -80,6 +83,18 @@ func (h *Handler) UploadEvidence(c echo.Context) error {
 
 	description := c.FormValue("description")
 
+	// The document category is matched against the evidence requirements of
+	// the question when the answer is submitted
+	var category *string
+	if value := c.FormValue("category"); value != "" {
+		if !evidence.ValidCategory(value) {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": fmt.Sprintf("Evidence category %s is not supported", value),
+			})
+		}
+		category = &value
+	}
+
 	// Get uploaded file
 	file, err := c.FormFile("file")
 	if err != nil {
@@ -155,14 +170,31 @@ func (h *Handler) UploadEvidence(c echo.Context) error {
 		desc = &description
 	}
 
-	evidence, err := clientQueries.CreateEvidence(ctx, clientdb.CreateEvidenceParams{
-		SubmissionID: submissionID,
-		FileName:     file.Filename,
-		FilePath:     objectName,
-		FileSize:     file.Size,
-		FileType:     &ext,
-		UploadedBy:   uploadedBy,
-		Description:  desc,
+	// Record the evidence and its event in the same transaction
+	var evidence clientdb.Evidence
+	err = h.clientStore.ExecClientTx(ctx, clientID, func(q *clientdb.Queries) error {
+		var err error
+		evidence, err = q.CreateEvidence(ctx, clientdb.CreateEvidenceParams{
+			SubmissionID: submissionID,
+			FileName:     file.Filename,
+			FilePath:     objectName,
+			FileSize:     file.Size,
+			FileType:     &ext,
+			UploadedBy:   uploadedBy,
+			Description:  desc,
+			Category:     category,
+		})
+		if err != nil {
+			return err
+		}
+		return h.emitEvent(ctx, q, clientID, eventbus.EvidenceUploadedEvent{
+			EvidenceID:   evidence.ID,
+			SubmissionID: submissionID,
+			UploadedBy:   uploadedBy,
+			FileName:     file.Filename,
+			FileSize:     file.Size,
+			ContentType:  file.Header.Get("Content-Type"),
+		})
 	})
 	if err != nil {
 		h.logger.Errorw("Failed to create evidence record", "error", err)
@@ -173,6 +205,13 @@ func (h *Handler) UploadEvidence(c echo.Context) error {
 		})
 	}
 
+	h.publishEvents()
+
+	// Answers shared with equivalent mapped questions share the new file too
+	if _, err := clientQueries.PropagateEvidence(ctx, pgtype.UUID{Bytes: submissionID, Valid: true}); err != nil {
+		h.logger.Errorw("Failed to propagate evidence", "error", err, "submission_id", submissionID)
+	}
+
 	h.logger.Infow("Evidence uploaded", 
 		"evidence_id", evidenceID, 
 		"submission_id", submissionID, 
@@ -493,6 +532,7 @@ func buildEvidenceResponse(evidence clientdb.Evidence, downloadURL *string) Evid
 		StoragePath:  evidence.FilePath,
 		UploadedBy:   evidence.UploadedBy.String(),
 		Description:  desc,
+		Category:     evidence.Category,
 		DownloadURL:  downloadURL,
 		CreatedAt:    evidence.UploadedAt.Time.Format(time.RFC3339),
 		UpdatedAt:    evidence.UploadedAt.Time.Format(time.RFC3339), // Using uploaded_at as updated_at
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/answers"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientdb"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/evidencereq"
+	"github.com/google/uuid"
+)
+
+// EvidenceRequirementsError is returned when an answer is submitted without
+// the evidence its question requires
+type EvidenceRequirementsError struct {
+	Error             string              `json:"error"`
+	UnmetRequirements []evidencereq.Check `json:"unmet_requirements"`
+}
+
+// checkAnswerComplete checks that the answer of a submission can be submitted
+// for review; incomplete answers fail with answers.ErrIncomplete
+func checkAnswerComplete(ctx context.Context, queries clientdb.Querier, submission clientdb.Submission) error {
+	question, err := queries.GetQuestionByID(ctx, submission.QuestionID)
+	if err != nil {
+		return fmt.Errorf("failed to get question: %w", err)
+	}
+
+	return answers.Complete(question, submission.AnswerValue, submission.AnswerData)
+}
+
+// unmetEvidenceRequirements returns the error an answer is rejected with when
+// its submission lacks the evidence its question requires, nil when the
+// requirements are met
+func unmetEvidenceRequirements(ctx context.Context, queries clientdb.Querier, submission clientdb.Submission) (*EvidenceRequirementsError, error) {
+	checklist, err := submissionEvidenceChecklist(ctx, queries, submission)
+	if err != nil {
+		return nil, err
+	}
+
+	unmet := evidencereq.Unmet(checklist)
+	if len(unmet) == 0 {
+		return nil, nil
+	}
+	return &EvidenceRequirementsError{
+		Error:             "Evidence requirements are not met",
+		UnmetRequirements: unmet,
+	}, nil
+}
+
+// submissionEvidenceChecklist checks the evidence of a submission against the
+// evidence requirements of its question. It returns nil when the question
+// needs no evidence.
+func submissionEvidenceChecklist(ctx context.Context, queries clientdb.Querier, submission clientdb.Submission) ([]evidencereq.Check, error) {
+	question, err := queries.GetQuestionByID(ctx, submission.QuestionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get question: %w", err)
+	}
+
+	return evidenceChecklist(ctx, queries, question.EvidenceRequired, question.EvidenceRequirements, submission.ID)
+}
+
+// evidenceChecklist checks the evidence uploaded for a submission against
+// evidence requirements
+func evidenceChecklist(ctx context.Context, queries clientdb.Querier, required bool, rawRequirements []byte, submissionID uuid.UUID) ([]evidencereq.Check, error) {
+	requirements, err := evidencereq.ForQuestion(required, rawRequirements)
+	if err != nil || requirements == nil {
+		return nil, err
+	}
+
+	evidence, err := queries.ListEvidenceBySubmission(ctx, submissionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list evidence: %w", err)
+	}
+
+	return evidencereq.Evaluate(requirements, evidencereq.FromEvidence(evidence)), nil
+}
@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/answers"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientdb"
+	"github.com/google/uuid"
+)
+
+// fakeClientQueries serves the questions and evidence of a client database
+// from memory; other queries panic
+type fakeClientQueries struct {
+	clientdb.Querier
+	questions map[uuid.UUID]clientdb.Question
+	evidence  map[uuid.UUID][]clientdb.Evidence
+}
+
+func (f *fakeClientQueries) GetQuestionByID(ctx context.Context, id uuid.UUID) (clientdb.Question, error) {
+	return f.questions[id], nil
+}
+
+func (f *fakeClientQueries) ListEvidenceBySubmission(ctx context.Context, submissionID uuid.UUID) ([]clientdb.Evidence, error) {
+	return f.evidence[submissionID], nil
+}
+
+func TestUnmetEvidenceRequirements(t *testing.T) {
+	policy := "policy"
+
+	tests := []struct {
+		name         string
+		question     clientdb.Question
+		evidence     []clientdb.Evidence
+		wantRejected bool
+		wantUnmet    []string
+	}{
+		{
+			name:     "no evidence needed",
+			question: clientdb.Question{},
+		},
+		{
+			name:         "required evidence missing",
+			question:     clientdb.Question{EvidenceRequired: true},
+			wantRejected: true,
+			wantUnmet:    []string{"At least 1 file"},
+		},
+		{
+			name:     "required evidence uploaded",
+			question: clientdb.Question{EvidenceRequired: true},
+			evidence: []clientdb.Evidence{{FileName: "policy.pdf"}},
+		},
+		{
+			name: "required category missing",
+			question: clientdb.Question{
+				EvidenceRequired:     true,
+				EvidenceRequirements: []byte(`{"min_files":1,"required_categories":["policy"]}`),
+			},
+			evidence:     []clientdb.Evidence{{FileName: "console.png"}},
+			wantRejected: true,
+			wantUnmet:    []string{"Category policy"},
+		},
+		{
+			name: "all requirements met",
+			question: clientdb.Question{
+				EvidenceRequired:     true,
+				EvidenceRequirements: []byte(`{"min_files":1,"allowed_file_types":[".pdf"],"required_categories":["policy"]}`),
+			},
+			evidence: []clientdb.Evidence{{FileName: "policy.pdf", Category: &policy}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			questionID, submissionID := uuid.New(), uuid.New()
+			queries := &fakeClientQueries{
+				questions: map[uuid.UUID]clientdb.Question{questionID: tt.question},
+				evidence:  map[uuid.UUID][]clientdb.Evidence{submissionID: tt.evidence},
+			}
+
+			unmet, err := unmetEvidenceRequirements(context.Background(), queries, clientdb.Submission{
+				ID:         submissionID,
+				QuestionID: questionID,
+			})
+			if err != nil {
+				t.Fatalf("unmetEvidenceRequirements() error = %v", err)
+			}
+			if (unmet != nil) != tt.wantRejected {
+				t.Fatalf("unmetEvidenceRequirements() = %+v, want rejected %v", unmet, tt.wantRejected)
+			}
+			if unmet == nil {
+				return
+			}
+
+			var got []string
+			for _, check := range unmet.UnmetRequirements {
+				got = append(got, check.Requirement)
+			}
+			if len(got) != len(tt.wantUnmet) {
+				t.Fatalf("unmet requirements = %v, want %v", got, tt.wantUnmet)
+			}
+			for i := range got {
+				if got[i] != tt.wantUnmet[i] {
+					t.Errorf("unmet requirements = %v, want %v", got, tt.wantUnmet)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckAnswerComplete(t *testing.T) {
+	choice := clientdb.Question{
+		QuestionType: clientdb.QuestionTypeEnumSingleChoice,
+		Options:      []byte(`[{"value":"yes","label":"Yes"}]`),
+	}
+
+	tests := []struct {
+		name           string
+		question       clientdb.Question
+		submission     clientdb.Submission
+		wantIncomplete bool
+	}{
+		{
+			name:           "choice without answer data",
+			question:       choice,
+			wantIncomplete: true,
+		},
+		{
+			name:       "choice with answer data",
+			question:   choice,
+			submission: clientdb.Submission{AnswerData: []byte(`{"selected":["yes"]}`)},
+		},
+		{
+			name:     "yes/no answer",
+			question: clientdb.Question{QuestionType: clientdb.QuestionTypeEnumYesNo},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			questionID := uuid.New()
+			queries := &fakeClientQueries{
+				questions: map[uuid.UUID]clientdb.Question{questionID: tt.question},
+			}
+
+			submission := tt.submission
+			submission.QuestionID = questionID
+			err := checkAnswerComplete(context.Background(), queries, submission)
+			if tt.wantIncomplete {
+				if !errors.Is(err, answers.ErrIncomplete) {
+					t.Errorf("checkAnswerComplete() error = %v, want answers.ErrIncomplete", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("checkAnswerComplete() error = %v, want nil", err)
+			}
+		})
+	}
+}
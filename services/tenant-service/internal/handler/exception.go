@@ -0,0 +1,598 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientdb"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+)
+
+// defaultExceptionReminderDays is how far ahead expiring exceptions are reported
+const defaultExceptionReminderDays = 30
+
+// ExceptionResponse represents a question exception in API responses
+type ExceptionResponse struct {
+	ID                   string  `json:"id"`
+	AuditID              string  `json:"audit_id"`
+	QuestionID           string  `json:"question_id"`
+	Justification        string  `json:"justification"`
+	CompensatingControls string  `json:"compensating_controls"`
+	Status               string  `json:"status"`
+	Active               bool    `json:"active"`
+	RequestedBy          string  `json:"requested_by"`
+	RequestedAt          string  `json:"requested_at"`
+	ReviewedBy           *string `json:"reviewed_by"`
+	ReviewedAt           *string `json:"reviewed_at"`
+	ReviewNotes          *string `json:"review_notes"`
+	ExpiresAt            *string `json:"expires_at"`
+	CarriedForwardFrom   *string `json:"carried_forward_from"`
+	CreatedAt            string  `json:"created_at"`
+	UpdatedAt            string  `json:"updated_at"`
+}
+
+// ExpiringExceptionResponse represents an approved exception that is due for renewal
+type ExpiringExceptionResponse struct {
+	ExceptionResponse
+	Section        string `json:"section"`
+	QuestionNumber string `json:"question_number"`
+	QuestionText   string `json:"question_text"`
+	FrameworkID    string `json:"framework_id"`
+	FrameworkName  string `json:"framework_name"`
+	DaysRemaining  int    `json:"days_remaining"`
+}
+
+// RequestExceptionRequest represents a client POC's request for an exception
+type RequestExceptionRequest struct {
+	QuestionID           string `json:"question_id" validate:"required"`
+	Justification        string `json:"justification" validate:"required"`
+	CompensatingControls string `json:"compensating_controls" validate:"required"`
+}
+
+// ApproveExceptionRequest represents an auditor's approval of an exception
+type ApproveExceptionRequest struct {
+	ExpiresAt string  `json:"expires_at" validate:"required"`
+	Notes     *string `json:"notes"`
+}
+
+// ReviewExceptionRequest represents an auditor's rejection or revocation of an exception
+type ReviewExceptionRequest struct {
+	Notes string `json:"notes" validate:"required"`
+}
+
+// ListAuditExceptions lists all exceptions requested in an audit
+func (h *Handler) ListAuditExceptions(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	clientID, err := uuid.Parse(c.Param("clientId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid client ID",
+		})
+	}
+
+	auditID, err := uuid.Parse(c.Param("auditId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid audit ID",
+		})
+	}
+
+	// Get client database queries
+	clientQueries, _, err := h.clientStore.GetClientQueries(ctx, clientID)
+	if err != nil {
+		h.logger.Errorw("Failed to get client queries", "error", err, "client_id", clientID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to access client data",
+		})
+	}
+
+	return h.listAuditExceptions(c, clientQueries, auditID)
+}
+
+// GetException retrieves a single exception
+func (h *Handler) GetException(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	clientID, err := uuid.Parse(c.Param("clientId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid client ID",
+		})
+	}
+
+	exceptionID, err := uuid.Parse(c.Param("exceptionId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid exception ID",
+		})
+	}
+
+	// Get client database queries
+	clientQueries, _, err := h.clientStore.GetClientQueries(ctx, clientID)
+	if err != nil {
+		h.logger.Errorw("Failed to get client queries", "error", err, "client_id", clientID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to access client data",
+		})
+	}
+
+	exception, err := clientQueries.GetQuestionExceptionByID(ctx, exceptionID)
+	if err != nil {
+		h.logger.Errorw("Failed to get exception", "error", err, "exception_id", exceptionID)
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Exception not found",
+		})
+	}
+
+	return c.JSON(http.StatusOK, buildExceptionResponse(exception))
+}
+
+// ApproveException approves a requested exception until the given expiry date
+func (h *Handler) ApproveException(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	clientID, err := uuid.Parse(c.Param("clientId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid client ID",
+		})
+	}
+
+	exceptionID, err := uuid.Parse(c.Param("exceptionId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid exception ID",
+		})
+	}
+
+	var req ApproveExceptionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	expiresAt, err := time.Parse("2006-01-02", req.ExpiresAt)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid expires_at format. Use YYYY-MM-DD",
+		})
+	}
+
+	if expiresAt.Before(today()) {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Expiry date must not be in the past",
+		})
+	}
+
+	reviewerID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User not authenticated",
+		})
+	}
+
+	// Get client database queries
+	clientQueries, _, err := h.clientStore.GetClientQueries(ctx, clientID)
+	if err != nil {
+		h.logger.Errorw("Failed to get client queries", "error", err, "client_id", clientID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to access client data",
+		})
+	}
+
+	exception, err := clientQueries.ApproveQuestionException(ctx, clientdb.ApproveQuestionExceptionParams{
+		ID:          exceptionID,
+		ReviewedBy:  pgtype.UUID{Bytes: reviewerID, Valid: true},
+		ReviewNotes: req.Notes,
+		ExpiresAt:   pgtype.Date{Time: expiresAt, Valid: true},
+	})
+	if err != nil {
+		h.logger.Errorw("Failed to approve exception", "error", err, "exception_id", exceptionID)
+		return c.JSON(http.StatusConflict, map[string]string{
+			"error": "Exception not found or not awaiting review",
+		})
+	}
+
+	h.logger.Infow("Exception approved",
+		"exception_id", exceptionID,
+		"client_id", clientID,
+		"expires_at", req.ExpiresAt,
+		"reviewed_by", reviewerID)
+
+	return c.JSON(http.StatusOK, buildExceptionResponse(exception))
+}
+
+// RejectException rejects a requested exception
+func (h *Handler) RejectException(c echo.Context) error {
+	return h.reviewException(c, false)
+}
+
+// RevokeException revokes an approved exception before it expires
+func (h *Handler) RevokeException(c echo.Context) error {
+	return h.reviewException(c, true)
+}
+
+// ListExpiringExceptions lists approved exceptions of a client that expire
+// within the next "days" days (30 by default), including lapsed ones
+func (h *Handler) ListExpiringExceptions(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	clientID, err := uuid.Parse(c.Param("clientId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid client ID",
+		})
+	}
+
+	// Get client database queries
+	clientQueries, _, err := h.clientStore.GetClientQueries(ctx, clientID)
+	if err != nil {
+		h.logger.Errorw("Failed to get client queries", "error", err, "client_id", clientID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to access client data",
+		})
+	}
+
+	return h.listExpiringExceptions(c, clientQueries)
+}
+
+// RequestClientException lets a client POC request an exception for a question
+func (h *Handler) RequestClientException(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req RequestExceptionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request payload",
+		})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	questionID, err := uuid.Parse(req.QuestionID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid question ID",
+		})
+	}
+
+	clientID, err := getClientIDFromUser(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "Client ID not found in context",
+		})
+	}
+
+	userID, err := getUserIDFromUser(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	isPOC, err := isUserPOCRole(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "Failed to determine user role",
+		})
+	}
+
+	if !isPOC {
+		return c.JSON(http.StatusForbidden, map[string]string{
+			"error": "Only the client POC can request exceptions",
+		})
+	}
+
+	// Get client database queries
+	clientQueries, _, err := h.clientStore.GetClientQueries(ctx, clientID)
+	if err != nil {
+		h.logger.Errorw("Failed to get client queries", "error", err, "client_id", clientID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to access client data",
+		})
+	}
+
+	question, err := clientQueries.GetQuestionByID(ctx, questionID)
+	if err != nil {
+		h.logger.Errorw("Failed to get question", "error", err, "question_id", questionID)
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Question not found",
+		})
+	}
+
+	existing, err := clientQueries.ListQuestionExceptionsByAudit(ctx, question.AuditID)
+	if err != nil {
+		h.logger.Errorw("Failed to list exceptions", "error", err, "audit_id", question.AuditID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to request exception",
+		})
+	}
+
+	for _, e := range existing {
+		if e.QuestionID == questionID && (e.Status == clientdb.ExceptionStatusEnumRequested || e.Status == clientdb.ExceptionStatusEnumApproved) {
+			return c.JSON(http.StatusConflict, map[string]string{
+				"error": "An exception is already requested or approved for this question",
+			})
+		}
+	}
+
+	exception, err := clientQueries.CreateQuestionException(ctx, clientdb.CreateQuestionExceptionParams{
+		AuditID:              question.AuditID,
+		QuestionID:           questionID,
+		Justification:        req.Justification,
+		CompensatingControls: req.CompensatingControls,
+		RequestedBy:          userID,
+	})
+	if err != nil {
+		h.logger.Errorw("Failed to create exception", "error", err, "question_id", questionID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to request exception",
+		})
+	}
+
+	h.logger.Infow("Exception requested",
+		"exception_id", exception.ID,
+		"question_id", questionID,
+		"client_id", clientID,
+		"requested_by", userID)
+
+	return c.JSON(http.StatusCreated, buildExceptionResponse(exception))
+}
+
+// ListClientExceptions lists the exceptions of an audit for the authenticated client user
+func (h *Handler) ListClientExceptions(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	auditID, err := uuid.Parse(c.Param("auditId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid audit ID",
+		})
+	}
+
+	clientID, err := getClientIDFromUser(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "Client ID not found in context",
+		})
+	}
+
+	// Get client database queries
+	clientQueries, _, err := h.clientStore.GetClientQueries(ctx, clientID)
+	if err != nil {
+		h.logger.Errorw("Failed to get client queries", "error", err, "client_id", clientID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to access client data",
+		})
+	}
+
+	return h.listAuditExceptions(c, clientQueries, auditID)
+}
+
+// ListClientExpiringExceptions lists the authenticated client's exceptions that are due for renewal
+func (h *Handler) ListClientExpiringExceptions(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	clientID, err := getClientIDFromUser(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "Client ID not found in context",
+		})
+	}
+
+	// Get client database queries
+	clientQueries, _, err := h.clientStore.GetClientQueries(ctx, clientID)
+	if err != nil {
+		h.logger.Errorw("Failed to get client queries", "error", err, "client_id", clientID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to access client data",
+		})
+	}
+
+	return h.listExpiringExceptions(c, clientQueries)
+}
+
+// reviewException rejects a requested exception or revokes an approved one
+func (h *Handler) reviewException(c echo.Context, revoke bool) error {
+	ctx := c.Request().Context()
+
+	clientID, err := uuid.Parse(c.Param("clientId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid client ID",
+		})
+	}
+
+	exceptionID, err := uuid.Parse(c.Param("exceptionId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid exception ID",
+		})
+	}
+
+	var req ReviewExceptionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	reviewerID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User not authenticated",
+		})
+	}
+
+	// Get client database queries
+	clientQueries, _, err := h.clientStore.GetClientQueries(ctx, clientID)
+	if err != nil {
+		h.logger.Errorw("Failed to get client queries", "error", err, "client_id", clientID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to access client data",
+		})
+	}
+
+	var exception clientdb.QuestionException
+	if revoke {
+		exception, err = clientQueries.RevokeQuestionException(ctx, clientdb.RevokeQuestionExceptionParams{
+			ID:          exceptionID,
+			ReviewedBy:  pgtype.UUID{Bytes: reviewerID, Valid: true},
+			ReviewNotes: &req.Notes,
+		})
+	} else {
+		exception, err = clientQueries.RejectQuestionException(ctx, clientdb.RejectQuestionExceptionParams{
+			ID:          exceptionID,
+			ReviewedBy:  pgtype.UUID{Bytes: reviewerID, Valid: true},
+			ReviewNotes: &req.Notes,
+		})
+	}
+	if err != nil {
+		h.logger.Errorw("Failed to review exception", "error", err, "exception_id", exceptionID, "revoke", revoke)
+		return c.JSON(http.StatusConflict, map[string]string{
+			"error": "Exception not found or not in a reviewable state",
+		})
+	}
+
+	h.logger.Infow("Exception reviewed",
+		"exception_id", exceptionID,
+		"client_id", clientID,
+		"status", exception.Status,
+		"reviewed_by", reviewerID)
+
+	return c.JSON(http.StatusOK, buildExceptionResponse(exception))
+}
+
+func (h *Handler) listAuditExceptions(c echo.Context, clientQueries *clientdb.Queries, auditID uuid.UUID) error {
+	exceptions, err := clientQueries.ListQuestionExceptionsByAudit(c.Request().Context(), auditID)
+	if err != nil {
+		h.logger.Errorw("Failed to list exceptions", "error", err, "audit_id", auditID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve exceptions",
+		})
+	}
+
+	responses := make([]ExceptionResponse, 0, len(exceptions))
+	for _, exception := range exceptions {
+		responses = append(responses, buildExceptionResponse(exception))
+	}
+
+	return c.JSON(http.StatusOK, responses)
+}
+
+func (h *Handler) listExpiringExceptions(c echo.Context, clientQueries *clientdb.Queries) error {
+	days := defaultExceptionReminderDays
+	if d := c.QueryParam("days"); d != "" {
+		parsed, err := strconv.Atoi(d)
+		if err != nil || parsed < 0 {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid days parameter",
+			})
+		}
+		days = parsed
+	}
+
+	now := today()
+	cutoff := now.AddDate(0, 0, days)
+
+	rows, err := clientQueries.ListExpiringExceptions(c.Request().Context(), pgtype.Date{Time: cutoff, Valid: true})
+	if err != nil {
+		h.logger.Errorw("Failed to list expiring exceptions", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve expiring exceptions",
+		})
+	}
+
+	responses := make([]ExpiringExceptionResponse, 0, len(rows))
+	for _, row := range rows {
+		responses = append(responses, ExpiringExceptionResponse{
+			ExceptionResponse: buildExceptionResponse(clientdb.QuestionException{
+				ID:                   row.ID,
+				AuditID:              row.AuditID,
+				QuestionID:           row.QuestionID,
+				Justification:        row.Justification,
+				CompensatingControls: row.CompensatingControls,
+				Status:               row.Status,
+				RequestedBy:          row.RequestedBy,
+				RequestedAt:          row.RequestedAt,
+				ReviewedBy:           row.ReviewedBy,
+				ReviewedAt:           row.ReviewedAt,
+				ReviewNotes:          row.ReviewNotes,
+				ExpiresAt:            row.ExpiresAt,
+				CarriedForwardFrom:   row.CarriedForwardFrom,
+				CreatedAt:            row.CreatedAt,
+				UpdatedAt:            row.UpdatedAt,
+			}),
+			Section:        row.Section,
+			QuestionNumber: row.QuestionNumber,
+			QuestionText:   row.QuestionText,
+			FrameworkID:    row.FrameworkID.String(),
+			FrameworkName:  row.FrameworkName,
+			DaysRemaining:  int(row.ExpiresAt.Time.Sub(now).Hours() / 24),
+		})
+	}
+
+	return c.JSON(http.StatusOK, responses)
+}
+
+// Helper functions
+
+// today returns the current date at midnight UTC, matching how DATE columns are scanned
+func today() time.Time {
+	return time.Now().UTC().Truncate(24 * time.Hour)
+}
+
+// isExceptionActive reports whether an exception currently satisfies its question
+func isExceptionActive(exception clientdb.QuestionException) bool {
+	return exception.Status == clientdb.ExceptionStatusEnumApproved &&
+		exception.ExpiresAt.Valid &&
+		!exception.ExpiresAt.Time.Before(today())
+}
+
+func buildExceptionResponse(exception clientdb.QuestionException) ExceptionResponse {
+	var expiresAt *string
+	if exception.ExpiresAt.Valid {
+		ea := exception.ExpiresAt.Time.Format("2006-01-02")
+		expiresAt = &ea
+	}
+
+	return ExceptionResponse{
+		ID:                   exception.ID.String(),
+		AuditID:              exception.AuditID.String(),
+		QuestionID:           exception.QuestionID.String(),
+		Justification:        exception.Justification,
+		CompensatingControls: exception.CompensatingControls,
+		Status:               string(exception.Status),
+		Active:               isExceptionActive(exception),
+		RequestedBy:          exception.RequestedBy.String(),
+		RequestedAt:          exception.RequestedAt.Time.Format(time.RFC3339),
+		ReviewedBy:           formatOptionalUUID(exception.ReviewedBy),
+		ReviewedAt:           formatOptionalTime(exception.ReviewedAt),
+		ReviewNotes:          exception.ReviewNotes,
+		ExpiresAt:            expiresAt,
+		CarriedForwardFrom:   formatOptionalUUID(exception.CarriedForwardFrom),
+		CreatedAt:            exception.CreatedAt.Time.Format(time.RFC3339),
+		UpdatedAt:            exception.UpdatedAt.Time.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientdb"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestIsExceptionActive(t *testing.T) {
+	date := func(days int) pgtype.Date {
+		return pgtype.Date{Time: today().AddDate(0, 0, days), Valid: true}
+	}
+
+	tests := []struct {
+		name      string
+		exception clientdb.QuestionException
+		want      bool
+	}{
+		{
+			name:      "approved until next month",
+			exception: clientdb.QuestionException{Status: clientdb.ExceptionStatusEnumApproved, ExpiresAt: date(30)},
+			want:      true,
+		},
+		{
+			name:      "approved until today",
+			exception: clientdb.QuestionException{Status: clientdb.ExceptionStatusEnumApproved, ExpiresAt: date(0)},
+			want:      true,
+		},
+		{
+			name:      "approved but lapsed",
+			exception: clientdb.QuestionException{Status: clientdb.ExceptionStatusEnumApproved, ExpiresAt: date(-1)},
+		},
+		{
+			name:      "approved without expiry",
+			exception: clientdb.QuestionException{Status: clientdb.ExceptionStatusEnumApproved},
+		},
+		{
+			name:      "requested",
+			exception: clientdb.QuestionException{Status: clientdb.ExceptionStatusEnumRequested, ExpiresAt: date(30)},
+		},
+		{
+			name:      "revoked",
+			exception: clientdb.QuestionException{Status: clientdb.ExceptionStatusEnumRevoked, ExpiresAt: date(30)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isExceptionActive(tt.exception); got != tt.want {
+				t.Errorf("isExceptionActive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildExceptionResponse(t *testing.T) {
+	expiresAt := time.Date(2099, time.March, 31, 0, 0, 0, 0, time.UTC)
+	exception := clientdb.QuestionException{
+		ID:            uuid.New(),
+		AuditID:       uuid.New(),
+		QuestionID:    uuid.New(),
+		Justification: "Legacy system is being decommissioned",
+		Status:        clientdb.ExceptionStatusEnumApproved,
+		RequestedBy:   uuid.New(),
+		ExpiresAt:     pgtype.Date{Time: expiresAt, Valid: true},
+	}
+
+	got := buildExceptionResponse(exception)
+	if !got.Active {
+		t.Error("Active = false, want true")
+	}
+	if got.ExpiresAt == nil || *got.ExpiresAt != "2099-03-31" {
+		t.Errorf("ExpiresAt = %v, want 2099-03-31", got.ExpiresAt)
+	}
+	if got.ReviewedBy != nil || got.CarriedForwardFrom != nil {
+		t.Errorf("ReviewedBy = %v CarriedForwardFrom = %v, want nil", got.ReviewedBy, got.CarriedForwardFrom)
+	}
+}
+
+func TestEvaluateReportReadinessCountsExceptions(t *testing.T) {
+	excepted := readinessRow("1.1", true, "", 0)
+	excepted.HasActiveException = true
+
+	got := evaluateReportReadiness(uuid.New(), []clientdb.ListQuestionReadinessRow{
+		excepted,
+		readinessRow("1.2", true, clientdb.SubmissionStatusEnumApproved, 1),
+	})
+
+	if !got.Ready {
+		t.Errorf("Ready = false, blocking = %+v", got.BlockingQuestions)
+	}
+	if got.ExceptedQuestions != 1 || got.MandatoryQuestions != 2 || got.ApprovedMandatory != 1 {
+		t.Errorf("excepted = %d mandatory = %d approved = %d, want 1, 2 and 1",
+			got.ExceptedQuestions, got.MandatoryQuestions, got.ApprovedMandatory)
+	}
+}
@@ -0,0 +1,979 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientdb"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/minio/minio-go/v7"
+)
+
+// FindingResponse represents a finding in API responses
+type FindingResponse struct {
+	ID              string                    `json:"id"`
+	AuditID         string                    `json:"audit_id"`
+	QuestionID      string                    `json:"question_id"`
+	SubmissionID    *string                   `json:"submission_id"`
+	Title           string                    `json:"title"`
+	Description     *string                   `json:"description"`
+	Severity        string                    `json:"severity"`
+	Status          string                    `json:"status"`
+	OwnerID         *string                   `json:"owner_id"`
+	TargetDate      *string                   `json:"target_date"`
+	RemediationPlan *string                   `json:"remediation_plan"`
+	RaisedBy        string                    `json:"raised_by"`
+	VerifiedBy      *string                   `json:"verified_by"`
+	VerifiedAt      *string                   `json:"verified_at"`
+	ClosedBy        *string                   `json:"closed_by"`
+	ClosedAt        *string                   `json:"closed_at"`
+	ClosureNotes    *string                   `json:"closure_notes"`
+	Evidence        []FindingEvidenceResponse `json:"evidence,omitempty"`
+	CreatedAt       string                    `json:"created_at"`
+	UpdatedAt       string                    `json:"updated_at"`
+}
+
+// FindingEvidenceResponse represents a closure evidence file in API responses
+type FindingEvidenceResponse struct {
+	ID          string  `json:"id"`
+	FindingID   string  `json:"finding_id"`
+	FileName    string  `json:"file_name"`
+	FileType    *string `json:"file_type"`
+	FileSize    int64   `json:"file_size"`
+	UploadedBy  string  `json:"uploaded_by"`
+	UploadedAt  string  `json:"uploaded_at"`
+	Description *string `json:"description"`
+}
+
+// CreateFindingRequest represents the request to raise a finding
+type CreateFindingRequest struct {
+	QuestionID      string  `json:"question_id" validate:"required"`
+	SubmissionID    *string `json:"submission_id"`
+	Title           string  `json:"title" validate:"required"`
+	Description     *string `json:"description"`
+	Severity        string  `json:"severity" validate:"omitempty,oneof=low medium high critical"`
+	OwnerID         *string `json:"owner_id"`
+	TargetDate      *string `json:"target_date"`
+	RemediationPlan *string `json:"remediation_plan"`
+}
+
+// UpdateFindingRequest represents the request to update finding details
+type UpdateFindingRequest struct {
+	Title           string  `json:"title" validate:"required"`
+	Description     *string `json:"description"`
+	Severity        string  `json:"severity" validate:"required,oneof=low medium high critical"`
+	OwnerID         *string `json:"owner_id"`
+	TargetDate      *string `json:"target_date"`
+	RemediationPlan *string `json:"remediation_plan"`
+}
+
+// UpdateFindingStatusRequest represents a status transition of a finding
+type UpdateFindingStatusRequest struct {
+	Status string  `json:"status" validate:"required,oneof=open in_remediation verified closed risk_accepted"`
+	Notes  *string `json:"notes"`
+}
+
+// UpdateFindingRemediationRequest represents the client's remediation plan for a finding
+type UpdateFindingRemediationRequest struct {
+	RemediationPlan string  `json:"remediation_plan" validate:"required"`
+	TargetDate      *string `json:"target_date"`
+}
+
+// findingTransitions lists the statuses a finding may move to from each status
+var findingTransitions = map[clientdb.FindingStatusEnum][]clientdb.FindingStatusEnum{
+	clientdb.FindingStatusEnumOpen: {
+		clientdb.FindingStatusEnumInRemediation,
+		clientdb.FindingStatusEnumClosed,
+		clientdb.FindingStatusEnumRiskAccepted,
+	},
+	clientdb.FindingStatusEnumInRemediation: {
+		clientdb.FindingStatusEnumOpen,
+		clientdb.FindingStatusEnumVerified,
+		clientdb.FindingStatusEnumRiskAccepted,
+	},
+	clientdb.FindingStatusEnumVerified: {
+		clientdb.FindingStatusEnumInRemediation,
+		clientdb.FindingStatusEnumClosed,
+	},
+	clientdb.FindingStatusEnumClosed: {
+		clientdb.FindingStatusEnumOpen,
+	},
+	clientdb.FindingStatusEnumRiskAccepted: {
+		clientdb.FindingStatusEnumOpen,
+	},
+}
+
+// canTransitionFinding reports whether a finding may move from one status to another
+func canTransitionFinding(from, to clientdb.FindingStatusEnum) bool {
+	for _, next := range findingTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateFinding raises a finding against a question of an audit
+func (h *Handler) CreateFinding(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	clientID, err := uuid.Parse(c.Param("clientId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid client ID",
+		})
+	}
+
+	var req CreateFindingRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	questionID, err := uuid.Parse(req.QuestionID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid question ID",
+		})
+	}
+
+	submissionID, err := parseOptionalUUID(req.SubmissionID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid submission ID",
+		})
+	}
+
+	ownerID, err := parseOptionalUUID(req.OwnerID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid owner ID",
+		})
+	}
+
+	targetDate, err := parseOptionalDate(req.TargetDate)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid target_date format. Use YYYY-MM-DD",
+		})
+	}
+
+	raisedBy, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User not authenticated",
+		})
+	}
+
+	// Get client database queries
+	clientQueries, _, err := h.clientStore.GetClientQueries(ctx, clientID)
+	if err != nil {
+		h.logger.Errorw("Failed to get client queries", "error", err, "client_id", clientID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to access client data",
+		})
+	}
+
+	question, err := clientQueries.GetQuestionByID(ctx, questionID)
+	if err != nil {
+		h.logger.Errorw("Failed to get question", "error", err, "question_id", questionID)
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Question not found",
+		})
+	}
+
+	if submissionID.Valid {
+		submission, err := clientQueries.GetSubmissionByID(ctx, submissionID.Bytes)
+		if err != nil || submission.QuestionID != questionID {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Submission does not belong to the question",
+			})
+		}
+	}
+
+	// Findings default to the severity of the question they are raised against
+	severity := question.Severity
+	if req.Severity != "" {
+		severity = clientdb.QuestionSeverityEnum(req.Severity)
+	}
+
+	finding, err := clientQueries.CreateFinding(ctx, clientdb.CreateFindingParams{
+		AuditID:         question.AuditID,
+		QuestionID:      questionID,
+		SubmissionID:    submissionID,
+		Title:           req.Title,
+		Description:     req.Description,
+		Severity:        severity,
+		OwnerID:         ownerID,
+		TargetDate:      targetDate,
+		RemediationPlan: req.RemediationPlan,
+		RaisedBy:        raisedBy,
+	})
+	if err != nil {
+		h.logger.Errorw("Failed to create finding", "error", err, "question_id", questionID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to create finding",
+		})
+	}
+
+	h.logger.Infow("Finding raised",
+		"finding_id", finding.ID,
+		"question_id", questionID,
+		"client_id", clientID,
+		"severity", finding.Severity)
+
+	return c.JSON(http.StatusCreated, buildFindingResponse(finding, nil))
+}
+
+// ListAuditFindings lists all findings raised in an audit
+func (h *Handler) ListAuditFindings(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	clientID, err := uuid.Parse(c.Param("clientId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid client ID",
+		})
+	}
+
+	auditID, err := uuid.Parse(c.Param("auditId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid audit ID",
+		})
+	}
+
+	// Get client database queries
+	clientQueries, _, err := h.clientStore.GetClientQueries(ctx, clientID)
+	if err != nil {
+		h.logger.Errorw("Failed to get client queries", "error", err, "client_id", clientID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to access client data",
+		})
+	}
+
+	findings, err := clientQueries.ListFindingsByAudit(ctx, auditID)
+	if err != nil {
+		h.logger.Errorw("Failed to list findings", "error", err, "audit_id", auditID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve findings",
+		})
+	}
+
+	status := c.QueryParam("status")
+
+	responses := make([]FindingResponse, 0, len(findings))
+	for _, finding := range findings {
+		if status != "" && string(finding.Status) != status {
+			continue
+		}
+		responses = append(responses, buildFindingResponse(finding, nil))
+	}
+
+	return c.JSON(http.StatusOK, responses)
+}
+
+// GetFinding retrieves a finding with its closure evidence
+func (h *Handler) GetFinding(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	clientID, err := uuid.Parse(c.Param("clientId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid client ID",
+		})
+	}
+
+	findingID, err := uuid.Parse(c.Param("findingId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid finding ID",
+		})
+	}
+
+	// Get client database queries
+	clientQueries, _, err := h.clientStore.GetClientQueries(ctx, clientID)
+	if err != nil {
+		h.logger.Errorw("Failed to get client queries", "error", err, "client_id", clientID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to access client data",
+		})
+	}
+
+	finding, err := clientQueries.GetFindingByID(ctx, findingID)
+	if err != nil {
+		h.logger.Errorw("Failed to get finding", "error", err, "finding_id", findingID)
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Finding not found",
+		})
+	}
+
+	evidence, err := clientQueries.ListFindingEvidence(ctx, findingID)
+	if err != nil {
+		h.logger.Errorw("Failed to list finding evidence", "error", err, "finding_id", findingID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve finding evidence",
+		})
+	}
+
+	return c.JSON(http.StatusOK, buildFindingResponse(finding, evidence))
+}
+
+// UpdateFinding updates the details of a finding
+func (h *Handler) UpdateFinding(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	clientID, err := uuid.Parse(c.Param("clientId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid client ID",
+		})
+	}
+
+	findingID, err := uuid.Parse(c.Param("findingId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid finding ID",
+		})
+	}
+
+	var req UpdateFindingRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	ownerID, err := parseOptionalUUID(req.OwnerID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid owner ID",
+		})
+	}
+
+	targetDate, err := parseOptionalDate(req.TargetDate)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid target_date format. Use YYYY-MM-DD",
+		})
+	}
+
+	// Get client database queries
+	clientQueries, _, err := h.clientStore.GetClientQueries(ctx, clientID)
+	if err != nil {
+		h.logger.Errorw("Failed to get client queries", "error", err, "client_id", clientID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to access client data",
+		})
+	}
+
+	finding, err := clientQueries.UpdateFinding(ctx, clientdb.UpdateFindingParams{
+		ID:              findingID,
+		Title:           req.Title,
+		Description:     req.Description,
+		Severity:        clientdb.QuestionSeverityEnum(req.Severity),
+		OwnerID:         ownerID,
+		TargetDate:      targetDate,
+		RemediationPlan: req.RemediationPlan,
+	})
+	if err != nil {
+		h.logger.Errorw("Failed to update finding", "error", err, "finding_id", findingID)
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Finding not found",
+		})
+	}
+
+	return c.JSON(http.StatusOK, buildFindingResponse(finding, nil))
+}
+
+// UpdateFindingStatus moves a finding through its lifecycle.
+// Verifying or closing a finding requires closure evidence, and accepting the
+// risk requires a justification in the notes.
+func (h *Handler) UpdateFindingStatus(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	clientID, err := uuid.Parse(c.Param("clientId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid client ID",
+		})
+	}
+
+	findingID, err := uuid.Parse(c.Param("findingId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid finding ID",
+		})
+	}
+
+	var req UpdateFindingStatusRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User not authenticated",
+		})
+	}
+
+	// Get client database queries
+	clientQueries, _, err := h.clientStore.GetClientQueries(ctx, clientID)
+	if err != nil {
+		h.logger.Errorw("Failed to get client queries", "error", err, "client_id", clientID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to access client data",
+		})
+	}
+
+	finding, err := clientQueries.GetFindingByID(ctx, findingID)
+	if err != nil {
+		h.logger.Errorw("Failed to get finding", "error", err, "finding_id", findingID)
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Finding not found",
+		})
+	}
+
+	next := clientdb.FindingStatusEnum(req.Status)
+	if !canTransitionFinding(finding.Status, next) {
+		return c.JSON(http.StatusConflict, map[string]string{
+			"error": fmt.Sprintf("Cannot move finding from %s to %s", finding.Status, next),
+		})
+	}
+
+	if next == clientdb.FindingStatusEnumVerified || next == clientdb.FindingStatusEnumClosed {
+		evidenceCount, err := clientQueries.CountFindingEvidence(ctx, findingID)
+		if err != nil {
+			h.logger.Errorw("Failed to count finding evidence", "error", err, "finding_id", findingID)
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Failed to update finding",
+			})
+		}
+		if evidenceCount == 0 {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Closure evidence is required before a finding can be verified or closed",
+			})
+		}
+	}
+
+	if next == clientdb.FindingStatusEnumRiskAccepted && (req.Notes == nil || *req.Notes == "") {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "A justification is required to accept the risk",
+		})
+	}
+
+	switch next {
+	case clientdb.FindingStatusEnumVerified:
+		finding, err = clientQueries.VerifyFinding(ctx, clientdb.VerifyFindingParams{
+			ID:         findingID,
+			VerifiedBy: pgtype.UUID{Bytes: userID, Valid: true},
+		})
+	case clientdb.FindingStatusEnumClosed, clientdb.FindingStatusEnumRiskAccepted:
+		finding, err = clientQueries.CloseFinding(ctx, clientdb.CloseFindingParams{
+			ID:           findingID,
+			Status:       next,
+			ClosedBy:     pgtype.UUID{Bytes: userID, Valid: true},
+			ClosureNotes: req.Notes,
+		})
+	case clientdb.FindingStatusEnumOpen:
+		finding, err = clientQueries.ReopenFinding(ctx, findingID)
+	default:
+		finding, err = clientQueries.UpdateFindingStatus(ctx, clientdb.UpdateFindingStatusParams{
+			ID:     findingID,
+			Status: next,
+		})
+	}
+	if err != nil {
+		h.logger.Errorw("Failed to update finding status", "error", err, "finding_id", findingID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to update finding",
+		})
+	}
+
+	h.logger.Infow("Finding status updated",
+		"finding_id", findingID,
+		"client_id", clientID,
+		"status", finding.Status,
+		"user_id", userID)
+
+	return c.JSON(http.StatusOK, buildFindingResponse(finding, nil))
+}
+
+// UploadFindingEvidence uploads a closure evidence file for a finding
+func (h *Handler) UploadFindingEvidence(c echo.Context) error {
+	clientID, err := uuid.Parse(c.Param("clientId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid client ID",
+		})
+	}
+
+	uploadedBy, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User not authenticated",
+		})
+	}
+
+	return h.storeFindingEvidence(c, clientID, uploadedBy, nil)
+}
+
+// DeleteFinding deletes a finding raised in error
+func (h *Handler) DeleteFinding(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	clientID, err := uuid.Parse(c.Param("clientId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid client ID",
+		})
+	}
+
+	findingID, err := uuid.Parse(c.Param("findingId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid finding ID",
+		})
+	}
+
+	// Get client database queries
+	clientQueries, _, err := h.clientStore.GetClientQueries(ctx, clientID)
+	if err != nil {
+		h.logger.Errorw("Failed to get client queries", "error", err, "client_id", clientID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to access client data",
+		})
+	}
+
+	if err := clientQueries.DeleteFinding(ctx, findingID); err != nil {
+		h.logger.Errorw("Failed to delete finding", "error", err, "finding_id", findingID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to delete finding",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Finding deleted successfully",
+	})
+}
+
+// ListClientFindings lists the findings of an audit for the authenticated client user.
+// POC users see every finding, other users only the findings they own.
+func (h *Handler) ListClientFindings(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	auditID, err := uuid.Parse(c.Param("auditId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid audit ID",
+		})
+	}
+
+	clientID, err := getClientIDFromUser(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "Client ID not found in context",
+		})
+	}
+
+	userID, err := getUserIDFromUser(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	isPOC, err := isUserPOCRole(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "Failed to determine user role",
+		})
+	}
+
+	// Get client database queries
+	clientQueries, _, err := h.clientStore.GetClientQueries(ctx, clientID)
+	if err != nil {
+		h.logger.Errorw("Failed to get client queries", "error", err, "client_id", clientID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to access client data",
+		})
+	}
+
+	findings, err := clientQueries.ListFindingsByAudit(ctx, auditID)
+	if err != nil {
+		h.logger.Errorw("Failed to list findings", "error", err, "audit_id", auditID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve findings",
+		})
+	}
+
+	responses := make([]FindingResponse, 0, len(findings))
+	for _, finding := range findings {
+		if !isPOC && !isFindingOwner(finding, userID) {
+			continue
+		}
+		responses = append(responses, buildFindingResponse(finding, nil))
+	}
+
+	return c.JSON(http.StatusOK, responses)
+}
+
+// UpdateClientFindingRemediation records the client's remediation plan and
+// moves an open finding into remediation
+func (h *Handler) UpdateClientFindingRemediation(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	findingID, err := uuid.Parse(c.Param("findingId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid finding ID",
+		})
+	}
+
+	var req UpdateFindingRemediationRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request payload",
+		})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	targetDate, err := parseOptionalDate(req.TargetDate)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid target_date format. Use YYYY-MM-DD",
+		})
+	}
+
+	clientID, err := getClientIDFromUser(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "Client ID not found in context",
+		})
+	}
+
+	userID, err := getUserIDFromUser(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	isPOC, err := isUserPOCRole(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "Failed to determine user role",
+		})
+	}
+
+	// Get client database queries
+	clientQueries, _, err := h.clientStore.GetClientQueries(ctx, clientID)
+	if err != nil {
+		h.logger.Errorw("Failed to get client queries", "error", err, "client_id", clientID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to access client data",
+		})
+	}
+
+	finding, err := clientQueries.GetFindingByID(ctx, findingID)
+	if err != nil || (!isPOC && !isFindingOwner(finding, userID)) {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Finding not found",
+		})
+	}
+
+	if finding.Status != clientdb.FindingStatusEnumOpen && finding.Status != clientdb.FindingStatusEnumInRemediation {
+		return c.JSON(http.StatusConflict, map[string]string{
+			"error": fmt.Sprintf("Remediation cannot be changed while the finding is %s", finding.Status),
+		})
+	}
+
+	if !targetDate.Valid {
+		targetDate = finding.TargetDate
+	}
+
+	finding, err = clientQueries.UpdateFindingRemediation(ctx, clientdb.UpdateFindingRemediationParams{
+		ID:              findingID,
+		RemediationPlan: &req.RemediationPlan,
+		TargetDate:      targetDate,
+	})
+	if err == nil && finding.Status == clientdb.FindingStatusEnumOpen {
+		finding, err = clientQueries.UpdateFindingStatus(ctx, clientdb.UpdateFindingStatusParams{
+			ID:     findingID,
+			Status: clientdb.FindingStatusEnumInRemediation,
+		})
+	}
+	if err != nil {
+		h.logger.Errorw("Failed to update finding remediation", "error", err, "finding_id", findingID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to update finding",
+		})
+	}
+
+	return c.JSON(http.StatusOK, buildFindingResponse(finding, nil))
+}
+
+// UploadClientFindingEvidence uploads closure evidence for a finding from the client side
+func (h *Handler) UploadClientFindingEvidence(c echo.Context) error {
+	clientID, err := getClientIDFromUser(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "Client ID not found in context",
+		})
+	}
+
+	userID, err := getUserIDFromUser(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	isPOC, err := isUserPOCRole(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "Failed to determine user role",
+		})
+	}
+
+	// Non-POC users may only upload evidence for findings they own
+	authorize := func(finding clientdb.Finding) bool {
+		return isPOC || isFindingOwner(finding, userID)
+	}
+
+	return h.storeFindingEvidence(c, clientID, userID, authorize)
+}
+
+// storeFindingEvidence uploads the "file" form field to MinIO and records it
+// as closure evidence of the finding in the path
+func (h *Handler) storeFindingEvidence(c echo.Context, clientID, uploadedBy uuid.UUID, authorize func(clientdb.Finding) bool) error {
+	ctx := c.Request().Context()
+
+	findingID, err := uuid.Parse(c.Param("findingId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid finding ID",
+		})
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "File is required",
+		})
+	}
+
+	// Validate file size
+	if file.Size > maxFileSize {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": fmt.Sprintf("File size exceeds maximum allowed size of %dMB", maxFileSize/(1024*1024)),
+		})
+	}
+
+	// Validate file type
+	ext := strings.ToLower(filepath.Ext(file.Filename))
+	if !allowedFileTypes[ext] {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": fmt.Sprintf("File type %s is not allowed", ext),
+		})
+	}
+
+	// Get client database queries
+	clientQueries, _, err := h.clientStore.GetClientQueries(ctx, clientID)
+	if err != nil {
+		h.logger.Errorw("Failed to get client queries", "error", err, "client_id", clientID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to access client data",
+		})
+	}
+
+	finding, err := clientQueries.GetFindingByID(ctx, findingID)
+	if err != nil || (authorize != nil && !authorize(finding)) {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Finding not found",
+		})
+	}
+
+	if finding.Status == clientdb.FindingStatusEnumClosed || finding.Status == clientdb.FindingStatusEnumRiskAccepted {
+		return c.JSON(http.StatusConflict, map[string]string{
+			"error": "Evidence cannot be added to a resolved finding",
+		})
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		h.logger.Errorw("Failed to open uploaded file", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to process file",
+		})
+	}
+	defer src.Close()
+
+	objectName := fmt.Sprintf("findings/%s/%s%s", findingID.String(), uuid.New().String(), ext)
+	bucketName := fmt.Sprintf("client-%s", clientID.String()[:8])
+
+	_, err = h.minio.PutObject(ctx, bucketName, objectName, src, file.Size, minio.PutObjectOptions{
+		ContentType: file.Header.Get("Content-Type"),
+	})
+	if err != nil {
+		h.logger.Errorw("Failed to upload to MinIO", "error", err, "bucket", bucketName, "object", objectName)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to upload file",
+		})
+	}
+
+	var desc *string
+	if description := c.FormValue("description"); description != "" {
+		desc = &description
+	}
+
+	evidence, err := clientQueries.CreateFindingEvidence(ctx, clientdb.CreateFindingEvidenceParams{
+		FindingID:   findingID,
+		FileName:    file.Filename,
+		FilePath:    objectName,
+		FileSize:    file.Size,
+		FileType:    &ext,
+		UploadedBy:  uploadedBy,
+		Description: desc,
+	})
+	if err != nil {
+		h.logger.Errorw("Failed to create finding evidence record", "error", err)
+		// Try to delete the uploaded file
+		h.minio.RemoveObject(ctx, bucketName, objectName, minio.RemoveObjectOptions{})
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to create evidence record",
+		})
+	}
+
+	h.logger.Infow("Finding evidence uploaded",
+		"finding_id", findingID,
+		"client_id", clientID,
+		"file_name", file.Filename)
+
+	return c.JSON(http.StatusCreated, buildFindingEvidenceResponse(evidence))
+}
+
+// Helper functions
+
+func isFindingOwner(finding clientdb.Finding, userID uuid.UUID) bool {
+	return finding.OwnerID.Valid && uuid.UUID(finding.OwnerID.Bytes) == userID
+}
+
+func parseOptionalUUID(value *string) (pgtype.UUID, error) {
+	if value == nil || *value == "" {
+		return pgtype.UUID{}, nil
+	}
+	id, err := uuid.Parse(*value)
+	if err != nil {
+		return pgtype.UUID{}, err
+	}
+	return pgtype.UUID{Bytes: id, Valid: true}, nil
+}
+
+func parseOptionalDate(value *string) (pgtype.Date, error) {
+	if value == nil || *value == "" {
+		return pgtype.Date{}, nil
+	}
+	date, err := time.Parse("2006-01-02", *value)
+	if err != nil {
+		return pgtype.Date{}, err
+	}
+	return pgtype.Date{Time: date, Valid: true}, nil
+}
+
+func formatOptionalUUID(id pgtype.UUID) *string {
+	if !id.Valid {
+		return nil
+	}
+	s := uuid.UUID(id.Bytes).String()
+	return &s
+}
+
+func formatOptionalTime(t pgtype.Timestamptz) *string {
+	if !t.Valid {
+		return nil
+	}
+	s := t.Time.Format(time.RFC3339)
+	return &s
+}
+
+func buildFindingResponse(finding clientdb.Finding, evidence []clientdb.FindingEvidence) FindingResponse {
+	var targetDate *string
+	if finding.TargetDate.Valid {
+		td := finding.TargetDate.Time.Format("2006-01-02")
+		targetDate = &td
+	}
+
+	var evidenceResponses []FindingEvidenceResponse
+	for _, e := range evidence {
+		evidenceResponses = append(evidenceResponses, buildFindingEvidenceResponse(e))
+	}
+
+	return FindingResponse{
+		ID:              finding.ID.String(),
+		AuditID:         finding.AuditID.String(),
+		QuestionID:      finding.QuestionID.String(),
+		SubmissionID:    formatOptionalUUID(finding.SubmissionID),
+		Title:           finding.Title,
+		Description:     finding.Description,
+		Severity:        string(finding.Severity),
+		Status:          string(finding.Status),
+		OwnerID:         formatOptionalUUID(finding.OwnerID),
+		TargetDate:      targetDate,
+		RemediationPlan: finding.RemediationPlan,
+		RaisedBy:        finding.RaisedBy.String(),
+		VerifiedBy:      formatOptionalUUID(finding.VerifiedBy),
+		VerifiedAt:      formatOptionalTime(finding.VerifiedAt),
+		ClosedBy:        formatOptionalUUID(finding.ClosedBy),
+		ClosedAt:        formatOptionalTime(finding.ClosedAt),
+		ClosureNotes:    finding.ClosureNotes,
+		Evidence:        evidenceResponses,
+		CreatedAt:       finding.CreatedAt.Time.Format(time.RFC3339),
+		UpdatedAt:       finding.UpdatedAt.Time.Format(time.RFC3339),
+	}
+}
+
+func buildFindingEvidenceResponse(evidence clientdb.FindingEvidence) FindingEvidenceResponse {
+	return FindingEvidenceResponse{
+		ID:          evidence.ID.String(),
+		FindingID:   evidence.FindingID.String(),
+		FileName:    evidence.FileName,
+		FileType:    evidence.FileType,
+		FileSize:    evidence.FileSize,
+		UploadedBy:  evidence.UploadedBy.String(),
+		UploadedAt:  evidence.UploadedAt.Time.Format(time.RFC3339),
+		Description: evidence.Description,
+	}
+}
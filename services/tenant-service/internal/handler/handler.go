@@ -1,26 +1,34 @@
 package handler
 
 import (
+	"github.com/NormaTech-AI/audity/packages/go/eventbus"
 	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientstore"
 	"github.com/NormaTech-AI/audity/services/tenant-service/internal/config"
 	"github.com/NormaTech-AI/audity/services/tenant-service/internal/crypto"
 	"github.com/NormaTech-AI/audity/services/tenant-service/internal/framework"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/mail"
 	"github.com/NormaTech-AI/audity/services/tenant-service/internal/migrations"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/notification"
 	"github.com/NormaTech-AI/audity/services/tenant-service/internal/store"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/webhook"
 	"github.com/minio/minio-go/v7"
 	"go.uber.org/zap"
 )
 
 // Handler holds all dependencies for HTTP handlers
 type Handler struct {
-	store                  *store.Store
-	config                 *config.Config
-	encryptor              *crypto.Encryptor
-	minio                  *minio.Client
-	logger                 *zap.SugaredLogger
-	clientMigrationRunner  *migrations.ClientMigrationRunner
-	clientStore            *clientstore.ClientStore
-	frameworkService       *framework.Service
+	store                 *store.Store
+	config                *config.Config
+	encryptor             *crypto.Encryptor
+	minio                 *minio.Client
+	logger                *zap.SugaredLogger
+	clientMigrationRunner *migrations.ClientMigrationRunner
+	clientStore           *clientstore.ClientStore
+	frameworkService      *framework.Service
+	mail                  *mail.MailService
+	notifications         *notification.Service
+	relay                 *eventbus.Relay
+	webhooks              *webhook.Dispatcher
 }
 
 // NewHandler creates a new Handler instance
@@ -33,6 +41,10 @@ func NewHandler(
 	clientMigrationRunner *migrations.ClientMigrationRunner,
 	clientStore *clientstore.ClientStore,
 	frameworkService *framework.Service,
+	mailService *mail.MailService,
+	notifications *notification.Service,
+	relay *eventbus.Relay,
+	webhooks *webhook.Dispatcher,
 ) *Handler {
 	return &Handler{
 		store:                 store,
@@ -43,5 +55,9 @@ func NewHandler(
 		clientMigrationRunner: clientMigrationRunner,
 		clientStore:           clientStore,
 		frameworkService:      frameworkService,
+		mail:                  mailService,
+		notifications:         notifications,
+		relay:                 relay,
+		webhooks:              webhooks,
 	}
 }
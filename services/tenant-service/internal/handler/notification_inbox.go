@@ -0,0 +1,287 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/db"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/notification"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+)
+
+// NotificationResponse represents a notification in the inbox of a user
+type NotificationResponse struct {
+	ID        string     `json:"id"`
+	Type      string     `json:"type"`
+	ClientID  *string    `json:"client_id,omitempty"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	Link      *string    `json:"link,omitempty"`
+	Read      bool       `json:"read"`
+	ReadAt    *time.Time `json:"read_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// NotificationPreferenceItem is the channel of one notification type
+type NotificationPreferenceItem struct {
+	Type    string `json:"type" validate:"required"`
+	Channel string `json:"channel" validate:"required"`
+}
+
+// UpdateNotificationPreferencesRequest sets the channels of notification
+// types; types left out keep their channel
+type UpdateNotificationPreferencesRequest struct {
+	Preferences []NotificationPreferenceItem `json:"preferences" validate:"required,min=1,dive"`
+}
+
+// ListMyNotifications lists the notifications of the authenticated user,
+// newest first. ?unread=true only lists the unread ones.
+func (h *Handler) ListMyNotifications(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	// Get pagination parameters
+	limit := 50
+	offset := 0
+
+	if l := c.QueryParam("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 100 {
+			limit = parsed
+		}
+	}
+
+	if o := c.QueryParam("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		}
+	}
+
+	unreadOnly, _ := strconv.ParseBool(c.QueryParam("unread"))
+
+	notifications, err := h.store.Queries.ListNotifications(ctx, db.ListNotificationsParams{
+		UserID:     userID,
+		UnreadOnly: unreadOnly,
+		PageLimit:  int32(limit),
+		PageOffset: int32(offset),
+	})
+	if err != nil {
+		h.logger.Errorw("Failed to list notifications", "error", err, "user_id", userID)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list notifications")
+	}
+
+	unread, err := h.store.Queries.CountUnreadNotifications(ctx, userID)
+	if err != nil {
+		h.logger.Errorw("Failed to count unread notifications", "error", err, "user_id", userID)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list notifications")
+	}
+
+	responses := make([]NotificationResponse, 0, len(notifications))
+	for _, n := range notifications {
+		responses = append(responses, buildNotificationResponse(n))
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"data":         responses,
+		"unread_count": unread,
+		"limit":        limit,
+		"offset":       offset,
+		"count":        len(responses),
+	})
+}
+
+// GetMyUnreadNotificationCount returns the number of unread notifications of
+// the authenticated user, e.g. for the badge of the inbox
+func (h *Handler) GetMyUnreadNotificationCount(c echo.Context) error {
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	unread, err := h.store.Queries.CountUnreadNotifications(c.Request().Context(), userID)
+	if err != nil {
+		h.logger.Errorw("Failed to count unread notifications", "error", err, "user_id", userID)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to count unread notifications")
+	}
+
+	return c.JSON(http.StatusOK, map[string]int64{
+		"unread_count": unread,
+	})
+}
+
+// MarkMyNotificationRead marks a notification of the authenticated user as read
+func (h *Handler) MarkMyNotificationRead(c echo.Context) error {
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	notificationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid notification ID")
+	}
+
+	n, err := h.store.Queries.MarkNotificationRead(c.Request().Context(), db.MarkNotificationReadParams{
+		ID:     notificationID,
+		UserID: userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return echo.NewHTTPError(http.StatusNotFound, "Notification not found")
+	}
+	if err != nil {
+		h.logger.Errorw("Failed to mark notification read", "error", err, "notification_id", notificationID)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to mark notification read")
+	}
+
+	return c.JSON(http.StatusOK, buildNotificationResponse(n))
+}
+
+// MarkAllMyNotificationsRead marks all notifications of the authenticated user
+// as read
+func (h *Handler) MarkAllMyNotificationsRead(c echo.Context) error {
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	marked, err := h.store.Queries.MarkAllNotificationsRead(c.Request().Context(), userID)
+	if err != nil {
+		h.logger.Errorw("Failed to mark all notifications read", "error", err, "user_id", userID)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to mark notifications read")
+	}
+
+	return c.JSON(http.StatusOK, map[string]int64{
+		"marked_read": marked,
+	})
+}
+
+// GetMyNotificationPreferences returns the channel of every notification type
+// for the authenticated user, defaults included
+func (h *Handler) GetMyNotificationPreferences(c echo.Context) error {
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	preferences, err := h.notificationPreferences(c, h.store.Queries, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"data": preferences,
+	})
+}
+
+// UpdateMyNotificationPreferences sets the channels the authenticated user
+// receives notification types on: inbox, email or both
+func (h *Handler) UpdateMyNotificationPreferences(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req UpdateNotificationPreferencesRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	for _, pref := range req.Preferences {
+		if !notification.Type(pref.Type).Valid() {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown notification type %s", pref.Type))
+		}
+		if !notification.Channel(pref.Channel).Valid() {
+			return echo.NewHTTPError(http.StatusBadRequest, "channel must be one of inbox, email or both")
+		}
+	}
+
+	var preferences []NotificationPreferenceItem
+	err = h.store.ExecTx(ctx, func(q *db.Queries) error {
+		for _, pref := range req.Preferences {
+			if _, err := q.UpsertNotificationPreference(ctx, db.UpsertNotificationPreferenceParams{
+				UserID:  userID,
+				Type:    pref.Type,
+				Channel: pref.Channel,
+			}); err != nil {
+				return err
+			}
+		}
+		var err error
+		preferences, err = h.notificationPreferences(c, q, userID)
+		return err
+	})
+	if err != nil {
+		var httpErr *echo.HTTPError
+		if errors.As(err, &httpErr) {
+			return httpErr
+		}
+		h.logger.Errorw("Failed to update notification preferences", "error", err, "user_id", userID)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update notification preferences")
+	}
+
+	h.logger.Infow("Notification preferences updated", "user_id", userID)
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"data": preferences,
+	})
+}
+
+// notificationPreferences returns the channel of every notification type of a
+// user, the default channel for types without a preference
+func (h *Handler) notificationPreferences(c echo.Context, q db.Querier, userID uuid.UUID) ([]NotificationPreferenceItem, error) {
+	stored, err := q.ListNotificationPreferences(c.Request().Context(), userID)
+	if err != nil {
+		h.logger.Errorw("Failed to list notification preferences", "error", err, "user_id", userID)
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to get notification preferences")
+	}
+
+	channels := make(map[string]string, len(stored))
+	for _, pref := range stored {
+		channels[pref.Type] = pref.Channel
+	}
+
+	preferences := make([]NotificationPreferenceItem, 0, len(notification.Types()))
+	for _, t := range notification.Types() {
+		channel, ok := channels[string(t)]
+		if !ok {
+			channel = string(notification.DefaultChannel)
+		}
+		preferences = append(preferences, NotificationPreferenceItem{
+			Type:    string(t),
+			Channel: channel,
+		})
+	}
+	return preferences, nil
+}
+
+func buildNotificationResponse(n db.Notification) NotificationResponse {
+	response := NotificationResponse{
+		ID:        n.ID.String(),
+		Type:      n.Type,
+		Title:     n.Title,
+		Body:      n.Body,
+		Link:      n.Link,
+		Read:      n.ReadAt.Valid,
+		CreatedAt: n.CreatedAt,
+	}
+	if n.ClientID.Valid {
+		clientID := uuid.UUID(n.ClientID.Bytes).String()
+		response.ClientID = &clientID
+	}
+	if n.ReadAt.Valid {
+		response.ReadAt = &n.ReadAt.Time
+	}
+	return response
+}
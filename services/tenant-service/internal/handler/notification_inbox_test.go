@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/db"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// inboxContext returns a request context of the given user, or of an
+// unauthenticated caller when userID is uuid.Nil
+func inboxContext(userID uuid.UUID, notificationID string) (echo.Context, *httptest.ResponseRecorder) {
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodPost, "/", nil), rec)
+	if notificationID != "" {
+		c.SetParamNames("id")
+		c.SetParamValues(notificationID)
+	}
+	if userID != uuid.Nil {
+		c.Set("user_id", userID)
+	}
+	return c, rec
+}
+
+// httpStatus returns the status of an error returned by a handler, or of the
+// response written when there is none
+func httpStatus(err error, rec *httptest.ResponseRecorder) int {
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code
+	}
+	return rec.Code
+}
+
+func TestMarkMyNotificationRead(t *testing.T) {
+	userID, notificationID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name           string
+		userID         uuid.UUID
+		notificationID string
+		owned          bool
+		wantStatus     int
+	}{
+		{name: "own notification", userID: userID, notificationID: notificationID.String(), owned: true, wantStatus: http.StatusOK},
+		{name: "notification of another user", userID: userID, notificationID: notificationID.String(), wantStatus: http.StatusNotFound},
+		{name: "invalid ID", userID: userID, notificationID: "inbox", wantStatus: http.StatusBadRequest},
+		{name: "unauthenticated", notificationID: notificationID.String(), wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenantDB := &fakeTenantDB{rows: map[string]rowFunc{}}
+			if tt.owned {
+				tenantDB.rows[db.MarkNotificationRead] = func(dest ...any) error {
+					*dest[0].(*uuid.UUID) = notificationID
+					*dest[1].(*uuid.UUID) = userID
+					return nil
+				}
+			}
+
+			c, rec := inboxContext(tt.userID, tt.notificationID)
+			err := newTestHandler(tenantDB).MarkMyNotificationRead(c)
+			if got := httpStatus(err, rec); got != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", got, tt.wantStatus)
+			}
+
+			args, queried := tenantDB.args[db.MarkNotificationRead]
+			if queried && args[1] != tt.userID {
+				t.Errorf("notification looked up for user %v, want %s", args[1], tt.userID)
+			}
+		})
+	}
+}
+
+func TestMyNotificationsAreScopedToTheUser(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name   string
+		handle func(*Handler, echo.Context) error
+		sql    string
+	}{
+		{name: "unread count", handle: (*Handler).GetMyUnreadNotificationCount, sql: db.CountUnreadNotifications},
+		{name: "mark all read", handle: (*Handler).MarkAllMyNotificationsRead, sql: db.MarkAllNotificationsRead},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenantDB := &fakeTenantDB{rows: map[string]rowFunc{
+				db.CountUnreadNotifications: func(dest ...any) error { return nil },
+			}}
+
+			c, rec := inboxContext(userID, "")
+			if err := tt.handle(newTestHandler(tenantDB), c); err != nil {
+				t.Fatalf("handler error = %v", err)
+			}
+			if rec.Code != http.StatusOK {
+				t.Errorf("status = %d, want %d", rec.Code, http.StatusOK)
+			}
+			if args := tenantDB.args[tt.sql]; len(args) != 1 || args[0] != userID {
+				t.Errorf("query arguments = %v, want only the user %s", args, userID)
+			}
+
+			c, rec = inboxContext(uuid.Nil, "")
+			if got := httpStatus(tt.handle(newTestHandler(&fakeTenantDB{}), c), rec); got != http.StatusUnauthorized {
+				t.Errorf("unauthenticated status = %d, want %d", got, http.StatusUnauthorized)
+			}
+		})
+	}
+}
@@ -0,0 +1,354 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/NormaTech-AI/audity/packages/go/emailtemplates"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientdb"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/db"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/mail"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/notification"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Lifecycle notifications and emails are best effort: they are sent after the
+// change they are about succeeded, and a failure to send one is logged without
+// failing the request.
+
+const emailDateFormat = "2 January 2006"
+
+func (h *Handler) sendTemplate(ctx context.Context, email mail.TemplateEmail) {
+	if err := h.mail.SendTemplate(ctx, email); err != nil {
+		h.logger.Errorw("Failed to queue email",
+			"error", err,
+			"template", email.Template,
+			"client_id", email.ClientID)
+	}
+}
+
+// notifyUser notifies a user in their inbox and by email, as set in their
+// notification preferences
+func (h *Handler) notifyUser(ctx context.Context, user db.User, n notification.Notification) {
+	if err := h.notifications.Notify(ctx, user, n); err != nil {
+		h.logger.Errorw("Failed to send notification",
+			"error", err,
+			"type", n.Type,
+			"user_id", user.ID,
+			"client_id", n.ClientID)
+	}
+}
+
+// notifyAddress notifies the user with an email address, or only emails the
+// address when no user has it
+func (h *Handler) notifyAddress(ctx context.Context, address string, n notification.Notification) {
+	if err := h.notifications.NotifyAddress(ctx, address, n); err != nil {
+		h.logger.Errorw("Failed to send notification",
+			"error", err,
+			"type", n.Type,
+			"client_id", n.ClientID)
+	}
+}
+
+// notifyFrameworkAssigned tells the client POC about a newly provisioned audit
+func (h *Handler) notifyFrameworkAssigned(ctx context.Context, clientID uuid.UUID, cycle db.AuditCycle, assignment db.AuditCycleFramework, auditID uuid.UUID) {
+	client, err := h.store.Queries.GetClient(ctx, clientID)
+	if err != nil {
+		h.logger.Errorw("Failed to get client for framework assignment email", "error", err, "client_id", clientID)
+		return
+	}
+
+	dueDate := assignment.DueDate
+	if !dueDate.Valid {
+		dueDate = cycle.EndDate
+	}
+
+	body := fmt.Sprintf("%s has to complete the %s audit of %s.", client.Name, assignment.FrameworkName, cycle.Name)
+	if dueDate.Valid {
+		body = fmt.Sprintf("%s has to complete the %s audit of %s by %s.", client.Name, assignment.FrameworkName, cycle.Name, formatEmailDate(dueDate))
+	}
+
+	h.notifyAddress(ctx, client.PocEmail, notification.Notification{
+		Type:     notification.Assignment,
+		ClientID: clientID,
+		Title:    fmt.Sprintf("New audit: %s", assignment.FrameworkName),
+		Body:     body,
+		Link:     fmt.Sprintf("/audit/%s", auditID),
+		Email: &mail.TemplateEmail{
+			ClientID: clientID,
+			Template: emailtemplates.FrameworkAssigned,
+			Data: emailtemplates.FrameworkAssignedData{
+				ClientName:     client.Name,
+				FrameworkName:  assignment.FrameworkName,
+				AuditCycleName: cycle.Name,
+				DueDate:        formatEmailDate(dueDate),
+				AuditURL:       h.mail.AppURL("/audit/%s", auditID),
+			},
+		},
+	})
+}
+
+// notifySubmissionReviewed tells the submitter that their answer was
+// approved, rejected or referred back to them. Approvals only go to the inbox.
+func (h *Handler) notifySubmissionReviewed(ctx context.Context, clientID uuid.UUID, clientQueries *clientdb.Queries, submission clientdb.Submission, action, notes string) {
+	question, err := clientQueries.GetQuestionByID(ctx, submission.QuestionID)
+	if err != nil {
+		h.logger.Errorw("Failed to get question for review notification", "error", err, "question_id", submission.QuestionID)
+		return
+	}
+	audit, err := clientQueries.GetAuditByID(ctx, question.AuditID)
+	if err != nil {
+		h.logger.Errorw("Failed to get audit for review notification", "error", err, "audit_id", question.AuditID)
+		return
+	}
+	submitter, err := h.store.Queries.GetUser(ctx, submission.SubmittedBy)
+	if err != nil {
+		h.logger.Errorw("Failed to get submitter for review notification", "error", err, "user_id", submission.SubmittedBy)
+		return
+	}
+
+	n := notification.Notification{
+		ClientID: clientID,
+		Link:     fmt.Sprintf("/audit/%s", audit.ID),
+	}
+	auditURL := h.mail.AppURL("/audit/%s", audit.ID)
+	switch action {
+	case "approve":
+		n.Type = notification.Review
+		n.Title = fmt.Sprintf("Answer approved: %s", questionLabel(question))
+		n.Body = fmt.Sprintf("Your answer on the %s audit was approved.", audit.FrameworkName)
+	case "reject":
+		n.Type = notification.Review
+		n.Title = fmt.Sprintf("Answer rejected: %s", questionLabel(question))
+		n.Body = fmt.Sprintf("Your answer on the %s audit was rejected: %s", audit.FrameworkName, notes)
+		n.Email = &mail.TemplateEmail{
+			ClientID: clientID,
+			Template: emailtemplates.SubmissionRejected,
+			Data: emailtemplates.SubmissionRejectedData{
+				RecipientName:  submitter.Name,
+				FrameworkName:  audit.FrameworkName,
+				QuestionNumber: question.QuestionNumber,
+				QuestionText:   question.QuestionText,
+				Reason:         notes,
+				AuditURL:       auditURL,
+			},
+		}
+	case "refer":
+		n.Type = notification.Referral
+		n.Title = fmt.Sprintf("Answer referred back: %s", questionLabel(question))
+		n.Body = fmt.Sprintf("Your answer on the %s audit needs more information: %s", audit.FrameworkName, notes)
+		n.Email = &mail.TemplateEmail{
+			ClientID: clientID,
+			Template: emailtemplates.ReferralAssigned,
+			Data: emailtemplates.ReferralAssignedData{
+				RecipientName:  submitter.Name,
+				FrameworkName:  audit.FrameworkName,
+				QuestionNumber: question.QuestionNumber,
+				QuestionText:   question.QuestionText,
+				Notes:          notes,
+				AuditURL:       auditURL,
+			},
+		}
+	default:
+		return
+	}
+	h.notifyUser(ctx, submitter, n)
+}
+
+// notifyQuestionDelegated tells a client user that a question was delegated to them
+func (h *Handler) notifyQuestionDelegated(ctx context.Context, clientID uuid.UUID, audit clientdb.Audit, question clientdb.Question, assignee db.User, delegatedBy string, notes *string) {
+	data := emailtemplates.QuestionDelegatedData{
+		AssigneeName:   assignee.Name,
+		DelegatedBy:    delegatedBy,
+		FrameworkName:  audit.FrameworkName,
+		QuestionNumber: question.QuestionNumber,
+		QuestionText:   question.QuestionText,
+		AuditURL:       h.mail.AppURL("/audit/%s", audit.ID),
+	}
+	if notes != nil {
+		data.Notes = *notes
+	}
+
+	h.notifyUser(ctx, assignee, notification.Notification{
+		Type:     notification.Assignment,
+		ClientID: clientID,
+		Title:    fmt.Sprintf("Question assigned: %s", questionLabel(question)),
+		Body:     fmt.Sprintf("%s asked you to answer a question of the %s audit.", delegatedBy, audit.FrameworkName),
+		Link:     fmt.Sprintf("/audit/%s", audit.ID),
+		Email: &mail.TemplateEmail{
+			ClientID: clientID,
+			Template: emailtemplates.QuestionDelegated,
+			Data:     data,
+		},
+	})
+}
+
+// mentionPattern matches mentions of users by email address, e.g.
+// "@jane@example.com", at the start of the text or after whitespace
+var mentionPattern = regexp.MustCompile(`(?:^|\s)@([A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,})`)
+
+// notifyCommentMentions notifies the users a new comment mentions. Mentions
+// of unknown addresses, of the author and of users who cannot see the comment
+// are ignored: client users only see the comments of their own client and no
+// internal comments.
+func (h *Handler) notifyCommentMentions(ctx context.Context, clientID uuid.UUID, clientQueries *clientdb.Queries, comment clientdb.Comment) {
+	matches := mentionPattern.FindAllStringSubmatch(comment.CommentText, -1)
+	if len(matches) == 0 {
+		return
+	}
+
+	var mentioned []db.User
+	seen := make(map[uuid.UUID]bool)
+	for _, match := range matches {
+		user, err := h.store.Queries.GetUserByEmail(ctx, match[1])
+		if err != nil {
+			if !errors.Is(err, pgx.ErrNoRows) {
+				h.logger.Errorw("Failed to get mentioned user", "error", err, "comment_id", comment.ID)
+			}
+			continue
+		}
+		if user.ID == comment.UserID || seen[user.ID] {
+			continue
+		}
+		if user.ClientID.Valid && (comment.IsInternal || uuid.UUID(user.ClientID.Bytes) != clientID) {
+			continue
+		}
+		seen[user.ID] = true
+		mentioned = append(mentioned, user)
+	}
+	if len(mentioned) == 0 {
+		return
+	}
+
+	submission, err := clientQueries.GetSubmissionByID(ctx, comment.SubmissionID)
+	if err != nil {
+		h.logger.Errorw("Failed to get submission for mention notification", "error", err, "submission_id", comment.SubmissionID)
+		return
+	}
+	question, err := clientQueries.GetQuestionByID(ctx, submission.QuestionID)
+	if err != nil {
+		h.logger.Errorw("Failed to get question for mention notification", "error", err, "question_id", submission.QuestionID)
+		return
+	}
+	audit, err := clientQueries.GetAuditByID(ctx, question.AuditID)
+	if err != nil {
+		h.logger.Errorw("Failed to get audit for mention notification", "error", err, "audit_id", question.AuditID)
+		return
+	}
+
+	authorName := comment.UserName
+	if author, err := h.store.Queries.GetUser(ctx, comment.UserID); err == nil {
+		authorName = author.Name
+	}
+
+	for _, user := range mentioned {
+		h.notifyUser(ctx, user, notification.Notification{
+			Type:     notification.Mention,
+			ClientID: clientID,
+			Title:    fmt.Sprintf("%s mentioned you on %s", authorName, questionLabel(question)),
+			Body:     comment.CommentText,
+			Link:     fmt.Sprintf("/audit/%s", audit.ID),
+			Email: &mail.TemplateEmail{
+				ClientID: clientID,
+				Template: emailtemplates.CommentMention,
+				Data: emailtemplates.CommentMentionData{
+					RecipientName:  user.Name,
+					AuthorName:     authorName,
+					FrameworkName:  audit.FrameworkName,
+					QuestionNumber: question.QuestionNumber,
+					QuestionText:   question.QuestionText,
+					Comment:        comment.CommentText,
+					AuditURL:       h.mail.AppURL("/audit/%s", audit.ID),
+				},
+			},
+		})
+	}
+}
+
+// notifyReportSigned tells the auditor who generated a report that it was
+// signed and can be delivered
+func (h *Handler) notifyReportSigned(ctx context.Context, clientID uuid.UUID, clientQueries *clientdb.Queries, report clientdb.Report, signedBy uuid.UUID) {
+	client, audit, ok := h.reportEmailContext(ctx, clientID, clientQueries, report)
+	if !ok {
+		return
+	}
+	generator, err := h.store.Queries.GetUser(ctx, report.GeneratedBy)
+	if err != nil {
+		h.logger.Errorw("Failed to get report author for signed email", "error", err, "user_id", report.GeneratedBy)
+		return
+	}
+	signer, err := h.store.Queries.GetUser(ctx, signedBy)
+	if err != nil {
+		h.logger.Errorw("Failed to get report signer for signed email", "error", err, "user_id", signedBy)
+		return
+	}
+
+	h.sendTemplate(ctx, mail.TemplateEmail{
+		To:       []string{generator.Email},
+		ClientID: clientID,
+		Template: emailtemplates.ReportSigned,
+		Data: emailtemplates.ReportSignedData{
+			RecipientName: generator.Name,
+			ClientName:    client.Name,
+			FrameworkName: audit.FrameworkName,
+			SignedBy:      signer.Name,
+			ReportURL:     h.mail.AppURL("/clients/%s", clientID),
+		},
+	})
+}
+
+// notifyReportDelivered tells the client POC that the report of their audit is available
+func (h *Handler) notifyReportDelivered(ctx context.Context, clientID uuid.UUID, clientQueries *clientdb.Queries, report clientdb.Report) {
+	client, audit, ok := h.reportEmailContext(ctx, clientID, clientQueries, report)
+	if !ok {
+		return
+	}
+
+	h.sendTemplate(ctx, mail.TemplateEmail{
+		To:       []string{client.PocEmail},
+		ClientID: clientID,
+		Template: emailtemplates.ReportDelivered,
+		Data: emailtemplates.ReportDeliveredData{
+			ClientName:    client.Name,
+			FrameworkName: audit.FrameworkName,
+			ReportURL:     h.mail.AppURL("/audit/%s", audit.ID),
+		},
+	})
+}
+
+func (h *Handler) reportEmailContext(ctx context.Context, clientID uuid.UUID, clientQueries *clientdb.Queries, report clientdb.Report) (db.Client, clientdb.Audit, bool) {
+	client, err := h.store.Queries.GetClient(ctx, clientID)
+	if err != nil {
+		h.logger.Errorw("Failed to get client for report email", "error", err, "client_id", clientID)
+		return db.Client{}, clientdb.Audit{}, false
+	}
+	audit, err := clientQueries.GetAuditByID(ctx, report.AuditID)
+	if err != nil {
+		h.logger.Errorw("Failed to get audit for report email", "error", err, "audit_id", report.AuditID)
+		return db.Client{}, clientdb.Audit{}, false
+	}
+	return client, audit, true
+}
+
+// questionLabel is the number of a question, or its text when it has none
+func questionLabel(question clientdb.Question) string {
+	if question.QuestionNumber != "" {
+		return question.QuestionNumber
+	}
+	text := []rune(question.QuestionText)
+	if len(text) > 60 {
+		return string(text[:60]) + "…"
+	}
+	return string(text)
+}
+
+func formatEmailDate(date pgtype.Date) string {
+	if !date.Valid {
+		return ""
+	}
+	return date.Time.Format(emailDateFormat)
+}
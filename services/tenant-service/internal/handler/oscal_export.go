@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/answers"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientdb"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/oscal"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// ExportAuditOSCAL exports a completed audit as an OSCAL assessment results
+// document with the audit's observations, findings and evidence references
+func (h *Handler) ExportAuditOSCAL(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	clientID, err := uuid.Parse(c.Param("clientId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid client ID",
+		})
+	}
+
+	auditID, err := uuid.Parse(c.Param("auditId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid audit ID",
+		})
+	}
+
+	client, err := h.store.GetClient(ctx, clientID)
+	if err != nil {
+		h.logger.Errorw("Failed to get client", "error", err, "client_id", clientID)
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Client not found",
+		})
+	}
+
+	// Get client database queries
+	clientQueries, _, err := h.clientStore.GetClientQueries(ctx, clientID)
+	if err != nil {
+		h.logger.Errorw("Failed to get client queries", "error", err, "client_id", clientID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to access client data",
+		})
+	}
+
+	audit, err := clientQueries.GetAuditByID(ctx, auditID)
+	if err != nil {
+		h.logger.Errorw("Failed to get audit", "error", err, "audit_id", auditID)
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Audit not found",
+		})
+	}
+
+	if audit.Status != clientdb.AuditStatusEnumCompleted {
+		return c.JSON(http.StatusConflict, map[string]string{
+			"error": "Only completed audits can be exported as OSCAL assessment results",
+		})
+	}
+
+	questions, err := clientQueries.ListQuestionsWithSubmissions(ctx, auditID)
+	if err != nil {
+		h.logger.Errorw("Failed to get questions", "error", err, "audit_id", auditID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve audit data",
+		})
+	}
+
+	// Readiness rows carry the visibility and exception state of each question
+	readinessRows, err := clientQueries.ListQuestionReadiness(ctx, auditID)
+	if err != nil {
+		h.logger.Errorw("Failed to get question readiness", "error", err, "audit_id", auditID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve audit data",
+		})
+	}
+
+	evidence, err := clientQueries.ListEvidenceByAudit(ctx, auditID)
+	if err != nil {
+		h.logger.Errorw("Failed to get evidence", "error", err, "audit_id", auditID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve audit evidence",
+		})
+	}
+
+	findings, err := clientQueries.ListFindingsByAudit(ctx, auditID)
+	if err != nil {
+		h.logger.Errorw("Failed to get findings", "error", err, "audit_id", auditID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve audit findings",
+		})
+	}
+
+	visible := resolveQuestionVisibility(readinessRows)
+	excepted := make(map[uuid.UUID]bool, len(readinessRows))
+	for _, row := range readinessRows {
+		excepted[row.ID] = row.HasActiveException
+	}
+
+	evidenceByQuestion := make(map[uuid.UUID][]clientdb.ListEvidenceByAuditRow)
+	for _, e := range evidence {
+		evidenceByQuestion[e.QuestionID] = append(evidenceByQuestion[e.QuestionID], e)
+	}
+
+	results := make([]oscal.QuestionResult, 0, len(questions))
+	for _, q := range questions {
+		if !visible[q.QuestionNumber] {
+			continue
+		}
+
+		var answer string
+		if q.SubmissionID.Valid {
+			answer = answers.Render(q.QuestionType, q.Options, q.AnswerValue, q.AnswerText, q.AnswerData)
+		}
+
+		results = append(results, oscal.QuestionResult{
+			Question: q,
+			Answer:   answer,
+			Excepted: excepted[q.ID],
+			Evidence: evidenceByQuestion[q.ID],
+		})
+	}
+
+	catalogVersion := "latest"
+	if audit.FrameworkVersionID.Valid {
+		catalogVersion = uuid.UUID(audit.FrameworkVersionID.Bytes).String()
+	}
+
+	doc := oscal.NewAssessmentResults(oscal.AuditResults{
+		ClientID:    client.ID,
+		ClientName:  client.Name,
+		Audit:       audit,
+		Questions:   results,
+		Findings:    findings,
+		CatalogHref: fmt.Sprintf("%s/api/frameworks/%s/oscal/catalog?version_id=%s", h.config.Services.FrameworkBaseURL, audit.FrameworkID, catalogVersion),
+		EvidenceHref: func(evidenceID uuid.UUID) string {
+			return fmt.Sprintf("/api/clients/%s/evidence/%s/download", clientID, evidenceID)
+		},
+	})
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		h.logger.Errorw("Failed to encode assessment results", "error", err, "audit_id", auditID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to export assessment results",
+		})
+	}
+
+	fileName := fmt.Sprintf("assessment-results-%s.json", auditID.String()[:8])
+	c.Response().Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", fileName))
+
+	return c.Blob(http.StatusOK, oscal.MediaTypeAssessmentResults, data)
+}
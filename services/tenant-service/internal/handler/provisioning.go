@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientdb"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/db"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/framework"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+)
+
+// Outcomes of provisioning a framework assignment in a client database
+const (
+	provisionStatusProvisioned        = "provisioned"
+	provisionStatusAlreadyProvisioned = "already_provisioned"
+	provisionStatusFailed             = "failed"
+)
+
+// provisionFrameworkAudit creates the audit and its questions for a framework
+// assignment in the client database from a published framework version and
+// links the two records, pinning the assignment to that version. An audit
+// already provisioned for the assignment is reused, so provisioning can be
+// retried safely. If any step fails, only the records this call created are
+// removed again: the assignment when assignmentCreated is set, and the audit
+// when it was not reused. Rows of concurrent or earlier requests, and the
+// answers in them, are never touched.
+// Assignments without a due date fall back to the end of the cycle.
+func (h *Handler) provisionFrameworkAudit(
+	ctx context.Context,
+	clientID uuid.UUID,
+	assignment db.AuditCycleFramework,
+	assignmentCreated bool,
+	checklist *framework.Checklist,
+	assignedBy uuid.UUID,
+	cycleEnd pgtype.Date,
+) (uuid.UUID, error) {
+	dueDate := cycleEnd.Time
+	if assignment.DueDate.Valid {
+		dueDate = assignment.DueDate.Time
+	}
+
+	var auditID uuid.UUID
+	auditCreated := false
+	versionID := pgtype.UUID{Bytes: checklist.VersionID, Valid: true}
+	version := &checklist.Version
+	err := h.clientStore.ExecClientTx(ctx, clientID, func(q *clientdb.Queries) error {
+		existing, err := q.GetAuditByCycleFramework(ctx, pgtype.UUID{Bytes: assignment.ID, Valid: true})
+		if err == nil {
+			// Keep the version the existing audit was created from
+			auditID = existing.ID
+			versionID = existing.FrameworkVersionID
+			version = existing.FrameworkVersion
+			return nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("failed to look up client audit: %w", err)
+		}
+
+		auditID, err = h.frameworkService.CreateAuditWithQuestions(ctx, q, framework.NewAudit{
+			FrameworkID:           assignment.FrameworkID,
+			FrameworkName:         assignment.FrameworkName,
+			AuditCycleFrameworkID: assignment.ID,
+			Checklist:             checklist,
+			AssignedBy:            assignedBy,
+			DueDate:               dueDate,
+			CarryForward:          assignment.CarryForward,
+		})
+		auditCreated = err == nil
+		return err
+	})
+	if err != nil {
+		if assignmentCreated {
+			h.removeFrameworkAssignment(ctx, assignment.ID)
+		}
+		return uuid.Nil, err
+	}
+
+	_, err = h.store.Queries.LinkAuditCycleFrameworkClientAudit(ctx, db.LinkAuditCycleFrameworkClientAuditParams{
+		ID:                 assignment.ID,
+		ClientAuditID:      pgtype.UUID{Bytes: auditID, Valid: true},
+		FrameworkVersionID: versionID,
+		FrameworkVersion:   version,
+	})
+	if err != nil {
+		if auditCreated {
+			h.removeClientAudit(ctx, clientID, auditID)
+		}
+		if assignmentCreated {
+			h.removeFrameworkAssignment(ctx, assignment.ID)
+		}
+		return uuid.Nil, fmt.Errorf("failed to link client audit: %w", err)
+	}
+
+	return auditID, nil
+}
+
+// removeFrameworkAssignment compensates a failed provisioning by deleting the
+// assignment from tenant_db
+func (h *Handler) removeFrameworkAssignment(ctx context.Context, assignmentID uuid.UUID) {
+	// Compensate even when the request that triggered provisioning was cancelled
+	ctx = context.WithoutCancel(ctx)
+
+	if err := h.store.Queries.DeleteAuditCycleFramework(ctx, assignmentID); err != nil {
+		h.logger.Errorw("Failed to roll back framework assignment",
+			"error", err,
+			"audit_cycle_framework_id", assignmentID)
+	}
+}
+
+// removeClientAudit compensates a failed provisioning by deleting the audit,
+// and with it its questions, from the client database
+func (h *Handler) removeClientAudit(ctx context.Context, clientID, auditID uuid.UUID) {
+	ctx = context.WithoutCancel(ctx)
+
+	clientQueries, _, err := h.clientStore.GetClientQueries(ctx, clientID)
+	if err == nil {
+		err = clientQueries.DeleteAudit(ctx, auditID)
+	}
+	if err != nil {
+		h.logger.Errorw("Failed to roll back client audit",
+			"error", err,
+			"client_id", clientID,
+			"audit_id", auditID)
+	}
+}
+
+// getAuthToken returns the caller's JWT, from the Authorization header or the
+// auth cookie, so it can be forwarded to other services
+func getAuthToken(c echo.Context) string {
+	if token, ok := strings.CutPrefix(c.Request().Header.Get("Authorization"), "Bearer "); ok {
+		return token
+	}
+
+	if cookie, err := c.Cookie("auth_token"); err == nil {
+		return cookie.Value
+	}
+
+	return ""
+}
@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientstore"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/db"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/framework"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/store"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// rowFunc is a pgx.Row that scans with the given function
+type rowFunc func(dest ...any) error
+
+func (f rowFunc) Scan(dest ...any) error { return f(dest...) }
+
+// fakeTenantDB answers tenant_db queries from canned rows keyed by their SQL
+// and records the statements it executes and the arguments of every call.
+// Queries without a canned row find nothing, so client databases cannot be
+// reached.
+type fakeTenantDB struct {
+	rows map[string]rowFunc
+	exec []string
+	args map[string][]any
+}
+
+func (f *fakeTenantDB) record(sql string, args []any) {
+	if f.args == nil {
+		f.args = make(map[string][]any)
+	}
+	f.args[sql] = args
+}
+
+func (f *fakeTenantDB) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	f.exec = append(f.exec, sql)
+	f.record(sql, args)
+	return pgconn.CommandTag{}, nil
+}
+
+func (f *fakeTenantDB) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return nil, errors.New("unexpected query")
+}
+
+func (f *fakeTenantDB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	f.record(sql, args)
+	if row, ok := f.rows[sql]; ok {
+		return row
+	}
+	return rowFunc(func(dest ...any) error { return pgx.ErrNoRows })
+}
+
+// newTestHandler returns a handler backed by the fake tenant database
+func newTestHandler(tenantDB *fakeTenantDB) *Handler {
+	logger := zap.NewNop().Sugar()
+	return &Handler{
+		store:       &store.Store{Queries: db.New(tenantDB)},
+		clientStore: clientstore.NewClientStore(db.New(tenantDB), nil, logger),
+		logger:      logger,
+	}
+}
+
+func TestProvisionFrameworkAuditRollback(t *testing.T) {
+	tests := []struct {
+		name              string
+		assignmentCreated bool
+		wantExec          []string
+	}{
+		{
+			name:              "created assignment is removed",
+			assignmentCreated: true,
+			wantExec:          []string{db.DeleteAuditCycleFramework},
+		},
+		{
+			name:              "existing assignment is kept",
+			assignmentCreated: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenantDB := &fakeTenantDB{}
+			h := newTestHandler(tenantDB)
+
+			assignment := db.AuditCycleFramework{ID: uuid.New(), FrameworkID: uuid.New(), FrameworkName: "ISO 27001"}
+			_, err := h.provisionFrameworkAudit(context.Background(), uuid.New(), assignment, tt.assignmentCreated,
+				&framework.Checklist{}, uuid.New(), pgtype.Date{})
+			if err == nil {
+				t.Fatal("provisionFrameworkAudit() succeeded without a client database")
+			}
+
+			if !slices.Equal(tenantDB.exec, tt.wantExec) {
+				t.Errorf("executed %q, want %q", tenantDB.exec, tt.wantExec)
+			}
+		})
+	}
+}
+
+func TestGetAuthToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		cookie string
+		want   string
+	}{
+		{name: "bearer header", header: "Bearer header-token", want: "header-token"},
+		{name: "auth cookie", cookie: "cookie-token", want: "cookie-token"},
+		{name: "header wins", header: "Bearer header-token", cookie: "cookie-token", want: "header-token"},
+		{name: "other scheme", header: "Basic dXNlcjpwYXNz", want: ""},
+		{name: "none", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			if tt.cookie != "" {
+				req.Header.Set("Cookie", "auth_token="+tt.cookie)
+			}
+			c := echo.New().NewContext(req, httptest.NewRecorder())
+
+			if got := getAuthToken(c); got != tt.want {
+				t.Errorf("getAuthToken() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
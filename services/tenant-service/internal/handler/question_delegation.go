@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientdb"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+)
+
+// DelegateQuestionRequest represents a POC delegating a question to a client user
+type DelegateQuestionRequest struct {
+	AssignedTo string  `json:"assigned_to" validate:"required"`
+	Notes      *string `json:"notes"`
+}
+
+// QuestionAssignmentResponse represents a question delegated to a client user
+type QuestionAssignmentResponse struct {
+	ID         string    `json:"id"`
+	QuestionID string    `json:"question_id"`
+	AssignedTo string    `json:"assigned_to"`
+	AssignedBy string    `json:"assigned_by"`
+	AssignedAt time.Time `json:"assigned_at"`
+	Notes      *string   `json:"notes,omitempty"`
+}
+
+// DelegateClientQuestion delegates a question to another user of the client
+// and emails them (POC only)
+func (h *Handler) DelegateClientQuestion(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	questionID, err := uuid.Parse(c.Param("questionId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid question ID",
+		})
+	}
+
+	var req DelegateQuestionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request payload",
+		})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	assigneeID, err := uuid.Parse(req.AssignedTo)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid assigned_to user ID",
+		})
+	}
+
+	clientID, err := getClientIDFromUser(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "Client ID not found in context",
+		})
+	}
+
+	userID, err := getUserIDFromUser(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "User ID not found in context",
+		})
+	}
+
+	isPOC, err := isUserPOCRole(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "Failed to determine user role",
+		})
+	}
+
+	if !isPOC {
+		return c.JSON(http.StatusForbidden, map[string]string{
+			"error": "Only the client POC can delegate questions",
+		})
+	}
+
+	// Questions can only be delegated within the client
+	assignee, err := h.store.Queries.GetUser(ctx, assigneeID)
+	if err != nil || !assignee.ClientID.Valid || uuid.UUID(assignee.ClientID.Bytes) != clientID {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Assignee is not a user of this client",
+		})
+	}
+
+	delegator, err := h.store.Queries.GetUser(ctx, userID)
+	if err != nil {
+		h.logger.Errorw("Failed to get user", "error", err, "user_id", userID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to delegate question",
+		})
+	}
+
+	// Get client database queries
+	clientQueries, _, err := h.clientStore.GetClientQueries(ctx, clientID)
+	if err != nil {
+		h.logger.Errorw("Failed to get client queries", "error", err, "client_id", clientID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to access client data",
+		})
+	}
+
+	question, err := clientQueries.GetQuestionByID(ctx, questionID)
+	if err != nil {
+		h.logger.Errorw("Failed to get question", "error", err, "question_id", questionID)
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Question not found",
+		})
+	}
+
+	audit, err := clientQueries.GetAuditByID(ctx, question.AuditID)
+	if err != nil {
+		h.logger.Errorw("Failed to get audit", "error", err, "audit_id", question.AuditID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to delegate question",
+		})
+	}
+
+	_, err = clientQueries.GetQuestionAssignment(ctx, clientdb.GetQuestionAssignmentParams{
+		QuestionID: questionID,
+		AssignedTo: assigneeID,
+	})
+	if err == nil {
+		return c.JSON(http.StatusConflict, map[string]string{
+			"error": "The question is already delegated to this user",
+		})
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		h.logger.Errorw("Failed to get question assignment", "error", err, "question_id", questionID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to delegate question",
+		})
+	}
+
+	assignment, err := clientQueries.CreateQuestionAssignment(ctx, clientdb.CreateQuestionAssignmentParams{
+		QuestionID: questionID,
+		AssignedTo: assigneeID,
+		AssignedBy: userID,
+		Notes:      req.Notes,
+	})
+	if err != nil {
+		h.logger.Errorw("Failed to create question assignment", "error", err, "question_id", questionID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to delegate question",
+		})
+	}
+
+	h.logger.Infow("Question delegated",
+		"question_id", questionID,
+		"client_id", clientID,
+		"assigned_to", assigneeID,
+		"assigned_by", userID)
+
+	h.notifyQuestionDelegated(ctx, clientID, audit, question, assignee, delegator.Name, req.Notes)
+
+	return c.JSON(http.StatusCreated, QuestionAssignmentResponse{
+		ID:         assignment.ID.String(),
+		QuestionID: assignment.QuestionID.String(),
+		AssignedTo: assignment.AssignedTo.String(),
+		AssignedBy: assignment.AssignedBy.String(),
+		AssignedAt: assignment.AssignedAt.Time,
+		Notes:      assignment.Notes,
+	})
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientdb"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/locale"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// questionLocales returns the locales to serve questions in, most preferred
+// first: the locale query parameter, the user's preferred locale and then the
+// Accept-Language header. The list stops at the canonical language of the
+// audit, e.g. Accept-Language: en, fr serves an English framework untranslated.
+func (h *Handler) questionLocales(c echo.Context, userID uuid.UUID, canonical string) []string {
+	preferred := []string{c.QueryParam("locale")}
+
+	user, err := h.store.Queries.GetUser(c.Request().Context(), userID)
+	if err != nil {
+		h.logger.Warnw("Failed to get preferred locale", "error", err, "user_id", userID)
+	} else if user.PreferredLocale != nil {
+		preferred = append(preferred, *user.PreferredLocale)
+	}
+
+	preferred = append(preferred, locale.FromAcceptLanguage(c.Request().Header.Get("Accept-Language"))...)
+	return locale.Before(locale.Candidates(preferred...), canonical)
+}
+
+// auditQuestionTranslations returns the translations of the questions of an
+// audit into the given locales, by question and locale
+func auditQuestionTranslations(ctx context.Context, queries *clientdb.Queries, auditID uuid.UUID, locales []string) (map[uuid.UUID]map[string]clientdb.QuestionTranslation, error) {
+	if len(locales) == 0 {
+		return nil, nil
+	}
+
+	rows, err := queries.ListQuestionTranslationsByAudit(ctx, clientdb.ListQuestionTranslationsByAuditParams{
+		AuditID: auditID,
+		Locales: locales,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list question translations: %w", err)
+	}
+
+	translations := make(map[uuid.UUID]map[string]clientdb.QuestionTranslation)
+	for _, t := range rows {
+		if translations[t.QuestionID] == nil {
+			translations[t.QuestionID] = make(map[string]clientdb.QuestionTranslation)
+		}
+		translations[t.QuestionID][t.Locale] = t
+	}
+	return translations, nil
+}
+
+// localizeClientQuestion replaces the text of a question with its translation
+// into the most preferred locale that has one, field by field. Fields without
+// a translation keep the canonical text, and so does the whole question when
+// the canonical language is preferred, i.e. locales is empty.
+func localizeClientQuestion(q *ClientQuestionResponse, translations map[string]clientdb.QuestionTranslation, locales []string) {
+	if len(translations) == 0 || len(locales) == 0 {
+		return
+	}
+
+	if text := locale.Pick(locales, func(l string) *string { return translations[l].QuestionText }); text != nil {
+		q.QuestionText = *text
+	}
+	if helpText := locale.Pick(locales, func(l string) *string { return translations[l].HelpText }); helpText != nil {
+		q.HelpText = helpText
+	}
+	if section := locale.Pick(locales, func(l string) *string { return translations[l].Section }); section != nil {
+		q.Section = *section
+	}
+	if subsection := locale.Pick(locales, func(l string) *string { return translations[l].Subsection }); subsection != nil {
+		q.Subsection = subsection
+	}
+
+	for _, l := range locales {
+		if _, ok := translations[l]; ok {
+			q.Locale = &l
+			return
+		}
+	}
+}
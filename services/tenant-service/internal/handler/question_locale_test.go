@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientdb"
+)
+
+func TestLocalizeClientQuestion(t *testing.T) {
+	text := func(s string) *string { return &s }
+	translations := map[string]clientdb.QuestionTranslation{
+		"hi": {
+			Locale:       "hi",
+			QuestionText: text("क्या नीति स्वीकृत है?"),
+			HelpText:     text("हस्ताक्षरित नीति संलग्न करें"),
+			Section:      text("शासन"),
+		},
+		"mr": {
+			Locale:       "mr",
+			QuestionText: text("धोरण मंजूर आहे का?"),
+		},
+	}
+
+	tests := []struct {
+		name        string
+		locales     []string
+		wantText    string
+		wantHelp    string
+		wantSection string
+		wantLocale  string
+	}{
+		{
+			name:        "canonical language preferred",
+			wantText:    "Is the policy approved?",
+			wantHelp:    "Attach the signed policy",
+			wantSection: "Governance",
+		},
+		{
+			name:        "fully translated",
+			locales:     []string{"hi"},
+			wantText:    "क्या नीति स्वीकृत है?",
+			wantHelp:    "हस्ताक्षरित नीति संलग्न करें",
+			wantSection: "शासन",
+			wantLocale:  "hi",
+		},
+		{
+			name:        "missing fields fall back to the next locale",
+			locales:     []string{"mr", "hi"},
+			wantText:    "धोरण मंजूर आहे का?",
+			wantHelp:    "हस्ताक्षरित नीति संलग्न करें",
+			wantSection: "शासन",
+			wantLocale:  "mr",
+		},
+		{
+			name:        "missing fields keep the canonical text",
+			locales:     []string{"mr"},
+			wantText:    "धोरण मंजूर आहे का?",
+			wantHelp:    "Attach the signed policy",
+			wantSection: "Governance",
+			wantLocale:  "mr",
+		},
+		{
+			name:        "no translation in the locale",
+			locales:     []string{"fr"},
+			wantText:    "Is the policy approved?",
+			wantHelp:    "Attach the signed policy",
+			wantSection: "Governance",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := ClientQuestionResponse{
+				Section:      "Governance",
+				QuestionText: "Is the policy approved?",
+				HelpText:     text("Attach the signed policy"),
+			}
+
+			localizeClientQuestion(&q, translations, tt.locales)
+
+			if q.QuestionText != tt.wantText || *q.HelpText != tt.wantHelp || q.Section != tt.wantSection {
+				t.Errorf("question = %q %q %q, want %q %q %q",
+					q.QuestionText, *q.HelpText, q.Section, tt.wantText, tt.wantHelp, tt.wantSection)
+			}
+			gotLocale := ""
+			if q.Locale != nil {
+				gotLocale = *q.Locale
+			}
+			if gotLocale != tt.wantLocale {
+				t.Errorf("locale = %q, want %q", gotLocale, tt.wantLocale)
+			}
+		})
+	}
+}
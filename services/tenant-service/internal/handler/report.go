@@ -6,8 +6,12 @@ import (
 	"fmt"
 	"html/template"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/NormaTech-AI/audity/packages/go/rbac"
+	"github.com/NormaTech-AI/audity/packages/go/eventbus"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/answers"
 	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientdb"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -40,6 +44,8 @@ type ReportData struct {
 	AuditStatus   string
 	DueDate       string
 	Questions     []QuestionReportData
+	Score         AuditScoreResponse
+	Findings      []FindingReportData
 	GeneratedAt   string
 	GeneratedBy   string
 }
@@ -51,11 +57,31 @@ type QuestionReportData struct {
 	QuestionText   string
 	Answer         string
 	AnswerValue    string
+	TableColumns   []string
+	TableRows      [][]string
 	Status         string
 	Evidence       []string
 }
 
+// FindingReportData holds an unresolved finding for reports
+type FindingReportData struct {
+	QuestionNumber  string
+	Title           string
+	Severity        string
+	Status          string
+	TargetDate      string
+	RemediationPlan string
+}
+
+// GenerateReportRequest represents the optional payload for report generation
+type GenerateReportRequest struct {
+	Override              bool   `json:"override"`
+	OverrideJustification string `json:"override_justification"`
+}
+
 // GenerateReport generates an audit report (HTML and PDF)
+// Generation is refused while questions block readiness unless an auditor
+// overrides the check with a justification.
 func (h *Handler) GenerateReport(c echo.Context) error {
 	ctx := c.Request().Context()
 
@@ -74,15 +100,22 @@ func (h *Handler) GenerateReport(c echo.Context) error {
 	}
 
 	// Get user info from context
-	userID := c.Get("user_id").(string)
-	userEmail := c.Get("user_email").(string)
-
-	generatedBy, err := uuid.Parse(userID)
+	generatedBy, err := getUserIDFromContext(c)
 	if err != nil {
 		return c.JSON(http.StatusUnauthorized, map[string]string{
 			"error": "Invalid user ID",
 		})
 	}
+	userEmail, _ := c.Get("user_email").(string)
+
+	// Request body is optional; it is only needed to override readiness checks
+	var req GenerateReportRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+	req.OverrideJustification = strings.TrimSpace(req.OverrideJustification)
 
 	// Get client database queries
 	clientQueries, _, err := h.clientStore.GetClientQueries(ctx, clientID)
@@ -102,6 +135,90 @@ func (h *Handler) GenerateReport(c echo.Context) error {
 		})
 	}
 
+	// Check that every mandatory question is approved before generating
+	readinessRows, err := clientQueries.ListQuestionReadiness(ctx, auditID)
+	if err != nil {
+		h.logger.Errorw("Failed to get question readiness", "error", err, "audit_id", auditID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to check report readiness",
+		})
+	}
+	readiness := evaluateReportReadiness(auditID, readinessRows)
+	score := evaluateAuditScore(auditID, readinessRows)
+
+	findings, err := clientQueries.ListUnresolvedFindingsByAudit(ctx, auditID)
+	if err != nil {
+		h.logger.Errorw("Failed to get findings", "error", err, "audit_id", auditID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve audit findings",
+		})
+	}
+
+	metadata := map[string]interface{}{
+		"readiness": map[string]interface{}{
+			"ready":               readiness.Ready,
+			"total_questions":     readiness.TotalQuestions,
+			"hidden_questions":    readiness.HiddenQuestions,
+			"mandatory_questions": readiness.MandatoryQuestions,
+			"approved_mandatory":  readiness.ApprovedMandatory,
+			"excepted_questions":  readiness.ExceptedQuestions,
+			"blocking_count":      len(readiness.BlockingQuestions),
+		},
+		"compliance_score": score.Score,
+		"open_findings":    len(findings),
+	}
+
+	if !readiness.Ready {
+		if !req.Override {
+			return c.JSON(http.StatusConflict, map[string]interface{}{
+				"error":     "Audit is not ready for report generation",
+				"readiness": readiness,
+			})
+		}
+
+		if req.OverrideJustification == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Override justification is required",
+			})
+		}
+
+		canOverride, err := rbac.HasPermission(ctx, h.store, generatedBy, "reports:override")
+		if err != nil {
+			h.logger.Errorw("Failed to check override permission", "error", err, "user_id", generatedBy)
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Failed to verify permissions",
+			})
+		}
+		if !canOverride {
+			return c.JSON(http.StatusForbidden, map[string]string{
+				"error":    "Insufficient permissions to override report readiness",
+				"required": "reports:override",
+			})
+		}
+
+		metadata["override"] = map[string]interface{}{
+			"justification":       req.OverrideJustification,
+			"overridden_by":       generatedBy.String(),
+			"overridden_by_email": userEmail,
+			"overridden_at":       time.Now().UTC().Format(time.RFC3339),
+			"blocking_questions":  readiness.BlockingQuestions,
+		}
+
+		h.logger.Warnw("Report readiness overridden",
+			"audit_id", auditID,
+			"client_id", clientID,
+			"user_id", generatedBy,
+			"blocking_count", len(readiness.BlockingQuestions))
+	}
+
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		h.logger.Errorw("Failed to marshal report metadata", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to generate report",
+		})
+	}
+
 	// Get questions with submissions
 	questions, err := clientQueries.ListQuestionsWithSubmissions(ctx, auditID)
 	if err != nil {
@@ -121,6 +238,22 @@ func (h *Handler) GenerateReport(c echo.Context) error {
 		GeneratedAt:   time.Now().Format("2006-01-02 15:04:05"),
 		GeneratedBy:   userEmail,
 		Questions:     make([]QuestionReportData, 0),
+		Score:         score,
+		Findings:      make([]FindingReportData, 0, len(findings)),
+	}
+
+	for _, f := range findings {
+		fData := FindingReportData{
+			QuestionNumber: f.QuestionNumber,
+			Title:          f.Title,
+			Severity:       string(f.Severity),
+			Status:         string(f.Status),
+			TargetDate:     formatDate(f.TargetDate),
+		}
+		if f.RemediationPlan != nil {
+			fData.RemediationPlan = *f.RemediationPlan
+		}
+		reportData.Findings = append(reportData.Findings, fData)
 	}
 
 	// Process questions
@@ -136,6 +269,24 @@ func (h *Handler) GenerateReport(c echo.Context) error {
 			qData.Status = string(q.SubmissionStatus.SubmissionStatusEnum)
 		}
 
+		if q.AnswerValue.Valid {
+			qData.AnswerValue = string(q.AnswerValue.AnswerValueEnum)
+		}
+
+		// Table answers are laid out as a table rather than inline text
+		answerData := q.AnswerData
+		if q.QuestionType == clientdb.QuestionTypeEnumTable {
+			if data, err := answers.ParseData(q.AnswerData); err == nil && data != nil {
+				columns := answers.TableColumns(q.QuestionType, q.Options, data)
+				for _, column := range columns {
+					qData.TableColumns = append(qData.TableColumns, column.Label)
+				}
+				qData.TableRows = answers.TableRows(columns, data)
+			}
+			answerData = nil
+		}
+		qData.Answer = answers.Render(q.QuestionType, q.Options, q.AnswerValue, q.AnswerText, answerData)
+
 		reportData.Questions = append(reportData.Questions, qData)
 	}
 
@@ -169,6 +320,7 @@ func (h *Handler) GenerateReport(c echo.Context) error {
 		UnsignedFilePath: &htmlPath,
 		GeneratedBy:      generatedBy,
 		Status:           clientdb.ReportStatusEnumGenerated,
+		Metadata:         metadataJSON,
 	})
 	if err != nil {
 		h.logger.Errorw("Failed to create report record", "error", err)
@@ -441,6 +593,8 @@ func (h *Handler) SignReport(c echo.Context) error {
 
 	h.logger.Infow("Report signed", "report_id", reportID, "signed_by", userID, "client_id", clientID)
 
+	h.notifyReportSigned(ctx, clientID, clientQueries, signedReport, signedBy)
+
 	response := buildReportResponse(signedReport, nil)
 
 	return c.JSON(http.StatusOK, response)
@@ -473,8 +627,19 @@ func (h *Handler) MarkReportDelivered(c echo.Context) error {
 		})
 	}
 
-	// Mark as delivered
-	report, err := clientQueries.MarkReportDelivered(ctx, reportID)
+	// Mark as delivered and record the event in the same transaction
+	var report clientdb.Report
+	err = h.clientStore.ExecClientTx(ctx, clientID, func(q *clientdb.Queries) error {
+		var err error
+		report, err = q.MarkReportDelivered(ctx, reportID)
+		if err != nil {
+			return err
+		}
+		return h.emitEvent(ctx, q, clientID, eventbus.ReportDeliveredEvent{
+			ReportID: report.ID,
+			AuditID:  report.AuditID,
+		})
+	})
 	if err != nil {
 		h.logger.Errorw("Failed to mark report as delivered", "error", err, "report_id", reportID)
 		return c.JSON(http.StatusInternalServerError, map[string]string{
@@ -482,8 +647,12 @@ func (h *Handler) MarkReportDelivered(c echo.Context) error {
 		})
 	}
 
+	h.publishEvents()
+
 	h.logger.Infow("Report marked as delivered", "report_id", reportID, "client_id", clientID)
 
+	h.notifyReportDelivered(ctx, clientID, clientQueries, report)
+
 	response := buildReportResponse(report, nil)
 
 	return c.JSON(http.StatusOK, response)
@@ -712,6 +881,15 @@ func generateHTMLReport(data ReportData) (string, error) {
             margin-top: 10px;
             border-left: 3px solid #28a745;
         }
+        .answer table {
+            border-collapse: collapse;
+            margin-top: 8px;
+        }
+        .answer th, .answer td {
+            border: 1px solid #ddd;
+            padding: 4px 8px;
+            text-align: left;
+        }
         .status {
             display: inline-block;
             padding: 3px 10px;
@@ -723,6 +901,17 @@ func generateHTMLReport(data ReportData) (string, error) {
         .status-submitted { background: #ffc107; color: #000; }
         .status-rejected { background: #dc3545; color: white; }
         .status-draft { background: #6c757d; color: white; }
+        .score-table {
+            width: 100%;
+            border-collapse: collapse;
+            margin-bottom: 30px;
+        }
+        .score-table th, .score-table td {
+            border: 1px solid #ddd;
+            padding: 6px 10px;
+            text-align: left;
+        }
+        .score-table th { background: #f0f6ff; }
         .footer {
             margin-top: 50px;
             padding-top: 20px;
@@ -763,8 +952,60 @@ func generateHTMLReport(data ReportData) (string, error) {
             <strong>Generated By</strong>
             {{.GeneratedBy}}
         </div>
+        <div class="meta-item">
+            <strong>Compliance Score</strong>
+            {{percent .Score.Score}}
+        </div>
     </div>
 
+    {{if .Score.Sections}}
+        <div class="section-header">Compliance Score by Section</div>
+        <table class="score-table">
+            <tr>
+                <th>Section</th>
+                <th>Score</th>
+                <th>Scored</th>
+                <th>Non-compliant</th>
+                <th>Not applicable</th>
+                <th>Pending</th>
+            </tr>
+            {{range .Score.Sections}}
+                <tr>
+                    <td>{{.Section}}</td>
+                    <td>{{percent .Score}}</td>
+                    <td>{{.ScoredQuestions}}</td>
+                    <td>{{.NonCompliantQuestions}}</td>
+                    <td>{{.ExcludedQuestions}}</td>
+                    <td>{{.PendingQuestions}}</td>
+                </tr>
+            {{end}}
+        </table>
+    {{end}}
+
+    {{if .Findings}}
+        <div class="section-header">Open Findings</div>
+        <table>
+            <tr>
+                <th>Question</th>
+                <th>Finding</th>
+                <th>Severity</th>
+                <th>Status</th>
+                <th>Target Date</th>
+                <th>Remediation Plan</th>
+            </tr>
+            {{range .Findings}}
+                <tr>
+                    <td>{{.QuestionNumber}}</td>
+                    <td>{{.Title}}</td>
+                    <td>{{.Severity}}</td>
+                    <td>{{.Status}}</td>
+                    <td>{{.TargetDate}}</td>
+                    <td>{{.RemediationPlan}}</td>
+                </tr>
+            {{end}}
+        </table>
+    {{end}}
+
     {{$currentSection := ""}}
     {{range .Questions}}
         {{if ne .Section $currentSection}}
@@ -782,12 +1023,20 @@ func generateHTMLReport(data ReportData) (string, error) {
                 <span class="status status-{{.Status | lower}}">{{.Status}}</span>
             </div>
             <div class="question-text">{{.QuestionText}}</div>
-            {{if .Answer}}
+            {{if or .Answer .TableRows}}
                 <div class="answer">
                     <strong>Answer:</strong> {{.Answer}}
                     {{if .AnswerValue}}
                         <br><strong>Value:</strong> {{.AnswerValue}}
                     {{end}}
+                    {{if .TableRows}}
+                        <table>
+                            <tr>{{range .TableColumns}}<th>{{.}}</th>{{end}}</tr>
+                            {{range .TableRows}}
+                                <tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+                            {{end}}
+                        </table>
+                    {{end}}
                 </div>
             {{end}}
         </div>
@@ -809,6 +1058,12 @@ func generateHTMLReport(data ReportData) (string, error) {
 		"lower": func(s string) string {
 			return fmt.Sprintf("%s", s)
 		},
+		"percent": func(score *float64) string {
+			if score == nil {
+				return "Not scored"
+			}
+			return fmt.Sprintf("%.2f%%", *score)
+		},
 	}
 
 	t, err := template.New("report").Funcs(funcMap).Parse(tmpl)
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+
+	"github.com/NormaTech-AI/audity/packages/go/spreadsheet"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/answers"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// ExportAuditAnswers exports every question of an audit with its rendered answer as CSV
+func (h *Handler) ExportAuditAnswers(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	clientID, err := uuid.Parse(c.Param("clientId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid client ID",
+		})
+	}
+
+	auditID, err := uuid.Parse(c.Param("auditId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid audit ID",
+		})
+	}
+
+	// Get client database queries
+	clientQueries, _, err := h.clientStore.GetClientQueries(ctx, clientID)
+	if err != nil {
+		h.logger.Errorw("Failed to get client queries", "error", err, "client_id", clientID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to access client data",
+		})
+	}
+
+	if _, err := clientQueries.GetAuditByID(ctx, auditID); err != nil {
+		h.logger.Errorw("Failed to get audit", "error", err, "audit_id", auditID)
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Audit not found",
+		})
+	}
+
+	questions, err := clientQueries.ListQuestionsWithSubmissions(ctx, auditID)
+	if err != nil {
+		h.logger.Errorw("Failed to get questions", "error", err, "audit_id", auditID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve audit data",
+		})
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"section", "question_number", "question_text", "question_type", "answer_value", "answer", "status"})
+
+	for _, q := range questions {
+		var answerValue string
+		if q.AnswerValue.Valid {
+			answerValue = string(q.AnswerValue.AnswerValueEnum)
+		}
+
+		status := "Not Answered"
+		if q.SubmissionStatus.Valid {
+			status = string(q.SubmissionStatus.SubmissionStatusEnum)
+		}
+
+		w.Write(spreadsheet.SafeRow([]string{
+			q.Section,
+			q.QuestionNumber,
+			q.QuestionText,
+			string(q.QuestionType),
+			answerValue,
+			answers.Render(q.QuestionType, q.Options, q.AnswerValue, q.AnswerText, q.AnswerData),
+			status,
+		}))
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		h.logger.Errorw("Failed to write answer export", "error", err, "audit_id", auditID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to export answers",
+		})
+	}
+
+	fileName := fmt.Sprintf("audit-answers-%s.csv", auditID.String()[:8])
+	c.Response().Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", fileName))
+
+	return c.Blob(http.StatusOK, "text/csv", buf.Bytes())
+}
@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/answers"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientdb"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/framework"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// Reasons a question blocks report generation
+const (
+	BlockingReasonUnanswered      = "unanswered"
+	BlockingReasonPendingReview   = "pending_review"
+	BlockingReasonRejected        = "rejected"
+	BlockingReasonReferred        = "referred"
+	BlockingReasonMissingEvidence = "missing_evidence"
+)
+
+// BlockingQuestion represents a question that prevents report generation
+type BlockingQuestion struct {
+	QuestionID       string   `json:"question_id"`
+	Section          string   `json:"section"`
+	QuestionNumber   string   `json:"question_number"`
+	QuestionText     string   `json:"question_text"`
+	IsMandatory      bool     `json:"is_mandatory"`
+	SubmissionStatus *string  `json:"submission_status"`
+	EvidenceCount    int64    `json:"evidence_count"`
+	Reasons          []string `json:"reasons"`
+}
+
+// ReportReadinessResponse describes whether an audit is ready for report generation
+type ReportReadinessResponse struct {
+	AuditID            string             `json:"audit_id"`
+	Ready              bool               `json:"ready"`
+	TotalQuestions     int                `json:"total_questions"`
+	HiddenQuestions    int                `json:"hidden_questions"`
+	MandatoryQuestions int                `json:"mandatory_questions"`
+	ApprovedMandatory  int                `json:"approved_mandatory"`
+	ExceptedQuestions  int                `json:"excepted_questions"`
+	BlockingQuestions  []BlockingQuestion `json:"blocking_questions"`
+}
+
+// GetReportReadiness lists the questions that block report generation for an audit
+func (h *Handler) GetReportReadiness(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	clientID, err := uuid.Parse(c.Param("clientId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid client ID",
+		})
+	}
+
+	auditID, err := uuid.Parse(c.Param("auditId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid audit ID",
+		})
+	}
+
+	// Get client database queries
+	clientQueries, _, err := h.clientStore.GetClientQueries(ctx, clientID)
+	if err != nil {
+		h.logger.Errorw("Failed to get client queries", "error", err, "client_id", clientID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to access client data",
+		})
+	}
+
+	if _, err := clientQueries.GetAuditByID(ctx, auditID); err != nil {
+		h.logger.Errorw("Failed to get audit", "error", err, "audit_id", auditID)
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Audit not found",
+		})
+	}
+
+	rows, err := clientQueries.ListQuestionReadiness(ctx, auditID)
+	if err != nil {
+		h.logger.Errorw("Failed to get question readiness", "error", err, "audit_id", auditID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to check report readiness",
+		})
+	}
+
+	return c.JSON(http.StatusOK, evaluateReportReadiness(auditID, rows))
+}
+
+// evaluateReportReadiness applies the report gating rules to the latest
+// submission of every question in the audit.
+//
+// Mandatory questions must be approved and, unless answered "na", carry at
+// least one evidence file. Optional questions may stay unanswered but block
+// the report while they are rejected or referred. Questions hidden by their
+// visibility condition are ignored, and questions covered by an approved,
+// unexpired exception count as satisfied.
+func evaluateReportReadiness(auditID uuid.UUID, rows []clientdb.ListQuestionReadinessRow) ReportReadinessResponse {
+	readiness := ReportReadinessResponse{
+		AuditID:           auditID.String(),
+		BlockingQuestions: make([]BlockingQuestion, 0),
+	}
+
+	visible := resolveQuestionVisibility(rows)
+
+	for _, row := range rows {
+		if !visible[row.QuestionNumber] {
+			readiness.HiddenQuestions++
+			continue
+		}
+		readiness.TotalQuestions++
+
+		if row.HasActiveException {
+			readiness.ExceptedQuestions++
+			if row.IsMandatory {
+				readiness.MandatoryQuestions++
+			}
+			continue
+		}
+
+		var reasons []string
+
+		status := clientdb.SubmissionStatusEnumNotStarted
+		if row.SubmissionStatus.Valid {
+			status = row.SubmissionStatus.SubmissionStatusEnum
+		}
+
+		switch status {
+		case clientdb.SubmissionStatusEnumRejected:
+			reasons = append(reasons, BlockingReasonRejected)
+		case clientdb.SubmissionStatusEnumReferred:
+			reasons = append(reasons, BlockingReasonReferred)
+		case clientdb.SubmissionStatusEnumSubmitted:
+			if row.IsMandatory {
+				reasons = append(reasons, BlockingReasonPendingReview)
+			}
+		case clientdb.SubmissionStatusEnumNotStarted, clientdb.SubmissionStatusEnumInProgress:
+			if row.IsMandatory {
+				reasons = append(reasons, BlockingReasonUnanswered)
+			}
+		}
+
+		if row.IsMandatory {
+			readiness.MandatoryQuestions++
+			if status == clientdb.SubmissionStatusEnumApproved {
+				readiness.ApprovedMandatory++
+			}
+
+			answeredNA := row.AnswerValue.Valid && row.AnswerValue.AnswerValueEnum == clientdb.AnswerValueEnumNa
+			if row.SubmissionID.Valid && !answeredNA && row.EvidenceCount == 0 {
+				reasons = append(reasons, BlockingReasonMissingEvidence)
+			}
+		}
+
+		if len(reasons) == 0 {
+			continue
+		}
+
+		var submissionStatus *string
+		if row.SubmissionStatus.Valid {
+			s := string(row.SubmissionStatus.SubmissionStatusEnum)
+			submissionStatus = &s
+		}
+
+		readiness.BlockingQuestions = append(readiness.BlockingQuestions, BlockingQuestion{
+			QuestionID:       row.ID.String(),
+			Section:          row.Section,
+			QuestionNumber:   row.QuestionNumber,
+			QuestionText:     row.QuestionText,
+			IsMandatory:      row.IsMandatory,
+			SubmissionStatus: submissionStatus,
+			EvidenceCount:    row.EvidenceCount,
+			Reasons:          reasons,
+		})
+	}
+
+	readiness.Ready = len(readiness.BlockingQuestions) == 0
+
+	return readiness
+}
+
+// resolveQuestionVisibility evaluates visibility conditions against the latest
+// answers of an audit, keyed by question number
+func resolveQuestionVisibility(rows []clientdb.ListQuestionReadinessRow) map[string]bool {
+	questions := make([]framework.ConditionalQuestion, 0, len(rows))
+	for _, row := range rows {
+		questions = append(questions, framework.ConditionalQuestion{
+			QuestionNumber: row.QuestionNumber,
+			Condition:      row.VisibilityCondition,
+			Answers:        answers.Values(row.AnswerValue, row.AnswerData),
+		})
+	}
+
+	return framework.ResolveVisibility(questions)
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientdb"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// readinessRow returns the readiness of a question with the given latest
+// submission status, or of an unanswered question when status is empty
+func readinessRow(number string, mandatory bool, status clientdb.SubmissionStatusEnum, evidence int64) clientdb.ListQuestionReadinessRow {
+	row := clientdb.ListQuestionReadinessRow{
+		ID:             uuid.New(),
+		Section:        "Governance",
+		QuestionNumber: number,
+		IsMandatory:    mandatory,
+		EvidenceCount:  evidence,
+	}
+	if status != "" {
+		row.SubmissionID = pgtype.UUID{Bytes: uuid.New(), Valid: true}
+		row.SubmissionStatus = clientdb.NullSubmissionStatusEnum{SubmissionStatusEnum: status, Valid: true}
+	}
+	return row
+}
+
+func TestEvaluateReportReadiness(t *testing.T) {
+	answeredNA := readinessRow("1.1", true, clientdb.SubmissionStatusEnumApproved, 0)
+	answeredNA.AnswerValue = clientdb.NullAnswerValueEnum{AnswerValueEnum: clientdb.AnswerValueEnumNa, Valid: true}
+
+	tests := []struct {
+		name          string
+		rows          []clientdb.ListQuestionReadinessRow
+		wantReady     bool
+		wantBlocking  map[string][]string
+		wantMandatory int
+		wantApproved  int
+	}{
+		{
+			name:          "approved mandatory with evidence",
+			rows:          []clientdb.ListQuestionReadinessRow{readinessRow("1.1", true, clientdb.SubmissionStatusEnumApproved, 1)},
+			wantReady:     true,
+			wantBlocking:  map[string][]string{},
+			wantMandatory: 1,
+			wantApproved:  1,
+		},
+		{
+			name:          "unanswered mandatory",
+			rows:          []clientdb.ListQuestionReadinessRow{readinessRow("1.1", true, "", 0)},
+			wantBlocking:  map[string][]string{"1.1": {BlockingReasonUnanswered}},
+			wantMandatory: 1,
+		},
+		{
+			name:          "mandatory in progress",
+			rows:          []clientdb.ListQuestionReadinessRow{readinessRow("1.1", true, clientdb.SubmissionStatusEnumInProgress, 1)},
+			wantBlocking:  map[string][]string{"1.1": {BlockingReasonUnanswered}},
+			wantMandatory: 1,
+		},
+		{
+			name:          "mandatory pending review",
+			rows:          []clientdb.ListQuestionReadinessRow{readinessRow("1.1", true, clientdb.SubmissionStatusEnumSubmitted, 1)},
+			wantBlocking:  map[string][]string{"1.1": {BlockingReasonPendingReview}},
+			wantMandatory: 1,
+		},
+		{
+			name:          "approved mandatory without evidence",
+			rows:          []clientdb.ListQuestionReadinessRow{readinessRow("1.1", true, clientdb.SubmissionStatusEnumApproved, 0)},
+			wantBlocking:  map[string][]string{"1.1": {BlockingReasonMissingEvidence}},
+			wantMandatory: 1,
+			wantApproved:  1,
+		},
+		{
+			name:          "mandatory answered not applicable needs no evidence",
+			rows:          []clientdb.ListQuestionReadinessRow{answeredNA},
+			wantReady:     true,
+			wantBlocking:  map[string][]string{},
+			wantMandatory: 1,
+			wantApproved:  1,
+		},
+		{
+			name:         "unanswered optional",
+			rows:         []clientdb.ListQuestionReadinessRow{readinessRow("1.1", false, "", 0)},
+			wantReady:    true,
+			wantBlocking: map[string][]string{},
+		},
+		{
+			name: "rejected and referred optional",
+			rows: []clientdb.ListQuestionReadinessRow{
+				readinessRow("1.1", false, clientdb.SubmissionStatusEnumRejected, 0),
+				readinessRow("1.2", false, clientdb.SubmissionStatusEnumReferred, 0),
+			},
+			wantBlocking: map[string][]string{
+				"1.1": {BlockingReasonRejected},
+				"1.2": {BlockingReasonReferred},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := evaluateReportReadiness(uuid.New(), tt.rows)
+
+			if got.Ready != tt.wantReady {
+				t.Errorf("Ready = %v, want %v", got.Ready, tt.wantReady)
+			}
+			if got.MandatoryQuestions != tt.wantMandatory || got.ApprovedMandatory != tt.wantApproved {
+				t.Errorf("mandatory = %d approved = %d, want %d and %d",
+					got.MandatoryQuestions, got.ApprovedMandatory, tt.wantMandatory, tt.wantApproved)
+			}
+
+			blocking := make(map[string][]string, len(got.BlockingQuestions))
+			for _, q := range got.BlockingQuestions {
+				blocking[q.QuestionNumber] = q.Reasons
+			}
+			if !reflect.DeepEqual(blocking, tt.wantBlocking) {
+				t.Errorf("blocking = %v, want %v", blocking, tt.wantBlocking)
+			}
+		})
+	}
+}
+
+func TestEvaluateReportReadinessSkipsHiddenQuestions(t *testing.T) {
+	parent := readinessRow("1.1", true, clientdb.SubmissionStatusEnumApproved, 1)
+	parent.AnswerValue = clientdb.NullAnswerValueEnum{AnswerValueEnum: clientdb.AnswerValueEnumNo, Valid: true}
+
+	hidden := readinessRow("1.2", true, "", 0)
+	hidden.VisibilityCondition = []byte(`{"depends_on":"1.1","operator":"equals","values":["yes"]}`)
+
+	shown := readinessRow("1.3", true, "", 0)
+	shown.VisibilityCondition = []byte(`{"depends_on":"1.1","operator":"equals","values":["no"]}`)
+
+	got := evaluateReportReadiness(uuid.New(), []clientdb.ListQuestionReadinessRow{parent, hidden, shown})
+
+	if got.HiddenQuestions != 1 || got.TotalQuestions != 2 || got.MandatoryQuestions != 2 {
+		t.Errorf("hidden = %d total = %d mandatory = %d, want 1, 2 and 2",
+			got.HiddenQuestions, got.TotalQuestions, got.MandatoryQuestions)
+	}
+	if len(got.BlockingQuestions) != 1 || got.BlockingQuestions[0].QuestionNumber != "1.3" {
+		t.Errorf("blocking = %+v, want only 1.3", got.BlockingQuestions)
+	}
+}
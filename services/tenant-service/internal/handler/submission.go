@@ -1,10 +1,16 @@
 package handler
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/NormaTech-AI/audity/packages/go/eventbus"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/answers"
 	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientdb"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/evidencereq"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/labstack/echo/v4"
@@ -12,32 +18,47 @@ import (
 
 // SubmissionResponse represents a submission in API responses
 type SubmissionResponse struct {
-	ID             string  `json:"id"`
-	QuestionID     string  `json:"question_id"`
-	SubmittedBy    string  `json:"submitted_by"`
-	Answer         *string `json:"answer"`
-	AnswerValue    *string `json:"answer_value"`
-	Status         string  `json:"status"`
-	Version        int32   `json:"version"`
-	ReviewedBy     *string `json:"reviewed_by"`
-	ReviewedAt     *string `json:"reviewed_at"`
-	RejectionNotes *string `json:"rejection_notes"`
-	SubmittedAt    *string `json:"submitted_at"`
-	CreatedAt      string  `json:"created_at"`
-	UpdatedAt      string  `json:"updated_at"`
+	ID             string          `json:"id"`
+	QuestionID     string          `json:"question_id"`
+	SubmittedBy    string          `json:"submitted_by"`
+	Answer         *string         `json:"answer"`
+	AnswerValue    *string         `json:"answer_value"`
+	AnswerData     json.RawMessage `json:"answer_data,omitempty"`
+	Status         string          `json:"status"`
+	Version        int32           `json:"version"`
+	ReviewedBy     *string         `json:"reviewed_by"`
+	ReviewedAt     *string         `json:"reviewed_at"`
+	RejectionNotes *string         `json:"rejection_notes"`
+	SubmittedAt    *string         `json:"submitted_at"`
+	CarriedForward bool            `json:"carried_forward"`
+	PropagatedFrom *string         `json:"propagated_from"`
+	// EvidenceChecklist checks the evidence of the answer against the
+	// requirements of its question, for review
+	EvidenceChecklist []evidencereq.Check `json:"evidence_checklist,omitempty"`
+	CreatedAt         string              `json:"created_at"`
+	UpdatedAt         string              `json:"updated_at"`
+}
+
+// SubmitSubmissionResponse is a submitted answer with the number of
+// equivalent mapped questions in other audits it was copied to
+type SubmitSubmissionResponse struct {
+	SubmissionResponse
+	PropagatedCount int64 `json:"propagated_count"`
 }
 
 // CreateSubmissionRequest represents the request to create/update a submission
 type CreateSubmissionRequest struct {
-	QuestionID  string  `json:"question_id" validate:"required"`
-	Answer      *string `json:"answer"`
-	AnswerValue *string `json:"answer_value"`
+	QuestionID  string        `json:"question_id" validate:"required"`
+	Answer      *string       `json:"answer"`
+	AnswerValue *string       `json:"answer_value"`
+	AnswerData  *answers.Data `json:"answer_data"`
 }
 
 // UpdateSubmissionRequest represents the request to update a submission answer
 type UpdateSubmissionRequest struct {
-	Answer      *string `json:"answer"`
-	AnswerValue *string `json:"answer_value"`
+	Answer      *string       `json:"answer"`
+	AnswerValue *string       `json:"answer_value"`
+	AnswerData  *answers.Data `json:"answer_data"`
 }
 
 // ReviewSubmissionRequest represents the request to review a submission
@@ -95,6 +116,28 @@ func (h *Handler) CreateOrUpdateSubmission(c echo.Context) error {
 		})
 	}
 
+	// Validate the answer against the question type
+	question, err := clientQueries.GetQuestionByID(ctx, questionID)
+	if err != nil {
+		h.logger.Errorw("Failed to get question", "error", err, "question_id", questionID)
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Question not found",
+		})
+	}
+
+	if err := answers.Validate(question, req.AnswerValue, req.AnswerData); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	answerData, err := answers.Marshal(req.AnswerData)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid answer data",
+		})
+	}
+
 	// Check if submission already exists
 	existingSubmissions, err := clientQueries.ListSubmissionsByUser(ctx, submittedBy)
 	if err != nil {
@@ -108,7 +151,7 @@ func (h *Handler) CreateOrUpdateSubmission(c echo.Context) error {
 	for _, sub := range existingSubmissions {
 		if sub.QuestionID == questionID && sub.Status == clientdb.SubmissionStatusEnumInProgress {
 			isUpdate = true
-			
+
 			// Convert answer_value to nullable enum
 			var answerValue clientdb.NullAnswerValueEnum
 			if req.AnswerValue != nil {
@@ -123,6 +166,7 @@ func (h *Handler) CreateOrUpdateSubmission(c echo.Context) error {
 				ID:          sub.ID,
 				AnswerText:  req.Answer,
 				AnswerValue: answerValue,
+				AnswerData:  answerData,
 			})
 			if err != nil {
 				h.logger.Errorw("Failed to update submission", "error", err)
@@ -152,6 +196,7 @@ func (h *Handler) CreateOrUpdateSubmission(c echo.Context) error {
 			AnswerValue: answerValue,
 			Explanation: "", // Optional explanation
 			Status:      clientdb.SubmissionStatusEnumInProgress,
+			AnswerData:  answerData,
 		})
 		if err != nil {
 			h.logger.Errorw("Failed to create submission", "error", err)
@@ -168,9 +213,9 @@ func (h *Handler) CreateOrUpdateSubmission(c echo.Context) error {
 		statusCode = http.StatusCreated
 	}
 
-	h.logger.Infow("Submission saved", 
-		"submission_id", submission.ID, 
-		"question_id", questionID, 
+	h.logger.Infow("Submission saved",
+		"submission_id", submission.ID,
+		"question_id", questionID,
 		"client_id", clientID,
 		"is_update", isUpdate)
 
@@ -204,20 +249,85 @@ func (h *Handler) SubmitForReview(c echo.Context) error {
 		})
 	}
 
-	// Submit the submission
-	submission, err := clientQueries.SubmitSubmission(ctx, submissionID)
+	current, err := clientQueries.GetSubmissionByID(ctx, submissionID)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Submission not found",
+		})
+	}
+
+	if current.IsCarriedForward {
+		return c.JSON(http.StatusConflict, map[string]string{
+			"error": "Carried forward answers must be confirmed or updated before submitting",
+		})
+	}
+
+	// Structured answers are only accepted for review once complete
+	if err := checkAnswerComplete(ctx, clientQueries, current); err != nil {
+		if errors.Is(err, answers.ErrIncomplete) {
+			return c.JSON(http.StatusUnprocessableEntity, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		h.logger.Errorw("Failed to check answer", "error", err, "submission_id", submissionID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to check answer",
+		})
+	}
+
+	// Answers are only accepted for review with the evidence the question requires
+	unmet, err := unmetEvidenceRequirements(ctx, clientQueries, current)
+	if err != nil {
+		h.logger.Errorw("Failed to check evidence requirements", "error", err, "submission_id", submissionID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to check evidence requirements",
+		})
+	}
+	if unmet != nil {
+		return c.JSON(http.StatusUnprocessableEntity, unmet)
+	}
+
+	// Submit the submission and record the event in the same transaction
+	var submission clientdb.Submission
+	err = h.clientStore.ExecClientTx(ctx, clientID, func(q *clientdb.Queries) error {
+		var err error
+		submission, err = q.SubmitSubmission(ctx, submissionID)
+		if err != nil {
+			return err
+		}
+		event, err := submissionSubmittedEvent(ctx, q, submission)
+		if err != nil {
+			return err
+		}
+		return h.emitEvent(ctx, q, clientID, event)
+	})
 	if err != nil {
 		h.logger.Errorw("Failed to submit submission", "error", err, "submission_id", submissionID)
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to submit submission",
 		})
 	}
+	h.publishEvents()
 
-	response := buildSubmissionResponse(submission)
+	// Share the answer with equivalent mapped questions of the client's other
+	// audits. This is best effort: the submission itself has succeeded.
+	var propagated int64
+	if !submission.PropagatedFrom.Valid {
+		propagated, err = h.propagateSubmission(c, clientQueries, submission)
+		if err != nil {
+			h.logger.Errorw("Failed to propagate submission", "error", err, "submission_id", submissionID)
+		}
+	}
 
-	h.logger.Infow("Submission submitted for review", 
-		"submission_id", submissionID, 
-		"client_id", clientID)
+	response := SubmitSubmissionResponse{
+		SubmissionResponse: buildSubmissionResponse(submission),
+		PropagatedCount:    propagated,
+	}
+
+	h.logger.Infow("Submission submitted for review",
+		"submission_id", submissionID,
+		"client_id", clientID,
+		"propagated_count", propagated)
 
 	return c.JSON(http.StatusOK, response)
 }
@@ -271,65 +381,94 @@ func (h *Handler) ReviewSubmission(c echo.Context) error {
 		})
 	}
 
-	var submission clientdb.Submission
-
 	switch req.Action {
 	case "approve":
-		var reviewNotes *string
-		if req.RejectionNotes != nil {
-			reviewNotes = req.RejectionNotes
-		}
-
-		submission, err = clientQueries.ApproveSubmission(ctx, clientdb.ApproveSubmissionParams{
-			ID:          submissionID,
-			ReviewedBy:  pgtype.UUID{Bytes: reviewedBy, Valid: true},
-			ReviewNotes: reviewNotes,
-		})
-
 	case "reject":
 		if req.RejectionNotes == nil || *req.RejectionNotes == "" {
 			return c.JSON(http.StatusBadRequest, map[string]string{
 				"error": "Rejection notes are required when rejecting a submission",
 			})
 		}
-
-		submission, err = clientQueries.RejectSubmission(ctx, clientdb.RejectSubmissionParams{
-			ID:              submissionID,
-			ReviewedBy:      pgtype.UUID{Bytes: reviewedBy, Valid: true},
-			RejectionReason: req.RejectionNotes,
-		})
-
 	case "refer":
 		if req.RejectionNotes == nil || *req.RejectionNotes == "" {
 			return c.JSON(http.StatusBadRequest, map[string]string{
 				"error": "Notes are required when referring a submission",
 			})
 		}
-
-		submission, err = clientQueries.ReferSubmission(ctx, clientdb.ReferSubmissionParams{
-			ID:          submissionID,
-			ReviewedBy:  pgtype.UUID{Bytes: reviewedBy, Valid: true},
-			ReviewNotes: req.RejectionNotes,
-		})
-
 	default:
 		return c.JSON(http.StatusBadRequest, map[string]string{
 			"error": "Invalid action",
 		})
 	}
 
+	// Review the submission and record the event in the same transaction
+	var submission clientdb.Submission
+	err = h.clientStore.ExecClientTx(ctx, clientID, func(q *clientdb.Queries) error {
+		var err error
+		var action string
+		switch req.Action {
+		case "approve":
+			action = eventbus.ReviewApproved
+			submission, err = q.ApproveSubmission(ctx, clientdb.ApproveSubmissionParams{
+				ID:          submissionID,
+				ReviewedBy:  pgtype.UUID{Bytes: reviewedBy, Valid: true},
+				ReviewNotes: req.RejectionNotes,
+			})
+		case "reject":
+			action = eventbus.ReviewRejected
+			submission, err = q.RejectSubmission(ctx, clientdb.RejectSubmissionParams{
+				ID:              submissionID,
+				ReviewedBy:      pgtype.UUID{Bytes: reviewedBy, Valid: true},
+				RejectionReason: req.RejectionNotes,
+			})
+		case "refer":
+			action = eventbus.ReviewReferred
+			submission, err = q.ReferSubmission(ctx, clientdb.ReferSubmissionParams{
+				ID:          submissionID,
+				ReviewedBy:  pgtype.UUID{Bytes: reviewedBy, Valid: true},
+				ReviewNotes: req.RejectionNotes,
+			})
+		}
+		if err != nil {
+			return err
+		}
+
+		question, err := q.GetQuestionByID(ctx, submission.QuestionID)
+		if err != nil {
+			return err
+		}
+		event := eventbus.SubmissionReviewedEvent{
+			SubmissionID: submission.ID,
+			AuditID:      question.AuditID,
+			QuestionID:   submission.QuestionID,
+			SubmittedBy:  submission.SubmittedBy,
+			ReviewedBy:   reviewedBy,
+			Action:       action,
+		}
+		if req.RejectionNotes != nil {
+			event.Notes = *req.RejectionNotes
+		}
+		return h.emitEvent(ctx, q, clientID, event)
+	})
 	if err != nil {
 		h.logger.Errorw("Failed to review submission", "error", err, "action", req.Action)
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to review submission",
 		})
 	}
+	h.publishEvents()
+
+	notes := ""
+	if req.RejectionNotes != nil {
+		notes = *req.RejectionNotes
+	}
+	h.notifySubmissionReviewed(ctx, clientID, clientQueries, submission, req.Action, notes)
 
 	response := buildSubmissionResponse(submission)
 
-	h.logger.Infow("Submission reviewed", 
-		"submission_id", submissionID, 
-		"action", req.Action, 
+	h.logger.Infow("Submission reviewed",
+		"submission_id", submissionID,
+		"action", req.Action,
 		"reviewer_id", reviewedBy,
 		"client_id", clientID)
 
@@ -391,6 +530,7 @@ func (h *Handler) ListSubmissionsByStatus(c echo.Context) error {
 			SubmittedAt:     sub.SubmittedAt,
 			CreatedAt:       sub.CreatedAt,
 			UpdatedAt:       sub.UpdatedAt,
+			AnswerData:      sub.AnswerData,
 		}))
 	}
 
@@ -435,9 +575,32 @@ func (h *Handler) GetSubmission(c echo.Context) error {
 
 	response := buildSubmissionResponse(submission)
 
+	response.EvidenceChecklist, err = submissionEvidenceChecklist(ctx, clientQueries, submission)
+	if err != nil {
+		h.logger.Warnw("Failed to check evidence requirements", "error", err, "submission_id", submissionID)
+	}
+
 	return c.JSON(http.StatusOK, response)
 }
 
+// propagateSubmission copies a submitted answer to the equivalent mapped
+// controls of the question in the client's other open audits
+func (h *Handler) propagateSubmission(c echo.Context, clientQueries *clientdb.Queries, submission clientdb.Submission) (int64, error) {
+	ctx := c.Request().Context()
+
+	question, err := clientQueries.GetQuestionByID(ctx, submission.QuestionID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get question: %w", err)
+	}
+
+	audit, err := clientQueries.GetAuditByID(ctx, question.AuditID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get audit: %w", err)
+	}
+
+	return h.frameworkService.PropagateAnswer(ctx, getAuthToken(c), clientQueries, submission.ID, audit.FrameworkID, question.QuestionNumber)
+}
+
 // Helper function to build submission response
 func buildSubmissionResponse(submission clientdb.Submission) SubmissionResponse {
 	var answer *string
@@ -451,6 +614,11 @@ func buildSubmissionResponse(submission clientdb.Submission) SubmissionResponse
 		answerValue = &av
 	}
 
+	var answerData json.RawMessage
+	if len(submission.AnswerData) > 0 {
+		answerData = submission.AnswerData
+	}
+
 	var reviewedBy *string
 	if submission.ReviewedBy.Valid {
 		rb := uuid.UUID(submission.ReviewedBy.Bytes).String()
@@ -476,18 +644,27 @@ func buildSubmissionResponse(submission clientdb.Submission) SubmissionResponse
 		submittedAt = &sa
 	}
 
+	var propagatedFrom *string
+	if submission.PropagatedFrom.Valid {
+		pf := uuid.UUID(submission.PropagatedFrom.Bytes).String()
+		propagatedFrom = &pf
+	}
+
 	return SubmissionResponse{
 		ID:             submission.ID.String(),
 		QuestionID:     submission.QuestionID.String(),
 		SubmittedBy:    submission.SubmittedBy.String(),
 		Answer:         answer,
 		AnswerValue:    answerValue,
+		AnswerData:     answerData,
 		Status:         string(submission.Status),
 		Version:        submission.Version,
 		ReviewedBy:     reviewedBy,
 		ReviewedAt:     reviewedAt,
 		RejectionNotes: rejectionNotes,
 		SubmittedAt:    submittedAt,
+		CarriedForward: submission.IsCarriedForward,
+		PropagatedFrom: propagatedFrom,
 		CreatedAt:      submission.CreatedAt.Time.Format(time.RFC3339),
 		UpdatedAt:      submission.UpdatedAt.Time.Format(time.RFC3339),
 	}
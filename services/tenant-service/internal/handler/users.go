@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/NormaTech-AI/audity/services/tenant-service/internal/db"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/locale"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/labstack/echo/v4"
@@ -18,11 +19,19 @@ type UserResponse struct {
 	Email        string  `json:"email"`
 	Name         string  `json:"name"`
 	OIDCProvider string  `json:"oidc_provider"`
-	Designation         string  `json:"designation"`
+	Designation  string  `json:"designation"`
 	ClientID     *string `json:"client_id"`
 	CreatedAt    string  `json:"created_at"`
 	UpdatedAt    string  `json:"updated_at"`
 	LastLogin    *string `json:"last_login"`
+	// PreferredLocale is the locale questions are served in, if translated
+	PreferredLocale *string `json:"preferred_locale"`
+}
+
+// UpdatePreferredLocaleRequest sets the locale questions are served in. An
+// empty locale restores the canonical language of each framework.
+type UpdatePreferredLocaleRequest struct {
+	PreferredLocale string `json:"preferred_locale"`
 }
 
 type ListUsersResponse struct {
@@ -46,8 +55,8 @@ func (h *Handler) ListUsers(c echo.Context) error {
 	var users []db.User
 	var err error
 	h.logger.Info("Listing users", "client_id", clientIDParam)
-	if clientIDParam == "tenant"{
-		users, err = h.store.Queries.ListTenantUsers(c.Request().Context() )
+	if clientIDParam == "tenant" {
+		users, err = h.store.Queries.ListTenantUsers(c.Request().Context())
 	} else if clientIDParam != "" {
 		// Filter by client_id
 		clientID, parseErr := uuid.Parse(clientIDParam)
@@ -74,13 +83,14 @@ func (h *Handler) ListUsers(c echo.Context) error {
 
 	for i, user := range users {
 		userResp := UserResponse{
-			ID:           user.ID.String(),
-			Email:        user.Email,
-			Name:         user.Name,
-			OIDCProvider: user.OidcProvider,
-			Designation:         string(user.Designation),
-			CreatedAt:    user.CreatedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
-			UpdatedAt:    user.UpdatedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
+			ID:              user.ID.String(),
+			Email:           user.Email,
+			Name:            user.Name,
+			OIDCProvider:    user.OidcProvider,
+			Designation:     string(user.Designation),
+			CreatedAt:       user.CreatedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
+			UpdatedAt:       user.UpdatedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
+			PreferredLocale: user.PreferredLocale,
 		}
 
 		if user.ClientID.Valid {
@@ -120,13 +130,14 @@ func (h *Handler) GetUser(c echo.Context) error {
 	}
 
 	response := UserResponse{
-		ID:           user.ID.String(),
-		Email:        user.Email,
-		Name:         user.Name,
-		OIDCProvider: user.OidcProvider,
-		Designation:         string(user.Designation),
-		CreatedAt:    user.CreatedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt:    user.UpdatedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
+		ID:              user.ID.String(),
+		Email:           user.Email,
+		Name:            user.Name,
+		OIDCProvider:    user.OidcProvider,
+		Designation:     string(user.Designation),
+		CreatedAt:       user.CreatedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:       user.UpdatedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
+		PreferredLocale: user.PreferredLocale,
 	}
 
 	if user.ClientID.Valid {
@@ -142,3 +153,66 @@ func (h *Handler) GetUser(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, response)
 }
+
+// UpdateMyPreferredLocale sets the locale questions are served in for the
+// authenticated user
+// @Summary Update preferred locale
+// @Description Set the locale, e.g. hi or mr, questions are served in when their framework is translated. An empty locale restores the canonical language.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body UpdatePreferredLocaleRequest true "Preferred locale"
+// @Success 200 {object} UserResponse
+// @Router /api/users/me/locale [put]
+func (h *Handler) UpdateMyPreferredLocale(c echo.Context) error {
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req UpdatePreferredLocaleRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	var preferredLocale *string
+	if req.PreferredLocale != "" {
+		normalized, err := locale.Normalize(req.PreferredLocale)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		preferredLocale = &normalized
+	}
+
+	user, err := h.store.Queries.UpdateUserPreferredLocale(c.Request().Context(), db.UpdateUserPreferredLocaleParams{
+		ID:              userID,
+		PreferredLocale: preferredLocale,
+	})
+	if err != nil {
+		h.logger.Errorw("Failed to update preferred locale", "error", err, "user_id", userID)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update preferred locale")
+	}
+
+	response := UserResponse{
+		ID:              user.ID.String(),
+		Email:           user.Email,
+		Name:            user.Name,
+		OIDCProvider:    user.OidcProvider,
+		Designation:     string(user.Designation),
+		CreatedAt:       user.CreatedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:       user.UpdatedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
+		PreferredLocale: user.PreferredLocale,
+	}
+
+	if user.ClientID.Valid {
+		clientID := uuid.UUID(user.ClientID.Bytes).String()
+		response.ClientID = &clientID
+	}
+
+	if user.LastLogin.Valid {
+		lastLogin := user.LastLogin.Time.Format("2006-01-02T15:04:05Z07:00")
+		response.LastLogin = &lastLogin
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,623 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/db"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/webhook"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+)
+
+// WebhookRequest creates or replaces a webhook of a client
+type WebhookRequest struct {
+	URL         string   `json:"url" validate:"required,url"`
+	Description *string  `json:"description" validate:"omitempty,max=255"`
+	EventTypes  []string `json:"event_types" validate:"required,min=1"`
+	// Signing secret, generated when empty. Only set on create.
+	Secret *string `json:"secret" validate:"omitempty,min=16,max=255"`
+	// Defaults to true on create and to the current value on update
+	IsActive *bool `json:"is_active"`
+}
+
+// WebhookResponse represents a webhook in API responses
+type WebhookResponse struct {
+	ID          string    `json:"id"`
+	ClientID    string    `json:"client_id"`
+	URL         string    `json:"url"`
+	Description *string   `json:"description,omitempty"`
+	EventTypes  []string  `json:"event_types"`
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// CreateWebhookResponse is returned once on create, with the signing secret
+type CreateWebhookResponse struct {
+	WebhookResponse
+	Secret string `json:"secret"`
+}
+
+// WebhookDeliveryResponse represents a delivery of an event to a webhook
+type WebhookDeliveryResponse struct {
+	ID             string     `json:"id"`
+	WebhookID      string     `json:"webhook_id"`
+	EventID        string     `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Status         string     `json:"status"`
+	Attempts       int32      `json:"attempts"`
+	MaxAttempts    int32      `json:"max_attempts"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	LastStatusCode *int32     `json:"last_status_code,omitempty"`
+	LastError      *string    `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	RedeliveryOf   *string    `json:"redelivery_of,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// WebhookDeliveryDetailResponse is a delivery with its payload and the log
+// of its requests
+type WebhookDeliveryDetailResponse struct {
+	WebhookDeliveryResponse
+	Payload     json.RawMessage                  `json:"payload"`
+	AttemptsLog []WebhookDeliveryAttemptResponse `json:"attempts_log"`
+}
+
+// WebhookDeliveryAttemptResponse represents a request of a delivery
+type WebhookDeliveryAttemptResponse struct {
+	Attempt      int32     `json:"attempt"`
+	StatusCode   *int32    `json:"status_code,omitempty"`
+	ResponseBody *string   `json:"response_body,omitempty"`
+	Error        *string   `json:"error,omitempty"`
+	DurationMs   int32     `json:"duration_ms"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// WebhookPingResponse is the outcome of a test ping
+type WebhookPingResponse struct {
+	Delivery     WebhookDeliveryResponse `json:"delivery"`
+	Succeeded    bool                    `json:"succeeded"`
+	StatusCode   int                     `json:"status_code,omitempty"`
+	ResponseBody string                  `json:"response_body,omitempty"`
+	Error        string                  `json:"error,omitempty"`
+	DurationMs   int64                   `json:"duration_ms"`
+}
+
+// ListWebhooks lists the webhooks of a client
+func (h *Handler) ListWebhooks(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	clientID, err := uuid.Parse(c.Param("clientId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid client ID",
+		})
+	}
+
+	subscriptions, err := h.store.Queries.ListWebhookSubscriptions(ctx, clientID)
+	if err != nil {
+		h.logger.Errorw("Failed to list webhooks", "error", err, "client_id", clientID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to list webhooks",
+		})
+	}
+
+	responses := make([]WebhookResponse, 0, len(subscriptions))
+	for _, sub := range subscriptions {
+		responses = append(responses, buildWebhookResponse(sub))
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"data":        responses,
+		"event_types": webhook.EventTypes(),
+	})
+}
+
+// CreateWebhook subscribes a URL to events of a client. The signing secret is
+// only returned in this response.
+func (h *Handler) CreateWebhook(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	clientID, err := uuid.Parse(c.Param("clientId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid client ID",
+		})
+	}
+
+	req, eventTypes, ok := h.bindWebhookRequest(c)
+	if !ok {
+		return nil
+	}
+
+	if _, err := h.store.Queries.GetClient(ctx, clientID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "Client not found",
+			})
+		}
+		h.logger.Errorw("Failed to get client", "error", err, "client_id", clientID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to create webhook",
+		})
+	}
+
+	secret := ""
+	if req.Secret != nil {
+		secret = *req.Secret
+	} else if secret, err = webhook.GenerateSecret(); err != nil {
+		h.logger.Errorw("Failed to generate webhook secret", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to create webhook",
+		})
+	}
+	encryptedSecret, err := h.encryptor.Encrypt(secret)
+	if err != nil {
+		h.logger.Errorw("Failed to encrypt webhook secret", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to create webhook",
+		})
+	}
+
+	var createdBy pgtype.UUID
+	if userID, err := getUserIDFromContext(c); err == nil {
+		createdBy = pgtype.UUID{Bytes: userID, Valid: true}
+	}
+
+	var sub db.WebhookSubscription
+	err = h.store.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+		sub, err = q.CreateWebhookSubscription(ctx, db.CreateWebhookSubscriptionParams{
+			ClientID:        clientID,
+			Url:             req.URL,
+			Description:     req.Description,
+			EncryptedSecret: encryptedSecret,
+			EventTypes:      eventTypes,
+			CreatedBy:       createdBy,
+		})
+		if err != nil || req.IsActive == nil || *req.IsActive {
+			return err
+		}
+		sub, err = q.UpdateWebhookSubscription(ctx, db.UpdateWebhookSubscriptionParams{
+			ID:          sub.ID,
+			ClientID:    clientID,
+			Url:         sub.Url,
+			Description: sub.Description,
+			EventTypes:  sub.EventTypes,
+			IsActive:    false,
+		})
+		return err
+	})
+	if err != nil {
+		h.logger.Errorw("Failed to create webhook", "error", err, "client_id", clientID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to create webhook",
+		})
+	}
+
+	h.logger.Infow("Webhook created", "webhook_id", sub.ID, "client_id", clientID, "event_types", eventTypes)
+
+	return c.JSON(http.StatusCreated, CreateWebhookResponse{
+		WebhookResponse: buildWebhookResponse(sub),
+		Secret:          secret,
+	})
+}
+
+// GetWebhook returns a webhook of a client
+func (h *Handler) GetWebhook(c echo.Context) error {
+	sub, ok := h.webhookFromPath(c)
+	if !ok {
+		return nil
+	}
+	return c.JSON(http.StatusOK, buildWebhookResponse(sub))
+}
+
+// UpdateWebhook replaces the URL, description, event types and state of a
+// webhook. The signing secret is kept.
+func (h *Handler) UpdateWebhook(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	current, ok := h.webhookFromPath(c)
+	if !ok {
+		return nil
+	}
+
+	req, eventTypes, ok := h.bindWebhookRequest(c)
+	if !ok {
+		return nil
+	}
+	if req.Secret != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "The secret of a webhook cannot be changed; create a new webhook instead",
+		})
+	}
+
+	isActive := current.IsActive
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	sub, err := h.store.Queries.UpdateWebhookSubscription(ctx, db.UpdateWebhookSubscriptionParams{
+		ID:          current.ID,
+		ClientID:    current.ClientID,
+		Url:         req.URL,
+		Description: req.Description,
+		EventTypes:  eventTypes,
+		IsActive:    isActive,
+	})
+	if err != nil {
+		h.logger.Errorw("Failed to update webhook", "error", err, "webhook_id", current.ID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to update webhook",
+		})
+	}
+
+	h.logger.Infow("Webhook updated", "webhook_id", sub.ID, "client_id", sub.ClientID, "is_active", sub.IsActive)
+
+	return c.JSON(http.StatusOK, buildWebhookResponse(sub))
+}
+
+// DeleteWebhook deletes a webhook and its delivery log
+func (h *Handler) DeleteWebhook(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	clientID, err := uuid.Parse(c.Param("clientId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid client ID",
+		})
+	}
+	webhookID, err := uuid.Parse(c.Param("webhookId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid webhook ID",
+		})
+	}
+
+	deleted, err := h.store.Queries.DeleteWebhookSubscription(ctx, db.DeleteWebhookSubscriptionParams{
+		ID:       webhookID,
+		ClientID: clientID,
+	})
+	if err != nil {
+		h.logger.Errorw("Failed to delete webhook", "error", err, "webhook_id", webhookID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to delete webhook",
+		})
+	}
+	if deleted == 0 {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Webhook not found",
+		})
+	}
+
+	h.logger.Infow("Webhook deleted", "webhook_id", webhookID, "client_id", clientID)
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// PingWebhook sends a test event to a webhook and returns the outcome. It
+// works for disabled webhooks too, to check them before enabling them.
+func (h *Handler) PingWebhook(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	sub, ok := h.webhookFromPath(c)
+	if !ok {
+		return nil
+	}
+
+	delivery, result, err := h.webhooks.Ping(ctx, sub)
+	if err != nil {
+		h.logger.Errorw("Failed to ping webhook", "error", err, "webhook_id", sub.ID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to ping webhook",
+		})
+	}
+
+	return c.JSON(http.StatusOK, WebhookPingResponse{
+		Delivery:     buildWebhookDeliveryResponse(delivery),
+		Succeeded:    result.Succeeded,
+		StatusCode:   result.StatusCode,
+		ResponseBody: result.ResponseBody,
+		Error:        result.Error,
+		DurationMs:   result.Duration.Milliseconds(),
+	})
+}
+
+// ListWebhookDeliveries lists the deliveries of a webhook, newest first,
+// optionally filtered by status
+func (h *Handler) ListWebhookDeliveries(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	sub, ok := h.webhookFromPath(c)
+	if !ok {
+		return nil
+	}
+
+	// Get pagination parameters
+	limit := 50
+	offset := 0
+
+	if l := c.QueryParam("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 100 {
+			limit = parsed
+		}
+	}
+
+	if o := c.QueryParam("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		}
+	}
+
+	var status *string
+	if s := c.QueryParam("status"); s != "" {
+		switch s {
+		case webhook.StatusPending, webhook.StatusSending, webhook.StatusSucceeded, webhook.StatusFailed:
+			status = &s
+		default:
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "status must be one of pending, sending, succeeded or failed",
+			})
+		}
+	}
+
+	deliveries, err := h.store.Queries.ListWebhookDeliveries(ctx, db.ListWebhookDeliveriesParams{
+		SubscriptionID: sub.ID,
+		Status:         status,
+		PageLimit:      int32(limit),
+		PageOffset:     int32(offset),
+	})
+	if err != nil {
+		h.logger.Errorw("Failed to list webhook deliveries", "error", err, "webhook_id", sub.ID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to list webhook deliveries",
+		})
+	}
+
+	responses := make([]WebhookDeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		responses = append(responses, buildWebhookDeliveryResponse(delivery))
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"data":   responses,
+		"limit":  limit,
+		"offset": offset,
+		"count":  len(responses),
+	})
+}
+
+// GetWebhookDelivery returns a delivery with its payload and request log
+func (h *Handler) GetWebhookDelivery(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	delivery, ok := h.webhookDeliveryFromPath(c)
+	if !ok {
+		return nil
+	}
+
+	attempts, err := h.store.Queries.ListWebhookDeliveryAttempts(ctx, delivery.ID)
+	if err != nil {
+		h.logger.Errorw("Failed to list webhook delivery attempts", "error", err, "delivery_id", delivery.ID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get webhook delivery",
+		})
+	}
+
+	log := make([]WebhookDeliveryAttemptResponse, 0, len(attempts))
+	for _, attempt := range attempts {
+		log = append(log, WebhookDeliveryAttemptResponse{
+			Attempt:      attempt.Attempt,
+			StatusCode:   attempt.StatusCode,
+			ResponseBody: attempt.ResponseBody,
+			Error:        attempt.Error,
+			DurationMs:   attempt.DurationMs,
+			CreatedAt:    attempt.CreatedAt,
+		})
+	}
+
+	return c.JSON(http.StatusOK, WebhookDeliveryDetailResponse{
+		WebhookDeliveryResponse: buildWebhookDeliveryResponse(delivery),
+		Payload:                 delivery.Payload,
+		AttemptsLog:             log,
+	})
+}
+
+// RedeliverWebhookDelivery queues the event of a delivery again, e.g. after
+// the receiver was fixed. The new delivery is retried like any other.
+func (h *Handler) RedeliverWebhookDelivery(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	original, ok := h.webhookDeliveryFromPath(c)
+	if !ok {
+		return nil
+	}
+	if original.EventType == string(webhook.PingEvent) {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Pings cannot be redelivered; send a new ping instead",
+		})
+	}
+
+	delivery, err := h.webhooks.Redeliver(ctx, original)
+	if err != nil {
+		h.logger.Errorw("Failed to redeliver webhook delivery", "error", err, "delivery_id", original.ID)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to redeliver",
+		})
+	}
+
+	h.logger.Infow("Webhook delivery redelivered", "delivery_id", delivery.ID, "redelivery_of", original.ID)
+
+	return c.JSON(http.StatusAccepted, buildWebhookDeliveryResponse(delivery))
+}
+
+// bindWebhookRequest binds and validates a webhook request and returns its
+// deduplicated event types. It writes the error response when not ok.
+func (h *Handler) bindWebhookRequest(c echo.Context) (WebhookRequest, []string, bool) {
+	var req WebhookRequest
+	if err := c.Bind(&req); err != nil {
+		_ = c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request payload",
+		})
+		return req, nil, false
+	}
+
+	req.URL = strings.TrimSpace(req.URL)
+	req.Description = trimmedOrNil(req.Description)
+
+	if err := c.Validate(&req); err != nil {
+		_ = c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return req, nil, false
+	}
+
+	if err := webhook.ValidateURL(req.URL, h.config.Server.Env != "production"); err != nil {
+		_ = c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return req, nil, false
+	}
+
+	supported := make(map[string]bool)
+	for _, t := range webhook.EventTypes() {
+		supported[string(t)] = true
+	}
+	seen := make(map[string]bool, len(req.EventTypes))
+	eventTypes := make([]string, 0, len(req.EventTypes))
+	for _, t := range req.EventTypes {
+		if !supported[t] {
+			_ = c.JSON(http.StatusBadRequest, map[string]string{
+				"error": fmt.Sprintf("Event type %s is not supported", t),
+			})
+			return req, nil, false
+		}
+		if !seen[t] {
+			seen[t] = true
+			eventTypes = append(eventTypes, t)
+		}
+	}
+
+	return req, eventTypes, true
+}
+
+// webhookFromPath returns the webhook of the :clientId and :webhookId path
+// parameters. It writes the error response when not ok.
+func (h *Handler) webhookFromPath(c echo.Context) (db.WebhookSubscription, bool) {
+	clientID, err := uuid.Parse(c.Param("clientId"))
+	if err != nil {
+		_ = c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid client ID",
+		})
+		return db.WebhookSubscription{}, false
+	}
+	webhookID, err := uuid.Parse(c.Param("webhookId"))
+	if err != nil {
+		_ = c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid webhook ID",
+		})
+		return db.WebhookSubscription{}, false
+	}
+
+	sub, err := h.store.Queries.GetWebhookSubscription(c.Request().Context(), db.GetWebhookSubscriptionParams{
+		ID:       webhookID,
+		ClientID: clientID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		_ = c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Webhook not found",
+		})
+		return db.WebhookSubscription{}, false
+	}
+	if err != nil {
+		h.logger.Errorw("Failed to get webhook", "error", err, "webhook_id", webhookID)
+		_ = c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get webhook",
+		})
+		return db.WebhookSubscription{}, false
+	}
+	return sub, true
+}
+
+// webhookDeliveryFromPath returns the delivery of the :deliveryId path
+// parameter of the webhook of the path. It writes the error response when
+// not ok.
+func (h *Handler) webhookDeliveryFromPath(c echo.Context) (db.WebhookDelivery, bool) {
+	sub, ok := h.webhookFromPath(c)
+	if !ok {
+		return db.WebhookDelivery{}, false
+	}
+	deliveryID, err := uuid.Parse(c.Param("deliveryId"))
+	if err != nil {
+		_ = c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid delivery ID",
+		})
+		return db.WebhookDelivery{}, false
+	}
+
+	delivery, err := h.store.Queries.GetWebhookDelivery(c.Request().Context(), db.GetWebhookDeliveryParams{
+		ID:             deliveryID,
+		SubscriptionID: sub.ID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		_ = c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Webhook delivery not found",
+		})
+		return db.WebhookDelivery{}, false
+	}
+	if err != nil {
+		h.logger.Errorw("Failed to get webhook delivery", "error", err, "delivery_id", deliveryID)
+		_ = c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to get webhook delivery",
+		})
+		return db.WebhookDelivery{}, false
+	}
+	return delivery, true
+}
+
+func buildWebhookResponse(sub db.WebhookSubscription) WebhookResponse {
+	return WebhookResponse{
+		ID:          sub.ID.String(),
+		ClientID:    sub.ClientID.String(),
+		URL:         sub.Url,
+		Description: sub.Description,
+		EventTypes:  sub.EventTypes,
+		IsActive:    sub.IsActive,
+		CreatedAt:   sub.CreatedAt,
+		UpdatedAt:   sub.UpdatedAt,
+	}
+}
+
+func buildWebhookDeliveryResponse(delivery db.WebhookDelivery) WebhookDeliveryResponse {
+	response := WebhookDeliveryResponse{
+		ID:             delivery.ID.String(),
+		WebhookID:      delivery.SubscriptionID.String(),
+		EventID:        delivery.EventID.String(),
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		MaxAttempts:    delivery.MaxAttempts,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt,
+	}
+	if delivery.Status == webhook.StatusPending {
+		response.NextAttemptAt = &delivery.NextAttemptAt
+	}
+	if delivery.DeliveredAt.Valid {
+		response.DeliveredAt = &delivery.DeliveredAt.Time
+	}
+	if delivery.RedeliveryOf.Valid {
+		redeliveryOf := uuid.UUID(delivery.RedeliveryOf.Bytes).String()
+		response.RedeliveryOf = &redeliveryOf
+	}
+	return response
+}
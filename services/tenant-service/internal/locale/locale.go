@@ -0,0 +1,97 @@
+// Package locale picks the locale questions are served in. Questions keep the
+// canonical text of their framework; translations are only used for display
+// and never for reports.
+package locale
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Normalize canonicalizes a BCP 47 language tag, e.g. hi_in to hi-IN
+func Normalize(locale string) (string, error) {
+	tag, err := language.Parse(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+	if err != nil || tag == language.Und {
+		return "", fmt.Errorf("locale %q is not a BCP 47 language tag such as hi or mr-IN", locale)
+	}
+	return tag.String(), nil
+}
+
+// Candidates returns the locales to look translations up in, most preferred
+// first. Regional locales such as hi-IN are followed by their base language.
+// Empty and invalid locales are skipped.
+func Candidates(locales ...string) []string {
+	var candidates []string
+	seen := make(map[string]bool)
+	add := func(locale string) {
+		if !seen[locale] {
+			seen[locale] = true
+			candidates = append(candidates, locale)
+		}
+	}
+
+	for _, l := range locales {
+		if l == "" {
+			continue
+		}
+		tag, err := language.Parse(strings.ReplaceAll(strings.TrimSpace(l), "_", "-"))
+		if err != nil || tag == language.Und {
+			continue
+		}
+		add(tag.String())
+		if base, confidence := tag.Base(); confidence != language.No {
+			add(base.String())
+		}
+	}
+
+	return candidates
+}
+
+// Before returns the candidates preferred over the canonical language of the
+// questions. Translations are only served for those; once the canonical
+// language is reached the untranslated text is what the user prefers.
+func Before(candidates []string, canonical string) []string {
+	canonical, err := Normalize(canonical)
+	if err != nil {
+		return candidates
+	}
+	for i, l := range candidates {
+		if l == canonical {
+			return candidates[:i]
+		}
+	}
+	return candidates
+}
+
+// FromAcceptLanguage returns the locales of an Accept-Language header in
+// order of preference
+func FromAcceptLanguage(header string) []string {
+	if header == "" {
+		return nil
+	}
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil {
+		return nil
+	}
+
+	locales := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if tag != language.Und {
+			locales = append(locales, tag.String())
+		}
+	}
+	return locales
+}
+
+// Pick returns the first non-empty value in locale order, or nil when no
+// candidate locale has one
+func Pick(candidates []string, value func(locale string) *string) *string {
+	for _, l := range candidates {
+		if v := value(l); v != nil && *v != "" {
+			return v
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package locale
+
+import (
+	"slices"
+	"testing"
+)
+
+func strPtr(s string) *string { return &s }
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "hi", want: "hi"},
+		{in: "hi_in", want: "hi-IN"},
+		{in: " mr-IN ", want: "mr-IN"},
+		{in: "", wantErr: true},
+		{in: "und", wantErr: true},
+		{in: "not a locale", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := Normalize(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("Normalize(%q) = %q, %v, want %q and error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestCandidates(t *testing.T) {
+	tests := []struct {
+		name    string
+		locales []string
+		want    []string
+	}{
+		{name: "base language", locales: []string{"hi"}, want: []string{"hi"}},
+		{name: "regional locale falls back to its base", locales: []string{"hi_IN"}, want: []string{"hi-IN", "hi"}},
+		{name: "duplicates are dropped", locales: []string{"mr-IN", "hi", "mr"}, want: []string{"mr-IN", "mr", "hi"}},
+		{name: "empty and invalid locales are skipped", locales: []string{"", "not a locale", "en"}, want: []string{"en"}},
+		{name: "none", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Candidates(tt.locales...); !slices.Equal(got, tt.want) {
+				t.Errorf("Candidates() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBefore(t *testing.T) {
+	tests := []struct {
+		name       string
+		candidates []string
+		canonical  string
+		want       []string
+	}{
+		{name: "canonical language preferred first", candidates: []string{"en", "hi"}, canonical: "en", want: []string{}},
+		{name: "translations preferred", candidates: []string{"hi-IN", "hi", "en"}, canonical: "en", want: []string{"hi-IN", "hi"}},
+		{name: "canonical language not requested", candidates: []string{"mr", "hi"}, canonical: "en", want: []string{"mr", "hi"}},
+		{name: "canonical tag is normalized", candidates: []string{"hi", "en-GB"}, canonical: "en_gb", want: []string{"hi"}},
+		{name: "unknown canonical language", candidates: []string{"hi"}, canonical: "", want: []string{"hi"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Before(tt.candidates, tt.canonical); !slices.Equal(got, tt.want) {
+				t.Errorf("Before() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   []string
+	}{
+		{header: "hi-IN,hi;q=0.9,en;q=0.8", want: []string{"hi-IN", "hi", "en"}},
+		{header: "en;q=0.5, mr", want: []string{"mr", "en"}},
+		{header: "", want: nil},
+	}
+
+	for _, tt := range tests {
+		if got := FromAcceptLanguage(tt.header); !slices.Equal(got, tt.want) {
+			t.Errorf("FromAcceptLanguage(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestPick(t *testing.T) {
+	values := map[string]*string{
+		"hi-IN": strPtr(""),
+		"hi":    strPtr("हिंदी"),
+		"mr":    strPtr("मराठी"),
+	}
+	value := func(locale string) *string { return values[locale] }
+
+	tests := []struct {
+		name       string
+		candidates []string
+		want       *string
+	}{
+		{name: "first locale with a value", candidates: []string{"mr", "hi"}, want: values["mr"]},
+		{name: "empty values are skipped", candidates: []string{"hi-IN", "hi"}, want: values["hi"]},
+		{name: "no locale has a value", candidates: []string{"fr"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Pick(tt.candidates, value); got != tt.want {
+				t.Errorf("Pick() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
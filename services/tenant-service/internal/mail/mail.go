@@ -1,48 +1,323 @@
+// Package mail queues emails in the email outbox and delivers them in the
+// background through the configured notifier, so emails are not lost while
+// the provider is down.
 package mail
 
 import (
-	"github.com/NormaTech-AI/audity/packages/go/microsoft-mail"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/NormaTech-AI/audity/packages/go/emailtemplates"
+	microsoftmail "github.com/NormaTech-AI/audity/packages/go/microsoft-mail"
+	"github.com/NormaTech-AI/audity/packages/go/notifier"
+	"github.com/NormaTech-AI/audity/packages/go/retry"
 	"github.com/NormaTech-AI/audity/services/tenant-service/internal/config"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/db"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
+const (
+	// Bound on a single delivery, immediate retries included
+	sendTimeout = 2 * time.Minute
+	// Claimed emails are picked up again by another dispatcher when this
+	// expires, so it must outlast sendTimeout. Emails are claimed one at a
+	// time, so a lease never covers more than one send.
+	leaseDuration = 5 * time.Minute
+)
+
 type MailService struct {
-	client *microsoftmail.Client
+	queries  db.Querier
+	notifier notifier.Notifier
+	cfg      config.MailConfig
+	log      *zap.SugaredLogger
+	wake     chan struct{}
 }
 
-var Mail *MailService
-
-func NewMailService(cfg *config.Config, log *zap.SugaredLogger) (*MailService, error) {
-	client, err := microsoftmail.NewClient(microsoftmail.Config{
-		TenantID:     cfg.MicrosoftMail.TenantID,
-		ClientID:     cfg.MicrosoftMail.ClientID,
-		ClientSecret: cfg.MicrosoftMail.ClientSecret,
-		SenderEmail:  cfg.MicrosoftMail.SenderEmail,
-	}, log)
+// NewMailService creates the mail service for the configured provider. Call
+// Run to start delivering queued emails.
+func NewMailService(cfg *config.Config, queries db.Querier, log *zap.SugaredLogger) (*MailService, error) {
+	n, err := newNotifier(cfg, log)
 	if err != nil {
-		log.Errorw("failed to create mail client", "error", err)
+		log.Errorw("failed to create mail notifier", "error", err)
 		return nil, err
 	}
-	log.Info("mail client created successfully")
-	Mail = &MailService{client: client}
-	return Mail, nil
-}
-// SendEmail sends an email using the configured mail client
-func (m *MailService) SendEmail(to []string, subject, body string, log *zap.SugaredLogger) error {
-	return m.client.SendEmail(microsoftmail.EmailParams{
-		To:          to,
-		Subject:     subject,
-		Body:        body,
-		ContentType: "HTML",
-	}, log)
-}
-
-// SendMail sends an email using the configured mail client
-func (m *MailService) SendMail(to []string, subject, body string, log *zap.SugaredLogger) error {
-	return m.client.SendEmail(microsoftmail.EmailParams{
-		To:          to,
-		Subject:     subject,
-		Body:        body,
-		ContentType: "HTML",
-	}, log)
+	log.Infow("mail service created successfully", "provider", n.Name())
+
+	return &MailService{
+		queries:  queries,
+		notifier: notifier.Retry(n, notifier.RetryOptions{Attempts: cfg.Mail.SendAttempts}, log),
+		cfg:      cfg.Mail,
+		log:      log,
+		wake:     make(chan struct{}, 1),
+	}, nil
+}
+
+func newNotifier(cfg *config.Config, log *zap.SugaredLogger) (notifier.Notifier, error) {
+	provider := cfg.Mail.Provider
+	if provider == "" {
+		provider = "log"
+		if cfg.MicrosoftMail.TenantID != "" {
+			provider = "graph"
+		}
+	}
+
+	switch provider {
+	case "graph":
+		return notifier.NewGraph(microsoftmail.Config{
+			TenantID:     cfg.MicrosoftMail.TenantID,
+			ClientID:     cfg.MicrosoftMail.ClientID,
+			ClientSecret: cfg.MicrosoftMail.ClientSecret,
+			SenderEmail:  cfg.MicrosoftMail.SenderEmail,
+		}, log)
+	case "smtp":
+		return notifier.NewSMTP(notifier.SMTPConfig{
+			Host:     cfg.Mail.SMTP.Host,
+			Port:     cfg.Mail.SMTP.Port,
+			Username: cfg.Mail.SMTP.Username,
+			Password: cfg.Mail.SMTP.Password,
+			From:     cfg.Mail.SMTP.From,
+			FromName: cfg.Mail.SMTP.FromName,
+			StartTLS: cfg.Mail.SMTP.StartTLS,
+			Timeout:  cfg.Mail.SMTP.Timeout,
+		})
+	case "log":
+		return notifier.NewLog(log), nil
+	case "file":
+		return notifier.NewFile(cfg.Mail.FileDir, cfg.Mail.SMTP.From)
+	default:
+		return nil, fmt.Errorf("unknown mail provider %q", provider)
+	}
+}
+
+// SendEmail queues an email in the outbox. It is delivered in the background,
+// retried with backoff while the provider fails.
+func (m *MailService) SendEmail(ctx context.Context, msg notifier.Message) error {
+	return m.enqueue(ctx, msg, nil, nil)
+}
+
+// TemplateEmail is an email rendered from one of the email templates
+type TemplateEmail struct {
+	To []string
+	// Client the email is sent on behalf of, whose branding is used;
+	// uuid.Nil for the default branding
+	ClientID uuid.UUID
+	Template emailtemplates.Name
+	Data     any
+}
+
+// SendTemplate renders a templated email with the branding of its client and
+// queues it like SendEmail
+func (m *MailService) SendTemplate(ctx context.Context, email TemplateEmail) error {
+	branding, err := m.branding(ctx, email.ClientID)
+	if err != nil {
+		return err
+	}
+
+	rendered, err := emailtemplates.Render(email.Template, branding, email.Data)
+	if err != nil {
+		return err
+	}
+
+	template := string(rendered.Template)
+	return m.enqueue(ctx, notifier.Message{
+		To:       email.To,
+		Subject:  rendered.Subject,
+		HTMLBody: rendered.HTML,
+		TextBody: rendered.Text,
+	}, &template, &rendered.Version)
+}
+
+// WithQueries returns a mail service that queues emails through q, e.g. in
+// the transaction of the change the email is about
+func (m *MailService) WithQueries(q db.Querier) *MailService {
+	scoped := *m
+	scoped.queries = q
+	return &scoped
+}
+
+// AppURL returns the link to a page of the web app, e.g. AppURL("/audit/%s", auditID)
+func (m *MailService) AppURL(format string, args ...any) string {
+	return strings.TrimRight(m.cfg.AppURL, "/") + fmt.Sprintf(format, args...)
+}
+
+// branding returns the email branding of a client, or the default branding
+// when it has none
+func (m *MailService) branding(ctx context.Context, clientID uuid.UUID) (emailtemplates.Branding, error) {
+	branding := emailtemplates.DefaultBranding()
+	if clientID == uuid.Nil {
+		return branding, nil
+	}
+
+	b, err := m.queries.GetClientBranding(ctx, clientID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return branding, nil
+	}
+	if err != nil {
+		return branding, fmt.Errorf("failed to get client branding: %w", err)
+	}
+
+	if b.DisplayName != nil {
+		branding.Name = *b.DisplayName
+	}
+	if b.LogoUrl != nil {
+		branding.LogoURL = *b.LogoUrl
+	}
+	if b.PrimaryColor != nil {
+		branding.PrimaryColor = *b.PrimaryColor
+	}
+	if b.FooterText != nil {
+		branding.FooterText = *b.FooterText
+	}
+	return branding, nil
+}
+
+func (m *MailService) enqueue(ctx context.Context, msg notifier.Message, template, templateVersion *string) error {
+	msg.To = recipients(msg.To)
+	if len(msg.To) == 0 {
+		return errors.New("email has no recipients")
+	}
+	if msg.HTMLBody == "" && msg.TextBody == "" {
+		return errors.New("email has no body")
+	}
+
+	email, err := m.queries.EnqueueEmail(ctx, db.EnqueueEmailParams{
+		Recipients:      msg.To,
+		Subject:         msg.Subject,
+		HtmlBody:        optional(msg.HTMLBody),
+		TextBody:        optional(msg.TextBody),
+		MaxAttempts:     int32(m.cfg.MaxAttempts),
+		Template:        template,
+		TemplateVersion: templateVersion,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to queue email: %w", err)
+	}
+	m.log.Infow("Email queued", "email_id", email.ID, "subject", msg.Subject)
+
+	// Deliver right away instead of waiting for the next poll
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Run delivers queued emails until ctx is cancelled. Several instances can run
+// against the same outbox.
+func (m *MailService) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		m.dispatch(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-m.wake:
+		}
+	}
+}
+
+// dispatch delivers due emails until none are left. Each email is claimed
+// right before it is sent, so its lease cannot expire while earlier emails
+// are still being sent and another dispatcher never sends it twice.
+func (m *MailService) dispatch(ctx context.Context) {
+	for {
+		emails, err := m.queries.ClaimDueEmails(ctx, db.ClaimDueEmailsParams{
+			LeaseSeconds: int32(leaseDuration / time.Second),
+			BatchSize:    1,
+		})
+		if err != nil {
+			if ctx.Err() == nil {
+				m.log.Errorw("Failed to claim queued emails", "error", err)
+			}
+			return
+		}
+		if len(emails) == 0 {
+			return
+		}
+
+		m.deliver(ctx, emails[0])
+	}
+}
+
+// deliver sends a claimed email and records the outcome: sent, rescheduled
+// with backoff, or failed once it is rejected or out of attempts
+func (m *MailService) deliver(ctx context.Context, email db.EmailOutbox) {
+	msg := notifier.Message{To: email.Recipients, Subject: email.Subject}
+	if email.HtmlBody != nil {
+		msg.HTMLBody = *email.HtmlBody
+	}
+	if email.TextBody != nil {
+		msg.TextBody = *email.TextBody
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+	err := m.notifier.Send(sendCtx, msg)
+	cancel()
+
+	if ctx.Err() != nil {
+		// Shutting down; the lease expires and the email is picked up again
+		return
+	}
+
+	if err == nil {
+		if err := m.queries.MarkEmailSent(ctx, email.ID); err != nil {
+			m.log.Errorw("Failed to mark email sent", "error", err, "email_id", email.ID)
+		}
+		return
+	}
+
+	lastError := err.Error()
+	if notifier.IsPermanent(err) || email.Attempts >= email.MaxAttempts {
+		m.log.Errorw("Giving up on email", "error", err, "email_id", email.ID, "attempts", email.Attempts)
+		if err := m.queries.MarkEmailFailed(ctx, db.MarkEmailFailedParams{
+			ID:        email.ID,
+			LastError: &lastError,
+		}); err != nil {
+			m.log.Errorw("Failed to mark email failed", "error", err, "email_id", email.ID)
+		}
+		return
+	}
+
+	next := time.Now().Add(retry.Backoff(int(email.Attempts), m.cfg.RetryBaseDelay, m.cfg.RetryMaxDelay))
+	m.log.Warnw("Failed to send email, rescheduled", "error", err, "email_id", email.ID,
+		"attempts", email.Attempts, "next_attempt_at", next)
+	if err := m.queries.RescheduleEmail(ctx, db.RescheduleEmailParams{
+		ID:            email.ID,
+		NextAttemptAt: next,
+		LastError:     &lastError,
+	}); err != nil {
+		m.log.Errorw("Failed to reschedule email", "error", err, "email_id", email.ID)
+	}
+}
+
+// recipients drops empty and duplicate addresses
+func recipients(to []string) []string {
+	seen := make(map[string]bool, len(to))
+	out := make([]string, 0, len(to))
+	for _, addr := range to {
+		addr = strings.TrimSpace(addr)
+		key := strings.ToLower(addr)
+		if addr == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, addr)
+	}
+	return out
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
 }
@@ -0,0 +1,204 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/NormaTech-AI/audity/packages/go/notifier"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/config"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/db"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// fakeOutbox is an email outbox handing out its queued emails one claim at a
+// time and recording what became of them
+type fakeOutbox struct {
+	db.Querier
+	queued      []db.EmailOutbox
+	claims      []int32
+	enqueued    []db.EnqueueEmailParams
+	sent        []uuid.UUID
+	failed      []uuid.UUID
+	rescheduled []db.RescheduleEmailParams
+}
+
+func (f *fakeOutbox) ClaimDueEmails(ctx context.Context, arg db.ClaimDueEmailsParams) ([]db.EmailOutbox, error) {
+	f.claims = append(f.claims, arg.BatchSize)
+	if len(f.queued) == 0 {
+		return nil, nil
+	}
+	n := min(int(arg.BatchSize), len(f.queued))
+	claimed := f.queued[:n]
+	f.queued = f.queued[n:]
+	return claimed, nil
+}
+
+func (f *fakeOutbox) EnqueueEmail(ctx context.Context, arg db.EnqueueEmailParams) (db.EmailOutbox, error) {
+	f.enqueued = append(f.enqueued, arg)
+	return db.EmailOutbox{ID: uuid.New()}, nil
+}
+
+func (f *fakeOutbox) MarkEmailSent(ctx context.Context, id uuid.UUID) error {
+	f.sent = append(f.sent, id)
+	return nil
+}
+
+func (f *fakeOutbox) MarkEmailFailed(ctx context.Context, arg db.MarkEmailFailedParams) error {
+	f.failed = append(f.failed, arg.ID)
+	return nil
+}
+
+func (f *fakeOutbox) RescheduleEmail(ctx context.Context, arg db.RescheduleEmailParams) error {
+	f.rescheduled = append(f.rescheduled, arg)
+	return nil
+}
+
+// fakeNotifier fails the sends to the recipients listed in errs
+type fakeNotifier struct {
+	errs map[string]error
+}
+
+func (f *fakeNotifier) Name() string { return "fake" }
+
+func (f *fakeNotifier) Send(ctx context.Context, msg notifier.Message) error {
+	return f.errs[msg.To[0]]
+}
+
+// cancelNotifier cancels the dispatcher while sending
+type cancelNotifier struct {
+	cancel context.CancelFunc
+}
+
+func (c *cancelNotifier) Name() string { return "cancel" }
+
+func (c *cancelNotifier) Send(ctx context.Context, msg notifier.Message) error {
+	c.cancel()
+	return ctx.Err()
+}
+
+func newTestService(outbox *fakeOutbox, n notifier.Notifier) *MailService {
+	return &MailService{
+		queries:  outbox,
+		notifier: n,
+		cfg: config.MailConfig{
+			MaxAttempts:    3,
+			RetryBaseDelay: time.Minute,
+			RetryMaxDelay:  time.Hour,
+		},
+		log:  zap.NewNop().Sugar(),
+		wake: make(chan struct{}, 1),
+	}
+}
+
+func queuedEmail(to string, attempts int32) db.EmailOutbox {
+	body := "Hello"
+	return db.EmailOutbox{ID: uuid.New(), Recipients: []string{to}, TextBody: &body, Attempts: attempts, MaxAttempts: 3}
+}
+
+func TestDispatch(t *testing.T) {
+	sent := queuedEmail("ok@example.com", 1)
+	transient := queuedEmail("down@example.com", 1)
+	rejected := queuedEmail("rejected@example.com", 1)
+	exhausted := queuedEmail("down@example.com", 3)
+
+	outbox := &fakeOutbox{queued: []db.EmailOutbox{sent, transient, rejected, exhausted}}
+	n := &fakeNotifier{errs: map[string]error{
+		"down@example.com":     errors.New("connection refused"),
+		"rejected@example.com": notifier.Permanent(errors.New("550 mailbox unavailable")),
+	}}
+
+	before := time.Now()
+	newTestService(outbox, n).dispatch(context.Background())
+
+	if want := []int32{1, 1, 1, 1, 1}; !slices.Equal(outbox.claims, want) {
+		t.Errorf("claims = %v, want one email per claim until the outbox is empty", outbox.claims)
+	}
+	if want := []uuid.UUID{sent.ID}; !slices.Equal(outbox.sent, want) {
+		t.Errorf("sent = %v, want %v", outbox.sent, want)
+	}
+	if want := []uuid.UUID{rejected.ID, exhausted.ID}; !slices.Equal(outbox.failed, want) {
+		t.Errorf("failed = %v, want the rejected and the exhausted email %v", outbox.failed, want)
+	}
+	if len(outbox.rescheduled) != 1 || outbox.rescheduled[0].ID != transient.ID {
+		t.Fatalf("rescheduled = %+v, want only the transient failure", outbox.rescheduled)
+	}
+	next := outbox.rescheduled[0].NextAttemptAt
+	if next.Before(before.Add(time.Minute/2)) || next.After(time.Now().Add(time.Hour)) {
+		t.Errorf("next attempt at %v, want a backoff between the base and max delay", next)
+	}
+	if got := outbox.rescheduled[0].LastError; got == nil || *got != "connection refused" {
+		t.Errorf("last error = %v, want the send error", got)
+	}
+}
+
+func TestDispatchLeavesClaimOnShutdown(t *testing.T) {
+	outbox := &fakeOutbox{queued: []db.EmailOutbox{queuedEmail("down@example.com", 1)}}
+	ctx, cancel := context.WithCancel(context.Background())
+	n := &cancelNotifier{cancel: cancel}
+
+	newTestService(outbox, n).dispatch(ctx)
+
+	if len(outbox.sent)+len(outbox.failed)+len(outbox.rescheduled) != 0 {
+		t.Errorf("outcome recorded while shutting down: sent %v failed %v rescheduled %v",
+			outbox.sent, outbox.failed, outbox.rescheduled)
+	}
+}
+
+func TestSendEmail(t *testing.T) {
+	tests := []struct {
+		name           string
+		msg            notifier.Message
+		wantErr        bool
+		wantRecipients []string
+	}{
+		{
+			name:           "queued and dispatcher woken",
+			msg:            notifier.Message{To: []string{" a@example.com", "A@example.com", "", "b@example.com"}, TextBody: "Hi"},
+			wantRecipients: []string{"a@example.com", "b@example.com"},
+		},
+		{
+			name:    "no recipients",
+			msg:     notifier.Message{To: []string{" "}, TextBody: "Hi"},
+			wantErr: true,
+		},
+		{
+			name:    "no body",
+			msg:     notifier.Message{To: []string{"a@example.com"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outbox := &fakeOutbox{}
+			m := newTestService(outbox, &fakeNotifier{})
+
+			err := m.SendEmail(context.Background(), tt.msg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SendEmail() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if len(outbox.enqueued) != 0 {
+					t.Errorf("invalid email queued: %+v", outbox.enqueued)
+				}
+				return
+			}
+
+			if len(outbox.enqueued) != 1 || !slices.Equal(outbox.enqueued[0].Recipients, tt.wantRecipients) {
+				t.Fatalf("enqueued = %+v, want recipients %v", outbox.enqueued, tt.wantRecipients)
+			}
+			if outbox.enqueued[0].MaxAttempts != 3 {
+				t.Errorf("max attempts = %d, want the configured 3", outbox.enqueued[0].MaxAttempts)
+			}
+			select {
+			case <-m.wake:
+			default:
+				t.Error("dispatcher not woken")
+			}
+		})
+	}
+}
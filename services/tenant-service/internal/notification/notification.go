@@ -0,0 +1,181 @@
+// Package notification notifies users of what needs their attention, in their
+// in-app notification inbox and by email as set in their preferences.
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/db"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/mail"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+// Type is the kind of event a notification is about. Users choose the
+// channels of each type.
+type Type string
+
+const (
+	// A question was delegated to the user or an audit assigned to their client
+	Assignment Type = "assignment"
+	// An answer of the user was reviewed
+	Review Type = "review"
+	// A comment mentions the user
+	Mention Type = "mention"
+	// An answer of the user was referred back to them
+	Referral Type = "referral"
+	// An audit of the user's client is due soon
+	DueDate Type = "due_date"
+	// An approved exception of the user's client expires soon or has expired
+	ExceptionExpiry Type = "exception_expiry"
+)
+
+// Types returns all notification types
+func Types() []Type {
+	return []Type{Assignment, Review, Mention, Referral, DueDate, ExceptionExpiry}
+}
+
+// Valid reports whether t is a known notification type
+func (t Type) Valid() bool {
+	for _, known := range Types() {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Channel is where notifications of a type are delivered
+type Channel string
+
+const (
+	ChannelInbox Channel = "inbox"
+	ChannelEmail Channel = "email"
+	ChannelBoth  Channel = "both"
+)
+
+// DefaultChannel is used for types the user has no preference for
+const DefaultChannel = ChannelBoth
+
+// Valid reports whether c is a known channel
+func (c Channel) Valid() bool {
+	return c == ChannelInbox || c == ChannelEmail || c == ChannelBoth
+}
+
+// Inbox reports whether the channel includes the in-app inbox
+func (c Channel) Inbox() bool {
+	return c == ChannelInbox || c == ChannelBoth
+}
+
+// Email reports whether the channel includes email
+func (c Channel) Email() bool {
+	return c == ChannelEmail || c == ChannelBoth
+}
+
+// Notification is a notification for one user
+type Notification struct {
+	Type Type
+	// Client the notification is about; uuid.Nil for none
+	ClientID uuid.UUID
+	Title    string
+	Body     string
+	// Path of the frontend page the notification is about, e.g. /audit/<id>
+	Link string
+	// Email sent when the user receives the type by email, to the address of
+	// the user; nil for notifications that only go to the inbox
+	Email *mail.TemplateEmail
+}
+
+type Service struct {
+	queries db.Querier
+	mail    *mail.MailService
+	log     *zap.SugaredLogger
+}
+
+func NewService(queries db.Querier, mailService *mail.MailService, log *zap.SugaredLogger) *Service {
+	return &Service{queries: queries, mail: mailService, log: log}
+}
+
+// WithQueries returns a copy of the service writing notifications and emails
+// with q, e.g. in the transaction of the change they are about
+func (s *Service) WithQueries(q db.Querier) *Service {
+	return &Service{queries: q, mail: s.mail.WithQueries(q), log: s.log}
+}
+
+// Channel returns the channel a user receives notifications of a type on
+func (s *Service) Channel(ctx context.Context, userID uuid.UUID, t Type) (Channel, error) {
+	pref, err := s.queries.GetNotificationPreference(ctx, db.GetNotificationPreferenceParams{
+		UserID: userID,
+		Type:   string(t),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return DefaultChannel, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get notification preference: %w", err)
+	}
+	return Channel(pref.Channel), nil
+}
+
+// Notify delivers a notification to a user on the channels they chose for its
+// type
+func (s *Service) Notify(ctx context.Context, user db.User, n Notification) error {
+	channel, err := s.Channel(ctx, user.ID, n.Type)
+	if err != nil {
+		return err
+	}
+
+	if channel.Inbox() {
+		var clientID pgtype.UUID
+		if n.ClientID != uuid.Nil {
+			clientID = pgtype.UUID{Bytes: n.ClientID, Valid: true}
+		}
+		var link *string
+		if n.Link != "" {
+			link = &n.Link
+		}
+		if _, err := s.queries.CreateNotification(ctx, db.CreateNotificationParams{
+			UserID:   user.ID,
+			ClientID: clientID,
+			Type:     string(n.Type),
+			Title:    n.Title,
+			Body:     n.Body,
+			Link:     link,
+		}); err != nil {
+			return fmt.Errorf("failed to create %s notification: %w", n.Type, err)
+		}
+	}
+
+	if channel.Email() && n.Email != nil {
+		email := *n.Email
+		email.To = []string{user.Email}
+		if err := s.mail.SendTemplate(ctx, email); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NotifyAddress delivers a notification to the user with the given email
+// address. Addresses without a user, such as client POCs who never signed
+// in, only get the email.
+func (s *Service) NotifyAddress(ctx context.Context, address string, n Notification) error {
+	user, err := s.queries.GetUserByEmail(ctx, address)
+	if err == nil {
+		return s.Notify(ctx, user, n)
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to get user by email: %w", err)
+	}
+
+	if n.Email == nil {
+		return nil
+	}
+	email := *n.Email
+	email.To = []string{address}
+	return s.mail.SendTemplate(ctx, email)
+}
@@ -0,0 +1,174 @@
+package notification
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/NormaTech-AI/audity/packages/go/emailtemplates"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/config"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/db"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/mail"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// fakeQueries answers preference and user lookups from maps and records the
+// notifications and emails written
+type fakeQueries struct {
+	db.Querier
+	channels      map[Type]Channel
+	users         map[string]db.User
+	notifications []db.CreateNotificationParams
+	emails        [][]string
+}
+
+func (f *fakeQueries) GetNotificationPreference(ctx context.Context, arg db.GetNotificationPreferenceParams) (db.NotificationPreference, error) {
+	channel, ok := f.channels[Type(arg.Type)]
+	if !ok {
+		return db.NotificationPreference{}, pgx.ErrNoRows
+	}
+	return db.NotificationPreference{UserID: arg.UserID, Type: arg.Type, Channel: string(channel)}, nil
+}
+
+func (f *fakeQueries) GetUserByEmail(ctx context.Context, email string) (db.User, error) {
+	user, ok := f.users[email]
+	if !ok {
+		return db.User{}, pgx.ErrNoRows
+	}
+	return user, nil
+}
+
+func (f *fakeQueries) CreateNotification(ctx context.Context, arg db.CreateNotificationParams) (db.Notification, error) {
+	f.notifications = append(f.notifications, arg)
+	return db.Notification{ID: uuid.New(), UserID: arg.UserID}, nil
+}
+
+func (f *fakeQueries) EnqueueEmail(ctx context.Context, arg db.EnqueueEmailParams) (db.EmailOutbox, error) {
+	f.emails = append(f.emails, arg.Recipients)
+	return db.EmailOutbox{ID: uuid.New()}, nil
+}
+
+func newTestService(t *testing.T, queries *fakeQueries) *Service {
+	t.Helper()
+
+	log := zap.NewNop().Sugar()
+	mailService, err := mail.NewMailService(&config.Config{Mail: config.MailConfig{Provider: "log", MaxAttempts: 3}}, queries, log)
+	if err != nil {
+		t.Fatalf("NewMailService() error = %v", err)
+	}
+	return NewService(queries, mailService, log)
+}
+
+func mentionNotification() Notification {
+	return Notification{
+		Type:  Mention,
+		Title: "You were mentioned",
+		Body:  "Please have a look",
+		Link:  "/audit/1",
+		Email: &mail.TemplateEmail{
+			Template: emailtemplates.CommentMention,
+			Data:     emailtemplates.CommentMentionData{},
+		},
+	}
+}
+
+func TestNotify(t *testing.T) {
+	user := db.User{ID: uuid.New(), Email: "auditor@example.com"}
+
+	tests := []struct {
+		name      string
+		channel   Channel
+		noEmail   bool
+		wantInbox bool
+		wantEmail bool
+	}{
+		{name: "default channel", wantInbox: true, wantEmail: true},
+		{name: "inbox", channel: ChannelInbox, wantInbox: true},
+		{name: "email", channel: ChannelEmail, wantEmail: true},
+		{name: "both", channel: ChannelBoth, wantInbox: true, wantEmail: true},
+		{name: "inbox only notification", channel: ChannelEmail, noEmail: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries := &fakeQueries{channels: map[Type]Channel{}}
+			if tt.channel != "" {
+				queries.channels[Mention] = tt.channel
+			}
+			n := mentionNotification()
+			if tt.noEmail {
+				n.Email = nil
+			}
+
+			if err := newTestService(t, queries).Notify(context.Background(), user, n); err != nil {
+				t.Fatalf("Notify() error = %v", err)
+			}
+
+			if got := len(queries.notifications) == 1; got != tt.wantInbox {
+				t.Errorf("inbox notifications = %+v, want one: %v", queries.notifications, tt.wantInbox)
+			}
+			if tt.wantInbox && queries.notifications[0].UserID != user.ID {
+				t.Errorf("notification for %s, want the notified user %s", queries.notifications[0].UserID, user.ID)
+			}
+			if got := len(queries.emails) == 1; got != tt.wantEmail {
+				t.Errorf("emails = %v, want one: %v", queries.emails, tt.wantEmail)
+			}
+			if tt.wantEmail && !slices.Equal(queries.emails[0], []string{user.Email}) {
+				t.Errorf("email to %v, want %s", queries.emails[0], user.Email)
+			}
+		})
+	}
+}
+
+func TestNotifyAddress(t *testing.T) {
+	user := db.User{ID: uuid.New(), Email: "auditor@example.com"}
+
+	tests := []struct {
+		name      string
+		address   string
+		wantInbox bool
+	}{
+		{name: "user", address: user.Email, wantInbox: true},
+		{name: "address without a user", address: "poc@client.example"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries := &fakeQueries{users: map[string]db.User{user.Email: user}}
+
+			if err := newTestService(t, queries).NotifyAddress(context.Background(), tt.address, mentionNotification()); err != nil {
+				t.Fatalf("NotifyAddress() error = %v", err)
+			}
+
+			if got := len(queries.notifications) == 1; got != tt.wantInbox {
+				t.Errorf("inbox notifications = %+v, want one: %v", queries.notifications, tt.wantInbox)
+			}
+			if len(queries.emails) != 1 || !slices.Equal(queries.emails[0], []string{tt.address}) {
+				t.Errorf("emails = %v, want one to %s", queries.emails, tt.address)
+			}
+		})
+	}
+}
+
+func TestChannel(t *testing.T) {
+	tests := []struct {
+		channel   Channel
+		valid     bool
+		wantInbox bool
+		wantEmail bool
+	}{
+		{channel: ChannelInbox, valid: true, wantInbox: true},
+		{channel: ChannelEmail, valid: true, wantEmail: true},
+		{channel: ChannelBoth, valid: true, wantInbox: true, wantEmail: true},
+		{channel: "sms"},
+	}
+
+	for _, tt := range tests {
+		if tt.channel.Valid() != tt.valid || tt.channel.Inbox() != tt.wantInbox || tt.channel.Email() != tt.wantEmail {
+			t.Errorf("channel %q: valid %v inbox %v email %v, want %v %v %v", tt.channel,
+				tt.channel.Valid(), tt.channel.Inbox(), tt.channel.Email(), tt.valid, tt.wantInbox, tt.wantEmail)
+		}
+	}
+}
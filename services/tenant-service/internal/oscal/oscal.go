@@ -0,0 +1,211 @@
+// Package oscal exports completed audits as NIST OSCAL assessment results.
+// Control IDs follow the catalogs exported by framework-service, so findings
+// target the statements of the framework's catalog.
+package oscal
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Version is the OSCAL version of the documents written by this package
+const Version = "1.1.2"
+
+// Namespace qualifies the properties specific to this platform
+const Namespace = "urn:audity:oscal"
+
+// MediaTypeAssessmentResults is the media type of assessment results in JSON
+const MediaTypeAssessmentResults = "application/oscal.assessment-results+json"
+
+// Objective states of a finding
+const (
+	StateSatisfied    = "satisfied"
+	StateNotSatisfied = "not-satisfied"
+)
+
+// Risk states
+const (
+	RiskOpen              = "open"
+	RiskRemediating       = "remediating"
+	RiskClosed            = "closed"
+	RiskDeviationApproved = "deviation-approved"
+)
+
+// Metadata describes an OSCAL document
+type Metadata struct {
+	Title        string     `json:"title"`
+	LastModified string     `json:"last-modified"`
+	Version      string     `json:"version"`
+	OSCALVersion string     `json:"oscal-version"`
+	Props        []Property `json:"props,omitempty"`
+	Parties      []Party    `json:"parties,omitempty"`
+}
+
+// Party is an organization or person referenced by a document
+type Party struct {
+	UUID string `json:"uuid"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+// Property is a name/value pair attached to most OSCAL objects
+type Property struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	NS      string `json:"ns,omitempty"`
+	Remarks string `json:"remarks,omitempty"`
+}
+
+// Resource is a back-matter entry that other objects can reference by UUID
+type Resource struct {
+	UUID        string     `json:"uuid"`
+	Title       string     `json:"title,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Props       []Property `json:"props,omitempty"`
+	RLinks      []RLink    `json:"rlinks,omitempty"`
+}
+
+// RLink locates a back-matter resource
+type RLink struct {
+	Href      string `json:"href"`
+	MediaType string `json:"media-type,omitempty"`
+}
+
+// BackMatter holds the resources referenced by a document
+type BackMatter struct {
+	Resources []Resource `json:"resources,omitempty"`
+}
+
+// AssessmentResults reports the outcome of an assessment
+type AssessmentResults struct {
+	UUID       string      `json:"uuid"`
+	Metadata   Metadata    `json:"metadata"`
+	ImportAP   ImportAP    `json:"import-ap"`
+	Results    []Result    `json:"results"`
+	BackMatter *BackMatter `json:"back-matter,omitempty"`
+}
+
+// AssessmentResultsDocument is the root of an OSCAL assessment results JSON file
+type AssessmentResultsDocument struct {
+	AssessmentResults *AssessmentResults `json:"assessment-results"`
+}
+
+// ImportAP references the assessment plan the results were produced for
+type ImportAP struct {
+	Href    string `json:"href"`
+	Remarks string `json:"remarks,omitempty"`
+}
+
+// Result is one assessment: its observations, risks and findings
+type Result struct {
+	UUID             string           `json:"uuid"`
+	Title            string           `json:"title"`
+	Description      string           `json:"description"`
+	Start            string           `json:"start"`
+	End              string           `json:"end,omitempty"`
+	Props            []Property       `json:"props,omitempty"`
+	ReviewedControls ReviewedControls `json:"reviewed-controls"`
+	Observations     []Observation    `json:"observations,omitempty"`
+	Risks            []Risk           `json:"risks,omitempty"`
+	Findings         []Finding        `json:"findings,omitempty"`
+	Remarks          string           `json:"remarks,omitempty"`
+}
+
+// ReviewedControls tells which controls were assessed
+type ReviewedControls struct {
+	ControlSelections []ControlSelection `json:"control-selections"`
+}
+
+// ControlSelection selects controls of the assessed catalog
+type ControlSelection struct {
+	IncludeAll *struct{} `json:"include-all,omitempty"`
+}
+
+// Observation records what was examined or asked, with its evidence
+type Observation struct {
+	UUID             string             `json:"uuid"`
+	Title            string             `json:"title,omitempty"`
+	Description      string             `json:"description"`
+	Props            []Property         `json:"props,omitempty"`
+	Methods          []string           `json:"methods"`
+	RelevantEvidence []RelevantEvidence `json:"relevant-evidence,omitempty"`
+	Collected        string             `json:"collected"`
+	Remarks          string             `json:"remarks,omitempty"`
+}
+
+// RelevantEvidence points at an evidence file, usually a back-matter resource
+type RelevantEvidence struct {
+	Href        string `json:"href,omitempty"`
+	Description string `json:"description"`
+}
+
+// Risk is an identified control gap
+type Risk struct {
+	UUID                string               `json:"uuid"`
+	Title               string               `json:"title"`
+	Description         string               `json:"description"`
+	Statement           string               `json:"statement"`
+	Props               []Property           `json:"props,omitempty"`
+	Status              string               `json:"status"`
+	Deadline            string               `json:"deadline,omitempty"`
+	RelatedObservations []RelatedObservation `json:"related-observations,omitempty"`
+	Remarks             string               `json:"remarks,omitempty"`
+}
+
+// Finding is the conclusion reached for a control objective
+type Finding struct {
+	UUID                string               `json:"uuid"`
+	Title               string               `json:"title"`
+	Description         string               `json:"description"`
+	Props               []Property           `json:"props,omitempty"`
+	Target              FindingTarget        `json:"target"`
+	RelatedObservations []RelatedObservation `json:"related-observations,omitempty"`
+	RelatedRisks        []AssociatedRisk     `json:"related-risks,omitempty"`
+}
+
+// FindingTarget identifies the assessed objective and its status
+type FindingTarget struct {
+	Type     string          `json:"type"`
+	TargetID string          `json:"target-id"`
+	Status   ObjectiveStatus `json:"status"`
+}
+
+// ObjectiveStatus tells whether an objective is satisfied
+type ObjectiveStatus struct {
+	State   string `json:"state"`
+	Reason  string `json:"reason,omitempty"`
+	Remarks string `json:"remarks,omitempty"`
+}
+
+// RelatedObservation references an observation by UUID
+type RelatedObservation struct {
+	ObservationUUID string `json:"observation-uuid"`
+}
+
+// AssociatedRisk references a risk by UUID
+type AssociatedRisk struct {
+	RiskUUID string `json:"risk-uuid"`
+}
+
+// ControlToken turns a framework control ID into the OSCAL control ID used
+// by framework-service catalog exports
+func ControlToken(s string) string {
+	var b strings.Builder
+	for _, r := range strings.TrimSpace(s) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '-' || r == '_':
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			b.WriteRune('-')
+		}
+	}
+
+	token := b.String()
+	if token == "" {
+		return "_"
+	}
+	if first := []rune(token)[0]; !unicode.IsLetter(first) && first != '_' {
+		token = "ctl-" + token
+	}
+	return token
+}
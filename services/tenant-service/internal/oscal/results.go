@@ -0,0 +1,269 @@
+package oscal
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientdb"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// AuditResults is the data of a completed audit to export
+type AuditResults struct {
+	ClientID   uuid.UUID
+	ClientName string
+	Audit      clientdb.Audit
+	// Questions visible in the audit, in display order
+	Questions []QuestionResult
+	Findings  []clientdb.Finding
+	// CatalogHref locates the framework catalog the audit was assessed against
+	CatalogHref string
+	// EvidenceHref returns the download location of an evidence file
+	EvidenceHref func(evidenceID uuid.UUID) string
+}
+
+// QuestionResult is a question with its latest answer and evidence
+type QuestionResult struct {
+	Question clientdb.ListQuestionsWithSubmissionsRow
+	// Answer is the rendered answer, empty when unanswered
+	Answer string
+	// Excepted is set when an approved, unexpired exception covers the question
+	Excepted bool
+	Evidence []clientdb.ListEvidenceByAuditRow
+}
+
+// NewAssessmentResults exports an audit as assessment results with a single
+// result. Every answer becomes an observation referencing its evidence files,
+// audit findings become risks, and every reviewed question becomes a finding
+// on the statement of its control: approved "no" answers are not satisfied,
+// other approved answers are satisfied and questions covered by an exception
+// are not satisfied with the exception noted. Questions still awaiting review
+// have no finding.
+func NewAssessmentResults(in AuditResults) AssessmentResultsDocument {
+	audit := in.Audit
+
+	version := "1"
+	if audit.FrameworkVersion != nil {
+		version = *audit.FrameworkVersion
+	}
+
+	catalogResource := uuid.NewSHA1(audit.ID, []byte("catalog"))
+	backMatter := &BackMatter{Resources: []Resource{{
+		UUID:        catalogResource.String(),
+		Title:       fmt.Sprintf("%s %s catalog", audit.FrameworkName, version),
+		Description: "Framework version the audit was assessed against",
+		RLinks:      []RLink{{Href: in.CatalogHref, MediaType: "application/oscal.catalog+json"}},
+	}}}
+
+	result := Result{
+		UUID:        audit.ID.String(),
+		Title:       fmt.Sprintf("%s audit", audit.FrameworkName),
+		Description: fmt.Sprintf("Assessment of %s against %s %s", in.ClientName, audit.FrameworkName, version),
+		Start:       timestamp(audit.CreatedAt),
+		End:         timestamp(audit.CompletedAt),
+		Props: []Property{
+			{Name: "audit-id", Value: audit.ID.String(), NS: Namespace},
+			{Name: "framework-id", Value: audit.FrameworkID.String(), NS: Namespace},
+		},
+		ReviewedControls: ReviewedControls{
+			ControlSelections: []ControlSelection{{IncludeAll: &struct{}{}}},
+		},
+	}
+	if audit.FrameworkVersionID.Valid {
+		result.Props = append(result.Props, Property{
+			Name:  "framework-version-id",
+			Value: uuid.UUID(audit.FrameworkVersionID.Bytes).String(),
+			NS:    Namespace,
+		})
+	}
+
+	risksByQuestion := make(map[uuid.UUID][]AssociatedRisk)
+	for _, f := range in.Findings {
+		risk := Risk{
+			UUID:        f.ID.String(),
+			Title:       f.Title,
+			Description: f.Title,
+			Statement:   f.Title,
+			Props:       []Property{{Name: "severity", Value: string(f.Severity), NS: Namespace}},
+			Status:      riskStatus(f.Status),
+		}
+		if f.Description != nil && *f.Description != "" {
+			risk.Description = *f.Description
+			risk.Statement = *f.Description
+		}
+		if f.RemediationPlan != nil {
+			risk.Remarks = *f.RemediationPlan
+		}
+		if f.TargetDate.Valid {
+			risk.Deadline = f.TargetDate.Time.UTC().Format(time.RFC3339)
+		}
+		if f.SubmissionID.Valid {
+			risk.RelatedObservations = []RelatedObservation{{
+				ObservationUUID: uuid.UUID(f.SubmissionID.Bytes).String(),
+			}}
+		}
+
+		result.Risks = append(result.Risks, risk)
+		risksByQuestion[f.QuestionID] = append(risksByQuestion[f.QuestionID], AssociatedRisk{RiskUUID: risk.UUID})
+	}
+
+	pending := 0
+	for _, qr := range in.Questions {
+		q := qr.Question
+
+		var observations []RelatedObservation
+		if q.SubmissionID.Valid {
+			observation := newObservation(qr, audit.CreatedAt)
+			result.Observations = append(result.Observations, observation)
+			observations = []RelatedObservation{{ObservationUUID: observation.UUID}}
+
+			for _, e := range qr.Evidence {
+				resource := Resource{
+					UUID:        e.ID.String(),
+					Title:       e.FileName,
+					Description: optionalText(e.Description),
+					RLinks:      []RLink{{Href: in.EvidenceHref(e.ID), MediaType: optionalText(e.FileType)}},
+				}
+				backMatter.Resources = append(backMatter.Resources, resource)
+			}
+		}
+
+		status, ok := objectiveStatus(qr)
+		if !ok {
+			pending++
+			continue
+		}
+
+		result.Findings = append(result.Findings, Finding{
+			UUID:        q.ID.String(),
+			Title:       fmt.Sprintf("%s %s", q.Section, q.QuestionNumber),
+			Description: q.QuestionText,
+			Props: []Property{
+				{Name: "label", Value: q.QuestionNumber},
+				{Name: "severity", Value: string(q.Severity), NS: Namespace},
+			},
+			Target: FindingTarget{
+				Type:     "statement-id",
+				TargetID: ControlToken(q.QuestionNumber) + "_smt",
+				Status:   status,
+			},
+			RelatedObservations: observations,
+			RelatedRisks:        risksByQuestion[q.ID],
+		})
+	}
+
+	if pending > 0 {
+		result.Remarks = fmt.Sprintf("%d questions had no reviewed answer and are not reported as findings.", pending)
+	}
+
+	return AssessmentResultsDocument{AssessmentResults: &AssessmentResults{
+		UUID: uuid.New().String(),
+		Metadata: Metadata{
+			Title:        fmt.Sprintf("%s assessment results for %s", audit.FrameworkName, in.ClientName),
+			LastModified: time.Now().UTC().Format(time.RFC3339),
+			Version:      version,
+			OSCALVersion: Version,
+			Props:        []Property{{Name: "client-id", Value: in.ClientID.String(), NS: Namespace}},
+			Parties: []Party{{
+				UUID: in.ClientID.String(),
+				Type: "organization",
+				Name: in.ClientName,
+			}},
+		},
+		ImportAP: ImportAP{
+			Href:    "#" + catalogResource.String(),
+			Remarks: "Audits are planned directly from a framework version; no separate assessment plan is kept.",
+		},
+		Results:    []Result{result},
+		BackMatter: backMatter,
+	}}
+}
+
+func newObservation(qr QuestionResult, fallback pgtype.Timestamptz) Observation {
+	q := qr.Question
+
+	description := qr.Answer
+	if description == "" {
+		description = "No answer given"
+	}
+
+	collected := q.SubmittedAt
+	if !collected.Valid {
+		collected = fallback
+	}
+
+	observation := Observation{
+		UUID:        uuid.UUID(q.SubmissionID.Bytes).String(),
+		Title:       fmt.Sprintf("Response to %s", q.QuestionNumber),
+		Description: description,
+		Methods:     []string{"INTERVIEW"},
+		Collected:   timestamp(collected),
+	}
+	if q.SubmissionStatus.Valid {
+		observation.Props = []Property{{
+			Name:  "submission-status",
+			Value: string(q.SubmissionStatus.SubmissionStatusEnum),
+			NS:    Namespace,
+		}}
+	}
+
+	if len(qr.Evidence) > 0 {
+		observation.Methods = append(observation.Methods, "EXAMINE")
+	}
+	for _, e := range qr.Evidence {
+		observation.RelevantEvidence = append(observation.RelevantEvidence, RelevantEvidence{
+			Href:        "#" + e.ID.String(),
+			Description: e.FileName,
+		})
+	}
+
+	return observation
+}
+
+// objectiveStatus derives the finding status of a question; it reports false
+// when the question has not been reviewed
+func objectiveStatus(qr QuestionResult) (ObjectiveStatus, bool) {
+	q := qr.Question
+
+	approved := q.SubmissionStatus.Valid && q.SubmissionStatus.SubmissionStatusEnum == clientdb.SubmissionStatusEnumApproved
+	switch {
+	case approved && q.AnswerValue.Valid && q.AnswerValue.AnswerValueEnum == clientdb.AnswerValueEnumNo:
+		return ObjectiveStatus{State: StateNotSatisfied, Reason: "fail"}, true
+	case approved && q.AnswerValue.Valid && q.AnswerValue.AnswerValueEnum == clientdb.AnswerValueEnumNa:
+		return ObjectiveStatus{State: StateSatisfied, Reason: "other", Remarks: "Not applicable"}, true
+	case approved:
+		return ObjectiveStatus{State: StateSatisfied, Reason: "pass"}, true
+	case qr.Excepted:
+		return ObjectiveStatus{State: StateNotSatisfied, Reason: "other", Remarks: "Accepted through an approved exception"}, true
+	}
+	return ObjectiveStatus{}, false
+}
+
+// riskStatus maps a finding status to the closest OSCAL risk status
+func riskStatus(status clientdb.FindingStatusEnum) string {
+	switch status {
+	case clientdb.FindingStatusEnumInRemediation:
+		return RiskRemediating
+	case clientdb.FindingStatusEnumVerified, clientdb.FindingStatusEnumClosed:
+		return RiskClosed
+	case clientdb.FindingStatusEnumRiskAccepted:
+		return RiskDeviationApproved
+	default:
+		return RiskOpen
+	}
+}
+
+func timestamp(t pgtype.Timestamptz) string {
+	if !t.Valid {
+		return ""
+	}
+	return t.Time.UTC().Format(time.RFC3339)
+}
+
+func optionalText(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,120 @@
+package oscal
+
+import (
+	"testing"
+
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientdb"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// questionResult returns a question with its latest submission in the given
+// status and answer, or an unanswered question when status is empty
+func questionResult(number string, status clientdb.SubmissionStatusEnum, answer clientdb.AnswerValueEnum) QuestionResult {
+	q := clientdb.ListQuestionsWithSubmissionsRow{ID: uuid.New(), Section: "Governance", QuestionNumber: number}
+	if status != "" {
+		q.SubmissionID = pgtype.UUID{Bytes: uuid.New(), Valid: true}
+		q.SubmissionStatus = clientdb.NullSubmissionStatusEnum{SubmissionStatusEnum: status, Valid: true}
+	}
+	if answer != "" {
+		q.AnswerValue = clientdb.NullAnswerValueEnum{AnswerValueEnum: answer, Valid: true}
+	}
+	return QuestionResult{Question: q}
+}
+
+func TestObjectiveStatus(t *testing.T) {
+	excepted := questionResult("1.1", clientdb.SubmissionStatusEnumRejected, clientdb.AnswerValueEnumNo)
+	excepted.Excepted = true
+
+	tests := []struct {
+		name       string
+		question   QuestionResult
+		wantState  string
+		wantReason string
+		wantOK     bool
+	}{
+		{name: "approved yes", question: questionResult("1.1", clientdb.SubmissionStatusEnumApproved, clientdb.AnswerValueEnumYes), wantState: StateSatisfied, wantReason: "pass", wantOK: true},
+		{name: "approved no", question: questionResult("1.1", clientdb.SubmissionStatusEnumApproved, clientdb.AnswerValueEnumNo), wantState: StateNotSatisfied, wantReason: "fail", wantOK: true},
+		{name: "approved not applicable", question: questionResult("1.1", clientdb.SubmissionStatusEnumApproved, clientdb.AnswerValueEnumNa), wantState: StateSatisfied, wantReason: "other", wantOK: true},
+		{name: "approved text answer", question: questionResult("1.1", clientdb.SubmissionStatusEnumApproved, ""), wantState: StateSatisfied, wantReason: "pass", wantOK: true},
+		{name: "excepted", question: excepted, wantState: StateNotSatisfied, wantReason: "other", wantOK: true},
+		{name: "pending review", question: questionResult("1.1", clientdb.SubmissionStatusEnumSubmitted, clientdb.AnswerValueEnumYes)},
+		{name: "unanswered", question: questionResult("1.1", "", "")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := objectiveStatus(tt.question)
+			if ok != tt.wantOK || got.State != tt.wantState || got.Reason != tt.wantReason {
+				t.Errorf("objectiveStatus() = %+v, %v, want state %q reason %q and %v",
+					got, ok, tt.wantState, tt.wantReason, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRiskStatus(t *testing.T) {
+	tests := []struct {
+		status clientdb.FindingStatusEnum
+		want   string
+	}{
+		{status: clientdb.FindingStatusEnumOpen, want: RiskOpen},
+		{status: clientdb.FindingStatusEnumInRemediation, want: RiskRemediating},
+		{status: clientdb.FindingStatusEnumVerified, want: RiskClosed},
+		{status: clientdb.FindingStatusEnumClosed, want: RiskClosed},
+		{status: clientdb.FindingStatusEnumRiskAccepted, want: RiskDeviationApproved},
+	}
+
+	for _, tt := range tests {
+		if got := riskStatus(tt.status); got != tt.want {
+			t.Errorf("riskStatus(%q) = %q, want %q", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestNewAssessmentResults(t *testing.T) {
+	approved := questionResult("1.1", clientdb.SubmissionStatusEnumApproved, clientdb.AnswerValueEnumNo)
+	approved.Evidence = []clientdb.ListEvidenceByAuditRow{{ID: uuid.New(), FileName: "policy.pdf"}}
+	pending := questionResult("1.2", clientdb.SubmissionStatusEnumSubmitted, clientdb.AnswerValueEnumYes)
+	unanswered := questionResult("1.3", "", "")
+
+	finding := clientdb.Finding{
+		ID:         uuid.New(),
+		QuestionID: approved.Question.ID,
+		Title:      "No security policy",
+		Status:     clientdb.FindingStatusEnumInRemediation,
+	}
+
+	doc := NewAssessmentResults(AuditResults{
+		ClientID:     uuid.New(),
+		ClientName:   "Acme",
+		Audit:        clientdb.Audit{ID: uuid.New(), FrameworkName: "ISO 27001"},
+		Questions:    []QuestionResult{approved, pending, unanswered},
+		Findings:     []clientdb.Finding{finding},
+		EvidenceHref: func(evidenceID uuid.UUID) string { return "/evidence/" + evidenceID.String() },
+	})
+
+	result := doc.AssessmentResults.Results[0]
+	if len(result.Observations) != 2 {
+		t.Errorf("observations = %d, want one per answered question", len(result.Observations))
+	}
+	if len(result.Findings) != 1 {
+		t.Fatalf("findings = %d, want only the reviewed question", len(result.Findings))
+	}
+	if got := result.Findings[0].Target.TargetID; got != "ctl-1.1_smt" {
+		t.Errorf("target = %q, want ctl-1.1_smt", got)
+	}
+	if risks := result.Findings[0].RelatedRisks; len(risks) != 1 || risks[0].RiskUUID != finding.ID.String() {
+		t.Errorf("related risks = %+v, want the finding of the question", risks)
+	}
+	if len(result.Risks) != 1 || result.Risks[0].Status != RiskRemediating {
+		t.Errorf("risks = %+v, want one remediating risk", result.Risks)
+	}
+	if result.Remarks == "" {
+		t.Error("remarks do not mention the questions without a reviewed answer")
+	}
+	// the catalog plus the evidence file
+	if got := len(doc.AssessmentResults.BackMatter.Resources); got != 2 {
+		t.Errorf("back matter resources = %d, want 2", got)
+	}
+}
@@ -0,0 +1,314 @@
+// Package reminder notifies client POCs when the due date of one of their
+// audits approaches, and when an approved exception of their client is about
+// to expire.
+package reminder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/NormaTech-AI/audity/packages/go/emailtemplates"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientdb"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientstore"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/db"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/mail"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/notification"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/store"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+// How often due dates and exception expiries are checked
+const interval = time.Hour
+
+type Scheduler struct {
+	store         *store.Store
+	clientStore   *clientstore.ClientStore
+	mail          *mail.MailService
+	notifications *notification.Service
+	days          []int
+	exceptionDays []int
+	log           *zap.SugaredLogger
+}
+
+// NewScheduler creates a scheduler sending a reminder the given numbers of
+// days before each due date, e.g. 7 and 1, and exceptionDays before each
+// approved exception expires, e.g. 30 and 7
+func NewScheduler(store *store.Store, clientStore *clientstore.ClientStore, mailService *mail.MailService, notifications *notification.Service, days, exceptionDays []int, log *zap.SugaredLogger) *Scheduler {
+	return &Scheduler{
+		store:         store,
+		clientStore:   clientStore,
+		mail:          mailService,
+		notifications: notifications,
+		days:          windows(days),
+		exceptionDays: windows(exceptionDays),
+		log:           log,
+	}
+}
+
+// windows sorts reminder windows closest first, so an assignment or exception
+// that is already within several windows only gets the reminder of the
+// closest one
+func windows(days []int) []int {
+	sorted := make([]int, 0, len(days))
+	for _, d := range days {
+		if d >= 0 {
+			sorted = append(sorted, d)
+		}
+	}
+	sort.Ints(sorted)
+	return sorted
+}
+
+// Run sends due reminders until ctx is cancelled. Several instances can run
+// at once; every reminder is sent once.
+func (s *Scheduler) Run(ctx context.Context) {
+	if len(s.days) == 0 && len(s.exceptionDays) == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.remind(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) remind(ctx context.Context) {
+	s.remindDueDates(ctx)
+	s.remindExpiringExceptions(ctx)
+}
+
+func (s *Scheduler) remindDueDates(ctx context.Context) {
+	for _, days := range s.days {
+		due, err := s.store.Queries.ListFrameworksDueForReminder(ctx, int32(days))
+		if err != nil {
+			if ctx.Err() == nil {
+				s.log.Errorw("Failed to list frameworks due for reminder", "error", err, "days_before", days)
+			}
+			return
+		}
+
+		for _, fw := range due {
+			if err := s.send(ctx, fw, days); err != nil {
+				s.log.Errorw("Failed to send due date reminder",
+					"error", err,
+					"audit_cycle_framework_id", fw.ID,
+					"days_before", days)
+			}
+		}
+	}
+}
+
+// send records the reminder and sends its notification in one transaction, so
+// it is neither lost nor sent twice
+func (s *Scheduler) send(ctx context.Context, fw db.ListFrameworksDueForReminderRow, days int) error {
+	return s.store.ExecTx(ctx, func(q *db.Queries) error {
+		created, err := q.CreateDueDateReminder(ctx, db.CreateDueDateReminderParams{
+			AuditCycleFrameworkID: fw.ID,
+			DueDate:               fw.DueDate,
+			DaysBefore:            int32(days),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to record reminder: %w", err)
+		}
+		if created == 0 {
+			// Sent by another instance in the meantime
+			return nil
+		}
+
+		today := time.Now().UTC().Truncate(24 * time.Hour)
+		daysLeft := int(fw.DueDate.Time.Sub(today).Hours() / 24)
+		if daysLeft < 0 {
+			daysLeft = 0
+		}
+
+		dueDate := fw.DueDate.Time.Format("2 January 2006")
+		auditID := uuid.UUID(fw.ClientAuditID.Bytes)
+		err = s.notifications.WithQueries(q).NotifyAddress(ctx, fw.PocEmail, notification.Notification{
+			Type:     notification.DueDate,
+			ClientID: fw.ClientID,
+			Title:    fmt.Sprintf("%s audit due %s", fw.FrameworkName, dueIn(daysLeft)),
+			Body:     fmt.Sprintf("The %s audit of %s is due on %s.", fw.FrameworkName, fw.ClientName, dueDate),
+			Link:     fmt.Sprintf("/audit/%s", auditID),
+			Email: &mail.TemplateEmail{
+				ClientID: fw.ClientID,
+				Template: emailtemplates.DueDateApproaching,
+				Data: emailtemplates.DueDateApproachingData{
+					ClientName:    fw.ClientName,
+					FrameworkName: fw.FrameworkName,
+					DueDate:       dueDate,
+					DaysLeft:      daysLeft,
+					AuditURL:      s.mail.AppURL("/audit/%s", auditID),
+				},
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		s.log.Infow("Due date reminder sent",
+			"audit_cycle_framework_id", fw.ID,
+			"client_id", fw.ClientID,
+			"days_left", daysLeft)
+		return nil
+	})
+}
+
+// remindExpiringExceptions reminds every client of its approved exceptions
+// that expire within a window, including those that already lapsed
+func (s *Scheduler) remindExpiringExceptions(ctx context.Context) {
+	if len(s.exceptionDays) == 0 {
+		return
+	}
+
+	databases, err := s.store.Queries.ListClientDatabases(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.log.Errorw("Failed to list client databases", "error", err)
+		}
+		return
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	for _, cdb := range databases {
+		clientQueries, _, err := s.clientStore.GetClientQueries(ctx, cdb.ClientID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			s.log.Warnw("Skipping exception reminders of unreachable client database", "error", err, "client_id", cdb.ClientID)
+			continue
+		}
+
+		client, err := s.store.Queries.GetClient(ctx, cdb.ClientID)
+		if err != nil {
+			s.log.Errorw("Failed to get client", "error", err, "client_id", cdb.ClientID)
+			continue
+		}
+
+		for _, days := range s.exceptionDays {
+			cutoff := pgtype.Date{Time: today.AddDate(0, 0, days), Valid: true}
+			expiring, err := clientQueries.ListExpiringExceptions(ctx, cutoff)
+			if err != nil {
+				if ctx.Err() == nil {
+					s.log.Errorw("Failed to list expiring exceptions", "error", err, "client_id", client.ID, "days_before", days)
+				}
+				break
+			}
+
+			for _, exception := range expiring {
+				if err := s.sendExceptionReminder(ctx, client, exception, days, today); err != nil {
+					s.log.Errorw("Failed to send exception expiry reminder",
+						"error", err,
+						"client_id", client.ID,
+						"exception_id", exception.ID,
+						"days_before", days)
+				}
+			}
+		}
+	}
+}
+
+// sendExceptionReminder records the reminder and notifies the requester of the
+// exception and the POC of its client in one transaction, so it is neither
+// lost nor sent twice
+func (s *Scheduler) sendExceptionReminder(ctx context.Context, client db.Client, exception clientdb.ListExpiringExceptionsRow, days int, today time.Time) error {
+	return s.store.ExecTx(ctx, func(q *db.Queries) error {
+		created, err := q.CreateExceptionExpiryReminder(ctx, db.CreateExceptionExpiryReminderParams{
+			ClientID:    client.ID,
+			ExceptionID: exception.ID,
+			ExpiresAt:   exception.ExpiresAt,
+			DaysBefore:  int32(days),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to record reminder: %w", err)
+		}
+		if created == 0 {
+			// Reminded in this or a closer window already
+			return nil
+		}
+
+		daysLeft := int(exception.ExpiresAt.Time.Sub(today).Hours() / 24)
+		expiresAt := exception.ExpiresAt.Time.Format("2 January 2006")
+
+		title := fmt.Sprintf("%s exception expires %s", exception.FrameworkName, dueIn(daysLeft))
+		body := fmt.Sprintf("The exception for question %s of the %s audit of %s expires on %s.",
+			exception.QuestionNumber, exception.FrameworkName, client.Name, expiresAt)
+		if daysLeft < 0 {
+			title = fmt.Sprintf("%s exception expired", exception.FrameworkName)
+			body = fmt.Sprintf("The exception for question %s of the %s audit of %s expired on %s.",
+				exception.QuestionNumber, exception.FrameworkName, client.Name, expiresAt)
+		}
+
+		n := notification.Notification{
+			Type:     notification.ExceptionExpiry,
+			ClientID: client.ID,
+			Title:    title,
+			Body:     body,
+			Link:     fmt.Sprintf("/audit/%s", exception.AuditID),
+			Email: &mail.TemplateEmail{
+				ClientID: client.ID,
+				Template: emailtemplates.ExceptionExpiring,
+				Data: emailtemplates.ExceptionExpiringData{
+					ClientName:     client.Name,
+					FrameworkName:  exception.FrameworkName,
+					QuestionNumber: exception.QuestionNumber,
+					QuestionText:   exception.QuestionText,
+					ExpiresAt:      expiresAt,
+					DaysLeft:       daysLeft,
+					AuditURL:       s.mail.AppURL("/audit/%s", exception.AuditID),
+				},
+			},
+		}
+
+		// The POC is reminded too unless they requested the exception
+		notifications := s.notifications.WithQueries(q)
+		notifyPOC := true
+		requester, err := q.GetUser(ctx, exception.RequestedBy)
+		switch {
+		case err == nil:
+			if err := notifications.Notify(ctx, requester, n); err != nil {
+				return err
+			}
+			notifyPOC = !strings.EqualFold(requester.Email, client.PocEmail)
+		case !errors.Is(err, pgx.ErrNoRows):
+			return fmt.Errorf("failed to get requester: %w", err)
+		}
+		if notifyPOC {
+			if err := notifications.NotifyAddress(ctx, client.PocEmail, n); err != nil {
+				return err
+			}
+		}
+
+		s.log.Infow("Exception expiry reminder sent",
+			"exception_id", exception.ID,
+			"client_id", client.ID,
+			"days_left", daysLeft)
+		return nil
+	})
+}
+
+func dueIn(days int) string {
+	switch days {
+	case 0:
+		return "today"
+	case 1:
+		return "tomorrow"
+	default:
+		return fmt.Sprintf("in %d days", days)
+	}
+}
@@ -0,0 +1,26 @@
+package reminder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWindows(t *testing.T) {
+	tests := []struct {
+		name string
+		days []int
+		want []int
+	}{
+		{name: "none", days: nil, want: []int{}},
+		{name: "closest first", days: []int{30, 7, 1}, want: []int{1, 7, 30}},
+		{name: "negative windows are dropped", days: []int{14, -1, 0}, want: []int{0, 14}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := windows(tt.days); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("windows(%v) = %v, want %v", tt.days, got, tt.want)
+			}
+		})
+	}
+}
@@ -62,11 +62,23 @@ func SetupRoutes(e *echo.Echo, h *handler.Handler, cfg *config.Config, store *st
 			rbac.PermissionMiddleware(store, logger, "audits:read"),
 		)
 
+		// Get compliance score with section breakdown
+		audits.GET("/:auditId/score",
+			h.GetAuditScore,
+			rbac.PermissionMiddleware(store, logger, "audits:read"),
+		)
+
 		// Update audit (assignment, status, due date)
 		audits.PATCH("/:auditId",
 			h.UpdateAudit,
 			rbac.PermissionMiddleware(store, logger, "audits:update"),
 		)
+
+		// Prefill answers and evidence from the previous audit of the framework
+		audits.POST("/:auditId/carry-forward",
+			h.CarryForwardAuditAnswers,
+			rbac.PermissionMiddleware(store, logger, "audits:update"),
+		)
 	}
 
 	// Submission management routes (protected, client-specific)
@@ -211,9 +223,113 @@ func SetupRoutes(e *echo.Echo, h *handler.Handler, cfg *config.Config, store *st
 		)
 	}
 
+	// Finding routes (protected, client-specific)
+	findings := api.Group("/clients/:clientId/findings")
+	{
+		// Raise a finding against a question
+		findings.POST("",
+			h.CreateFinding,
+			rbac.PermissionMiddleware(store, logger, "findings:create"),
+		)
+
+		// List findings of an audit
+		findings.GET("/audits/:auditId",
+			h.ListAuditFindings,
+			rbac.PermissionMiddleware(store, logger, "findings:read"),
+		)
+
+		// Get finding with closure evidence
+		findings.GET("/:findingId",
+			h.GetFinding,
+			rbac.PermissionMiddleware(store, logger, "findings:read"),
+		)
+
+		// Update finding details
+		findings.PUT("/:findingId",
+			h.UpdateFinding,
+			rbac.PermissionMiddleware(store, logger, "findings:update"),
+		)
+
+		// Move finding through its lifecycle
+		findings.POST("/:findingId/status",
+			h.UpdateFindingStatus,
+			rbac.PermissionMiddleware(store, logger, "findings:update"),
+		)
+
+		// Upload closure evidence
+		findings.POST("/:findingId/evidence",
+			h.UploadFindingEvidence,
+			rbac.PermissionMiddleware(store, logger, "findings:update"),
+		)
+
+		// Delete finding
+		findings.DELETE("/:findingId",
+			h.DeleteFinding,
+			rbac.PermissionMiddleware(store, logger, "findings:delete"),
+		)
+	}
+
+	// Exception routes (protected, client-specific)
+	exceptions := api.Group("/clients/:clientId/exceptions")
+	{
+		// List approved exceptions that are expiring or have lapsed
+		exceptions.GET("/expiring",
+			h.ListExpiringExceptions,
+			rbac.PermissionMiddleware(store, logger, "exceptions:read"),
+		)
+
+		// List exceptions of an audit
+		exceptions.GET("/audits/:auditId",
+			h.ListAuditExceptions,
+			rbac.PermissionMiddleware(store, logger, "exceptions:read"),
+		)
+
+		// Get exception
+		exceptions.GET("/:exceptionId",
+			h.GetException,
+			rbac.PermissionMiddleware(store, logger, "exceptions:read"),
+		)
+
+		// Approve exception with an expiry date
+		exceptions.POST("/:exceptionId/approve",
+			h.ApproveException,
+			rbac.PermissionMiddleware(store, logger, "exceptions:approve"),
+		)
+
+		// Reject exception
+		exceptions.POST("/:exceptionId/reject",
+			h.RejectException,
+			rbac.PermissionMiddleware(store, logger, "exceptions:approve"),
+		)
+
+		// Revoke approved exception
+		exceptions.POST("/:exceptionId/revoke",
+			h.RevokeException,
+			rbac.PermissionMiddleware(store, logger, "exceptions:approve"),
+		)
+	}
+
 	// Report generation routes (protected, client-specific)
 	reports := api.Group("/clients/:clientId/reports")
 	{
+		// Check whether an audit is ready for report generation
+		reports.GET("/audits/:auditId/readiness",
+			h.GetReportReadiness,
+			rbac.PermissionMiddleware(store, logger, "reports:read"),
+		)
+
+		// Export audit answers as CSV
+		reports.GET("/audits/:auditId/export",
+			h.ExportAuditAnswers,
+			rbac.PermissionMiddleware(store, logger, "reports:read"),
+		)
+
+		// Export a completed audit as OSCAL assessment results
+		reports.GET("/audits/:auditId/oscal",
+			h.ExportAuditOSCAL,
+			rbac.PermissionMiddleware(store, logger, "reports:read"),
+		)
+
 		// Generate new report for audit
 		reports.POST("/audits/:auditId/generate",
 			h.GenerateReport,
@@ -257,6 +373,80 @@ func SetupRoutes(e *echo.Echo, h *handler.Handler, cfg *config.Config, store *st
 		)
 	}
 
+	// Email branding routes (protected, client-specific)
+	branding := api.Group("/clients/:clientId/branding")
+	{
+		// Get the email branding of a client
+		branding.GET("",
+			h.GetClientBranding,
+			rbac.PermissionMiddleware(store, logger, "clients:read"),
+		)
+
+		// Update the email branding of a client
+		branding.PUT("",
+			h.UpdateClientBranding,
+			rbac.PermissionMiddleware(store, logger, "clients:update"),
+		)
+	}
+
+	// Outbound webhook routes (protected, client-specific)
+	webhooks := api.Group("/clients/:clientId/webhooks")
+	{
+		// List the webhooks of a client
+		webhooks.GET("",
+			h.ListWebhooks,
+			rbac.PermissionMiddleware(store, logger, "clients:read"),
+		)
+
+		// Subscribe a URL to events of a client
+		webhooks.POST("",
+			h.CreateWebhook,
+			rbac.PermissionMiddleware(store, logger, "clients:update"),
+		)
+
+		// Get a webhook
+		webhooks.GET("/:webhookId",
+			h.GetWebhook,
+			rbac.PermissionMiddleware(store, logger, "clients:read"),
+		)
+
+		// Update a webhook
+		webhooks.PUT("/:webhookId",
+			h.UpdateWebhook,
+			rbac.PermissionMiddleware(store, logger, "clients:update"),
+		)
+
+		// Delete a webhook
+		webhooks.DELETE("/:webhookId",
+			h.DeleteWebhook,
+			rbac.PermissionMiddleware(store, logger, "clients:update"),
+		)
+
+		// Send a test event to a webhook
+		webhooks.POST("/:webhookId/ping",
+			h.PingWebhook,
+			rbac.PermissionMiddleware(store, logger, "clients:update"),
+		)
+
+		// List the deliveries of a webhook
+		webhooks.GET("/:webhookId/deliveries",
+			h.ListWebhookDeliveries,
+			rbac.PermissionMiddleware(store, logger, "clients:read"),
+		)
+
+		// Get a delivery with its request log
+		webhooks.GET("/:webhookId/deliveries/:deliveryId",
+			h.GetWebhookDelivery,
+			rbac.PermissionMiddleware(store, logger, "clients:read"),
+		)
+
+		// Queue the event of a delivery again
+		webhooks.POST("/:webhookId/deliveries/:deliveryId/redeliver",
+			h.RedeliverWebhookDelivery,
+			rbac.PermissionMiddleware(store, logger, "clients:update"),
+		)
+	}
+
 	// Audit Cycle management routes (protected)
 	auditCycles := api.Group("/audit-cycles")
 	{
@@ -290,6 +480,12 @@ func SetupRoutes(e *echo.Echo, h *handler.Handler, cfg *config.Config, store *st
 			rbac.PermissionMiddleware(store, logger, "audit_cycles:delete"),
 		)
 
+		// Clone audit cycle into a new period
+		auditCycles.POST("/:id/clone",
+			h.CloneAuditCycle,
+			rbac.PermissionMiddleware(store, logger, "audit_cycles:create"),
+		)
+
 		// Get audit cycle statistics
 		auditCycles.GET("/:id/stats",
 			h.GetAuditCycleStats,
@@ -322,6 +518,12 @@ func SetupRoutes(e *echo.Echo, h *handler.Handler, cfg *config.Config, store *st
 			h.AssignFrameworkToClient,
 			rbac.PermissionMiddleware(store, logger, "audit_cycles:assign_frameworks"),
 		)
+
+		// Assign frameworks to many clients at once
+		auditCycles.POST("/:id/frameworks/bulk",
+			h.BulkAssignFrameworks,
+			rbac.PermissionMiddleware(store, logger, "audit_cycles:assign_frameworks"),
+		)
 	}
 
 	// Users management routes (protected)
@@ -333,6 +535,9 @@ func SetupRoutes(e *echo.Echo, h *handler.Handler, cfg *config.Config, store *st
 			rbac.PermissionMiddleware(store, logger, "users:list"),
 		)
 
+		// Set the locale questions are served in for the authenticated user
+		users.PUT("/me/locale", h.UpdateMyPreferredLocale)
+
 		// Get specific user
 		users.GET("/:id",
 			h.GetUser,
@@ -340,6 +545,29 @@ func SetupRoutes(e *echo.Echo, h *handler.Handler, cfg *config.Config, store *st
 		)
 	}
 
+	// Notification inbox of the authenticated user (protected, no permission
+	// needed as users only see their own notifications)
+	notifications := api.Group("/notifications")
+	{
+		// List notifications, newest first
+		notifications.GET("", h.ListMyNotifications)
+
+		// Count unread notifications
+		notifications.GET("/unread-count", h.GetMyUnreadNotificationCount)
+
+		// Mark all notifications read
+		notifications.POST("/read-all", h.MarkAllMyNotificationsRead)
+
+		// Get the channels of each notification type
+		notifications.GET("/preferences", h.GetMyNotificationPreferences)
+
+		// Set the channels of notification types
+		notifications.PUT("/preferences", h.UpdateMyNotificationPreferences)
+
+		// Mark a notification read
+		notifications.POST("/:id/read", h.MarkMyNotificationRead)
+	}
+
 	// Client Audit View routes (for client users to view and submit)
 	clientAudit := api.Group("/client-audit")
 	{
@@ -366,5 +594,53 @@ func SetupRoutes(e *echo.Echo, h *handler.Handler, cfg *config.Config, store *st
 			h.SubmitClientAnswer,
 			rbac.PermissionMiddleware(store, logger, "audit:submit"),
 		)
+
+		// Confirm a carried forward answer unchanged
+		clientAudit.POST("/submissions/:submissionId/confirm",
+			h.ConfirmCarriedForwardAnswer,
+			rbac.PermissionMiddleware(store, logger, "audit:submit"),
+		)
+
+		// List findings of an audit (role-based filtering)
+		clientAudit.GET("/:auditId/findings",
+			h.ListClientFindings,
+			rbac.PermissionMiddleware(store, logger, "audit:read"),
+		)
+
+		// Record remediation plan for a finding
+		clientAudit.PUT("/findings/:findingId/remediation",
+			h.UpdateClientFindingRemediation,
+			rbac.PermissionMiddleware(store, logger, "audit:submit"),
+		)
+
+		// Upload closure evidence for a finding
+		clientAudit.POST("/findings/:findingId/evidence",
+			h.UploadClientFindingEvidence,
+			rbac.PermissionMiddleware(store, logger, "audit:submit"),
+		)
+
+		// List exceptions of an audit
+		clientAudit.GET("/:auditId/exceptions",
+			h.ListClientExceptions,
+			rbac.PermissionMiddleware(store, logger, "audit:read"),
+		)
+
+		// Request an exception for a question (POC only)
+		clientAudit.POST("/exceptions",
+			h.RequestClientException,
+			rbac.PermissionMiddleware(store, logger, "audit:submit"),
+		)
+
+		// List exceptions due for renewal
+		clientAudit.GET("/exceptions/expiring",
+			h.ListClientExpiringExceptions,
+			rbac.PermissionMiddleware(store, logger, "audit:read"),
+		)
+
+		// Delegate a question to a client user (POC only)
+		clientAudit.POST("/questions/:questionId/assignments",
+			h.DelegateClientQuestion,
+			rbac.PermissionMiddleware(store, logger, "audit:submit"),
+		)
 	}
 }
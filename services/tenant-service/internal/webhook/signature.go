@@ -0,0 +1,67 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Request headers of a delivery
+const (
+	HeaderEvent     = "X-Audity-Event"
+	HeaderDelivery  = "X-Audity-Delivery"
+	HeaderWebhook   = "X-Audity-Webhook"
+	HeaderSignature = "X-Audity-Signature"
+)
+
+// secretPrefix marks generated signing secrets
+const secretPrefix = "whsec_"
+
+// GenerateSecret returns a new random signing secret
+func GenerateSecret() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return secretPrefix + hex.EncodeToString(b), nil
+}
+
+// Sign returns the signature header of a request body sent at the given time:
+// t=<unix seconds>,v1=<hex HMAC-SHA256 of "<unix seconds>.<body>">. Receivers
+// recompute the HMAC with their secret, compare it in constant time and
+// reject old timestamps to prevent replays.
+func Sign(secret string, at time.Time, body []byte) string {
+	ts := strconv.FormatInt(at.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// ValidateURL checks a webhook URL. HTTPS is required unless allowHTTP.
+func ValidateURL(raw string, allowHTTP bool) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return errors.New("url must be an absolute http(s) URL")
+	}
+	switch u.Scheme {
+	case "https":
+	case "http":
+		if !allowHTTP {
+			return errors.New("url must use https")
+		}
+	default:
+		return errors.New("url must be an absolute http(s) URL")
+	}
+	if u.User != nil {
+		return errors.New("url must not contain credentials")
+	}
+	return nil
+}
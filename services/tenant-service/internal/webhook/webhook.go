@@ -0,0 +1,415 @@
+// Package webhook delivers domain events to the webhooks of clients. Events
+// consumed from the event bus are queued as deliveries in tenant_db and sent
+// in the background as signed HTTP POST requests, retried with backoff.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/NormaTech-AI/audity/packages/go/eventbus"
+	"github.com/NormaTech-AI/audity/packages/go/retry"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/config"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/crypto"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/db"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/events"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/store"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+// PingEvent is the type of the test deliveries of a webhook
+const PingEvent eventbus.Type = "ping"
+
+// Delivery statuses
+const (
+	StatusPending   = "pending"
+	StatusSending   = "sending"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+const (
+	// How many due deliveries a dispatcher claims at once
+	batchSize = 20
+	// Claimed deliveries are picked up again by another dispatcher when this
+	// expires, so it must outlast the request timeout
+	leaseDuration = 5 * time.Minute
+	// How much of the response body is kept in the delivery log
+	maxResponseBody = 1024
+)
+
+var errPrivateAddress = errors.New("webhook url resolves to a private address")
+
+// EventTypes returns the event types webhooks can subscribe to. Clients are
+// created before they can have webhooks, so client.created is not one of them.
+func EventTypes() []eventbus.Type {
+	types := make([]eventbus.Type, 0, len(eventbus.Types()))
+	for _, t := range eventbus.Types() {
+		if t != eventbus.ClientCreated {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+// Result is the outcome of a delivery attempt
+type Result struct {
+	Succeeded    bool
+	StatusCode   int // 0 when no response was received
+	ResponseBody string
+	Error        string
+	Duration     time.Duration
+}
+
+type Dispatcher struct {
+	store     *store.Store
+	encryptor *crypto.Encryptor
+	client    *http.Client
+	cfg       config.WebhooksConfig
+	log       *zap.SugaredLogger
+	wake      chan struct{}
+}
+
+// NewDispatcher creates a dispatcher. Call Run to start sending queued
+// deliveries and register HandleEvent with the event consumer.
+func NewDispatcher(store *store.Store, encryptor *crypto.Encryptor, cfg config.WebhooksConfig, log *zap.SugaredLogger) *Dispatcher {
+	return &Dispatcher{
+		store:     store,
+		encryptor: encryptor,
+		client:    newHTTPClient(cfg),
+		cfg:       cfg,
+		log:       log,
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+// newHTTPClient returns a client that does not follow redirects and, unless
+// allowed, refuses to connect to loopback and private addresses
+func newHTTPClient(cfg config.WebhooksConfig) *http.Client {
+	dialer := &net.Dialer{Timeout: cfg.Timeout}
+	if !cfg.AllowPrivateNetworks {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+				ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsMulticast() {
+				return errPrivateAddress
+			}
+			return nil
+		}
+	}
+
+	return &http.Client{
+		Timeout: cfg.Timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: cfg.Timeout,
+			MaxIdleConns:        100,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// HandleEvent queues the deliveries of an event to the webhooks of its
+// client. Events are consumed at least once; an event is queued once per
+// webhook.
+func (d *Dispatcher) HandleEvent(ctx context.Context, env eventbus.Envelope) error {
+	subscriptions, err := d.store.Queries.ListWebhookSubscriptionsForEvent(ctx, db.ListWebhookSubscriptionsForEventParams{
+		ClientID:  env.ClientID,
+		EventType: string(env.Type),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(env)
+	if err != nil {
+		return eventbus.Permanent(fmt.Errorf("failed to encode event: %w", err))
+	}
+
+	queued := int64(0)
+	for _, sub := range subscriptions {
+		n, err := d.store.Queries.EnqueueWebhookDelivery(ctx, db.EnqueueWebhookDeliveryParams{
+			SubscriptionID: sub.ID,
+			EventID:        env.ID,
+			EventType:      string(env.Type),
+			Payload:        payload,
+			MaxAttempts:    int32(d.cfg.MaxAttempts),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to queue webhook delivery: %w", err)
+		}
+		queued += n
+	}
+	if queued > 0 {
+		d.Wake()
+	}
+	return nil
+}
+
+// Ping sends a test event to a webhook right away and returns the delivery
+// and its outcome. Pings are not retried.
+func (d *Dispatcher) Ping(ctx context.Context, sub db.WebhookSubscription) (db.WebhookDelivery, Result, error) {
+	env := eventbus.Envelope{
+		ID:         uuid.New(),
+		Type:       PingEvent,
+		Version:    1,
+		Source:     events.Source,
+		ClientID:   sub.ClientID,
+		OccurredAt: time.Now().UTC(),
+	}
+	data, err := json.Marshal(map[string]string{"webhook_id": sub.ID.String()})
+	if err != nil {
+		return db.WebhookDelivery{}, Result{}, err
+	}
+	env.Data = data
+	payload, err := json.Marshal(env)
+	if err != nil {
+		return db.WebhookDelivery{}, Result{}, err
+	}
+
+	// Queued and claimed at once, so the dispatcher never picks it up
+	var delivery db.WebhookDelivery
+	err = d.store.ExecTx(ctx, func(q *db.Queries) error {
+		created, err := q.CreateWebhookDelivery(ctx, db.CreateWebhookDeliveryParams{
+			SubscriptionID: sub.ID,
+			EventID:        env.ID,
+			EventType:      string(PingEvent),
+			Payload:        payload,
+			MaxAttempts:    1,
+		})
+		if err != nil {
+			return err
+		}
+		delivery, err = q.ClaimWebhookDelivery(ctx, db.ClaimWebhookDeliveryParams{
+			LeaseSeconds: int32(leaseDuration / time.Second),
+			ID:           created.ID,
+		})
+		return err
+	})
+	if err != nil {
+		return db.WebhookDelivery{}, Result{}, fmt.Errorf("failed to queue ping: %w", err)
+	}
+
+	result := d.deliver(ctx, delivery)
+	delivery, err = d.store.Queries.GetWebhookDelivery(ctx, db.GetWebhookDeliveryParams{
+		ID:             delivery.ID,
+		SubscriptionID: sub.ID,
+	})
+	if err != nil {
+		return db.WebhookDelivery{}, result, fmt.Errorf("failed to get ping delivery: %w", err)
+	}
+	return delivery, result, nil
+}
+
+// Redeliver queues a new delivery of the payload of a previous one
+func (d *Dispatcher) Redeliver(ctx context.Context, original db.WebhookDelivery) (db.WebhookDelivery, error) {
+	delivery, err := d.store.Queries.CreateWebhookDelivery(ctx, db.CreateWebhookDeliveryParams{
+		SubscriptionID: original.SubscriptionID,
+		EventID:        original.EventID,
+		EventType:      original.EventType,
+		Payload:        original.Payload,
+		MaxAttempts:    int32(d.cfg.MaxAttempts),
+		RedeliveryOf:   pgtype.UUID{Bytes: original.ID, Valid: true},
+	})
+	if err != nil {
+		return db.WebhookDelivery{}, fmt.Errorf("failed to queue redelivery: %w", err)
+	}
+	d.Wake()
+	return delivery, nil
+}
+
+// Wake makes the dispatcher send due deliveries now instead of at the next poll
+func (d *Dispatcher) Wake() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run sends queued deliveries until ctx is cancelled. Several instances can
+// run against the same queue.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		d.dispatch(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		}
+	}
+}
+
+// dispatch sends due deliveries until none are left
+func (d *Dispatcher) dispatch(ctx context.Context) {
+	for {
+		deliveries, err := d.store.Queries.ClaimDueWebhookDeliveries(ctx, db.ClaimDueWebhookDeliveriesParams{
+			LeaseSeconds: int32(leaseDuration / time.Second),
+			BatchSize:    batchSize,
+		})
+		if err != nil {
+			if ctx.Err() == nil {
+				d.log.Errorw("Failed to claim webhook deliveries", "error", err)
+			}
+			return
+		}
+
+		for _, delivery := range deliveries {
+			d.deliver(ctx, delivery)
+		}
+		if len(deliveries) < batchSize {
+			return
+		}
+	}
+}
+
+// deliver sends a claimed delivery, logs the attempt and records the
+// outcome: succeeded, rescheduled with backoff, or failed once it is out of
+// attempts
+func (d *Dispatcher) deliver(ctx context.Context, delivery db.WebhookDelivery) Result {
+	result, permanent := d.send(ctx, delivery)
+	if ctx.Err() != nil {
+		// Shutting down; the lease expires and the delivery is picked up again
+		return result
+	}
+
+	var statusCode *int32
+	if result.StatusCode != 0 {
+		code := int32(result.StatusCode)
+		statusCode = &code
+	}
+	if err := d.store.Queries.CreateWebhookDeliveryAttempt(ctx, db.CreateWebhookDeliveryAttemptParams{
+		DeliveryID:   delivery.ID,
+		Attempt:      delivery.Attempts,
+		StatusCode:   statusCode,
+		ResponseBody: optional(result.ResponseBody),
+		Error:        optional(result.Error),
+		DurationMs:   int32(result.Duration / time.Millisecond),
+	}); err != nil {
+		d.log.Errorw("Failed to log webhook delivery attempt", "error", err, "delivery_id", delivery.ID)
+	}
+
+	if result.Succeeded {
+		if err := d.store.Queries.MarkWebhookDeliverySucceeded(ctx, db.MarkWebhookDeliverySucceededParams{
+			ID:             delivery.ID,
+			LastStatusCode: statusCode,
+		}); err != nil {
+			d.log.Errorw("Failed to mark webhook delivery succeeded", "error", err, "delivery_id", delivery.ID)
+		}
+		return result
+	}
+
+	lastError := result.Error
+	if permanent || delivery.Attempts >= delivery.MaxAttempts {
+		d.log.Warnw("Giving up on webhook delivery", "error", lastError, "delivery_id", delivery.ID,
+			"status_code", result.StatusCode, "attempts", delivery.Attempts)
+		if err := d.store.Queries.MarkWebhookDeliveryFailed(ctx, db.MarkWebhookDeliveryFailedParams{
+			ID:             delivery.ID,
+			LastStatusCode: statusCode,
+			LastError:      &lastError,
+		}); err != nil {
+			d.log.Errorw("Failed to mark webhook delivery failed", "error", err, "delivery_id", delivery.ID)
+		}
+		return result
+	}
+
+	next := time.Now().Add(retry.Backoff(int(delivery.Attempts), d.cfg.RetryBaseDelay, d.cfg.RetryMaxDelay))
+	d.log.Infow("Webhook delivery failed, rescheduled", "error", lastError, "delivery_id", delivery.ID,
+		"status_code", result.StatusCode, "attempts", delivery.Attempts, "next_attempt_at", next)
+	if err := d.store.Queries.RescheduleWebhookDelivery(ctx, db.RescheduleWebhookDeliveryParams{
+		ID:             delivery.ID,
+		NextAttemptAt:  next,
+		LastStatusCode: statusCode,
+		LastError:      &lastError,
+	}); err != nil {
+		d.log.Errorw("Failed to reschedule webhook delivery", "error", err, "delivery_id", delivery.ID)
+	}
+	return result
+}
+
+// send posts the payload of a delivery to its webhook. Permanent failures
+// are not retried.
+func (d *Dispatcher) send(ctx context.Context, delivery db.WebhookDelivery) (result Result, permanent bool) {
+	sub, err := d.store.Queries.GetWebhookSubscriptionByID(ctx, delivery.SubscriptionID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Result{Error: "webhook was deleted"}, true
+	}
+	if err != nil {
+		return Result{Error: fmt.Sprintf("failed to get webhook: %v", err)}, false
+	}
+	if !sub.IsActive && delivery.EventType != string(PingEvent) {
+		return Result{Error: "webhook is disabled"}, true
+	}
+
+	secret, err := d.encryptor.Decrypt(sub.EncryptedSecret)
+	if err != nil {
+		return Result{Error: "failed to decrypt webhook secret"}, true
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return Result{Error: fmt.Sprintf("invalid webhook url: %v", err)}, true
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Audity-Webhooks/1.0")
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, delivery.ID.String())
+	req.Header.Set(HeaderWebhook, sub.ID.String())
+	req.Header.Set(HeaderSignature, Sign(secret, time.Now(), delivery.Payload))
+
+	start := time.Now()
+	resp, err := d.client.Do(req)
+	result.Duration = time.Since(start)
+	if err != nil {
+		result.Error = err.Error()
+		return result, errors.Is(err, errPrivateAddress)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	result.StatusCode = resp.StatusCode
+	result.ResponseBody = sanitize(body)
+	result.Succeeded = resp.StatusCode >= 200 && resp.StatusCode < 300
+	if !result.Succeeded {
+		result.Error = fmt.Sprintf("webhook responded with status %d", resp.StatusCode)
+	}
+	return result, false
+}
+
+// sanitize makes a response body storable as text
+func sanitize(body []byte) string {
+	return strings.ReplaceAll(strings.ToValidUTF8(string(body), "�"), "\x00", "")
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
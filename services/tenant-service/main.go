@@ -8,17 +8,22 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/NormaTech-AI/audity/packages/go/eventbus"
 	"github.com/NormaTech-AI/audity/services/tenant-service/internal/clientstore"
 	"github.com/NormaTech-AI/audity/services/tenant-service/internal/config"
 	"github.com/NormaTech-AI/audity/services/tenant-service/internal/crypto"
 	"github.com/NormaTech-AI/audity/services/tenant-service/internal/db"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/events"
 	"github.com/NormaTech-AI/audity/services/tenant-service/internal/framework"
 	"github.com/NormaTech-AI/audity/services/tenant-service/internal/handler"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/mail"
 	"github.com/NormaTech-AI/audity/services/tenant-service/internal/migrations"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/notification"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/reminder"
 	"github.com/NormaTech-AI/audity/services/tenant-service/internal/router"
 	"github.com/NormaTech-AI/audity/services/tenant-service/internal/store"
 	"github.com/NormaTech-AI/audity/services/tenant-service/internal/validator"
-	"github.com/NormaTech-AI/audity/services/tenant-service/internal/mail"
+	"github.com/NormaTech-AI/audity/services/tenant-service/internal/webhook"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/labstack/echo/v4"
 	"github.com/minio/minio-go/v7"
@@ -58,9 +63,6 @@ func main() {
 
 	log.Infow("Configuration loaded", "env", cfg.Server.Env)
 
-	// Initialize Microsoft Graph API client
-	mail.NewMailService(cfg, log)
-
 	// Initialize database connection pool
 	poolConfig, err := pgxpool.ParseConfig(cfg.Database.TenantDBURL)
 	if err != nil {
@@ -108,16 +110,81 @@ func main() {
 	// Initialize tenant DB queries for clientStore
 	tenantQueries := db.New(pool)
 
+	// Initialize mail service and start delivering queued emails
+	mailService, err := mail.NewMailService(cfg, tenantQueries, log)
+	if err != nil {
+		log.Fatalw("Failed to initialize mail service", "error", err)
+	}
+	mailCtx, stopMail := context.WithCancel(context.Background())
+	defer stopMail()
+	go mailService.Run(mailCtx)
+	log.Info("Mail dispatcher started")
+
+	// Initialize in-app notifications, sent along with their emails
+	notifications := notification.NewService(tenantQueries, mailService, log)
+
 	// Initialize client store
 	clientStore := clientstore.NewClientStore(tenantQueries, encryptor, log)
 	log.Info("Client store initialized")
 
+	// Start the due date and exception expiry reminders
+	reminders := reminder.NewScheduler(st, clientStore, mailService, notifications, cfg.Mail.DueDateReminderDays, cfg.Mail.ExceptionReminderDays, log)
+	go reminders.Run(mailCtx)
+	log.Infow("Reminders started",
+		"days_before", cfg.Mail.DueDateReminderDays,
+		"exception_days_before", cfg.Mail.ExceptionReminderDays)
+
+	// Start publishing the domain events of the event outboxes
+	publisher, err := eventbus.NewPublisher(eventbus.Config{
+		URL:      cfg.RabbitMQ.URL,
+		Exchange: cfg.RabbitMQ.Exchange,
+	}, log)
+	if err != nil {
+		log.Fatalw("Failed to initialize event publisher", "error", err)
+	}
+	defer publisher.Close()
+	relay := eventbus.NewRelay(publisher, events.Outboxes(tenantQueries, clientStore, log), eventbus.RelayOptions{
+		PollInterval:   cfg.RabbitMQ.PollInterval,
+		RetryBaseDelay: cfg.RabbitMQ.RetryBaseDelay,
+		RetryMaxDelay:  cfg.RabbitMQ.RetryMaxDelay,
+	}, log)
+	relayCtx, stopRelay := context.WithCancel(context.Background())
+	defer stopRelay()
+	go relay.Run(relayCtx)
+	log.Infow("Event relay started", "exchange", cfg.RabbitMQ.Exchange)
+
+	// Start fanning events out to the webhooks of the clients and delivering them
+	webhooks := webhook.NewDispatcher(st, encryptor, cfg.Webhooks, log)
+	go webhooks.Run(relayCtx)
+	webhookConsumer, err := eventbus.NewConsumer(eventbus.Config{
+		URL:      cfg.RabbitMQ.URL,
+		Exchange: cfg.RabbitMQ.Exchange,
+	}, eventbus.ConsumerOptions{Group: "webhooks"}, log)
+	if err != nil {
+		log.Fatalw("Failed to initialize webhook event consumer", "error", err)
+	}
+	for _, t := range webhook.EventTypes() {
+		webhookConsumer.Handle(t, webhooks.HandleEvent)
+	}
+	go func() {
+		if err := webhookConsumer.Run(relayCtx); err != nil {
+			log.Errorw("Webhook event consumer stopped", "error", err)
+		}
+	}()
+	log.Infow("Webhook dispatcher started", "queue", webhookConsumer.Queue())
+
 	// Initialize framework service
-	frameworkService := framework.NewService("./templates/frameworks", log)
+	frameworkClient := framework.NewClient(cfg.Services.FrameworkBaseURL, framework.ClientOptions{
+		Timeout:    cfg.Services.FrameworkTimeout,
+		MaxRetries: cfg.Services.FrameworkMaxRetries,
+		CacheTTL:   cfg.Services.FrameworkCacheTTL,
+		CacheSize:  cfg.Services.FrameworkCacheSize,
+	})
+	frameworkService := framework.NewService(frameworkClient, log)
 	log.Info("Framework service initialized")
 
 	// Initialize handler
-	h := handler.NewHandler(st, cfg, encryptor, minioClient, log, clientMigrationRunner, clientStore, frameworkService)
+	h := handler.NewHandler(st, cfg, encryptor, minioClient, log, clientMigrationRunner, clientStore, frameworkService, mailService, notifications, relay, webhooks)
 
 	// Initialize Echo
 	e := echo.New()
@@ -142,6 +209,8 @@ func main() {
 	<-quit
 
 	log.Info("Shutting down server...")
+	stopMail()
+	stopRelay()
 
 	// Graceful shutdown with 10 second timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -152,4 +221,4 @@ func main() {
 	}
 
 	log.Info("Server exited")
-}
\ No newline at end of file
+}